@@ -21,9 +21,10 @@ import (
 	"strconv"
 	"strings"
 
-	log "github.com/golang/glog"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -129,8 +130,21 @@ type impl struct {
 	profileNameCache map[string]string
 }
 
+func init() {
+	registry.Register(ftconsts.AristaCFMPMFunctionalTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Arista CFM PM functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
 	i := &impl{
 		profileNameCache: make(map[string]string),
 	}
@@ -151,9 +165,9 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Arista CFM PM functional translator: %v", err)
+		return nil, err
 	}
-	return ft
+	return ft, nil
 }
 
 // valueToString converts a TypedValue to a string.
@@ -499,22 +513,9 @@ func (i *impl) convertMetricValue(tv *gnmipb.TypedValue, metricType string) (*gn
 		return nil, fmt.Errorf("empty metric value")
 	}
 
-	var valFloat float64
-	switch v := tv.GetValue().(type) {
-	case *gnmipb.TypedValue_DoubleVal:
-		valFloat = v.DoubleVal
-	case *gnmipb.TypedValue_IntVal:
-		valFloat = float64(v.IntVal)
-	case *gnmipb.TypedValue_UintVal:
-		valFloat = float64(v.UintVal)
-	case *gnmipb.TypedValue_StringVal:
-		f, err := strconv.ParseFloat(v.StringVal, 64)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse string metric value %q: %w", v.StringVal, err)
-		}
-		valFloat = f
-	default:
-		return nil, fmt.Errorf("unsupported metric value type: %T", v)
+	valFloat, err := ftutilities.ToDouble(tv)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metric value: %w", err)
 	}
 
 	var uintVal uint64
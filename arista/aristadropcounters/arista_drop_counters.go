@@ -0,0 +1,293 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aristadropcounters translates the Arista hardware drop counters of the packet processors
+// from native to the openconfig vendor drop counters, following
+// https://github.com/openconfig/public/blob/master/doc/vendor_counter_guide.md.
+//
+// Each entry of /Smash/hardware/counter/internalDrop/SandCounters/internalDrop/<entry> holds the
+// chipName, counterName and dropCount of a drop counter of a chip. Since these leaves may be
+// streamed in different notifications, they are cached per target, and the drop count is translated
+// to /components/component[name=<chipName>]/integrated-circuit/pipeline-counters/drop/vendor/
+// Arista/sand/adverse/state/<counterName> once the chip and counter names are known.
+package aristadropcounters
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftstate"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	leafChipName    = "chipName"
+	leafCounterName = "counterName"
+	leafDropCount   = "dropCount"
+)
+
+var (
+	// Arista does not support `*` subscription for the native paths.
+	// Therefore, we need to subscribe to the longest prefix/container of a path.
+	// Example:
+	// for native path: /eos_native/Smash/hardware/counter/internalDrop/SandCounters/internalDrop/<entry>/dropCount
+	// Subscribe to: /eos_native/Smash/hardware/counter/internalDrop/SandCounters/internalDrop
+	translateMap = map[string][]string{
+		"/openconfig/components/component/integrated-circuit/pipeline-counters/drop/vendor": {
+			"/eos_native/Smash/hardware/counter/internalDrop/SandCounters/internalDrop",
+		},
+	}
+	paths = ftutilities.MustStringMapPaths(translateMap)
+	// entryPattern matches the paths of the drop counter entries and their leaves.
+	entryPattern = &gnmipb.Path{
+		Origin: "eos_native",
+		Elem: []*gnmipb.PathElem{
+			{Name: "Smash"}, {Name: "hardware"}, {Name: "counter"}, {Name: "internalDrop"},
+			{Name: "SandCounters"}, {Name: "internalDrop"}, {Name: "..."},
+		},
+	}
+	// entryIndex is the index of the entry element in the native paths.
+	entryIndex = len(entryPattern.GetElem()) - 1
+)
+
+// entry is a cached native drop counter entry.
+type entry struct {
+	chipName    string
+	counterName string
+	dropCount   uint64
+	hasCount    bool
+}
+
+// complete returns true if the drop count of the entry can be translated.
+func (e entry) complete() bool {
+	return e.chipName != "" && e.counterName != "" && e.hasCount
+}
+
+// cache holds the drop counter entries of each target, by entry name.
+type cache struct {
+	mu      sync.Mutex
+	entries *ftstate.TargetStore[map[string]entry]
+}
+
+func newCache() *cache {
+	return &cache{entries: ftstate.NewTargetStore[map[string]entry](ftstate.Options{})}
+}
+
+func (c *cache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries.Reset()
+}
+
+func (c *cache) resetTarget(target string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries.ResetTarget(target)
+}
+
+func (c *cache) clone() any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Clone(maps.Clone[map[string]entry])
+}
+
+func (c *cache) restore(snapshot any) error {
+	entries, ok := snapshot.(*ftstate.TargetStore[map[string]entry])
+	if !ok {
+		return fmt.Errorf("unexpected state type %T", snapshot)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries.Restore(entries, maps.Clone[map[string]entry])
+	return nil
+}
+
+func init() {
+	registry.Register(ftconsts.AristaDropCountersTranslator, NewE)
+}
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Arista drop counters functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	c := newCache()
+	return translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:               ftconsts.AristaDropCountersTranslator,
+			Translate:        c.translate,
+			OutputToInputMap: paths,
+			State: &translator.StateOptions{
+				Reset:        c.reset,
+				State:        c.clone,
+				RestoreState: c.restore,
+				Store:        c.entries,
+				ResetTarget:  c.resetTarget,
+			},
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorArista,
+				},
+			},
+		},
+	)
+}
+
+// counterPath returns the openconfig path of a drop counter.
+func counterPath(e entry) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": e.chipName}},
+			{Name: "integrated-circuit"},
+			{Name: "pipeline-counters"},
+			{Name: "drop"},
+			{Name: "vendor"},
+			{Name: "Arista"},
+			{Name: "sand"},
+			{Name: "adverse"},
+			{Name: "state"},
+			{Name: strings.ReplaceAll(e.counterName, " ", "_")},
+		},
+	}
+}
+
+// applyUpdate applies a native leaf to the cached entry. It returns the updated entry, and true if
+// the leaf changed a name of the entry.
+func applyUpdate(e entry, leaf string, val *gnmipb.TypedValue) (entry, bool, error) {
+	switch leaf {
+	case leafChipName, leafCounterName:
+		v, ok := val.GetValue().(*gnmipb.TypedValue_StringVal)
+		if !ok {
+			return e, false, fmt.Errorf("unexpected value type %T for %s", val.GetValue(), leaf)
+		}
+		if leaf == leafChipName {
+			renamed := e.chipName != v.StringVal
+			e.chipName = v.StringVal
+			return e, renamed, nil
+		}
+		renamed := e.counterName != v.StringVal
+		e.counterName = v.StringVal
+		return e, renamed, nil
+	case leafDropCount:
+		count, err := ftutilities.ToUint64(val)
+		if err != nil {
+			return e, false, fmt.Errorf("invalid %s: %v", leaf, err)
+		}
+		e.dropCount = count
+		e.hasCount = true
+	}
+	return e, false, nil
+}
+
+func (c *cache) translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	var (
+		updates []*gnmipb.Update
+		deletes []*gnmipb.Path
+	)
+	c.mu.Lock()
+	entries := c.entries.GetOrCreate(n.GetPrefix().GetTarget(), func() map[string]entry { return map[string]entry{} })
+	// gNMI processes deletes before updates.
+	for _, d := range n.GetDelete() {
+		path := ftutilities.Join(n.GetPrefix(), d)
+		// Deletes of the leaves of an entry are ignored.
+		if !ftutilities.MatchPath(path, entryPattern) || len(path.GetElem()) > entryIndex+1 {
+			continue
+		}
+		for _, key := range slices.Sorted(maps.Keys(entries)) {
+			e := entries[key]
+			// A delete of the container, or of an entry, removes the counters of the entries.
+			if len(path.GetElem()) > entryIndex && path.GetElem()[entryIndex].GetName() != key {
+				continue
+			}
+			if e.complete() {
+				deletes = append(deletes, counterPath(e))
+			}
+			delete(entries, key)
+		}
+	}
+	// changed holds the entries whose drop count is translated, in the order they are updated.
+	var changed []string
+	for _, u := range n.GetUpdate() {
+		path := ftutilities.Join(n.GetPrefix(), u.GetPath())
+		if !ftutilities.MatchPath(path, entryPattern) || len(path.GetElem()) != entryIndex+2 {
+			continue
+		}
+		key := path.GetElem()[entryIndex].GetName()
+		prev := entries[key]
+		e, renamed, err := applyUpdate(prev, path.GetElem()[entryIndex+1].GetName(), u.GetVal())
+		if err != nil {
+			log.Errorf("Failed to translate update %v: %v", u, err)
+			continue
+		}
+		if renamed && prev.complete() {
+			deletes = append(deletes, counterPath(prev))
+		}
+		if e == prev {
+			continue
+		}
+		entries[key] = e
+		if e.complete() {
+			changed = append(changed, key)
+		}
+	}
+	seen := map[string]bool{}
+	for _, key := range changed {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		e := entries[key]
+		updates = append(updates, &gnmipb.Update{
+			Path: counterPath(e),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: e.dropCount}},
+		})
+	}
+	c.mu.Unlock()
+	if len(updates) == 0 && len(deletes) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+				Delete: deletes,
+			},
+		},
+	}, nil
+}
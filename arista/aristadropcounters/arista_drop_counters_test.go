@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aristadropcounters
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/functional-translators/ftstate"
+	"github.com/openconfig/functional-translators/fttest"
+)
+
+// cachedEntries returns the state of a translator which translated testdata/entries_input.txt.
+func cachedEntries() map[string]map[string]entry {
+	return map[string]map[string]entry{
+		"ar1": {
+			"1": {chipName: "Jericho3/0", counterName: "dropVoqInPortNotVlanMember", dropCount: 42, hasCount: true},
+			"2": {chipName: "Jericho3/1", counterName: "Ingress Mc Drop", dropCount: 7, hasCount: true},
+			"3": {chipName: "Jericho3/1", counterName: "dropTcamMiss"},
+		},
+	}
+}
+
+// snapshotEntries returns the entries of each target in a state snapshot.
+func snapshotEntries(t *testing.T, snapshot any) map[string]map[string]entry {
+	t.Helper()
+	store, ok := snapshot.(*ftstate.TargetStore[map[string]entry])
+	if !ok {
+		t.Fatalf("State() returned unexpected type %T", snapshot)
+	}
+	entries := map[string]map[string]entry{}
+	store.Range(func(target string, e map[string]entry) {
+		entries[target] = e
+	})
+	return entries
+}
+
+func TestTranslate(t *testing.T) {
+	fttest.RunGoldenTests(t, New(), "testdata")
+}
+
+func TestState(t *testing.T) {
+	cases, err := fttest.LoadCases("testdata")
+	if err != nil {
+		t.Fatalf("LoadCases() got unexpected error: %v", err)
+	}
+	byName := map[string]*fttest.Case{}
+	for _, c := range cases {
+		byName[c.Name] = c
+	}
+	ft := New()
+	if _, err := byName["entries"].Run(ft); err != nil {
+		t.Fatalf("Translate() returned unexpected error: %v", err)
+	}
+	snapshot := ft.State()
+	if diff := cmp.Diff(cachedEntries(), snapshotEntries(t, snapshot), cmp.AllowUnexported(entry{})); diff != "" {
+		t.Errorf("State() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	// The counts of the entries are translated once their names are restored.
+	restored := New()
+	if err := restored.RestoreState(snapshot); err != nil {
+		t.Fatalf("RestoreState() got unexpected error: %v", err)
+	}
+	got, err := restored.Translate(byName["count_without_names"].Inputs[0])
+	if err != nil {
+		t.Fatalf("Translate() returned unexpected error: %v", err)
+	}
+	want := byName["count"].Want
+	if diff := cmp.Diff(want[len(want)-1], got, protocmp.Transform()); diff != "" {
+		t.Errorf("Translate() after RestoreState() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	if err := restored.RestoreState("invalid"); err == nil {
+		t.Errorf("RestoreState(%q) got nil error, want error", "invalid")
+	}
+}
+
+func TestOnTargetDisconnect(t *testing.T) {
+	cases, err := fttest.LoadCases("testdata")
+	if err != nil {
+		t.Fatalf("LoadCases() got unexpected error: %v", err)
+	}
+	byName := map[string]*fttest.Case{}
+	for _, c := range cases {
+		byName[c.Name] = c
+	}
+	ft := New()
+	if _, err := byName["entries"].Run(ft); err != nil {
+		t.Fatalf("Translate() returned unexpected error: %v", err)
+	}
+	ft.OnTargetDisconnect("other")
+	if diff := cmp.Diff(cachedEntries(), snapshotEntries(t, ft.State()), cmp.AllowUnexported(entry{})); diff != "" {
+		t.Errorf("State() after OnTargetDisconnect() of another target returned unexpected diff (-want +got):\n%s", diff)
+	}
+	// The names of the entries are forgotten, so their counts are no longer translated.
+	ft.OnTargetDisconnect("ar1")
+	if got := snapshotEntries(t, ft.State()); len(got) != 0 {
+		t.Errorf("State() after OnTargetDisconnect() returned %v, want no entries", got)
+	}
+	got, err := ft.Translate(byName["count_without_names"].Inputs[0])
+	if err != nil {
+		t.Fatalf("Translate() returned unexpected error: %v", err)
+	}
+	if got != nil {
+		t.Errorf("Translate() after OnTargetDisconnect() returned %v, want nil", got)
+	}
+}
@@ -0,0 +1,370 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aristainterface
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/openconfig/functional-translators/ftstate"
+	"github.com/openconfig/functional-translators/ftutilities"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// The interfaces of a breakout port are named after the port and their lane, e.g. "Ethernet1/1" to
+// "Ethernet1/4" for port "Ethernet1" of a fixed system, or "Ethernet3/1/1" for port "Ethernet3/1"
+// of a modular system. The interface of the primary lane exists whether or not the port is broken
+// out, and its MAC address is the MAC address of the port. As the ports of the modular systems are
+// named after their linecard, e.g. "Ethernet3/1", a target is known to be modular once it streams
+// an interface with a linecard, port and lane, and its interfaces without lane are then no longer
+// considered as lanes of a port.
+const (
+	ethernetPrefix = "Ethernet"
+	primaryLane    = "1"
+)
+
+var (
+	intfNameSchema     = "/openconfig/interfaces/interface/state/name"
+	hardwarePortSchema = "/openconfig/interfaces/interface/state/hardware-port"
+)
+
+// breakoutPort holds the interfaces of a port learned from a target.
+type breakoutPort struct {
+	// mac is the MAC address of the interface of the primary lane, empty if unknown.
+	mac string
+	// intfs maps the interfaces of the port to whether they report their own MAC address.
+	intfs map[string]bool
+}
+
+// breakoutPorts maps the port names to their interfaces.
+type breakoutPorts map[string]*breakoutPort
+
+func (p breakoutPorts) clone() breakoutPorts {
+	c := breakoutPorts{}
+	for name, port := range p {
+		c[name] = &breakoutPort{mac: port.mac, intfs: maps.Clone(port.intfs)}
+	}
+	return c
+}
+
+// port returns the port of the interface, creating it if needed, and registers the interface.
+func (p breakoutPorts) port(name, intf string) *breakoutPort {
+	port, ok := p[name]
+	if !ok {
+		port = &breakoutPort{intfs: map[string]bool{}}
+		p[name] = port
+	}
+	if _, ok := port.intfs[intf]; !ok {
+		port.intfs[intf] = false
+	}
+	return port
+}
+
+// splitBreakout returns the port and lane of an interface of a breakout capable port, with
+// modular true if the interface has a linecard, port and lane.
+func splitBreakout(intf string) (port, lane string, modular, ok bool) {
+	if !strings.HasPrefix(intf, ethernetPrefix) {
+		return "", "", false, false
+	}
+	i := strings.LastIndex(intf, "/")
+	if i < 0 {
+		return "", "", false, false
+	}
+	return intf[:i], intf[i+1:], strings.Count(intf, "/") == 2, true
+}
+
+// modular returns true if the ports are the ports of a modular system.
+func (p breakoutPorts) modular() bool {
+	for name := range p {
+		if strings.Contains(name, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// split is like splitBreakout, but only returns ok for the interfaces which are lanes of a port on
+// the system of the ports.
+func (p breakoutPorts) split(intf string) (port, lane string, ok bool) {
+	port, lane, modular, ok := splitBreakout(intf)
+	if !ok || (!modular && p.modular()) {
+		return "", "", false
+	}
+	return port, lane, true
+}
+
+// makeModular removes the ports learned before the system was known to be modular, returning the
+// deletes of the leaves synthesized for their interfaces.
+func (p breakoutPorts) makeModular() []*gnmipb.Path {
+	var deletes []*gnmipb.Path
+	for _, name := range slices.Sorted(maps.Keys(p)) {
+		if strings.Contains(name, "/") {
+			continue
+		}
+		port := p[name]
+		for _, intf := range slices.Sorted(maps.Keys(port.intfs)) {
+			deletes = append(deletes, hardwarePortPath(intf))
+		}
+		if port.mac != "" {
+			deletes = append(deletes, port.uninherited()...)
+		}
+		delete(p, name)
+	}
+	return deletes
+}
+
+// breakout synthesizes the relationships between the breakout ports and their interfaces: the
+// hardware-port leaf of each interface references its port, and the interfaces which do not
+// report a MAC address inherit the MAC address of the primary lane of their port.
+type breakout struct {
+	mu    sync.Mutex
+	ports *ftstate.TargetStore[breakoutPorts]
+}
+
+func newBreakout() *breakout {
+	return &breakout{ports: ftstate.NewTargetStore[breakoutPorts](ftstate.Options{})}
+}
+
+func (b *breakout) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.ports.Reset()
+}
+
+func (b *breakout) resetTarget(target string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.ports.ResetTarget(target)
+}
+
+func (b *breakout) state() any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.ports.Clone(breakoutPorts.clone)
+}
+
+func (b *breakout) restoreState(snapshot any) error {
+	ports, ok := snapshot.(*ftstate.TargetStore[breakoutPorts])
+	if !ok {
+		return fmt.Errorf("unexpected state type %T", snapshot)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.ports.Restore(ports, breakoutPorts.clone)
+	return nil
+}
+
+func hardwarePortPath(intf string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": intf}},
+			{Name: "state"},
+			{Name: "hardware-port"},
+		},
+	}
+}
+
+func hardwarePortUpdate(intf, port string) *gnmipb.Update {
+	return &gnmipb.Update{
+		Path: hardwarePortPath(intf),
+		Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: port}},
+	}
+}
+
+func macUpdate(intf, mac string) *gnmipb.Update {
+	return &gnmipb.Update{
+		Path: intfMacPath(intf),
+		Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: mac}},
+	}
+}
+
+// inheriting returns the interfaces of the port which inherit its MAC address.
+func (p *breakoutPort) inheriting() []string {
+	var intfs []string
+	for intf, ownMAC := range p.intfs {
+		if _, lane, _, _ := splitBreakout(intf); !ownMAC && lane != primaryLane {
+			intfs = append(intfs, intf)
+		}
+	}
+	slices.Sort(intfs)
+	return intfs
+}
+
+// inherited returns the MAC updates of the interfaces of the port which inherit its MAC address.
+func (p *breakoutPort) inherited() []*gnmipb.Update {
+	var updates []*gnmipb.Update
+	for _, intf := range p.inheriting() {
+		updates = append(updates, macUpdate(intf, p.mac))
+	}
+	return updates
+}
+
+// uninherited returns the MAC deletes of the interfaces of the port which inherit its MAC address.
+func (p *breakoutPort) uninherited() []*gnmipb.Path {
+	var deletes []*gnmipb.Path
+	for _, intf := range p.inheriting() {
+		deletes = append(deletes, intfMacPath(intf))
+	}
+	return deletes
+}
+
+// isIntfNameUpdate returns true for the updates of the name leaf of the interfaces, which are only
+// used to learn the interfaces of the breakout ports.
+func isIntfNameUpdate(prefix *gnmipb.Path, u *gnmipb.Update) bool {
+	return ftutilities.GNMIPathToSchemaString(ftutilities.Join(prefix, u.GetPath()), true) == intfNameSchema
+}
+
+// translate returns the updates and deletes synthesized for the breakout ports from a notification.
+func (b *breakout) translate(n *gnmipb.Notification) ([]*gnmipb.Update, []*gnmipb.Path) {
+	var (
+		updates []*gnmipb.Update
+		deletes []*gnmipb.Path
+	)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ports := b.ports.GetOrCreate(n.GetPrefix().GetTarget(), func() breakoutPorts { return breakoutPorts{} })
+	for _, d := range n.GetDelete() {
+		fullPath := ftutilities.Join(n.GetPrefix(), d)
+		schema := ftutilities.GNMIPathToSchemaString(fullPath, true)
+		elems := fullPath.GetElem()
+		if !strings.HasPrefix(intfMacSchema, schema) || len(elems) == 0 || elems[0].GetName() != "interfaces" {
+			continue
+		}
+		if len(elems) == 1 {
+			// All the interfaces are deleted.
+			clear(ports)
+			continue
+		}
+		intf := elems[1].GetKey()["name"]
+		name, lane, ok := ports.split(intf)
+		port, known := ports[name]
+		if !ok || !known {
+			continue
+		}
+		if len(elems) == 2 {
+			// The interface is deleted, with its MAC address.
+			delete(port.intfs, intf)
+		} else {
+			// The MAC address of the interface is deleted, it inherits the MAC address of its port
+			// unless it is the primary lane.
+			port.intfs[intf] = false
+			if lane != primaryLane && port.mac != "" {
+				updates = append(updates, macUpdate(intf, port.mac))
+			}
+		}
+		if lane == primaryLane && port.mac != "" {
+			port.mac = ""
+			deletes = append(deletes, port.uninherited()...)
+		}
+		if len(port.intfs) == 0 {
+			delete(ports, name)
+		}
+	}
+	for _, u := range n.GetUpdate() {
+		fullPath := ftutilities.Join(n.GetPrefix(), u.GetPath())
+		schema := ftutilities.GNMIPathToSchemaString(fullPath, true)
+		if schema != intfNameSchema && schema != intfMacSchema {
+			continue
+		}
+		intf := fullPath.GetElem()[1].GetKey()["name"]
+		if _, _, modular, _ := splitBreakout(intf); modular && !ports.modular() {
+			deletes = append(deletes, ports.makeModular()...)
+		}
+		name, lane, ok := ports.split(intf)
+		if !ok {
+			continue
+		}
+		_, known := ports[name]
+		if known {
+			_, known = ports[name].intfs[intf]
+		}
+		port := ports.port(name, intf)
+		if schema == intfNameSchema || !known {
+			updates = append(updates, hardwarePortUpdate(intf, name))
+		}
+		switch {
+		case schema == intfNameSchema:
+			if lane != primaryLane && !port.intfs[intf] && port.mac != "" {
+				updates = append(updates, macUpdate(intf, port.mac))
+			}
+		case lane == primaryLane:
+			port.intfs[intf] = true
+			port.mac = u.GetVal().GetStringVal()
+			if port.mac != "" {
+				updates = append(updates, port.inherited()...)
+			}
+		default:
+			port.intfs[intf] = true
+		}
+	}
+	return updates, deletes
+}
+
+// wrap returns the translate function of the MAC FT, which adds the breakout relationships to the
+// output of handler.
+func (b *breakout) wrap(handler func(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)) func(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	return func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+		n := sr.GetUpdate()
+		if n == nil {
+			return nil, nil
+		}
+		in := sr
+		if slices.ContainsFunc(n.GetUpdate(), func(u *gnmipb.Update) bool { return isIntfNameUpdate(n.GetPrefix(), u) }) {
+			// The interface names are not in the schema of the handler.
+			in = &gnmipb.SubscribeResponse{
+				Response: &gnmipb.SubscribeResponse_Update{
+					Update: &gnmipb.Notification{
+						Timestamp: n.GetTimestamp(),
+						Prefix:    n.GetPrefix(),
+						Update: ftutilities.FilterUpdates(n.GetUpdate(), func(u *gnmipb.Update) bool {
+							return !isIntfNameUpdate(n.GetPrefix(), u)
+						}),
+						Delete: n.GetDelete(),
+					},
+				},
+			}
+		}
+		out, err := handler(in)
+		if err != nil {
+			return nil, err
+		}
+		updates, deletes := b.translate(n)
+		if len(updates) == 0 && len(deletes) == 0 {
+			return out, nil
+		}
+		if out == nil {
+			out = &gnmipb.SubscribeResponse{
+				Response: &gnmipb.SubscribeResponse_Update{
+					Update: &gnmipb.Notification{
+						Timestamp: n.GetTimestamp(),
+						Prefix: &gnmipb.Path{
+							Origin: "openconfig",
+							Target: n.GetPrefix().GetTarget(),
+						},
+					},
+				},
+			}
+		}
+		outN := out.GetUpdate()
+		outN.Update = append(outN.Update, updates...)
+		outN.Delete = append(outN.Delete, deletes...)
+		return out, nil
+	}
+}
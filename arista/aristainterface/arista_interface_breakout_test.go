@@ -0,0 +1,239 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aristainterface
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func intfPath(intf string, elems ...string) *gnmipb.Path {
+	p := &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": intf}},
+		},
+	}
+	for _, e := range elems {
+		p.Elem = append(p.Elem, &gnmipb.PathElem{Name: e})
+	}
+	return p
+}
+
+func stringUpdate(p *gnmipb.Path, v string) *gnmipb.Update {
+	return &gnmipb.Update{Path: p, Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: v}}}
+}
+
+func nameUpdate(intf string) *gnmipb.Update {
+	return stringUpdate(intfPath(intf, "state", "name"), intf)
+}
+
+func macAddressUpdate(intf, mac string) *gnmipb.Update {
+	return stringUpdate(intfPath(intf, "ethernet", "state", "mac-address"), mac)
+}
+
+func hardwarePort(intf, port string) *gnmipb.Update {
+	return stringUpdate(intfPath(intf, "state", "hardware-port"), port)
+}
+
+func breakoutNotification(prefix *gnmipb.Path, ts int64, updates []*gnmipb.Update, deletes ...*gnmipb.Path) *gnmipb.SubscribeResponse {
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{Timestamp: ts, Prefix: prefix, Update: updates, Delete: deletes},
+		},
+	}
+}
+
+func TestBreakout(t *testing.T) {
+	notif := func(ts int64, updates []*gnmipb.Update, deletes ...*gnmipb.Path) *gnmipb.SubscribeResponse {
+		return breakoutNotification(&gnmipb.Path{Origin: "openconfig", Target: "dut"}, ts, updates, deletes...)
+	}
+	steps := []struct {
+		name string
+		in   *gnmipb.SubscribeResponse
+		want *gnmipb.SubscribeResponse
+	}{
+		{
+			name: "interfaces learned",
+			in: notif(1, []*gnmipb.Update{
+				nameUpdate("Ethernet1/1"),
+				nameUpdate("Ethernet1/2"),
+				nameUpdate("Ethernet1/3"),
+				macAddressUpdate("Ethernet1/3", "00:00:00:00:01:03"),
+				nameUpdate("Management1"),
+			}),
+			want: notif(1, []*gnmipb.Update{
+				macAddressUpdate("Ethernet1/3", "00:00:00:00:01:03"),
+				hardwarePort("Ethernet1/1", "Ethernet1"),
+				hardwarePort("Ethernet1/2", "Ethernet1"),
+				hardwarePort("Ethernet1/3", "Ethernet1"),
+			}),
+		},
+		{
+			name: "primary lane MAC propagated",
+			in:   notif(2, []*gnmipb.Update{macAddressUpdate("Ethernet1/1", "00:00:00:00:01:01")}),
+			want: notif(2, []*gnmipb.Update{
+				macAddressUpdate("Ethernet1/1", "00:00:00:00:01:01"),
+				macAddressUpdate("Ethernet1/2", "00:00:00:00:01:01"),
+			}),
+		},
+		{
+			name: "new interface inherits MAC",
+			in:   notif(3, []*gnmipb.Update{nameUpdate("Ethernet1/4")}),
+			want: notif(3, []*gnmipb.Update{
+				hardwarePort("Ethernet1/4", "Ethernet1"),
+				macAddressUpdate("Ethernet1/4", "00:00:00:00:01:01"),
+			}),
+		},
+		{
+			name: "own MAC deleted",
+			in:   notif(4, nil, intfPath("Ethernet1/3", "ethernet", "state", "mac-address")),
+			want: notif(4,
+				[]*gnmipb.Update{macAddressUpdate("Ethernet1/3", "00:00:00:00:01:01")},
+				intfPath("Ethernet1/3", "ethernet", "state", "mac-address"),
+			),
+		},
+		{
+			name: "primary lane deleted",
+			in:   notif(5, nil, intfPath("Ethernet1/1")),
+			want: notif(5, nil,
+				intfPath("Ethernet1/1"),
+				intfPath("Ethernet1/2", "ethernet", "state", "mac-address"),
+				intfPath("Ethernet1/3", "ethernet", "state", "mac-address"),
+				intfPath("Ethernet1/4", "ethernet", "state", "mac-address"),
+			),
+		},
+	}
+	ft := NewMacFT()
+	for _, step := range steps {
+		got, err := ft.Translate(step.in)
+		if err != nil {
+			t.Fatalf("%s: Translate() returned an unexpected error: %v", step.name, err)
+		}
+		if diff := cmp.Diff(step.want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update", "delete")); diff != "" {
+			t.Errorf("%s: Translate() returned an unexpected diff (-want +got):\n%s", step.name, diff)
+		}
+	}
+}
+
+func TestBreakoutModular(t *testing.T) {
+	notif := func(ts int64, updates []*gnmipb.Update, deletes ...*gnmipb.Path) *gnmipb.SubscribeResponse {
+		return breakoutNotification(&gnmipb.Path{Origin: "openconfig", Target: "dut"}, ts, updates, deletes...)
+	}
+	steps := []struct {
+		name string
+		in   *gnmipb.SubscribeResponse
+		want *gnmipb.SubscribeResponse
+	}{
+		{
+			name: "linecard ports taken for lanes",
+			in: notif(1, []*gnmipb.Update{
+				nameUpdate("Ethernet3/1"),
+				macAddressUpdate("Ethernet3/1", "00:00:00:00:03:01"),
+				nameUpdate("Ethernet3/2"),
+			}),
+			want: notif(1, []*gnmipb.Update{
+				macAddressUpdate("Ethernet3/1", "00:00:00:00:03:01"),
+				hardwarePort("Ethernet3/1", "Ethernet3"),
+				hardwarePort("Ethernet3/2", "Ethernet3"),
+				macAddressUpdate("Ethernet3/2", "00:00:00:00:03:01"),
+			}),
+		},
+		{
+			name: "modular system learned",
+			in: notif(2, []*gnmipb.Update{
+				nameUpdate("Ethernet4/1/1"),
+				nameUpdate("Ethernet4/1/2"),
+			}),
+			want: notif(2,
+				[]*gnmipb.Update{
+					hardwarePort("Ethernet4/1/1", "Ethernet4/1"),
+					hardwarePort("Ethernet4/1/2", "Ethernet4/1"),
+				},
+				intfPath("Ethernet3/1", "state", "hardware-port"),
+				intfPath("Ethernet3/2", "state", "hardware-port"),
+				intfPath("Ethernet3/2", "ethernet", "state", "mac-address"),
+			),
+		},
+		{
+			name: "linecard port not a lane",
+			in: notif(3, []*gnmipb.Update{
+				nameUpdate("Ethernet3/3"),
+				macAddressUpdate("Ethernet3/1", "00:00:00:00:03:11"),
+			}),
+			want: notif(3, []*gnmipb.Update{
+				macAddressUpdate("Ethernet3/1", "00:00:00:00:03:11"),
+			}),
+		},
+		{
+			name: "linecard port deleted",
+			in:   notif(4, nil, intfPath("Ethernet3/1")),
+			want: notif(4, nil, intfPath("Ethernet3/1")),
+		},
+	}
+	ft := NewMacFT()
+	for _, step := range steps {
+		got, err := ft.Translate(step.in)
+		if err != nil {
+			t.Fatalf("%s: Translate() returned an unexpected error: %v", step.name, err)
+		}
+		if diff := cmp.Diff(step.want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update", "delete")); diff != "" {
+			t.Errorf("%s: Translate() returned an unexpected diff (-want +got):\n%s", step.name, diff)
+		}
+	}
+}
+
+func TestBreakoutStateRestore(t *testing.T) {
+	prefix := &gnmipb.Path{Target: "dut"}
+	ft := NewMacFT()
+	learn := breakoutNotification(prefix, 1, []*gnmipb.Update{
+		nameUpdate("Ethernet3/1/1"),
+		nameUpdate("Ethernet3/1/2"),
+		macAddressUpdate("Ethernet3/1/1", "00:00:00:03:01:01"),
+	})
+	if _, err := ft.Translate(learn); err != nil {
+		t.Fatalf("Translate() returned an unexpected error: %v", err)
+	}
+	restored := NewMacFT()
+	if err := restored.RestoreState(ft.State()); err != nil {
+		t.Fatalf("RestoreState() returned an unexpected error: %v", err)
+	}
+	got, err := restored.Translate(breakoutNotification(prefix, 2, []*gnmipb.Update{nameUpdate("Ethernet3/1/2")}))
+	if err != nil {
+		t.Fatalf("Translate() returned an unexpected error: %v", err)
+	}
+	want := breakoutNotification(&gnmipb.Path{Origin: "openconfig", Target: "dut"}, 2, []*gnmipb.Update{
+		hardwarePort("Ethernet3/1/2", "Ethernet3/1"),
+		macAddressUpdate("Ethernet3/1/2", "00:00:00:03:01:01"),
+	})
+	if diff := cmp.Diff(want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update")); diff != "" {
+		t.Errorf("Translate() after RestoreState() returned an unexpected diff (-want +got):\n%s", diff)
+	}
+	restored.OnTargetDisconnect("dut")
+	got, err = restored.Translate(breakoutNotification(prefix, 3, []*gnmipb.Update{nameUpdate("Ethernet3/1/2")}))
+	if err != nil {
+		t.Fatalf("Translate() returned an unexpected error: %v", err)
+	}
+	want = breakoutNotification(&gnmipb.Path{Origin: "openconfig", Target: "dut"}, 3, []*gnmipb.Update{
+		hardwarePort("Ethernet3/1/2", "Ethernet3/1"),
+	})
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Translate() after OnTargetDisconnect() returned an unexpected diff (-want +got):\n%s", diff)
+	}
+}
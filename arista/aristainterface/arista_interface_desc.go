@@ -16,12 +16,14 @@
 package aristainterface
 
 import (
+	"fmt"
 	"strings"
 
-	log "github.com/golang/glog"
 	"github.com/openconfig/functional-translators/arista/aristainterface/yang/openconfig"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/simplemapper"
 	"github.com/openconfig/functional-translators/translator"
 
@@ -45,8 +47,22 @@ func descDeleteHandler(n *gnmipb.Notification) ([]*gnmipb.Path, error) {
 	return returnDeletes, nil
 }
 
+func init() {
+	registry.Register(ftconsts.AristaInterfaceDescriptionFunctionalTranslator, NewDescFTE)
+}
+
 // NewDescFT returns a new FunctionalTranslator for Arista interface descriptions.
 func NewDescFT() *translator.FunctionalTranslator {
+	ft, err := NewDescFTE()
+	if err != nil {
+		log.Fatalf("Failed to create Arista interface description functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewDescFTE is like NewDescFT, but returns an error instead of exiting if the translator cannot be
+// created.
+func NewDescFTE() (*translator.FunctionalTranslator, error) {
 	m, err := simplemapper.NewSimpleMapper(openconfig.Schema, openconfig.Schema,
 		map[string]string{
 			"/openconfig/interfaces/interface[name=<interfaceName>]/state/description": "/openconfig/interfaces/interface[name=<interfaceName>]/config/description",
@@ -54,7 +70,7 @@ func NewDescFT() *translator.FunctionalTranslator {
 		descDeleteHandler,
 	)
 	if err != nil {
-		log.Fatalf("Failed to create mapper: %v", err)
+		return nil, fmt.Errorf("failed to create mapper: %v", err)
 	}
 
 	p := ftutilities.MustStringMapPaths(m.OutputToInputSchemaStrings())
@@ -72,7 +88,7 @@ func NewDescFT() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Arista interface description functional translator: %v", err)
+		return nil, err
 	}
-	return ft
+	return ft, nil
 }
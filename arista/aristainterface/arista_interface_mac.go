@@ -15,12 +15,16 @@
 package aristainterface
 
 import (
+	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
-	log "github.com/golang/glog"
 	"github.com/openconfig/functional-translators/arista/aristainterface/yang/openconfig"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/simplemapper"
 	"github.com/openconfig/functional-translators/translator"
 
@@ -69,8 +73,22 @@ func macDeleteHandler(n *gnmipb.Notification) ([]*gnmipb.Path, error) {
 	return returnDeletes, nil
 }
 
+func init() {
+	registry.Register(ftconsts.AristaInterfaceMacFunctionalTranslator, NewMacFTE)
+}
+
 // NewMacFT returns a new FunctionalTranslator for Arista interface mac addresses.
 func NewMacFT() *translator.FunctionalTranslator {
+	ft, err := NewMacFTE()
+	if err != nil {
+		log.Fatalf("Failed to create Arista interface MAC functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewMacFTE is like NewMacFT, but returns an error instead of exiting if the translator cannot be
+// created.
+func NewMacFTE() (*translator.FunctionalTranslator, error) {
 	m, err := simplemapper.NewSimpleMapper(openconfig.Schema, openconfig.Schema,
 		map[string]string{
 			"/openconfig/interfaces/interface[name=<lagIntfName>]/ethernet/state/mac-address":      "/openconfig/lacp/interfaces/interface[name=<lagIntfName>]/state/system-id-mac",
@@ -79,16 +97,28 @@ func NewMacFT() *translator.FunctionalTranslator {
 		macDeleteHandler,
 	)
 	if err != nil {
-		log.Fatalf("Failed to create mapper: %v", err)
+		return nil, fmt.Errorf("failed to create mapper: %v", err)
 	}
 
-	p := ftutilities.MustStringMapPaths(m.OutputToInputSchemaStrings())
+	// The interface names are subscribed to learn the interfaces of the breakout ports.
+	outputToInput := maps.Clone(m.OutputToInputSchemaStrings())
+	outputToInput[intfMacSchema] = append(slices.Clone(outputToInput[intfMacSchema]), intfNameSchema)
+	outputToInput[hardwarePortSchema] = []string{intfNameSchema, intfMacSchema}
+	p := ftutilities.MustStringMapPaths(outputToInput)
 
+	b := newBreakout()
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
-			Translate:        m.Handler,
+			Translate:        b.wrap(m.Handler),
 			ID:               ftconsts.AristaInterfaceMacFunctionalTranslator,
 			OutputToInputMap: p,
+			State: &translator.StateOptions{
+				Reset:        b.reset,
+				State:        b.state,
+				RestoreState: b.restoreState,
+				Store:        b.ports,
+				ResetTarget:  b.resetTarget,
+			},
 			Metadata: []*translator.FTMetadata{
 				{
 					Vendor: "arista",
@@ -97,7 +127,7 @@ func NewMacFT() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Arista interface MAC functional translator: %v", err)
+		return nil, err
 	}
-	return ft
+	return ft, nil
 }
@@ -0,0 +1,344 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aristalldp translates the Arista LLDP neighbor tables from native to openconfig.
+//
+// The remote systems learned on a port are translated to the neighbors of the interface under
+// /lldp/interfaces/interface/neighbors/neighbor, keyed by the native remote system index, for the
+// EOS versions which do not report them in openconfig. When a neighbor ages out, its remote system
+// is deleted from the native table, and the neighbor is deleted.
+//
+// It is not meant to be used alongside the openconfig LLDP neighbors of the versions which report
+// them. It is therefore not registered for the automatic selection of the translators of a device:
+// consumers create it with New for the affected devices, e.g. in the Translators of the
+// functionaltranslators options.
+package aristalldp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	leafSysName          = "sysName"
+	leafSysDesc          = "sysDesc"
+	leafChassisID        = "chassisId"
+	leafChassisIDSubtype = "chassisIdSubtype"
+	leafPortID           = "portId"
+	leafPortIDSubtype    = "portIdSubtype"
+	leafPortDesc         = "portDesc"
+	leafTTL              = "ttl"
+
+	// remoteSystem is the native container of the neighbors of a port, keyed by index.
+	remoteSystem = "remoteSystem"
+)
+
+var (
+	// Arista does not support `*` subscription for the native paths.
+	// Therefore, we need to subscribe to the longest prefix/container of a path.
+	// Example:
+	// for native path: /eos_native/Sysdb/l2discovery/lldp/status/all/portStatus/<intf>/remoteSystem/<id>/sysName
+	// Subscribe to: /eos_native/Sysdb/l2discovery/lldp/status/all/portStatus
+	translateMap = map[string][]string{
+		"/openconfig/lldp/interfaces/interface/neighbors/neighbor/state/id": {
+			"/eos_native/Sysdb/l2discovery/lldp/status/all/portStatus",
+		},
+		"/openconfig/lldp/interfaces/interface/neighbors/neighbor/state/system-name": {
+			"/eos_native/Sysdb/l2discovery/lldp/status/all/portStatus",
+		},
+		"/openconfig/lldp/interfaces/interface/neighbors/neighbor/state/system-description": {
+			"/eos_native/Sysdb/l2discovery/lldp/status/all/portStatus",
+		},
+		"/openconfig/lldp/interfaces/interface/neighbors/neighbor/state/chassis-id": {
+			"/eos_native/Sysdb/l2discovery/lldp/status/all/portStatus",
+		},
+		"/openconfig/lldp/interfaces/interface/neighbors/neighbor/state/chassis-id-type": {
+			"/eos_native/Sysdb/l2discovery/lldp/status/all/portStatus",
+		},
+		"/openconfig/lldp/interfaces/interface/neighbors/neighbor/state/port-id": {
+			"/eos_native/Sysdb/l2discovery/lldp/status/all/portStatus",
+		},
+		"/openconfig/lldp/interfaces/interface/neighbors/neighbor/state/port-id-type": {
+			"/eos_native/Sysdb/l2discovery/lldp/status/all/portStatus",
+		},
+		"/openconfig/lldp/interfaces/interface/neighbors/neighbor/state/port-description": {
+			"/eos_native/Sysdb/l2discovery/lldp/status/all/portStatus",
+		},
+		"/openconfig/lldp/interfaces/interface/neighbors/neighbor/state/ttl": {
+			"/eos_native/Sysdb/l2discovery/lldp/status/all/portStatus",
+		},
+	}
+	paths = ftutilities.MustStringMapPaths(translateMap)
+	// portPrefix is the native container holding the LLDP status of all ports.
+	portPrefix = []string{"Sysdb", "l2discovery", "lldp", "status", "all", "portStatus"}
+
+	// ocLeaves maps the native remote system leaves to the openconfig neighbor state leaves.
+	ocLeaves = map[string]string{
+		leafSysName:          "system-name",
+		leafSysDesc:          "system-description",
+		leafChassisID:        "chassis-id",
+		leafChassisIDSubtype: "chassis-id-type",
+		leafPortID:           "port-id",
+		leafPortIDSubtype:    "port-id-type",
+		leafPortDesc:         "port-description",
+		leafTTL:              "ttl",
+	}
+	// chassisIDTypes maps the native chassis id subtypes to the openconfig chassis-id-type values.
+	chassisIDTypes = map[string]string{
+		"chassisComponent": "CHASSIS_COMPONENT",
+		"interfaceAlias":   "INTERFACE_ALIAS",
+		"portComponent":    "PORT_COMPONENT",
+		"macAddress":       "MAC_ADDRESS",
+		"networkAddress":   "NETWORK_ADDRESS",
+		"interfaceName":    "INTERFACE_NAME",
+		"local":            "LOCAL",
+	}
+	// portIDTypes maps the native port id subtypes to the openconfig port-id-type values.
+	portIDTypes = map[string]string{
+		"interfaceAlias": "INTERFACE_ALIAS",
+		"portComponent":  "PORT_COMPONENT",
+		"macAddress":     "MAC_ADDRESS",
+		"networkAddress": "NETWORK_ADDRESS",
+		"interfaceName":  "INTERFACE_NAME",
+		"agentCircuitId": "AGENT_CIRCUIT_ID",
+		"local":          "LOCAL",
+	}
+)
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Arista LLDP functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	ft, err := translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:               ftconsts.AristaLLDPTranslator,
+			Translate:        translate,
+			OutputToInputMap: paths,
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorArista,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ft, nil
+}
+
+// nativePath is a parsed native LLDP port status path.
+type nativePath struct {
+	// intf is empty if the path addresses the container of all ports.
+	intf string
+	// id is the index of the remote system, empty if the path addresses all of them.
+	id string
+	// leaf is the native leaf of the remote system, empty if the path addresses a container.
+	leaf string
+}
+
+// parsePath parses a native LLDP port status path. As eos_native paths have no keys, interface
+// names that contain "/" span multiple path elements, up to the remoteSystem container.
+func parsePath(path *gnmipb.Path) (nativePath, bool) {
+	if path.GetOrigin() != "eos_native" {
+		return nativePath{}, false
+	}
+	elems := path.GetElem()
+	if len(elems) < len(portPrefix) {
+		return nativePath{}, false
+	}
+	for i, name := range portPrefix {
+		if elems[i].GetName() != name {
+			return nativePath{}, false
+		}
+	}
+	var p nativePath
+	names := elems[len(portPrefix):]
+	// The first element is part of the interface name, even if it is named remoteSystem.
+	for i := 1; i < len(names); i++ {
+		if names[i].GetName() != remoteSystem {
+			continue
+		}
+		switch tail := names[i+1:]; len(tail) {
+		case 0:
+		case 1:
+			p.id = tail[0].GetName()
+		case 2:
+			p.id, p.leaf = tail[0].GetName(), tail[1].GetName()
+		default:
+			// Nested containers of a remote system, e.g. its management addresses, are not
+			// translated.
+			return nativePath{}, false
+		}
+		names = names[:i]
+		break
+	}
+	var intf []string
+	for _, e := range names {
+		intf = append(intf, e.GetName())
+	}
+	p.intf = strings.Join(intf, "/")
+	return p, true
+}
+
+// neighborsPath returns the path of the neighbors of the interface, the neighbor with the given id
+// if set, and its state leaf if set.
+func neighborsPath(intf, id, leaf string) *gnmipb.Path {
+	p := &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "lldp"},
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": intf}},
+			{Name: "neighbors"},
+		},
+	}
+	if id == "" {
+		return p
+	}
+	p.Elem = append(p.Elem, &gnmipb.PathElem{Name: "neighbor", Key: map[string]string{"id": id}})
+	if leaf != "" {
+		p.Elem = append(p.Elem, &gnmipb.PathElem{Name: "state"}, &gnmipb.PathElem{Name: leaf})
+	}
+	return p
+}
+
+func stringValue(s string) *gnmipb.TypedValue {
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: s}}
+}
+
+// leafValue returns the openconfig value of a native remote system leaf.
+func leafValue(leaf string, val *gnmipb.TypedValue) (*gnmipb.TypedValue, error) {
+	switch leaf {
+	case leafTTL:
+		ttl, err := ftutilities.ToUint64(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", leaf, err)
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: ttl}}, nil
+	}
+	s, ok := val.GetValue().(*gnmipb.TypedValue_StringVal)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type %T for %s", val.GetValue(), leaf)
+	}
+	var types map[string]string
+	switch leaf {
+	case leafChassisIDSubtype:
+		types = chassisIDTypes
+	case leafPortIDSubtype:
+		types = portIDTypes
+	default:
+		return val, nil
+	}
+	t, ok := types[s.StringVal]
+	if !ok {
+		return nil, fmt.Errorf("unknown %s %q", leaf, s.StringVal)
+	}
+	return stringValue(t), nil
+}
+
+// translateDelete returns the openconfig delete of a native delete, or nil if it cannot be
+// translated.
+func translateDelete(p nativePath) *gnmipb.Path {
+	switch {
+	case p.intf == "":
+		// The ports are not cached, so a delete of all of them cannot be translated.
+		return nil
+	case p.leaf != "":
+		if _, ok := ocLeaves[p.leaf]; !ok {
+			return nil
+		}
+		return neighborsPath(p.intf, p.id, ocLeaves[p.leaf])
+	default:
+		// The delete of a remote system, when the neighbor ages out, or of all the remote
+		// systems or the whole port.
+		return neighborsPath(p.intf, p.id, "")
+	}
+}
+
+func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	var (
+		updates []*gnmipb.Update
+		deletes []*gnmipb.Path
+	)
+	for _, d := range n.GetDelete() {
+		p, ok := parsePath(ftutilities.Join(n.GetPrefix(), d))
+		if !ok {
+			continue
+		}
+		if del := translateDelete(p); del != nil {
+			deletes = append(deletes, del)
+		} else {
+			log.V(1).Infof("Ignoring delete %v", d)
+		}
+	}
+	// neighbors holds the neighbors whose id leaf is already translated, by interface.
+	neighbors := map[string]map[string]bool{}
+	for _, u := range n.GetUpdate() {
+		p, ok := parsePath(ftutilities.Join(n.GetPrefix(), u.GetPath()))
+		if !ok || p.intf == "" || p.id == "" {
+			continue
+		}
+		ocLeaf, ok := ocLeaves[p.leaf]
+		if !ok {
+			continue
+		}
+		v, err := leafValue(p.leaf, u.GetVal())
+		if err != nil {
+			log.Errorf("Failed to translate update %v: %v", u, err)
+			continue
+		}
+		updates = append(updates, &gnmipb.Update{Path: neighborsPath(p.intf, p.id, ocLeaf), Val: v})
+		if !neighbors[p.intf][p.id] {
+			if neighbors[p.intf] == nil {
+				neighbors[p.intf] = map[string]bool{}
+			}
+			neighbors[p.intf][p.id] = true
+			updates = append(updates, &gnmipb.Update{Path: neighborsPath(p.intf, p.id, "id"), Val: stringValue(p.id)})
+		}
+	}
+	if len(updates) == 0 && len(deletes) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+				Delete: deletes,
+			},
+		},
+	}, nil
+}
@@ -18,10 +18,12 @@ package aristamacseccounters
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
-	log "github.com/golang/glog"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -133,15 +135,35 @@ var (
 		"outDiscards":      "tx-pkts-dropped",
 		"inDiscards":       "rx-pkts-dropped",
 	}
+	// subscriptionModes samples the counters at the interval at which the device updates them.
+	subscriptionModes = map[string]*translator.SubscriptionMode{
+		"/eos_native/Smash/counters/ethIntf/SandCounters/current/counter": {Mode: gnmipb.SubscriptionMode_SAMPLE, SampleInterval: 30 * time.Second},
+		"/eos_native/Smash/hardware/counter/macsec":                       {Mode: gnmipb.SubscriptionMode_SAMPLE, SampleInterval: 30 * time.Second},
+		"/eos_native/Smash/macsec/counters/msgCounter":                    {Mode: gnmipb.SubscriptionMode_SAMPLE, SampleInterval: 30 * time.Second},
+	}
 )
 
+func init() {
+	registry.Register(ftconsts.AristaMacsecCountersTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Arista MACSec counters functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
-			ID:               ftconsts.AristaMacsecCountersTranslator,
-			Translate:        translate,
-			OutputToInputMap: ftutilities.MustStringMapPaths(translateMap),
+			ID:                ftconsts.AristaMacsecCountersTranslator,
+			Translate:         translate,
+			OutputToInputMap:  ftutilities.MustStringMapPaths(translateMap),
+			SubscriptionModes: subscriptionModes,
 			Metadata: []*translator.FTMetadata{
 				{
 					Vendor: ftconsts.VendorArista,
@@ -154,9 +176,9 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Arista MACSec counters functional translator: %v", err)
+		return nil, err
 	}
-	return ft
+	return ft, nil
 }
 
 func outgoingVal(fullPath *gnmipb.Path, incomingVal *gnmipb.TypedValue) (*gnmipb.TypedValue, error) {
@@ -12,17 +12,21 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package aristamacsecstate translates the interface MACSec state from native to openconfig.
+// Package aristamacsecstate translates the interface MACSec state and secure channel counters from
+// native to openconfig.
 package aristamacsecstate
 
 import (
 	"fmt"
 	"maps"
+	"slices"
 	"sort"
+	"strings"
 
-	log "github.com/golang/glog"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -42,6 +46,43 @@ var (
 		"/openconfig/macsec/interfaces/interface/state/ckn": {
 			"/eos_native/Sysdb/macsec/mkaStatus/portStatus",
 		},
+		"/openconfig/macsec/interfaces/interface/mka/sessions/session/state/ckn": {
+			"/eos_native/Sysdb/macsec/mkaStatus/portStatus",
+		},
+		"/openconfig/macsec/interfaces/interface/mka/sessions/session/state/status": {
+			"/eos_native/Sysdb/macsec/status/cpStatus",
+			"/eos_native/Sysdb/macsec/mkaStatus/portStatus",
+		},
+		"/openconfig/macsec/interfaces/interface/scsa-tx/scsa-tx/state/sci-tx": {
+			"/eos_native/Sysdb/macsec/counters",
+		},
+		"/openconfig/macsec/interfaces/interface/scsa-tx/scsa-tx/state/counters/sc-encrypted": {
+			"/eos_native/Sysdb/macsec/counters",
+		},
+		"/openconfig/macsec/interfaces/interface/scsa-tx/scsa-tx/state/counters/sa-encrypted": {
+			"/eos_native/Sysdb/macsec/counters",
+		},
+		"/openconfig/macsec/interfaces/interface/scsa-tx/scsa-tx/state/counters/sc-auth-only": {
+			"/eos_native/Sysdb/macsec/counters",
+		},
+		"/openconfig/macsec/interfaces/interface/scsa-tx/scsa-tx/state/counters/sa-auth-only": {
+			"/eos_native/Sysdb/macsec/counters",
+		},
+		"/openconfig/macsec/interfaces/interface/scsa-rx/scsa-rx/state/sci-rx": {
+			"/eos_native/Sysdb/macsec/counters",
+		},
+		"/openconfig/macsec/interfaces/interface/scsa-rx/scsa-rx/state/counters/sc-valid": {
+			"/eos_native/Sysdb/macsec/counters",
+		},
+		"/openconfig/macsec/interfaces/interface/scsa-rx/scsa-rx/state/counters/sa-valid": {
+			"/eos_native/Sysdb/macsec/counters",
+		},
+		"/openconfig/macsec/interfaces/interface/scsa-rx/scsa-rx/state/counters/sc-invalid": {
+			"/eos_native/Sysdb/macsec/counters",
+		},
+		"/openconfig/macsec/interfaces/interface/scsa-rx/scsa-rx/state/counters/sa-invalid": {
+			"/eos_native/Sysdb/macsec/counters",
+		},
 	}
 	paths        = ftutilities.MustStringMapPaths(translateMap)
 	pathPatterns = []*gnmipb.Path{
@@ -74,6 +115,60 @@ var (
 			},
 		},
 	}
+	// counterPattern matches the native counters of the secure channels:
+	// Sysdb/macsec/counters/<interface-id>/<txSc|rxSc>/<SCI>/<counter>
+	counterPattern = &gnmipb.Path{
+		Origin: "eos_native",
+		Elem: []*gnmipb.PathElem{
+			{Name: "Sysdb"}, {Name: "macsec"}, {Name: "counters"},
+			{Name: "*"}, // interface-id
+			{Name: "*"}, // direction
+			{Name: "*"}, // SCI
+			{Name: "*"}, // counter
+		},
+	}
+	// counterDeletePatterns match the deletes of the counters of all the secure channels of an
+	// interface, and of one secure channel.
+	counterDeletePatterns = []*gnmipb.Path{
+		{
+			Origin: "eos_native",
+			Elem: []*gnmipb.PathElem{
+				{Name: "Sysdb"}, {Name: "macsec"}, {Name: "counters"},
+				{Name: "*"}, // interface-id
+			},
+		},
+		{
+			Origin: "eos_native",
+			Elem: []*gnmipb.PathElem{
+				{Name: "Sysdb"}, {Name: "macsec"}, {Name: "counters"},
+				{Name: "*"}, // interface-id
+				{Name: "*"}, // direction
+				{Name: "*"}, // SCI
+			},
+		},
+	}
+	// secureChannelLists maps the native directions of the secure channels to the openconfig lists.
+	secureChannelLists = map[string]string{
+		"txSc": "scsa-tx",
+		"rxSc": "scsa-rx",
+	}
+	// secureChannelCounters maps the native counters of the secure channels to the openconfig
+	// counters, by direction. Protected packets are the packets sent with integrity protection
+	// only, and decrypted packets are the packets received and validated.
+	secureChannelCounters = map[string]map[string]string{
+		"txSc": {
+			"scEncryptedPkts": "sc-encrypted",
+			"saEncryptedPkts": "sa-encrypted",
+			"scProtectedPkts": "sc-auth-only",
+			"saProtectedPkts": "sa-auth-only",
+		},
+		"rxSc": {
+			"scDecryptedPkts":      "sc-valid",
+			"saDecryptedPkts":      "sa-valid",
+			"scValidationFailures": "sc-invalid",
+			"saValidationFailures": "sa-invalid",
+		},
+	}
 	deletePathPatterns = []*gnmipb.Path{
 		{
 			Origin: "eos_native",
@@ -99,15 +194,49 @@ var (
 			},
 		},
 	}
+	// subscriptionModes streams the status of the interfaces on change, the counters being left to
+	// the device.
+	subscriptionModes = map[string]*translator.SubscriptionMode{
+		"/eos_native/Sysdb/macsec/status/cpStatus":      {Mode: gnmipb.SubscriptionMode_ON_CHANGE},
+		"/eos_native/Sysdb/macsec/mkaStatus/portStatus": {Mode: gnmipb.SubscriptionMode_ON_CHANGE},
+	}
 )
 
+type impl struct {
+	cache *ftutilities.AristaMACSecMapCache
+}
+
+func init() {
+	registry.Register(ftconsts.AristaMacsecStateFunctionalTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Arista MACsec state functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	i := &impl{
+		cache: ftutilities.NewAristaMACSecMapCache(),
+	}
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
-			ID:               ftconsts.AristaMacsecStateFunctionalTranslator,
-			Translate:        translate,
-			OutputToInputMap: paths,
+			ID:                ftconsts.AristaMacsecStateFunctionalTranslator,
+			Translate:         i.translate,
+			Sync:              i.sync,
+			OutputToInputMap:  paths,
+			SubscriptionModes: subscriptionModes,
+			State: &translator.StateOptions{
+				Reset:        i.cache.ClearAllTargetMacSecInfo,
+				State:        func() any { return i.cache.Clone() },
+				RestoreState: i.restoreState,
+				Store:        i.cache,
+			},
 			Metadata: []*translator.FTMetadata{
 				{
 					Vendor: ftconsts.VendorArista,
@@ -120,9 +249,19 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Arista MACsec state functional translator: %v", err)
+		return nil, err
 	}
-	return ft
+	return ft, nil
+}
+
+// restoreState replaces the cache with a snapshot returned by State.
+func (i *impl) restoreState(snapshot any) error {
+	cache, ok := snapshot.(*ftutilities.AristaMACSecMapCache)
+	if !ok {
+		return fmt.Errorf("unexpected state type %T", snapshot)
+	}
+	i.cache.Restore(cache)
+	return nil
 }
 
 // interfaceIDAndCKN returns the interface ID and CKN from the path for an update.
@@ -197,17 +336,24 @@ func extractDeleteInfo(path *gnmipb.Path) (*deleteInfo, error) {
 }
 
 // deleteHandler updates the cache based on delete notifications.
-// It returns a map of interfaces that need an OC Delete, and a map of interfaces that need an OC Update.
-func deleteHandler(n *gnmipb.Notification) (interfacesForOCDelete, interfacesForOCUpdate map[string]bool) {
+// It returns a map of interfaces that need an OC Delete, a map of interfaces that need an OC Update,
+// the CKNs removed from the interfaces that remain, whose MKA sessions need an OC Delete, and the
+// OC Deletes of the secure channels whose counters are removed.
+func (i *impl) deleteHandler(n *gnmipb.Notification) (interfacesForOCDelete, interfacesForOCUpdate map[string]bool, deletedCKNs map[string][]string, counterDeletes []*gnmipb.Path) {
 	prefix := n.GetPrefix()
 	deletes := n.GetDelete()
 	target := prefix.GetTarget()
 
 	interfacesForOCDelete = make(map[string]bool)
 	interfacesForOCUpdate = make(map[string]bool)
+	deletedCKNs = make(map[string][]string)
 
 	for _, del := range deletes {
 		fullPath := ftutilities.Join(prefix, del)
+		if paths, ok := i.counterDeletes(fullPath, target); ok {
+			counterDeletes = append(counterDeletes, paths...)
+			continue
+		}
 		deleteInfo, err := extractDeleteInfo(fullPath)
 		if err != nil {
 			log.Errorf("failed to extract interface ID or CKN from delete path %v after matching a pattern: %v", fullPath, err)
@@ -219,7 +365,7 @@ func deleteHandler(n *gnmipb.Notification) (interfacesForOCDelete, interfacesFor
 		}
 
 		// Check if the map for the specific target exists
-		if targetInfo, targetExists := ftutilities.AristaMACSecMap.RetrieveTargetMacSecInfo(target); targetExists {
+		if targetInfo, targetExists := i.cache.RetrieveTargetMacSecInfo(target); targetExists {
 			ifaceInfo, ok := targetInfo.InterfaceInfo(deleteInfo.intfID)
 			if !ok {
 				log.V(1).Infof("interface '%s' on target '%s' not found for delete handler.", deleteInfo.intfID, target)
@@ -227,17 +373,20 @@ func deleteHandler(n *gnmipb.Notification) (interfacesForOCDelete, interfacesFor
 			}
 			switch deleteInfo.deleteType {
 			case "intf-delete":
+				counterDeletes = append(counterDeletes, secureChannelDeletes(deleteInfo.intfID, ifaceInfo)...)
 				targetInfo.ClearInterfaceInfo(deleteInfo.intfID)
 				interfacesForOCDelete[deleteInfo.intfID] = true
 				if len(targetInfo.Interfaces) == 0 {
 					log.V(1).Infof("no more interfaces for target '%s', removing target from map.", target)
-					ftutilities.AristaMACSecMap.DeleteTargetMacSecInfo(target)
+					i.cache.DeleteTargetMacSecInfo(target)
 				}
 			case "ckn-delete":
 				ifaceInfo.RemoveCkn(deleteInfo.ckn)
 				interfacesForOCUpdate[deleteInfo.intfID] = true
+				deletedCKNs[deleteInfo.intfID] = append(deletedCKNs[deleteInfo.intfID], deleteInfo.ckn)
 				if len(ifaceInfo.CloneStatuses()) == 0 {
 					log.V(1).Infof("no more CKNs for interface '%s' on target '%s', removing interface from map.", deleteInfo.intfID, target)
+					counterDeletes = append(counterDeletes, secureChannelDeletes(deleteInfo.intfID, ifaceInfo)...)
 					targetInfo.ClearInterfaceInfo(deleteInfo.intfID)
 					interfacesForOCDelete[deleteInfo.intfID] = true
 				}
@@ -247,7 +396,58 @@ func deleteHandler(n *gnmipb.Notification) (interfacesForOCDelete, interfacesFor
 			}
 		}
 	}
-	return interfacesForOCDelete, interfacesForOCUpdate
+	return interfacesForOCDelete, interfacesForOCUpdate, deletedCKNs, counterDeletes
+}
+
+// counterDeletes returns the OC Deletes of the secure channels whose native counters are deleted
+// by the path, and removes the secure channels from the cache. It returns false if the path is not
+// a delete of counters.
+func (i *impl) counterDeletes(path *gnmipb.Path, target string) ([]*gnmipb.Path, bool) {
+	if !ftutilities.MatchPath(path, counterDeletePatterns[0]) && !ftutilities.MatchPath(path, counterDeletePatterns[1]) {
+		return nil, false
+	}
+	elems := path.GetElem()
+	intfName := elems[3].GetName()
+	var ifaceInfo *ftutilities.InterfaceMacSecInfo
+	if targetInfo, ok := i.cache.RetrieveTargetMacSecInfo(target); ok {
+		ifaceInfo, _ = targetInfo.InterfaceInfo(intfName)
+	}
+	// Interface level delete:
+	// Sysdb/macsec/counters/<interface-id>
+	if len(elems) == 4 {
+		if ifaceInfo != nil {
+			ifaceInfo.ClearSecureChannels()
+		}
+		var deletes []*gnmipb.Path
+		for _, direction := range slices.Sorted(maps.Keys(secureChannelLists)) {
+			deletes = append(deletes, returnPathForSecureChannels(intfName, direction, ""))
+		}
+		return deletes, true
+	}
+	// Secure channel level delete:
+	// Sysdb/macsec/counters/<interface-id>/<txSc|rxSc>/<SCI>
+	direction, sci := elems[4].GetName(), elems[5].GetName()
+	if _, ok := secureChannelLists[direction]; !ok {
+		log.V(1).Infof("delete path %v has unknown secure channel direction %q.", path, direction)
+		return nil, true
+	}
+	if ifaceInfo != nil {
+		ifaceInfo.RemoveSecureChannel(direction, sci)
+	}
+	return []*gnmipb.Path{returnPathForSecureChannels(intfName, direction, sci)}, true
+}
+
+// secureChannelDeletes returns the OC Deletes of the secure channels of the interface, if the
+// counters of any were translated.
+func secureChannelDeletes(intfName string, ifaceInfo *ftutilities.InterfaceMacSecInfo) []*gnmipb.Path {
+	if !ifaceInfo.HasSecureChannels() {
+		return nil
+	}
+	var deletes []*gnmipb.Path
+	for _, direction := range slices.Sorted(maps.Keys(secureChannelLists)) {
+		deletes = append(deletes, returnPathForSecureChannels(intfName, direction, ""))
+	}
+	return deletes
 }
 
 // returnPathForMACSecStatus returns a gNMI path for MACSec status of the given interface.
@@ -288,8 +488,119 @@ func returnPathForMACSecCKN(interfaceName string) *gnmipb.Path {
 	}
 }
 
+// returnPathForMKASessions returns a gNMI path for the MKA sessions of the given interface, or for
+// the MKA session of the CKN if it is not empty.
+// Does not set the origin or the target.
+func returnPathForMKASessions(interfaceName, ckn string) *gnmipb.Path {
+	path := &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "macsec"},
+			{Name: "interfaces"},
+			{
+				Name: "interface",
+				Key: map[string]string{
+					"name": interfaceName,
+				},
+			},
+			{Name: "mka"},
+			{Name: "sessions"},
+		},
+	}
+	if ckn != "" {
+		path.Elem = append(path.Elem, &gnmipb.PathElem{Name: "session", Key: map[string]string{"ckn": ckn}})
+	}
+	return path
+}
+
+// mkaSessionUpdates returns the updates of the MKA session of a CKN of the given interface.
+func mkaSessionUpdates(interfaceName, ckn, status string) []*gnmipb.Update {
+	leaf := func(name, value string) *gnmipb.Update {
+		path := returnPathForMKASessions(interfaceName, ckn)
+		path.Elem = append(path.Elem, &gnmipb.PathElem{Name: "state"}, &gnmipb.PathElem{Name: name})
+		return &gnmipb.Update{
+			Path: path,
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: value}},
+		}
+	}
+	return []*gnmipb.Update{leaf("ckn", ckn), leaf("status", status)}
+}
+
+// returnPathForSecureChannels returns a gNMI path for the secure channels of the given interface in
+// the native direction, e.g. txSc, or for the secure channel of the SCI if it is not empty.
+// Does not set the origin or the target.
+func returnPathForSecureChannels(interfaceName, direction, sci string) *gnmipb.Path {
+	list := secureChannelLists[direction]
+	path := &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "macsec"},
+			{Name: "interfaces"},
+			{
+				Name: "interface",
+				Key: map[string]string{
+					"name": interfaceName,
+				},
+			},
+			{Name: list},
+		},
+	}
+	if sci != "" {
+		// The key of scsa-tx is sci-tx, and the key of scsa-rx is sci-rx.
+		key := "sci-" + strings.TrimPrefix(list, "scsa-")
+		path.Elem = append(path.Elem, &gnmipb.PathElem{Name: list, Key: map[string]string{key: sci}})
+	}
+	return path
+}
+
+// secureChannelLeaf returns the update of a state leaf of the secure channel.
+func secureChannelLeaf(interfaceName, direction, sci string, val *gnmipb.TypedValue, elems ...string) *gnmipb.Update {
+	path := returnPathForSecureChannels(interfaceName, direction, sci)
+	path.Elem = append(path.Elem, &gnmipb.PathElem{Name: "state"})
+	for _, e := range elems {
+		path.Elem = append(path.Elem, &gnmipb.PathElem{Name: e})
+	}
+	return &gnmipb.Update{Path: path, Val: val}
+}
+
+// counterValue returns the value of a native counter as an unsigned integer.
+func counterValue(val *gnmipb.TypedValue) (*gnmipb.TypedValue, error) {
+	v, err := ftutilities.ToUint64(val)
+	if err != nil {
+		return nil, fmt.Errorf("invalid counter: %v", err)
+	}
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}}, nil
+}
+
+// counterUpdates returns the OC Updates of a native counter of a secure channel, including its key
+// leaf if the secure channel is not in seen, and records the secure channel of the interface in the
+// cache. It returns false if the update is not a known counter.
+func (i *impl) counterUpdates(prefix *gnmipb.Path, update *gnmipb.Update, target string, seen map[string]bool) ([]*gnmipb.Update, bool, error) {
+	fullPath := ftutilities.Join(prefix, update.GetPath())
+	if !ftutilities.MatchPath(fullPath, counterPattern) {
+		return nil, false, nil
+	}
+	elems := fullPath.GetElem()
+	interfaceName, direction, sci := elems[3].GetName(), elems[4].GetName(), elems[5].GetName()
+	counter, ok := secureChannelCounters[direction][elems[6].GetName()]
+	if !ok {
+		log.V(1).Infof("ignoring unknown secure channel counter %v", fullPath)
+		return nil, false, nil
+	}
+	val, err := counterValue(update.GetVal())
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to translate counter %v: %v", fullPath, err)
+	}
+	i.cache.CreateOrUpdateTargetMacSecInfo(target).CreateOrGetInterface(interfaceName).AddSecureChannel(direction, sci)
+	var updates []*gnmipb.Update
+	if key := strings.Join([]string{interfaceName, direction, sci}, "/"); !seen[key] {
+		seen[key] = true
+		keyLeaf := "sci-" + strings.TrimPrefix(secureChannelLists[direction], "scsa-")
+		updates = append(updates, secureChannelLeaf(interfaceName, direction, sci, &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: sci}}, keyLeaf))
+	}
+	return append(updates, secureChannelLeaf(interfaceName, direction, sci, val, "counters", counter)), true, nil
+}
+
 // metadata populates the MACSec map with the native paths that contribute to the derived MACSec status.
-func metadata(prefix *gnmipb.Path, update *gnmipb.Update, target string) (string, error) {
+func (i *impl) metadata(prefix *gnmipb.Path, update *gnmipb.Update, target string) (string, error) {
 	fullPath := ftutilities.Join(prefix, update.GetPath())
 	matched := false
 	interfaceName, ckn, err := interfaceIDAndCKN(fullPath)
@@ -299,7 +610,7 @@ func metadata(prefix *gnmipb.Path, update *gnmipb.Update, target string) (string
 	for _, pattern := range pathPatterns {
 		if ftutilities.MatchPath(fullPath, pattern) {
 			matched = true
-			targetInfo := ftutilities.AristaMACSecMap.CreateOrUpdateTargetMacSecInfo(target)
+			targetInfo := i.cache.CreateOrUpdateTargetMacSecInfo(target)
 			ifaceInfo := targetInfo.CreateOrGetInterface(interfaceName)
 
 			leafName := fullPath.GetElem()[len(fullPath.GetElem())-1].GetName()
@@ -324,11 +635,11 @@ func metadata(prefix *gnmipb.Path, update *gnmipb.Update, target string) (string
 }
 
 // translateMACSecState returns the MACSec ckn and status for the given interface.
-func translateMACSecState(interfaceName string, target string) (intfMACSecStatus, cknKeys []string, skip bool) {
+func (i *impl) translateMACSecState(interfaceName string, target string) (intfMACSecStatus, cknKeys []string, skip bool) {
 	var success, principal bool
-	targetInfo, ok := ftutilities.AristaMACSecMap.RetrieveTargetMacSecInfo(target)
+	targetInfo, ok := i.cache.RetrieveTargetMacSecInfo(target)
 	if !ok {
-		log.V(1).Infof("target '%s' not found in AristaMACSecMap for status translation.", target)
+		log.V(1).Infof("target '%s' not found in MACsec cache for status translation.", target)
 		return nil, nil, true
 	}
 
@@ -386,10 +697,24 @@ func translateMACSecState(interfaceName string, target string) (intfMACSecStatus
 	return intfMACSecStatus, cknKeys, false
 }
 
-func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+// sync removes the cached interfaces of the target which no longer hold any MACsec status once its
+// initial updates are complete, e.g. interfaces whose cpStatus was deleted. The interfaces are kept
+// if the target is unknown, since they may belong to a target still in its initial updates.
+func (i *impl) sync(target string, _ *gnmipb.SubscribeResponse) error {
+	if target == "" {
+		return nil
+	}
+	i.cache.PruneEmptyInterfaces(target)
+	return nil
+}
+
+func (i *impl) translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
 	if sr.GetUpdate() == nil {
 		return nil, nil
 	}
+	if evicted := i.cache.EvictStaleTargets(); len(evicted) > 0 {
+		log.V(1).Infof("evicted the state of stale targets %v", evicted)
+	}
 	notification := sr.GetUpdate()
 	prefix := notification.GetPrefix()
 	target := prefix.GetTarget()
@@ -397,9 +722,21 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 	var outgoingUpdates []*gnmipb.Update
 	var outgoingDeletes []*gnmipb.Path
 	interfaceSeen := make(map[string]bool)
+	// Translated counters are appended after the status updates.
+	var counterUpdates []*gnmipb.Update
+	secureChannelSeen := make(map[string]bool)
 
 	for _, update := range notification.GetUpdate() {
-		interfaceName, err := metadata(prefix, update, target)
+		updates, isCounter, err := i.counterUpdates(prefix, update, target, secureChannelSeen)
+		if err != nil {
+			log.Errorf("Failed to translate update %v: %v", update, err)
+			continue
+		}
+		if isCounter {
+			counterUpdates = append(counterUpdates, updates...)
+			continue
+		}
+		interfaceName, err := i.metadata(prefix, update, target)
 		if err != nil {
 			return nil, fmt.Errorf("failed to populate MACSec map: %v", err)
 		}
@@ -410,7 +747,7 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 	finalInterfacesForOCUpdate := make(map[string]bool)
 	// Determine final set of interfaces for OC Update
 	// These are interfaces affected by native updates or "modifying" native deletes,
-	interfacesForOCDelete, interfacesForOCUpdate := deleteHandler(notification)
+	interfacesForOCDelete, interfacesForOCUpdate, deletedCKNs, counterDeletes := i.deleteHandler(notification)
 	for intfName := range interfaceSeen {
 		if !interfacesForOCDelete[intfName] {
 			finalInterfacesForOCUpdate[intfName] = true
@@ -422,11 +759,20 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 		}
 	}
 	// Generate final set of deletes
-	for intfName := range interfacesForOCDelete {
-		outgoingDeletes = append(outgoingDeletes, returnPathForMACSecStatus(intfName), returnPathForMACSecCKN(intfName))
+	for _, intfName := range slices.Sorted(maps.Keys(interfacesForOCDelete)) {
+		outgoingDeletes = append(outgoingDeletes, returnPathForMACSecStatus(intfName), returnPathForMACSecCKN(intfName), returnPathForMKASessions(intfName, ""))
+	}
+	for _, intfName := range slices.Sorted(maps.Keys(deletedCKNs)) {
+		if interfacesForOCDelete[intfName] {
+			continue
+		}
+		for _, ckn := range deletedCKNs[intfName] {
+			outgoingDeletes = append(outgoingDeletes, returnPathForMKASessions(intfName, ckn))
+		}
 	}
-	for interfaceName := range finalInterfacesForOCUpdate {
-		intfMACSecStatuses, ckns, skip := translateMACSecState(interfaceName, target)
+	outgoingDeletes = append(outgoingDeletes, counterDeletes...)
+	for _, interfaceName := range slices.Sorted(maps.Keys(finalInterfacesForOCUpdate)) {
+		intfMACSecStatuses, ckns, skip := i.translateMACSecState(interfaceName, target)
 		if skip {
 			continue
 		}
@@ -449,9 +795,14 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_LeaflistVal{LeaflistVal: &gnmipb.ScalarArray{Element: cknElements}}},
 		}
 		outgoingUpdates = append(outgoingUpdates, cknUpdate)
+		for ix, ckn := range ckns {
+			outgoingUpdates = append(outgoingUpdates, mkaSessionUpdates(interfaceName, ckn, intfMACSecStatuses[ix])...)
+		}
 		log.V(1).Infof("target %s: Generating OC Update for status (len %d) and CKN (len %d) leaf-lists for interface '%s'.", target, len(intfMACSecStatuses), len(ckns), interfaceName)
 	}
 
+	outgoingUpdates = append(outgoingUpdates, counterUpdates...)
+
 	if len(outgoingUpdates) == 0 && len(outgoingDeletes) == 0 {
 		return nil, nil
 	}
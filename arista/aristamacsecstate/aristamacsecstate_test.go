@@ -20,6 +20,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/protobuf/testing/protocmp"
 	"github.com/openconfig/functional-translators/ftutilities"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 func TestTranslate(t *testing.T) {
@@ -101,6 +103,26 @@ func TestTranslate(t *testing.T) {
 			wantOutputPath: "testdata/verify_CKN_map_is_initialized_and_usable_output.txt",
 			wantNil:        true,
 		},
+		{
+			name:           "counters_success",
+			inputPath:      "testdata/counters_success_input.txt",
+			wantOutputPath: "testdata/counters_success_output.txt",
+		},
+		{
+			name:           "counters_auth_only",
+			inputPath:      "testdata/counters_auth_only_input.txt",
+			wantOutputPath: "testdata/counters_auth_only_output.txt",
+		},
+		{
+			name:           "counters_delete",
+			inputPath:      "testdata/counters_delete_input.txt",
+			wantOutputPath: "testdata/counters_delete_output.txt",
+		},
+		{
+			name:      "counters_invalid_value",
+			inputPath: "testdata/counters_invalid_value_input.txt",
+			wantNil:   true,
+		},
 		{
 			name:      "invalidpath",
 			inputPath: "testdata/invalidpath_input.txt",
@@ -188,6 +210,44 @@ func TestTranslate(t *testing.T) {
 	}
 }
 
+func TestSecureChannelsDeletedWithInterface(t *testing.T) {
+	ft := New()
+	countersSR, err := ftutilities.LoadSubscribeResponse("testdata/counters_success_input.txt")
+	if err != nil {
+		t.Fatalf("ftutilities.LoadSubscribeResponse() failed: %v", err)
+	}
+	if _, err := ft.Translate(countersSR); err != nil {
+		t.Fatalf("ft.Translate(%v) failed with unexpected err: %v", countersSR, err)
+	}
+	deleteSR := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 124,
+				Prefix:    &gnmipb.Path{Origin: "eos_native", Target: "cx12.sql12"},
+				Delete: []*gnmipb.Path{{
+					Elem: []*gnmipb.PathElem{
+						{Name: "Sysdb"}, {Name: "macsec"}, {Name: "mkaStatus"}, {Name: "portStatus"}, {Name: "Ethernet12"},
+					},
+				}},
+			},
+		},
+	}
+	gotSR, err := ft.Translate(deleteSR)
+	if err != nil {
+		t.Fatalf("ft.Translate(%v) failed with unexpected err: %v", deleteSR, err)
+	}
+	want := []*gnmipb.Path{
+		returnPathForMACSecStatus("Ethernet12"),
+		returnPathForMACSecCKN("Ethernet12"),
+		returnPathForMKASessions("Ethernet12", ""),
+		returnPathForSecureChannels("Ethernet12", "rxSc", ""),
+		returnPathForSecureChannels("Ethernet12", "txSc", ""),
+	}
+	if diff := cmp.Diff(want, gotSR.GetUpdate().GetDelete(), protocmp.Transform()); diff != "" {
+		t.Errorf("ft.Translate(%v) returned unexpected deletes (-want +got):\n%s", deleteSR, diff)
+	}
+}
+
 func TestFinalInterfacesForOCUpdate(t *testing.T) {
 	tests := []struct {
 		name                                  string
@@ -277,3 +337,35 @@ func TestFinalInterfacesForOCUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestInstanceScopedCache(t *testing.T) {
+	countersSR, err := ftutilities.LoadSubscribeResponse("testdata/counters_success_input.txt")
+	if err != nil {
+		t.Fatalf("ftutilities.LoadSubscribeResponse() failed: %v", err)
+	}
+	deleteSR := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 124,
+				Prefix:    &gnmipb.Path{Origin: "eos_native", Target: "cx12.sql12"},
+				Delete: []*gnmipb.Path{{
+					Elem: []*gnmipb.PathElem{
+						{Name: "Sysdb"}, {Name: "macsec"}, {Name: "mkaStatus"}, {Name: "portStatus"}, {Name: "Ethernet12"},
+					},
+				}},
+			},
+		},
+	}
+	first, second := New(), New()
+	if _, err := first.Translate(countersSR); err != nil {
+		t.Fatalf("ft.Translate(%v) failed with unexpected err: %v", countersSR, err)
+	}
+	// The secure channels learned by the first instance are unknown to the second one.
+	if gotSR, err := second.Translate(deleteSR); err != nil || gotSR != nil {
+		t.Errorf("second ft.Translate(%v) = %v, %v, want nil, nil", deleteSR, gotSR, err)
+	}
+	first.Reset()
+	if gotSR, err := first.Translate(deleteSR); err != nil || gotSR != nil {
+		t.Errorf("ft.Translate(%v) after Reset() = %v, %v, want nil, nil", deleteSR, gotSR, err)
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aristapoe translates the Arista power-over-ethernet (PoE) port status from native to
+// openconfig.
+//
+// The enabled state, class and power draw of a port are translated to the openconfig-if-poe leaves
+// under /interfaces/interface/ethernet/poe/state. The allocated power and fault status, which are
+// not modeled by openconfig, are translated to the Arista vendor state of the port under
+// /interfaces/interface/ethernet/poe/state/vendor/Arista/port/state. When a port is disabled, its
+// class, power and fault leaves are deleted, and the native updates of these leaves streamed with
+// the disabled state are ignored.
+package aristapoe
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	leafPortState      = "portState"
+	leafPortClass      = "portClass"
+	leafOutputPower    = "outputPower"
+	leafPowerAllocated = "powerAllocated"
+	leafFaultStatus    = "faultStatus"
+
+	// portStateDisabled is the native state of a port with PoE administratively disabled. All other
+	// states, e.g. searching, powered or fault, are reported as enabled.
+	portStateDisabled = "disabled"
+)
+
+var (
+	// Arista does not support `*` subscription for the native paths.
+	// Therefore, we need to subscribe to the longest prefix/container of a path.
+	// Example:
+	// for native path: /eos_native/Sysdb/hardware/poe/status/port/<intf>/portState
+	// Subscribe to: /eos_native/Sysdb/hardware/poe/status/port
+	translateMap = map[string][]string{
+		"/openconfig/interfaces/interface/ethernet/poe/state/enabled": {
+			"/eos_native/Sysdb/hardware/poe/status/port",
+		},
+		"/openconfig/interfaces/interface/ethernet/poe/state/power-class": {
+			"/eos_native/Sysdb/hardware/poe/status/port",
+		},
+		"/openconfig/interfaces/interface/ethernet/poe/state/power-used": {
+			"/eos_native/Sysdb/hardware/poe/status/port",
+		},
+		"/openconfig/interfaces/interface/ethernet/poe/state/vendor/Arista/port/state/power-allocated": {
+			"/eos_native/Sysdb/hardware/poe/status/port",
+		},
+		"/openconfig/interfaces/interface/ethernet/poe/state/vendor/Arista/port/state/fault-status": {
+			"/eos_native/Sysdb/hardware/poe/status/port",
+		},
+	}
+	paths = ftutilities.MustStringMapPaths(translateMap)
+	// portPrefix is the native container holding the PoE status of all ports.
+	portPrefix = []string{"Sysdb", "hardware", "poe", "status", "port"}
+
+	// vendorState is the path of the Arista vendor state of a port, relative to its poe state.
+	vendorState = []string{"vendor", "Arista", "port", "state"}
+
+	// ocLeaves maps the native leaves to the paths of the openconfig leaves, relative to the poe
+	// state of the port.
+	ocLeaves = map[string][]string{
+		leafPortState:      {"enabled"},
+		leafPortClass:      {"power-class"},
+		leafOutputPower:    {"power-used"},
+		leafPowerAllocated: append(slices.Clone(vendorState), "power-allocated"),
+		leafFaultStatus:    append(slices.Clone(vendorState), "fault-status"),
+	}
+	// disabledDeletes are the native leaves whose openconfig leaves are deleted when PoE is disabled
+	// on a port.
+	disabledDeletes = []string{leafPortClass, leafOutputPower, leafPowerAllocated, leafFaultStatus}
+)
+
+func init() {
+	registry.Register(ftconsts.AristaPoETranslator, NewE)
+}
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Arista PoE functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	ft, err := translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:                   ftconsts.AristaPoETranslator,
+			TranslateWithOptions: translate,
+			ValidateOptions:      validateOptions,
+			OutputToInputMap:     paths,
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorArista,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ft, nil
+}
+
+// parsePath returns the interface and native leaf of a PoE port status path. As eos_native paths
+// have no keys, interface names that contain "/" span multiple path elements. leaf is empty if the
+// path addresses a port container, and intf is empty if it addresses the container of all ports.
+func parsePath(path *gnmipb.Path) (intf, leaf string, ok bool) {
+	if path.GetOrigin() != "eos_native" {
+		return "", "", false
+	}
+	elems := path.GetElem()
+	if len(elems) < len(portPrefix) {
+		return "", "", false
+	}
+	for i, name := range portPrefix {
+		if elems[i].GetName() != name {
+			return "", "", false
+		}
+	}
+	var names []string
+	for _, e := range elems[len(portPrefix):] {
+		names = append(names, e.GetName())
+	}
+	if n := len(names); n >= 2 {
+		if _, ok := ocLeaves[names[n-1]]; ok {
+			return strings.Join(names[:n-1], "/"), names[n-1], true
+		}
+	}
+	return strings.Join(names, "/"), "", true
+}
+
+// poePath returns the path of the poe container of an interface, or of the leaf at elems in its
+// state.
+func poePath(intf string, elems ...string) *gnmipb.Path {
+	p := &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": intf}},
+			{Name: "ethernet"},
+			{Name: "poe"},
+		},
+	}
+	if len(elems) > 0 {
+		p.Elem = append(p.Elem, &gnmipb.PathElem{Name: "state"})
+	}
+	for _, e := range elems {
+		p.Elem = append(p.Elem, &gnmipb.PathElem{Name: e})
+	}
+	return p
+}
+
+// handleUpdate returns the openconfig updates and deletes of a native port leaf, with the power
+// values in the analog precision.
+func handleUpdate(intf, leaf string, val *gnmipb.TypedValue, precision uint32) ([]*gnmipb.Update, []*gnmipb.Path, error) {
+	p := poePath(intf, ocLeaves[leaf]...)
+	switch leaf {
+	case leafPortState, leafFaultStatus:
+		s, ok := val.GetValue().(*gnmipb.TypedValue_StringVal)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected value type %T for %s", val.GetValue(), leaf)
+		}
+		if leaf == leafFaultStatus {
+			return []*gnmipb.Update{{Path: p, Val: val}}, nil, nil
+		}
+		enabled := s.StringVal != portStateDisabled
+		updates := []*gnmipb.Update{{Path: p, Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: enabled}}}}
+		if enabled {
+			return updates, nil, nil
+		}
+		var deletes []*gnmipb.Path
+		for _, l := range disabledDeletes {
+			deletes = append(deletes, poePath(intf, ocLeaves[l]...))
+		}
+		return updates, deletes, nil
+	case leafPortClass:
+		c, err := ftutilities.ToUint64(val)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %v", leaf, err)
+		}
+		return []*gnmipb.Update{{Path: p, Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: c}}}}, nil, nil
+	case leafOutputPower, leafPowerAllocated:
+		w, err := ftutilities.ToDouble(val)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %v", leaf, err)
+		}
+		return []*gnmipb.Update{{Path: p, Val: ftutilities.AnalogValue(w, precision)}}, nil, nil
+	}
+	return nil, nil, nil
+}
+
+// validateOptions rejects an invalid analog precision.
+func validateOptions(opts translator.Options) error {
+	_, err := ftutilities.AnalogPrecision(opts)
+	return err
+}
+
+func translate(sr *gnmipb.SubscribeResponse, opts translator.Options) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	precision, err := ftutilities.AnalogPrecision(opts)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		updates []*gnmipb.Update
+		deletes []*gnmipb.Path
+	)
+	for _, d := range n.GetDelete() {
+		intf, leaf, ok := parsePath(ftutilities.Join(n.GetPrefix(), d))
+		if !ok {
+			continue
+		}
+		switch {
+		case leaf != "":
+			deletes = append(deletes, poePath(intf, ocLeaves[leaf]...))
+		case intf != "":
+			deletes = append(deletes, poePath(intf))
+		default:
+			// The ports are not cached, so a delete of all of them cannot be translated.
+			log.V(1).Infof("Ignoring delete of all PoE ports: %v", d)
+		}
+	}
+	// disabled holds the ports disabled by the notification, whose other leaves are deleted.
+	disabled := map[string]bool{}
+	for _, u := range n.GetUpdate() {
+		intf, leaf, ok := parsePath(ftutilities.Join(n.GetPrefix(), u.GetPath()))
+		if ok && leaf == leafPortState && u.GetVal().GetStringVal() == portStateDisabled {
+			disabled[intf] = true
+		}
+	}
+	for _, u := range n.GetUpdate() {
+		intf, leaf, ok := parsePath(ftutilities.Join(n.GetPrefix(), u.GetPath()))
+		if !ok || leaf == "" || (disabled[intf] && leaf != leafPortState) {
+			continue
+		}
+		ups, dels, err := handleUpdate(intf, leaf, u.GetVal(), precision)
+		if err != nil {
+			log.Errorf("Failed to translate update %v: %v", u, err)
+			continue
+		}
+		updates = append(updates, ups...)
+		deletes = append(deletes, dels...)
+	}
+	if len(updates) == 0 && len(deletes) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+				Delete: deletes,
+			},
+		},
+	}, nil
+}
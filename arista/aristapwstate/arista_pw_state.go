@@ -18,9 +18,10 @@ package aristapwstate
 import (
 	"fmt"
 
-	log "github.com/golang/glog"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -64,8 +65,21 @@ var (
 	}
 )
 
+func init() {
+	registry.Register(ftconsts.AristaPWStateFunctionalTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Arista PW state functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
 			ID:               ftconsts.AristaPWStateFunctionalTranslator,
@@ -87,9 +101,9 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Arista PW state functional translator: %v", err)
+		return nil, err
 	}
-	return ft
+	return ft, nil
 }
 func updateHandler(n *gnmipb.Notification) ([]*gnmipb.Update, error) {
 	if len(n.GetUpdate()) == 0 {
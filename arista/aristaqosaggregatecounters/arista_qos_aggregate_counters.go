@@ -3,11 +3,16 @@ package aristaqosaggregatecounters
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
-	log "github.com/golang/glog"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -17,20 +22,100 @@ var (
 	translateMap = map[string][]string{
 		"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-octets": {
 			"/openconfig/interfaces/interface/ethernet/state/aggregate-id",
+			"/openconfig/lacp/interfaces/interface/members/member/state/interface",
 			"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-octets",
 		},
 		"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts": {
 			"/openconfig/interfaces/interface/ethernet/state/aggregate-id",
+			"/openconfig/lacp/interfaces/interface/members/member/state/interface",
 			"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts",
 		},
 		"/openconfig/qos/interfaces/interface/output/queues/queue/state/dropped-octets": {
 			"/openconfig/interfaces/interface/ethernet/state/aggregate-id",
+			"/openconfig/lacp/interfaces/interface/members/member/state/interface",
 			"/openconfig/qos/interfaces/interface/output/queues/queue/state/dropped-octets",
 		},
 		"/openconfig/qos/interfaces/interface/output/queues/queue/state/dropped-pkts": {
 			"/openconfig/interfaces/interface/ethernet/state/aggregate-id",
+			"/openconfig/lacp/interfaces/interface/members/member/state/interface",
 			"/openconfig/qos/interfaces/interface/output/queues/queue/state/dropped-pkts",
 		},
+		"/openconfig/qos/interfaces/interface/output/vendor/Arista/queue-families/queue-family/state/transmit-octets": {
+			"/openconfig/interfaces/interface/ethernet/state/aggregate-id",
+			"/openconfig/lacp/interfaces/interface/members/member/state/interface",
+			"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-octets",
+		},
+		"/openconfig/qos/interfaces/interface/output/vendor/Arista/queue-families/queue-family/state/transmit-pkts": {
+			"/openconfig/interfaces/interface/ethernet/state/aggregate-id",
+			"/openconfig/lacp/interfaces/interface/members/member/state/interface",
+			"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts",
+		},
+		"/openconfig/qos/interfaces/interface/output/vendor/Arista/queue-families/queue-family/state/dropped-octets": {
+			"/openconfig/interfaces/interface/ethernet/state/aggregate-id",
+			"/openconfig/lacp/interfaces/interface/members/member/state/interface",
+			"/openconfig/qos/interfaces/interface/output/queues/queue/state/dropped-octets",
+		},
+		"/openconfig/qos/interfaces/interface/output/vendor/Arista/queue-families/queue-family/state/dropped-pkts": {
+			"/openconfig/interfaces/interface/ethernet/state/aggregate-id",
+			"/openconfig/lacp/interfaces/interface/members/member/state/interface",
+			"/openconfig/qos/interfaces/interface/output/queues/queue/state/dropped-pkts",
+		},
+		"/openconfig/qos/interfaces/interface/input/classifiers/classifier/terms/term/state/matched-octets": {
+			"/openconfig/interfaces/interface/ethernet/state/aggregate-id",
+			"/openconfig/lacp/interfaces/interface/members/member/state/interface",
+			"/openconfig/qos/interfaces/interface/input/classifiers/classifier/terms/term/state/matched-octets",
+		},
+		"/openconfig/qos/interfaces/interface/input/classifiers/classifier/terms/term/state/matched-packets": {
+			"/openconfig/interfaces/interface/ethernet/state/aggregate-id",
+			"/openconfig/lacp/interfaces/interface/members/member/state/interface",
+			"/openconfig/qos/interfaces/interface/input/classifiers/classifier/terms/term/state/matched-packets",
+		},
+	}
+	// subscriptionModes streams the membership of the port-channels on change, so that the
+	// aggregates follow it, and samples the counters.
+	subscriptionModes = map[string]*translator.SubscriptionMode{
+		"/openconfig/interfaces/interface/ethernet/state/aggregate-id":                                       {Mode: gnmipb.SubscriptionMode_ON_CHANGE},
+		"/openconfig/lacp/interfaces/interface/members/member/state/interface":                               {Mode: gnmipb.SubscriptionMode_ON_CHANGE},
+		"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-octets":                     {Mode: gnmipb.SubscriptionMode_SAMPLE, SampleInterval: 30 * time.Second},
+		"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts":                       {Mode: gnmipb.SubscriptionMode_SAMPLE, SampleInterval: 30 * time.Second},
+		"/openconfig/qos/interfaces/interface/output/queues/queue/state/dropped-octets":                      {Mode: gnmipb.SubscriptionMode_SAMPLE, SampleInterval: 30 * time.Second},
+		"/openconfig/qos/interfaces/interface/output/queues/queue/state/dropped-pkts":                        {Mode: gnmipb.SubscriptionMode_SAMPLE, SampleInterval: 30 * time.Second},
+		"/openconfig/qos/interfaces/interface/input/classifiers/classifier/terms/term/state/matched-octets":  {Mode: gnmipb.SubscriptionMode_SAMPLE, SampleInterval: 30 * time.Second},
+		"/openconfig/qos/interfaces/interface/input/classifiers/classifier/terms/term/state/matched-packets": {Mode: gnmipb.SubscriptionMode_SAMPLE, SampleInterval: 30 * time.Second},
+	}
+	// classifierTermPattern matches the counters of the terms of the input classifiers.
+	classifierTermPattern = &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "qos"}, {Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"interface-id": "*"}},
+			{Name: "input"}, {Name: "classifiers"},
+			{Name: "classifier", Key: map[string]string{"type": "*"}},
+			{Name: "terms"},
+			{Name: "term", Key: map[string]string{"id": "*"}},
+			{Name: "state"}, {Name: "*"},
+		},
+	}
+	// lacpMemberPattern matches the LACP member leaf from which the membership is learned, on EOS
+	// versions which do not report the aggregate-id of the members.
+	lacpMemberPattern = &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "lacp"}, {Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "*"}},
+			{Name: "members"},
+			{Name: "member", Key: map[string]string{"interface": "*"}},
+			{Name: "state"}, {Name: "interface"},
+		},
+	}
+	// lacpMemberDeletePattern matches the deletes of LACP members, of their state or of their
+	// interface leaf.
+	lacpMemberDeletePattern = &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "lacp"}, {Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "*"}},
+			{Name: "members"},
+			{Name: "member", Key: map[string]string{"interface": "*"}},
+			{Name: "..."},
+		},
 	}
 	updatePathPatterns = []*gnmipb.Path{
 		{
@@ -40,6 +125,7 @@ var (
 				{Name: "ethernet"}, {Name: "state"}, {Name: "aggregate-id"},
 			},
 		},
+		lacpMemberPattern,
 		{
 			Elem: []*gnmipb.PathElem{
 				{Name: "qos"}, {Name: "interfaces"},
@@ -76,6 +162,7 @@ var (
 				{Name: "state"}, {Name: "dropped-pkts"},
 			},
 		},
+		classifierTermPattern,
 	}
 	deletePathPatterns = []*gnmipb.Path{
 		{
@@ -85,6 +172,7 @@ var (
 				{Name: "ethernet"}, {Name: "state"}, {Name: "aggregate-id"},
 			},
 		},
+		lacpMemberDeletePattern,
 	}
 )
 
@@ -94,15 +182,68 @@ const (
 	leafTransmitPkts   = "transmit-pkts"
 	leafDroppedOctets  = "dropped-octets"
 	leafDroppedPkts    = "dropped-pkts"
+	leafMatchedOctets  = "matched-octets"
+	leafMatchedPkts    = "matched-packets"
+
+	// multicastQueuePrefix is the prefix of the simple names of the multicast queues, e.g. "MC-0".
+	multicastQueuePrefix = "MC-"
+	familyUnicast        = "UNICAST"
+	familyMulticast      = "MULTICAST"
+
+	// debounceOption is the translator option setting the minimum interval between the aggregates
+	// emitted for a port-channel on member counter updates, e.g. "500ms", measured with the
+	// notification timestamps, to limit the output volume of port-channels with many members. The
+	// counter updates received within the interval update the cache without emitting the
+	// aggregates, which are emitted with the next counter update after the interval. Membership
+	// changes always emit the aggregates. Zero, the default, emits the aggregates on every update.
+	debounceOption = "debounce"
 )
 
+type impl struct {
+	cache *ftutilities.QoSAggregationMapCache
+
+	mu sync.Mutex
+	// lastEmitted maps the targets to the timestamps of the last aggregates emitted for their
+	// port-channels. It is not part of the state: a restored FT emits the next aggregates.
+	lastEmitted map[string]map[string]int64
+}
+
+func init() {
+	registry.Register(ftconsts.AristaQoSAggregateCountersTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
-	ft, err := translator.NewFunctionalTranslator(
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("failed to create Arista QOS aggregate counters functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	return newWithCache(ftutilities.NewQoSAggregationMapCache())
+}
+
+// newWithCache creates a functional translator aggregating the counters in the given cache.
+func newWithCache(cache *ftutilities.QoSAggregationMapCache) (*translator.FunctionalTranslator, error) {
+	i := &impl{cache: cache, lastEmitted: map[string]map[string]int64{}}
+	return translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
-			ID:               ftconsts.AristaQoSAggregateCountersTranslator,
-			Translate:        translate,
-			OutputToInputMap: ftutilities.MustStringMapPaths(translateMap),
+			ID:                   ftconsts.AristaQoSAggregateCountersTranslator,
+			TranslateWithOptions: i.translate,
+			ValidateOptions:      validateOptions,
+			Sync:                 i.sync,
+			OutputToInputMap:     ftutilities.MustStringMapPaths(translateMap),
+			SubscriptionModes:    subscriptionModes,
+			State: &translator.StateOptions{
+				Reset:        i.reset,
+				State:        func() any { return i.cache.Clone() },
+				RestoreState: i.restoreState,
+				Store:        i.cache,
+				ResetTarget:  i.resetTarget,
+			},
 			Metadata: []*translator.FTMetadata{
 				{
 					Vendor: ftconsts.VendorArista,
@@ -114,10 +255,81 @@ func New() *translator.FunctionalTranslator {
 			},
 		},
 	)
+}
+
+// reset clears the cache and the emission timestamps.
+func (i *impl) reset() {
+	i.cache.ClearAllTargetQoSInfo()
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.lastEmitted = map[string]map[string]int64{}
+}
+
+// resetTarget clears the cache and the emission timestamps of the target.
+func (i *impl) resetTarget(target string) {
+	i.cache.ResetTarget(target)
+	i.forget([]string{target})
+}
+
+// validateOptions rejects a debounce option which is not a non-negative duration.
+func validateOptions(opts translator.Options) error {
+	d, err := opts.Duration(debounceOption, 0)
 	if err != nil {
-		log.Fatalf("failed to create Arista QOS aggregate counters functional translator: %v", err)
+		return err
 	}
-	return ft
+	if d < 0 {
+		return fmt.Errorf("negative debounce %v", d)
+	}
+	return nil
+}
+
+// due returns true if the aggregates of the port-channel are to be emitted for a notification
+// with the timestamp, given the debounce interval, and records their emission. forced is set for
+// membership changes, which are not debounced.
+func (i *impl) due(target, pcName string, timestamp int64, forced bool, interval time.Duration) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	last, ok := i.lastEmitted[target][pcName]
+	if d := int64(interval); !forced && ok && d > 0 && timestamp >= last && timestamp-last < d {
+		return false
+	}
+	if i.lastEmitted[target] == nil {
+		i.lastEmitted[target] = map[string]int64{}
+	}
+	i.lastEmitted[target][pcName] = timestamp
+	return true
+}
+
+// forget removes the emission timestamps of the targets.
+func (i *impl) forget(targets []string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, t := range targets {
+		delete(i.lastEmitted, t)
+	}
+}
+
+// removeIfEmpty removes the port-channel from the cache, and its emission timestamp, if it has no
+// members, and returns true if it was removed.
+func (i *impl) removeIfEmpty(target, pcName string) bool {
+	targetInfo, ok := i.cache.RetrieveTargetQoSInfo(target)
+	if !ok || !targetInfo.RemovePortChannelIfEmpty(pcName) {
+		return false
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.lastEmitted[target], pcName)
+	return true
+}
+
+// restoreState replaces the cache with a snapshot returned by State.
+func (i *impl) restoreState(snapshot any) error {
+	cache, ok := snapshot.(*ftutilities.QoSAggregationMapCache)
+	if !ok {
+		return fmt.Errorf("unexpected state type %T", snapshot)
+	}
+	i.cache.Restore(cache)
+	return nil
 }
 
 // parsePath extracts key information from the matched path.
@@ -182,6 +394,48 @@ func parsePath(path *gnmipb.Path) (interfaceName, simpleQueueName, leafName stri
 	}
 }
 
+// parseLACPMemberPath returns the port-channel and the member interface of an LACP member path.
+func parseLACPMemberPath(path *gnmipb.Path) (pcName, interfaceName string, err error) {
+	for _, elem := range path.GetElem() {
+		switch elem.GetName() {
+		case "interface":
+			if name, ok := elem.GetKey()["name"]; ok && pcName == "" {
+				pcName = name
+			}
+		case "member":
+			interfaceName = elem.GetKey()["interface"]
+		}
+	}
+	if pcName == "" || interfaceName == "" {
+		return "", "", fmt.Errorf("could not find keys 'name' and 'interface' for LACP member path: %v", path)
+	}
+	return pcName, interfaceName, nil
+}
+
+// parseClassifierTermPath returns the interface, the classifier type, the term ID and the leaf of a
+// classifier term counter path.
+func parseClassifierTermPath(path *gnmipb.Path) (interfaceName, classifierType, termID, leafName string, err error) {
+	for _, elem := range path.GetElem() {
+		switch elem.GetName() {
+		case "interface":
+			interfaceName = elem.GetKey()["interface-id"]
+		case "classifier":
+			classifierType = elem.GetKey()["type"]
+		case "term":
+			termID = elem.GetKey()["id"]
+		}
+	}
+	if interfaceName == "" || classifierType == "" || termID == "" {
+		return "", "", "", "", fmt.Errorf("could not find keys 'interface-id', 'type' and 'id' for classifier term path: %v", path)
+	}
+	switch leafName = path.GetElem()[len(path.GetElem())-1].GetName(); leafName {
+	case leafMatchedOctets, leafMatchedPkts:
+		return interfaceName, classifierType, termID, leafName, nil
+	default:
+		return "", "", "", "", fmt.Errorf("unrecognized leaf %q for a classifier term path", leafName)
+	}
+}
+
 // newCounterUpdate creates a gNMI update for a given port-channel, queueID, and counter leaf.
 func newCounterUpdate(interfaceID, queueName, leafName string, value uint64) *gnmipb.Update {
 	return &gnmipb.Update{
@@ -209,9 +463,72 @@ func newCounterUpdate(interfaceID, queueName, leafName string, value uint64) *gn
 	}
 }
 
-// aggregateAndBuildUpdates calculates the sum of counters for a port-channel and creates gNMI updates.
-func aggregateAndBuildUpdates(target, pcName string) []*gnmipb.Update {
-	targetInfo, ok := ftutilities.QoSAggMap.RetrieveTargetQoSInfo(target)
+// queueFamily returns the family of a queue, unicast or multicast, from its simple name.
+func queueFamily(simpleQueueName string) string {
+	if strings.HasPrefix(simpleQueueName, multicastQueuePrefix) {
+		return familyMulticast
+	}
+	return familyUnicast
+}
+
+// newFamilyCounterUpdate creates a gNMI update for the total of a queue family of a port-channel,
+// in the Arista vendor extension of its output queues.
+func newFamilyCounterUpdate(interfaceID, family, leafName string, value uint64) *gnmipb.Update {
+	return &gnmipb.Update{
+		Path: &gnmipb.Path{
+			Elem: []*gnmipb.PathElem{
+				{Name: "qos"}, {Name: "interfaces"},
+				{Name: "interface", Key: map[string]string{"interface-id": interfaceID}},
+				{Name: "output"}, {Name: "vendor"}, {Name: "Arista"}, {Name: "queue-families"},
+				{Name: "queue-family", Key: map[string]string{"name": family}},
+				{Name: "state"},
+				{Name: leafName},
+			},
+		},
+		Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: value}},
+	}
+}
+
+// newTermCounterUpdate creates a gNMI update for a given port-channel, input classifier term, and
+// counter leaf.
+func newTermCounterUpdate(interfaceID, classifierType, termID, leafName string, value uint64) *gnmipb.Update {
+	return &gnmipb.Update{
+		Path: &gnmipb.Path{
+			Elem: []*gnmipb.PathElem{
+				{Name: "qos"}, {Name: "interfaces"},
+				{Name: "interface", Key: map[string]string{"interface-id": interfaceID}},
+				{Name: "input"}, {Name: "classifiers"},
+				{Name: "classifier", Key: map[string]string{"type": classifierType}},
+				{Name: "terms"},
+				{Name: "term", Key: map[string]string{"id": termID}},
+				{Name: "state"},
+				{Name: leafName},
+			},
+		},
+		Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: value}},
+	}
+}
+
+// aggregateDeletePaths returns the paths deleting the aggregates of a port-channel, for each queue,
+// for the queue families and for the input classifiers.
+func aggregateDeletePaths(pcName string) []*gnmipb.Path {
+	prefix := []*gnmipb.PathElem{
+		{Name: "qos"}, {Name: "interfaces"},
+		{Name: "interface", Key: map[string]string{"interface-id": pcName}},
+	}
+	output := append(slices.Clone(prefix), &gnmipb.PathElem{Name: "output"})
+	return []*gnmipb.Path{
+		{Elem: append(slices.Clone(output), &gnmipb.PathElem{Name: "queues"})},
+		{Elem: append(slices.Clone(output), &gnmipb.PathElem{Name: "vendor"}, &gnmipb.PathElem{Name: "Arista"}, &gnmipb.PathElem{Name: "queue-families"})},
+		{Elem: append(slices.Clone(prefix), &gnmipb.PathElem{Name: "input"}, &gnmipb.PathElem{Name: "classifiers"})},
+	}
+}
+
+// aggregateAndBuildUpdates calculates the sum of counters for a port-channel and creates gNMI
+// updates, for each queue, for the unicast and multicast queue families and for each input
+// classifier term.
+func (i *impl) aggregateAndBuildUpdates(target, pcName string) []*gnmipb.Update {
+	targetInfo, ok := i.cache.RetrieveTargetQoSInfo(target)
 	if !ok {
 		return nil
 	}
@@ -222,9 +539,12 @@ func aggregateAndBuildUpdates(target, pcName string) []*gnmipb.Update {
 
 	// aggregatedCounters maps a simple queue name to its summed counters.
 	aggregatedCounters := pcInfo.AggregateCounters()
+	// familyCounters maps a queue family to the sum of the counters of its queues.
+	familyCounters := map[string]*ftutilities.QueueCounters{}
 	outgoingUpdates := make([]*gnmipb.Update, 0, len(aggregatedCounters)*4)
 	// Build gNMI Updates from the aggregated results.
-	for simpleQueueName, counters := range aggregatedCounters {
+	for _, simpleQueueName := range slices.Sorted(maps.Keys(aggregatedCounters)) {
+		counters := aggregatedCounters[simpleQueueName]
 		// Create the new composite queue ID for the aggregated path.
 		// e.g., if pcName is "Port-Channel10" and simpleQueueName is "0", this becomes "Port-Channel10-0".
 		newCompositeQueueID := pcName + "-" + simpleQueueName
@@ -235,26 +555,69 @@ func aggregateAndBuildUpdates(target, pcName string) []*gnmipb.Update {
 			newCounterUpdate(pcName, newCompositeQueueID, leafDroppedOctets, counters.DroppedBytes),
 			newCounterUpdate(pcName, newCompositeQueueID, leafDroppedPkts, counters.DroppedPackets),
 		)
+
+		family := queueFamily(simpleQueueName)
+		total, ok := familyCounters[family]
+		if !ok {
+			total = new(ftutilities.QueueCounters)
+			familyCounters[family] = total
+		}
+		total.TxBytes += counters.TxBytes
+		total.TxPackets += counters.TxPackets
+		total.DroppedBytes += counters.DroppedBytes
+		total.DroppedPackets += counters.DroppedPackets
+	}
+	for _, family := range slices.Sorted(maps.Keys(familyCounters)) {
+		total := familyCounters[family]
+		outgoingUpdates = append(outgoingUpdates,
+			newFamilyCounterUpdate(pcName, family, leafTransmitOctets, total.TxBytes),
+			newFamilyCounterUpdate(pcName, family, leafTransmitPkts, total.TxPackets),
+			newFamilyCounterUpdate(pcName, family, leafDroppedOctets, total.DroppedBytes),
+			newFamilyCounterUpdate(pcName, family, leafDroppedPkts, total.DroppedPackets),
+		)
+	}
+	termCounters := pcInfo.AggregateTermCounters()
+	for _, classifierType := range slices.Sorted(maps.Keys(termCounters)) {
+		terms := termCounters[classifierType]
+		for _, termID := range slices.Sorted(maps.Keys(terms)) {
+			counters := terms[termID]
+			outgoingUpdates = append(outgoingUpdates,
+				newTermCounterUpdate(pcName, classifierType, termID, leafMatchedOctets, counters.MatchedOctets),
+				newTermCounterUpdate(pcName, classifierType, termID, leafMatchedPkts, counters.MatchedPackets),
+			)
+		}
 	}
 	return outgoingUpdates
 }
 
 // deleteHandler processes delete notifications to handle port-channel member removals.
-// It updates the cache and returns a map of port-channel names that were affected.
-func deleteHandler(n *gnmipb.Notification) (impactedPortChannels map[string]bool) {
+// It updates the cache and returns a map of port-channel names that were affected, all of them
+// forced as the removals change their membership.
+func (i *impl) deleteHandler(n *gnmipb.Notification) (impactedPortChannels map[string]bool) {
 	impactedPortChannels = make(map[string]bool)
 	prefix := n.GetPrefix()
 	target := prefix.GetTarget()
 
 	for _, delPath := range n.GetDelete() {
 		fullPath := ftutilities.Join(prefix, delPath)
+		if ftutilities.MatchPath(fullPath, lacpMemberDeletePattern) {
+			pcName, interfaceName, err := parseLACPMemberPath(fullPath)
+			if err != nil {
+				log.Warningf("failed to parse delete path %v: %v", fullPath, err)
+				continue
+			}
+			if i.processLACPMemberRemoval(target, interfaceName, pcName) {
+				impactedPortChannels[pcName] = true
+			}
+			continue
+		}
 		interfaceName, _, _, err := parsePath(fullPath)
 		if err != nil {
 			log.Warningf("failed to parse delete path %v: %v", fullPath, err)
 			continue
 		}
 
-		if oldPCName, removed := processMemberRemoval(target, interfaceName); removed {
+		if oldPCName, removed := i.processMemberRemoval(target, interfaceName); removed {
 			impactedPortChannels[oldPCName] = true
 		}
 	}
@@ -262,14 +625,13 @@ func deleteHandler(n *gnmipb.Notification) (impactedPortChannels map[string]bool
 }
 
 // processMemberRemoval updates the cache and returns the name of the impacted port-channel and whether a removal occurred.
-func processMemberRemoval(target, interfaceName string) (string, bool) {
-	targetInfo, ok := ftutilities.QoSAggMap.RetrieveTargetQoSInfo(target)
+func (i *impl) processMemberRemoval(target, interfaceName string) (string, bool) {
+	targetInfo, ok := i.cache.RetrieveTargetQoSInfo(target)
 	if !ok {
 		return "", false
 	}
 	// Also clean up the member if it's in the "waiting room"
-	if _, found := targetInfo.UnassociatedMembers[interfaceName]; found {
-		delete(targetInfo.UnassociatedMembers, interfaceName)
+	if _, found := targetInfo.RemoveUnassociatedMember(interfaceName); found {
 		log.V(2).Infof("removed unassociated member %s from the waiting room on target %s.", interfaceName, target)
 		return "", true
 	}
@@ -282,8 +644,34 @@ func processMemberRemoval(target, interfaceName string) (string, bool) {
 	return "", false
 }
 
+// processLACPMemberRemoval removes a member from the cache on the delete of its LACP member entry,
+// and returns true if it was removed. The member is only removed from the port-channel of the
+// entry, since the delete may follow its move to another port-channel.
+func (i *impl) processLACPMemberRemoval(target, interfaceName, pcName string) bool {
+	targetInfo, ok := i.cache.RetrieveTargetQoSInfo(target)
+	if !ok {
+		return false
+	}
+	if current, ok := targetInfo.RetrievePortChannelForMember(interfaceName); !ok || current != pcName {
+		return false
+	}
+	targetInfo.FindAndRemoveMember(interfaceName)
+	log.V(2).Infof("processed LACP removal of member %s from Port-Channel %s on target %s.", interfaceName, pcName, target)
+	return true
+}
+
+// handleLACPMemberUpdate processes membership changes based on an LACP member update, for the
+// devices which do not report the aggregate-id of the members. A member already in the
+// port-channel is left unchanged, so that its counters are kept.
+func handleLACPMemberUpdate(targetInfo *ftutilities.TargetQoSInfo, interfaceName, pcName string, impactedPortChannels map[string]bool) {
+	if current, ok := targetInfo.RetrievePortChannelForMember(interfaceName); ok && current == pcName {
+		return
+	}
+	handleAggregateIDUpdate(targetInfo, interfaceName, pcName, impactedPortChannels)
+}
+
 // handleAggregateIDUpdate processes membership changes based on an aggregate-id update.
-// It updates the cache and the set of impacted port-channels.
+// It updates the cache and the set of impacted port-channels, which are forced.
 func handleAggregateIDUpdate(targetInfo *ftutilities.TargetQoSInfo, interfaceName, newPCName string, impactedPortChannels map[string]bool) {
 	// Process the implicit removal from any old port-channel.
 	if oldPCName, removed := targetInfo.FindAndRemoveMember(interfaceName); removed {
@@ -300,12 +688,10 @@ func handleAggregateIDUpdate(targetInfo *ftutilities.TargetQoSInfo, interfaceNam
 	pcInfo := targetInfo.CreateOrRetrievePortChannel(newPCName)
 	targetInfo.SetPortChannelForMember(interfaceName, newPCName)
 
-	// Check the "waiting room" for pending counters for this interface.
-	if unassociatedMember, found := targetInfo.UnassociatedMembers[interfaceName]; found {
+	// Check the "waiting room" for pending counters for this interface, removing them from it.
+	if unassociatedMember, found := targetInfo.RemoveUnassociatedMember(interfaceName); found {
 		// Move the cached member info into the port-channel's member list.
 		pcInfo.AddMemberInfo(unassociatedMember)
-		// Clean up the waiting room.
-		delete(targetInfo.UnassociatedMembers, interfaceName)
 		log.V(2).Infof("moved pending counters for %s to Port-Channel %s", interfaceName, newPCName)
 	} else {
 		// If no pending data, just ensure the member struct exists.
@@ -317,32 +703,35 @@ func handleAggregateIDUpdate(targetInfo *ftutilities.TargetQoSInfo, interfaceNam
 	log.V(1).Infof("member %s assigned to new Port-Channel %s", interfaceName, newPCName)
 }
 
-// handleQoSUpdate processes a QoS counter update by updating the appropriate cache location.
-func handleQoSUpdate(targetInfo *ftutilities.TargetQoSInfo, interfaceName, simpleQueueName, leafName string, value uint64, impactedPortChannels map[string]bool) {
-	var memberInfo *ftutilities.MemberInterfaceInfo
-
+// retrieveMember returns the cached info of a member, from its port-channel or from the "waiting
+// room" if its port-channel is not known yet. The port-channel of the member is added to the
+// impacted port-channels without forcing it.
+func retrieveMember(targetInfo *ftutilities.TargetQoSInfo, interfaceName string, impactedPortChannels map[string]bool) *ftutilities.MemberInterfaceInfo {
 	// The member is already part of a known port-channel. Update its state directly.
 	pcName, ok := targetInfo.RetrievePortChannelForMember(interfaceName)
 	if ok {
-		impactedPortChannels[pcName] = true
+		if _, found := impactedPortChannels[pcName]; !found {
+			impactedPortChannels[pcName] = false
+		}
 
 		// Get the PortChannelInfo and then the MemberInterfaceInfo.
 		pcInfo, pcOk := targetInfo.PortChannelInfo(pcName)
 		if !pcOk {
 			log.Errorf("cache inconsistency: port-channel %s not found for member %s", pcName, interfaceName)
-			return
-		}
-		memberInfo = pcInfo.CreateOrRetrieveMember(interfaceName)
-	} else {
-		// We don't know its LAG yet. Put its data in the "waiting room".
-		memberInfo, ok = targetInfo.UnassociatedMembers[interfaceName]
-		if !ok {
-			// Member is not in the waiting room yet, so create it.
-			memberInfo = ftutilities.NewMemberInterfaceInfo(interfaceName)
-			targetInfo.UnassociatedMembers[interfaceName] = memberInfo
+			return nil
 		}
+		return pcInfo.CreateOrRetrieveMember(interfaceName)
 	}
+	// We don't know its LAG yet. Put its data in the "waiting room".
+	return targetInfo.CreateOrRetrieveUnassociatedMember(interfaceName)
+}
 
+// handleQoSUpdate processes a QoS counter update by updating the appropriate cache location.
+func handleQoSUpdate(targetInfo *ftutilities.TargetQoSInfo, interfaceName, simpleQueueName, leafName string, value uint64, impactedPortChannels map[string]bool) {
+	memberInfo := retrieveMember(targetInfo, interfaceName, impactedPortChannels)
+	if memberInfo == nil {
+		return
+	}
 	switch leafName {
 	case leafTransmitOctets:
 		memberInfo.SetTxBytes(simpleQueueName, value)
@@ -355,18 +744,67 @@ func handleQoSUpdate(targetInfo *ftutilities.TargetQoSInfo, interfaceName, simpl
 	}
 }
 
-func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+// handleClassifierTermUpdate processes an input classifier term counter update by updating the
+// appropriate cache location.
+func handleClassifierTermUpdate(targetInfo *ftutilities.TargetQoSInfo, interfaceName, classifierType, termID, leafName string, value uint64, impactedPortChannels map[string]bool) {
+	memberInfo := retrieveMember(targetInfo, interfaceName, impactedPortChannels)
+	if memberInfo == nil {
+		return
+	}
+	switch leafName {
+	case leafMatchedOctets:
+		memberInfo.SetMatchedOctets(classifierType, termID, value)
+	case leafMatchedPkts:
+		memberInfo.SetMatchedPackets(classifierType, termID, value)
+	}
+}
+
+// sync flushes the "waiting room" of the target once its initial updates are complete. Interfaces
+// which have not been assigned to a port-channel by then are singleton ports, whose counters have
+// already been passed through. The waiting rooms are kept if the target is unknown, since they may
+// belong to a target still in its initial updates.
+func (i *impl) sync(target string, _ *gnmipb.SubscribeResponse) error {
+	if target == "" {
+		return nil
+	}
+	if targetInfo, ok := i.cache.RetrieveTargetQoSInfo(target); ok {
+		targetInfo.ClearUnassociatedMembers()
+	}
+	return nil
+}
+
+// passthroughUpdate returns the update of a member counter passed through to the output. This
+// ensures the singleton port QOS counters are preserved.
+func passthroughUpdate(fullPath *gnmipb.Path, update *gnmipb.Update) *gnmipb.Update {
+	// Clear the Origin and Target from the fullPath as they will be set in the Notification Prefix.
+	fullPath.Origin = ""
+	fullPath.Target = ""
+	return &gnmipb.Update{
+		Path: fullPath,
+		Val:  update.GetVal(),
+	}
+}
+
+func (i *impl) translate(sr *gnmipb.SubscribeResponse, opts translator.Options) (*gnmipb.SubscribeResponse, error) {
 	notification := sr.GetUpdate()
 	if notification == nil {
 		return nil, nil
 	}
+	interval, err := opts.Duration(debounceOption, 0)
+	if err != nil {
+		return nil, err
+	}
+	if evicted := i.cache.EvictStaleTargets(); len(evicted) > 0 {
+		i.forget(evicted)
+		log.V(1).Infof("evicted the state of stale targets %v", evicted)
+	}
 
 	prefix := notification.GetPrefix()
 	target := prefix.GetTarget()
 	timestamp := notification.GetTimestamp()
 
 	// Handle deletes first and get the initial set of impacted port-channels.
-	impactedPortChannels := deleteHandler(notification)
+	impactedPortChannels := i.deleteHandler(notification)
 
 	// A slice to store all original QOS counter updates for singleton ports that should be passed through.
 	var passthroughUpdates []*gnmipb.Update
@@ -387,13 +825,41 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 			continue
 		}
 
+		// Update to a member's port-channel assignment reported by LACP.
+		if ftutilities.MatchPath(fullPath, lacpMemberPattern) {
+			pcName, interfaceName, err := parseLACPMemberPath(fullPath)
+			if err != nil {
+				log.V(2).Infof("matched path but failed to parse: %v, err: %v", fullPath, err)
+				continue
+			}
+			handleLACPMemberUpdate(i.cache.CreateOrUpdateTargetQoSInfo(target), interfaceName, pcName, impactedPortChannels)
+			continue
+		}
+
+		// Update to an input classifier term counter of a member.
+		if ftutilities.MatchPath(fullPath, classifierTermPattern) {
+			interfaceName, classifierType, termID, leafName, err := parseClassifierTermPath(fullPath)
+			if err != nil {
+				log.V(2).Infof("matched path but failed to parse: %v, err: %v", fullPath, err)
+				continue
+			}
+			val, err := ftutilities.ToUint64(update.GetVal())
+			if err != nil {
+				log.V(2).Infof("matched path but failed to parse the value: %v, err: %v", fullPath, err)
+				continue
+			}
+			passthroughUpdates = append(passthroughUpdates, passthroughUpdate(fullPath, update))
+			handleClassifierTermUpdate(i.cache.CreateOrUpdateTargetQoSInfo(target), interfaceName, classifierType, termID, leafName, val, impactedPortChannels)
+			continue
+		}
+
 		interfaceName, queueIDStr, leafName, err := parsePath(fullPath)
 		if err != nil {
 			log.V(2).Infof("matched path but failed to parse: %v, err: %v", fullPath, err)
 			continue
 		}
 
-		targetInfo := ftutilities.QoSAggMap.CreateOrUpdateTargetQoSInfo(target)
+		targetInfo := i.cache.CreateOrUpdateTargetQoSInfo(target)
 
 		// Update to a member's port-channel assignment.
 		if leafName == "aggregate-id" {
@@ -402,30 +868,39 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 			continue
 		}
 
-		// This ensures the singleton port QOS counters are preserved.
-		// Clear the Origin and Target from the fullPath as they will be set in the Notification Prefix.
-		fullPath.Origin = ""
-		fullPath.Target = ""
-		passthroughUpdates = append(passthroughUpdates, &gnmipb.Update{
-			Path: fullPath,
-			Val:  update.GetVal(),
-		})
-		val := update.GetVal().GetUintVal()
+		val, err := ftutilities.ToUint64(update.GetVal())
+		if err != nil {
+			log.V(2).Infof("matched path but failed to parse the value: %v, err: %v", fullPath, err)
+			continue
+		}
+		passthroughUpdates = append(passthroughUpdates, passthroughUpdate(fullPath, update))
 		handleQoSUpdate(targetInfo, interfaceName, queueIDStr, leafName, val, impactedPortChannels)
 	}
 
-	// Aggregate and generate the aggregate updates.
+	// Aggregate and generate the aggregate updates, or the deletes of the aggregates of the
+	// port-channels left without members.
 	var aggregateUpdates []*gnmipb.Update
-	for pcName := range impactedPortChannels {
+	var aggregateDeletes []*gnmipb.Path
+	for _, pcName := range slices.Sorted(maps.Keys(impactedPortChannels)) {
+		forced := impactedPortChannels[pcName]
+		if i.removeIfEmpty(target, pcName) {
+			log.V(1).Infof("deleting the aggregates of Port-Channel %s left without members", pcName)
+			aggregateDeletes = append(aggregateDeletes, aggregateDeletePaths(pcName)...)
+			continue
+		}
+		if !i.due(target, pcName, timestamp, forced, interval) {
+			log.V(2).Infof("debounced aggregates for Port-Channel: %s", pcName)
+			continue
+		}
 		log.V(2).Infof("recalculating aggregates for impacted Port-Channel: %s", pcName)
-		newUpdates := aggregateAndBuildUpdates(target, pcName)
+		newUpdates := i.aggregateAndBuildUpdates(target, pcName)
 		aggregateUpdates = append(aggregateUpdates, newUpdates...)
 	}
 
 	// Combine the original passthrough updates with the new aggregated updates.
 	finalUpdates := append(passthroughUpdates, aggregateUpdates...)
 
-	if len(finalUpdates) == 0 {
+	if len(finalUpdates) == 0 && len(aggregateDeletes) == 0 {
 		return nil, nil
 	}
 
@@ -433,6 +908,7 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 		Timestamp: timestamp,
 		Prefix:    &gnmipb.Path{Origin: "openconfig", Target: target},
 		Update:    finalUpdates,
+		Delete:    aggregateDeletes,
 	}
 
 	return &gnmipb.SubscribeResponse{
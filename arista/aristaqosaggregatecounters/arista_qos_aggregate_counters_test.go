@@ -16,22 +16,19 @@ package aristaqosaggregatecounters
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/protobuf/testing/protocmp"
 	"github.com/openconfig/functional-translators/ftutilities"
-)
+	"github.com/openconfig/functional-translators/translator"
 
-// ResetGlobalCache is a test helper that calls the ClearAllTargetQoSInfo method
-// from ftutilities to ensure a clean state for each test run.
-func ResetGlobalCache() {
-	ftutilities.QoSAggMap.ClearAllTargetQoSInfo()
-}
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
 
 // setupStateForTwoMembers pre-populates the cache with one member in a port-channel.
-func setupStateForTwoMembers() {
-	ResetGlobalCache()
-	targetInfo := ftutilities.QoSAggMap.CreateOrUpdateTargetQoSInfo("cx12.sql12")
+func setupStateForTwoMembers(cache *ftutilities.QoSAggregationMapCache) {
+	targetInfo := cache.CreateOrUpdateTargetQoSInfo("cx12.sql12")
 	pcInfo := targetInfo.CreateOrRetrievePortChannel("Port-Channel10")
 	targetInfo.SetPortChannelForMember("Ethernet19/1", "Port-Channel10")
 	member1 := pcInfo.CreateOrRetrieveMember("Ethernet19/1")
@@ -43,9 +40,8 @@ func setupStateForTwoMembers() {
 
 // setupStateForMemberRemoval pre-populates the cache with two members.
 // This is used to test that re-aggregation is correct when one member is removed.
-func setupStateForMemberRemoval() {
-	ResetGlobalCache()
-	targetInfo := ftutilities.QoSAggMap.CreateOrUpdateTargetQoSInfo("cx12.sql12")
+func setupStateForMemberRemoval(cache *ftutilities.QoSAggregationMapCache) {
+	targetInfo := cache.CreateOrUpdateTargetQoSInfo("cx12.sql12")
 	pcInfo := targetInfo.CreateOrRetrievePortChannel("Port-Channel10")
 
 	// Member 1 (this one will be removed)
@@ -67,9 +63,8 @@ func setupStateForMemberRemoval() {
 
 // setupStateForMultipleTargets pre-populates the cache for 'cx12.sql12'.
 // This is used to verify that state is correctly isolated when an update for a new target arrives.
-func setupStateForMultipleTargets() {
-	ResetGlobalCache()
-	targetInfo := ftutilities.QoSAggMap.CreateOrUpdateTargetQoSInfo("cx12.sql12")
+func setupStateForMultipleTargets(cache *ftutilities.QoSAggregationMapCache) {
+	targetInfo := cache.CreateOrUpdateTargetQoSInfo("cx12.sql12")
 	pcInfo := targetInfo.CreateOrRetrievePortChannel("Port-Channel10")
 
 	// Member on cx12.sql12
@@ -82,9 +77,8 @@ func setupStateForMultipleTargets() {
 }
 
 // setupStateForCounterChange pre-populates the cache with two members.
-func setupStateForCounterChange() {
-	ResetGlobalCache()
-	targetInfo := ftutilities.QoSAggMap.CreateOrUpdateTargetQoSInfo("cx12.sql12")
+func setupStateForCounterChange(cache *ftutilities.QoSAggregationMapCache) {
+	targetInfo := cache.CreateOrUpdateTargetQoSInfo("cx12.sql12")
 	pcInfo := targetInfo.CreateOrRetrievePortChannel("Port-Channel10")
 
 	targetInfo.SetPortChannelForMember("Ethernet11/2", "Port-Channel10")
@@ -102,10 +96,76 @@ func setupStateForCounterChange() {
 	member2.SetDroppedPackets("0", 0)
 }
 
+// setupStateForCounterReset pre-populates the cache with two members, the counters of the first
+// being higher than those of its next update, as after the reload of its line card.
+func setupStateForCounterReset(cache *ftutilities.QoSAggregationMapCache) {
+	targetInfo := cache.CreateOrUpdateTargetQoSInfo("cx12.sql12")
+	pcInfo := targetInfo.CreateOrRetrievePortChannel("Port-Channel10")
+
+	targetInfo.SetPortChannelForMember("Ethernet11/2", "Port-Channel10")
+	member1 := pcInfo.CreateOrRetrieveMember("Ethernet11/2")
+	member1.SetTxBytes("0", 3000)
+	member1.SetTxPackets("0", 300)
+	member1.SetDroppedBytes("0", 10)
+	member1.SetDroppedPackets("0", 1)
+
+	targetInfo.SetPortChannelForMember("Ethernet22/3", "Port-Channel10")
+	member2 := pcInfo.CreateOrRetrieveMember("Ethernet22/3")
+	member2.SetTxBytes("0", 500)
+	member2.SetTxPackets("0", 50)
+	member2.SetDroppedBytes("0", 5)
+	member2.SetDroppedPackets("0", 0)
+}
+
+// setupStateForQueueFamilies pre-populates the cache with two members with unicast and multicast
+// queues.
+func setupStateForQueueFamilies(cache *ftutilities.QoSAggregationMapCache) {
+	targetInfo := cache.CreateOrUpdateTargetQoSInfo("cx12.sql12")
+	pcInfo := targetInfo.CreateOrRetrievePortChannel("Port-Channel10")
+
+	targetInfo.SetPortChannelForMember("Ethernet11/2", "Port-Channel10")
+	member1 := pcInfo.CreateOrRetrieveMember("Ethernet11/2")
+	member1.SetTxBytes("1", 300)
+	member1.SetTxPackets("1", 30)
+	member1.SetTxBytes("MC-0", 200)
+	member1.SetTxPackets("MC-0", 20)
+	member1.SetDroppedPackets("MC-0", 4)
+
+	targetInfo.SetPortChannelForMember("Ethernet22/3", "Port-Channel10")
+	member2 := pcInfo.CreateOrRetrieveMember("Ethernet22/3")
+	member2.SetTxBytes("MC-0", 50)
+	member2.SetTxPackets("MC-0", 5)
+	member2.SetTxBytes("MC-1", 70)
+	member2.SetTxPackets("MC-1", 7)
+	member2.SetDroppedBytes("MC-1", 9)
+}
+
+// setupStateForClassifiers pre-populates the cache with one member with input classifier counters.
+func setupStateForClassifiers(cache *ftutilities.QoSAggregationMapCache) {
+	targetInfo := cache.CreateOrUpdateTargetQoSInfo("cx12.sql12")
+	pcInfo := targetInfo.CreateOrRetrievePortChannel("Port-Channel10")
+
+	targetInfo.SetPortChannelForMember("Ethernet19/1", "Port-Channel10")
+	member1 := pcInfo.CreateOrRetrieveMember("Ethernet19/1")
+	member1.SetMatchedOctets("IPV4", "10", 1000)
+	member1.SetMatchedPackets("IPV4", "10", 10)
+	member1.SetMatchedOctets("IPV6", "20", 400)
+	member1.SetMatchedPackets("IPV6", "20", 4)
+}
+
+func mustNewWithCache(t *testing.T, cache *ftutilities.QoSAggregationMapCache) *translator.FunctionalTranslator {
+	t.Helper()
+	ft, err := newWithCache(cache)
+	if err != nil {
+		t.Fatalf("newWithCache() got unexpected error: %v", err)
+	}
+	return ft
+}
+
 func TestTranslate(t *testing.T) {
 	tests := []struct {
 		name           string
-		setup          func()
+		setup          func(*ftutilities.QoSAggregationMapCache)
 		inputPath      string
 		wantOutputPath string
 		wantNil        bool
@@ -113,13 +173,11 @@ func TestTranslate(t *testing.T) {
 	}{
 		{
 			name:           "first_member_joins_and_updates_counter",
-			setup:          ResetGlobalCache,
 			inputPath:      "testdata/join_pc_and_counter_update_input.txt",
 			wantOutputPath: "testdata/join_pc_and_counter_update_output.txt",
 		},
 		{
 			name:           "multiple_updates_in_a_single_notification",
-			setup:          ResetGlobalCache,
 			inputPath:      "testdata/counter_change_input_single_notification.txt",
 			wantOutputPath: "testdata/counter_change_output_single_notification.txt",
 		},
@@ -135,6 +193,12 @@ func TestTranslate(t *testing.T) {
 			inputPath:      "testdata/remove_member_input.txt",
 			wantOutputPath: "testdata/remove_member_output.txt",
 		},
+		{
+			name:           "removing_the_last_member_deletes_the_aggregates",
+			setup:          setupStateForTwoMembers, // Pre-populates cache with one member for cx12.sql12
+			inputPath:      "testdata/remove_member_input.txt",
+			wantOutputPath: "testdata/remove_last_member_output.txt",
+		},
 		{
 			name:           "multiple_targets_state_isolation",
 			setup:          setupStateForMultipleTargets, // Pre-populates cache for cx12.sql12
@@ -147,45 +211,79 @@ func TestTranslate(t *testing.T) {
 			inputPath:      "testdata/counter_change_input.txt",
 			wantOutputPath: "testdata/counter_change_output.txt",
 		},
+		{
+			name:           "counter_reset_does_not_decrease_the_aggregates",
+			setup:          setupStateForCounterReset, // Pre-populates cache for cx12.sql12
+			inputPath:      "testdata/counter_change_input.txt",
+			wantOutputPath: "testdata/counter_reset_output.txt",
+		},
+		{
+			name:           "unicast_and_multicast_queue_families",
+			setup:          setupStateForQueueFamilies, // Pre-populates cache for cx12.sql12
+			inputPath:      "testdata/counter_change_input.txt",
+			wantOutputPath: "testdata/queue_families_output.txt",
+		},
+		{
+			name:           "input_classifier_counters_from_the_waiting_room",
+			setup:          setupStateForClassifiers, // Pre-populates cache for cx12.sql12
+			inputPath:      "testdata/classifier_counters_input.txt",
+			wantOutputPath: "testdata/classifier_counters_output.txt",
+		},
 		{
 			name:           "member_removed_while_still_in_the_waiting_room",
-			setup:          ResetGlobalCache,
 			inputPath:      "testdata/remove_from_waiting_room_input.txt",
 			wantOutputPath: "testdata/remove_from_waiting_room_output.txt",
 		},
+		{
+			name:           "member_joins_from_lacp_and_updates_counter",
+			inputPath:      "testdata/lacp_join_and_counter_update_input.txt",
+			wantOutputPath: "testdata/join_pc_and_counter_update_output.txt",
+		},
+		{
+			name:           "removing_a_lacp_member_triggers_re_aggregation",
+			setup:          setupStateForMemberRemoval, // Pre-populates with both members for cx12.sql12
+			inputPath:      "testdata/lacp_remove_member_input.txt",
+			wantOutputPath: "testdata/remove_member_output.txt",
+		},
+		{
+			name:      "lacp_member_already_in_the_port_channel_is_unchanged",
+			setup:     setupStateForTwoMembers, // Pre-populates cache with the member for cx12.sql12
+			inputPath: "testdata/lacp_member_unchanged_input.txt",
+			wantNil:   true,
+		},
+		{
+			name:      "lacp_member_delete_of_another_port_channel_is_ignored",
+			setup:     setupStateForMemberRemoval, // Pre-populates with both members for cx12.sql12
+			inputPath: "testdata/lacp_remove_member_other_port_channel_input.txt",
+			wantNil:   true,
+		},
 		{
 			name:      "malformed_aggregate_id_path_that_matches_pattern_but_fails_parsing",
-			setup:     ResetGlobalCache,
 			inputPath: "testdata/malformed_aggregate_id_input.txt",
 			wantNil:   true,
 		},
 		{
 			name:      "delete_handler_ignores_non_aggregate_id_paths",
-			setup:     ResetGlobalCache,
 			inputPath: "testdata/delete_non_aggregate_id_input.txt",
 			wantNil:   true,
 		},
 		{
 			name:      "qos_path_with_unexpected_queue_name_format",
-			setup:     ResetGlobalCache,
 			inputPath: "testdata/unexpected_queue_format_input.txt",
 			wantNil:   true,
 		},
 		{
 			name:      "invalid_update_path_that_does_not_match_patterns",
-			setup:     ResetGlobalCache,
 			inputPath: "testdata/invalid_update_path_input.txt",
 			wantNil:   true, // The translator should ignore this update completely.
 		},
 		{
 			name:      "invalid_delete_path_that_does_not_match_patterns",
-			setup:     ResetGlobalCache,
 			inputPath: "testdata/invalid_delete_path_input.txt",
 			wantNil:   true, // The translator should ignore this delete.
 		},
 		{
 			name:      "malformed_qos_path_that_matches_pattern_but_fails_parsing",
-			setup:     ResetGlobalCache,
 			inputPath: "testdata/malformed_qos_path_input.txt",
 			wantNil:   true, // The update is matched but then rejected by the parser, resulting in no output.
 		},
@@ -193,14 +291,17 @@ func TestTranslate(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.setup()
+			cache := ftutilities.NewQoSAggregationMapCache()
+			if tc.setup != nil {
+				tc.setup(cache)
+			}
 
 			inputSR, err := ftutilities.LoadSubscribeResponse(tc.inputPath)
 			if err != nil {
 				t.Fatalf("failed to load input message: %v", err)
 			}
 
-			ft := New()
+			ft := mustNewWithCache(t, cache)
 			gotSR, err := ft.Translate(inputSR)
 
 			if gotNil, gotErr := gotSR == nil, err != nil; gotNil || gotErr {
@@ -230,3 +331,227 @@ func TestTranslate(t *testing.T) {
 		})
 	}
 }
+
+// aggregateUpdates returns the number of updates of the port-channel in the response.
+func aggregateUpdates(sr *gnmipb.SubscribeResponse, pcName string) int {
+	n := 0
+	for _, u := range sr.GetUpdate().GetUpdate() {
+		if u.GetPath().GetElem()[2].GetKey()["interface-id"] == pcName {
+			n++
+		}
+	}
+	return n
+}
+
+func TestDebounce(t *testing.T) {
+	cache := ftutilities.NewQoSAggregationMapCache()
+	setupStateForCounterChange(cache)
+	ft := mustNewWithCache(t, cache)
+	if err := ft.SetOptions(translator.Options{"debounce": "1s"}); err != nil {
+		t.Fatalf("SetOptions() got unexpected error: %v", err)
+	}
+	counterSR, err := ftutilities.LoadSubscribeResponse("testdata/counter_change_input.txt")
+	if err != nil {
+		t.Fatalf("failed to load input message: %v", err)
+	}
+	joinSR, err := ftutilities.LoadSubscribeResponse("testdata/two_members_aggregation_input.txt")
+	if err != nil {
+		t.Fatalf("failed to load input message: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		input     *gnmipb.SubscribeResponse
+		timestamp time.Duration
+		want      int
+	}{
+		{name: "first counter update", input: counterSR, timestamp: 0, want: 8},
+		{name: "counter update within the interval", input: counterSR, timestamp: 500 * time.Millisecond, want: 0},
+		{name: "membership change within the interval", input: joinSR, timestamp: 700 * time.Millisecond, want: 8},
+		{name: "counter update within the interval of the membership change", input: counterSR, timestamp: 1200 * time.Millisecond, want: 0},
+		{name: "counter update after the interval", input: counterSR, timestamp: 1700 * time.Millisecond, want: 8},
+	}
+	for _, tc := range tests {
+		tc.input.GetUpdate().Timestamp = int64(tc.timestamp)
+		got, err := ft.Translate(tc.input)
+		if err != nil {
+			t.Fatalf("%s: Translate() got unexpected error: %v", tc.name, err)
+		}
+		if n := aggregateUpdates(got, "Port-Channel10"); n != tc.want {
+			t.Errorf("%s: Translate() returned %d Port-Channel10 updates, want %d", tc.name, n, tc.want)
+		}
+	}
+
+	// Reset forgets the emissions.
+	ft.Reset()
+	setupStateForCounterChange(cache)
+	counterSR.GetUpdate().Timestamp = int64(1800 * time.Millisecond)
+	got, err := ft.Translate(counterSR)
+	if err != nil {
+		t.Fatalf("Translate() after Reset() got unexpected error: %v", err)
+	}
+	if n := aggregateUpdates(got, "Port-Channel10"); n != 8 {
+		t.Errorf("Translate() after Reset() returned %d Port-Channel10 updates, want 8", n)
+	}
+}
+
+func TestDebounceOption(t *testing.T) {
+	counterSR, err := ftutilities.LoadSubscribeResponse("testdata/counter_change_input.txt")
+	if err != nil {
+		t.Fatalf("failed to load input message: %v", err)
+	}
+	tests := []struct {
+		name    string
+		options translator.Options
+		// want is the number of Port-Channel10 updates of a counter update 500ms after the first.
+		want int
+	}{
+		{name: "option set", options: translator.Options{"debounce": "1s"}, want: 0},
+		{name: "zero", options: translator.Options{"debounce": "0s"}, want: 8},
+		{name: "no option", want: 8},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cache := ftutilities.NewQoSAggregationMapCache()
+			setupStateForCounterChange(cache)
+			ft := mustNewWithCache(t, cache)
+			if err := ft.SetOptions(tc.options); err != nil {
+				t.Fatalf("SetOptions(%v) got unexpected error: %v", tc.options, err)
+			}
+			var got *gnmipb.SubscribeResponse
+			for _, ts := range []time.Duration{0, 500 * time.Millisecond} {
+				counterSR.GetUpdate().Timestamp = int64(ts)
+				if got, err = ft.Translate(counterSR); err != nil {
+					t.Fatalf("Translate() got unexpected error: %v", err)
+				}
+			}
+			if n := aggregateUpdates(got, "Port-Channel10"); n != tc.want {
+				t.Errorf("Translate() returned %d Port-Channel10 updates, want %d", n, tc.want)
+			}
+		})
+	}
+}
+
+func TestInvalidDebounceOption(t *testing.T) {
+	ft := New()
+	for _, opts := range []translator.Options{{"debounce": "-1s"}, {"debounce": "soon"}} {
+		if err := ft.SetOptions(opts); err == nil {
+			t.Errorf("SetOptions(%v) got no error, want error", opts)
+		}
+	}
+}
+
+func TestSyncKeepsWaitingRoom(t *testing.T) {
+	cache := ftutilities.NewQoSAggregationMapCache()
+	targetInfo := cache.CreateOrUpdateTargetQoSInfo("cx12.sql12")
+	targetInfo.CreateOrRetrieveUnassociatedMember("Ethernet19/1").SetTxBytes("0", 1000)
+
+	syncSR := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true},
+	}
+	got, err := mustNewWithCache(t, cache).Translate(syncSR)
+	if err != nil {
+		t.Fatalf("Translate(%v) got unexpected error: %v", syncSR, err)
+	}
+	if diff := cmp.Diff(syncSR, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Translate(%v) returned unexpected diff (-want +got):\n%s", syncSR, diff)
+	}
+	// Sync responses do not carry a target, so the waiting room of a target still in its initial
+	// sync is kept.
+	if _, ok := targetInfo.RemoveUnassociatedMember("Ethernet19/1"); !ok {
+		t.Errorf("Translate(%v) removed Ethernet19/1 from the waiting room", syncSR)
+	}
+}
+
+func TestSyncTargetFlushesWaitingRoom(t *testing.T) {
+	cache := ftutilities.NewQoSAggregationMapCache()
+	synced := cache.CreateOrUpdateTargetQoSInfo("cx12.sql12")
+	synced.CreateOrRetrieveUnassociatedMember("Ethernet19/1").SetTxBytes("0", 1000)
+	syncing := cache.CreateOrUpdateTargetQoSInfo("cx13.sql13")
+	syncing.CreateOrRetrieveUnassociatedMember("Ethernet19/1").SetTxBytes("0", 1000)
+
+	syncSR := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true},
+	}
+	got, err := mustNewWithCache(t, cache).SyncTarget("cx12.sql12", syncSR)
+	if err != nil {
+		t.Fatalf("SyncTarget(%q) got unexpected error: %v", "cx12.sql12", err)
+	}
+	if diff := cmp.Diff([]*gnmipb.SubscribeResponse{syncSR}, got, protocmp.Transform()); diff != "" {
+		t.Errorf("SyncTarget(%q) returned unexpected diff (-want +got):\n%s", "cx12.sql12", diff)
+	}
+	if _, ok := synced.RemoveUnassociatedMember("Ethernet19/1"); ok {
+		t.Errorf("SyncTarget(%q) left Ethernet19/1 in its waiting room", "cx12.sql12")
+	}
+	// The waiting room of a target still in its initial updates is kept.
+	if _, ok := syncing.RemoveUnassociatedMember("Ethernet19/1"); !ok {
+		t.Errorf("SyncTarget(%q) removed Ethernet19/1 from the waiting room of %q", "cx12.sql12", "cx13.sql13")
+	}
+}
+
+func TestState(t *testing.T) {
+	cache := ftutilities.NewQoSAggregationMapCache()
+	setupStateForTwoMembers(cache)
+	ft := mustNewWithCache(t, cache)
+	snapshot := ft.State()
+	ft.Reset()
+
+	inputSR, err := ftutilities.LoadSubscribeResponse("testdata/two_members_aggregation_input.txt")
+	if err != nil {
+		t.Fatalf("failed to load input message: %v", err)
+	}
+	wantSR, err := ftutilities.LoadSubscribeResponse("testdata/two_members_aggregation_output.txt")
+	if err != nil {
+		t.Fatalf("failed to load want message: %v", err)
+	}
+	gotSR, err := ft.Translate(inputSR)
+	if err != nil {
+		t.Fatalf("Translate() after Reset() got unexpected error: %v", err)
+	}
+	if cmp.Equal(wantSR, gotSR, protocmp.Transform()) {
+		t.Errorf("Translate() after Reset() aggregated the members of the discarded state")
+	}
+
+	// The snapshot is not modified by the translation.
+	ft.Reset()
+	if err := ft.RestoreState(snapshot); err != nil {
+		t.Fatalf("RestoreState() got unexpected error: %v", err)
+	}
+	gotSR, err = ft.Translate(inputSR)
+	if err != nil {
+		t.Fatalf("Translate() after RestoreState() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(wantSR, gotSR, protocmp.Transform()); diff != "" {
+		t.Errorf("Translate() after RestoreState() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	if err := ft.RestoreState("invalid"); err == nil {
+		t.Errorf("RestoreState() with an invalid snapshot returned nil error, want error")
+	}
+}
+
+func TestOnTargetDisconnect(t *testing.T) {
+	cache := ftutilities.NewQoSAggregationMapCache()
+	setupStateForTwoMembers(cache)
+	cache.CreateOrUpdateTargetQoSInfo("other")
+	ft := mustNewWithCache(t, cache)
+
+	ft.OnTargetDisconnect("cx12.sql12")
+	if diff := cmp.Diff([]string{"other"}, ft.Store().Targets()); diff != "" {
+		t.Errorf("Targets() after OnTargetDisconnect() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	inputSR, err := ftutilities.LoadSubscribeResponse("testdata/two_members_aggregation_input.txt")
+	if err != nil {
+		t.Fatalf("failed to load input message: %v", err)
+	}
+	wantSR, err := ftutilities.LoadSubscribeResponse("testdata/two_members_aggregation_output.txt")
+	if err != nil {
+		t.Fatalf("failed to load want message: %v", err)
+	}
+	gotSR, err := ft.Translate(inputSR)
+	if err != nil {
+		t.Fatalf("Translate() after OnTargetDisconnect() got unexpected error: %v", err)
+	}
+	if cmp.Equal(wantSR, gotSR, protocmp.Transform()) {
+		t.Errorf("Translate() after OnTargetDisconnect() aggregated the members of the disconnected target")
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aristaqosmaps translates the Arista dscp-to-tc and tc-to-queue QoS maps from native to
+// openconfig, so that the numeric queue names reported in the queue counters can be interpreted
+// without consulting the device configuration.
+//
+// The dscp-to-tc map is represented as a single classifier with one term per DSCP value, whose
+// target-group is the forwarding group of the traffic class. The tc-to-queue map is represented
+// as the output-queue of each of those forwarding groups.
+package aristaqosmaps
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	// classifierName is the name of the openconfig classifier holding the dscp-to-tc map.
+	classifierName = "dscp-to-tc"
+
+	leafDscpToTc    = "dscpToTcMap"
+	leafTcToTxQueue = "tcToTxQueueMap"
+)
+
+var (
+	// Arista does not support `*` subscription for the native paths.
+	// Therefore, we need to subscribe to the longest prefix/container of a path.
+	// Example:
+	// for native path: /eos_native/Sysdb/qos/status/dscpToTcMap/<dscp>
+	// Subscribe to: /eos_native/Sysdb/qos/status/dscpToTcMap
+	translateMap = map[string][]string{
+		"/openconfig/qos/classifiers/classifier/terms/term/conditions/ipv4/state/dscp": {
+			"/eos_native/Sysdb/qos/status/dscpToTcMap",
+		},
+		"/openconfig/qos/classifiers/classifier/terms/term/actions/state/target-group": {
+			"/eos_native/Sysdb/qos/status/dscpToTcMap",
+		},
+		"/openconfig/qos/forwarding-groups/forwarding-group/state/output-queue": {
+			"/eos_native/Sysdb/qos/status/tcToTxQueueMap",
+		},
+	}
+	paths        = ftutilities.MustStringMapPaths(translateMap)
+	pathPatterns = []*gnmipb.Path{
+		{
+			Origin: "eos_native",
+			Elem: []*gnmipb.PathElem{
+				{Name: "Sysdb"}, {Name: "qos"}, {Name: "status"}, {Name: leafDscpToTc},
+				{Name: "*"}, // dscp
+			},
+		},
+		{
+			Origin: "eos_native",
+			Elem: []*gnmipb.PathElem{
+				{Name: "Sysdb"}, {Name: "qos"}, {Name: "status"}, {Name: leafTcToTxQueue},
+				{Name: "*"}, // traffic-class
+			},
+		},
+	}
+)
+
+func init() {
+	registry.Register(ftconsts.AristaQoSMapsTranslator, NewE)
+}
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Arista QoS maps functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	ft, err := translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:               ftconsts.AristaQoSMapsTranslator,
+			Translate:        translate,
+			OutputToInputMap: paths,
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorArista,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ft, nil
+}
+
+// forwardingGroupName returns the openconfig forwarding group name of a traffic class.
+func forwardingGroupName(tc uint64) string {
+	return fmt.Sprintf("tc%d", tc)
+}
+
+// termID returns the openconfig classifier term id of a DSCP value.
+func termID(dscp uint64) string {
+	return fmt.Sprintf("dscp-%d", dscp)
+}
+
+func termPath(dscp uint64, leaf ...string) *gnmipb.Path {
+	p := &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "qos"},
+			{Name: "classifiers"},
+			{Name: "classifier", Key: map[string]string{"name": classifierName}},
+			{Name: "terms"},
+			{Name: "term", Key: map[string]string{"id": termID(dscp)}},
+		},
+	}
+	for _, l := range leaf {
+		p.Elem = append(p.Elem, &gnmipb.PathElem{Name: l})
+	}
+	return p
+}
+
+func outputQueuePath(tc uint64) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "qos"},
+			{Name: "forwarding-groups"},
+			{Name: "forwarding-group", Key: map[string]string{"name": forwardingGroupName(tc)}},
+			{Name: "state"},
+			{Name: "output-queue"},
+		},
+	}
+}
+
+// mapEntry returns the map name and the numeric index of the map entry addressed by path.
+func mapEntry(path *gnmipb.Path) (mapName string, index uint64, err error) {
+	elems := path.GetElem()
+	mapName = elems[len(elems)-2].GetName()
+	index, err = strconv.ParseUint(elems[len(elems)-1].GetName(), 10, 8)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid %s index in path %v: %v", mapName, path, err)
+	}
+	return mapName, index, nil
+}
+
+// buildUpdates translates a single native map entry to openconfig updates.
+func buildUpdates(path *gnmipb.Path, val *gnmipb.TypedValue) ([]*gnmipb.Update, error) {
+	mapName, index, err := mapEntry(path)
+	if err != nil {
+		return nil, err
+	}
+	v, err := ftutilities.ToUint64(val)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s[%d]: %v", mapName, index, err)
+	}
+	switch mapName {
+	case leafDscpToTc:
+		return []*gnmipb.Update{
+			{
+				Path: termPath(index, "conditions", "ipv4", "state", "dscp"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: index}},
+			},
+			{
+				Path: termPath(index, "actions", "state", "target-group"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: forwardingGroupName(v)}},
+			},
+		}, nil
+	case leafTcToTxQueue:
+		return []*gnmipb.Update{
+			{
+				Path: outputQueuePath(index),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: strconv.FormatUint(v, 10)}},
+			},
+		}, nil
+	}
+	return nil, nil
+}
+
+// buildDeletes translates the deletion of a single native map entry to openconfig deletes.
+func buildDeletes(path *gnmipb.Path) ([]*gnmipb.Path, error) {
+	mapName, index, err := mapEntry(path)
+	if err != nil {
+		return nil, err
+	}
+	switch mapName {
+	case leafDscpToTc:
+		return []*gnmipb.Path{termPath(index)}, nil
+	case leafTcToTxQueue:
+		return []*gnmipb.Path{outputQueuePath(index)}, nil
+	}
+	return nil, nil
+}
+
+func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	var (
+		updates []*gnmipb.Update
+		deletes []*gnmipb.Path
+	)
+	for _, u := range n.GetUpdate() {
+		path := ftutilities.Join(n.GetPrefix(), u.GetPath())
+		if !ftutilities.PathInList(path, pathPatterns) {
+			continue
+		}
+		ups, err := buildUpdates(path, u.GetVal())
+		if err != nil {
+			log.Errorf("Failed to translate update %v: %v", u, err)
+			continue
+		}
+		updates = append(updates, ups...)
+	}
+	for _, d := range n.GetDelete() {
+		path := ftutilities.Join(n.GetPrefix(), d)
+		if !ftutilities.PathInList(path, pathPatterns) {
+			continue
+		}
+		dels, err := buildDeletes(path)
+		if err != nil {
+			log.Errorf("Failed to translate delete %v: %v", d, err)
+			continue
+		}
+		deletes = append(deletes, dels...)
+	}
+	if len(updates) == 0 && len(deletes) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+				Delete: deletes,
+			},
+		},
+	}, nil
+}
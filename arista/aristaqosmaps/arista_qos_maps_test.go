@@ -0,0 +1,70 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aristaqosmaps
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/functional-translators/ftutilities"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name           string
+		inputPath      string
+		wantOutputPath string
+	}{
+		{
+			name:           "dscp_and_tc_maps",
+			inputPath:      "testdata/maps_input.txt",
+			wantOutputPath: "testdata/maps_output.txt",
+		},
+		{
+			name:           "map_entries_deleted",
+			inputPath:      "testdata/maps_delete_input.txt",
+			wantOutputPath: "testdata/maps_delete_output.txt",
+		},
+		{
+			name:      "invalid_entries_are_dropped",
+			inputPath: "testdata/invalid_entries_input.txt",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			inputSR, err := ftutilities.LoadSubscribeResponse(tc.inputPath)
+			if err != nil {
+				t.Fatalf("failed to load input message: %v", err)
+			}
+			var wantSR *gnmipb.SubscribeResponse
+			if tc.wantOutputPath != "" {
+				wantSR, err = ftutilities.LoadSubscribeResponse(tc.wantOutputPath)
+				if err != nil {
+					t.Fatalf("failed to load want message: %v", err)
+				}
+			}
+			gotSR, err := New().Translate(inputSR)
+			if err != nil {
+				t.Fatalf("Translate() returned unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(wantSR, gotSR, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update", "delete")); diff != "" {
+				t.Errorf("Translate() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aristatransceiver translates the Arista transceiver DOM (digital optical monitoring) data
+// from native to openconfig transceiver physical channels, for EOS releases which do not populate
+// the openconfig DOM leaves.
+//
+// The native DOM data of each lane is read from
+// /Sysdb/hardware/archer/xcvr/status/all/<slot>/laneDomInfo/<lane>/<leaf>. The transceiver
+// component is named after the xcvr slot (e.g. "Ethernet1"), as done by aristaxcvrpresence, and the
+// channel index is the native lane index.
+//
+// It is not meant to be used alongside the openconfig DOM leaves of the releases which populate
+// them. It is therefore not registered for the automatic selection of the translators of a device:
+// consumers create it with New for the affected devices, e.g. in the Translators of the
+// functionaltranslators options.
+package aristatransceiver
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	nativeOrigin    = "eos_native"
+	laneDomInfo     = "laneDomInfo"
+	leafTxPower     = "txPower"
+	leafRxPower     = "rxPower"
+	leafTxBias      = "txBias"
+	leafTemperature = "laserTemperature"
+)
+
+var (
+	// Arista does not support `*` subscription for the native paths.
+	// Therefore, we need to subscribe to the longest prefix/container of a path.
+	// Example:
+	// for native path: /eos_native/Sysdb/hardware/archer/xcvr/status/all/<slot>/laneDomInfo/<lane>/txPower
+	// Subscribe to: /eos_native/Sysdb/hardware/archer/xcvr/status/all
+	translateMap = map[string][]string{
+		"/openconfig/components/component/transceiver/physical-channels/channel/state/index": {
+			"/eos_native/Sysdb/hardware/archer/xcvr/status/all",
+		},
+		"/openconfig/components/component/transceiver/physical-channels/channel/state/output-power/instant": {
+			"/eos_native/Sysdb/hardware/archer/xcvr/status/all",
+		},
+		"/openconfig/components/component/transceiver/physical-channels/channel/state/input-power/instant": {
+			"/eos_native/Sysdb/hardware/archer/xcvr/status/all",
+		},
+		"/openconfig/components/component/transceiver/physical-channels/channel/state/laser-bias-current/instant": {
+			"/eos_native/Sysdb/hardware/archer/xcvr/status/all",
+		},
+		"/openconfig/components/component/transceiver/physical-channels/channel/state/laser-temperature/instant": {
+			"/eos_native/Sysdb/hardware/archer/xcvr/status/all",
+		},
+	}
+	paths = ftutilities.MustStringMapPaths(translateMap)
+	// statusPrefix is the native container holding the status of all xcvr slots.
+	statusPrefix = []string{"Sysdb", "hardware", "archer", "xcvr", "status", "all"}
+	// channelLeaves maps the native lane leaves to the openconfig channel state leaves. The native
+	// power is in dBm, the bias current in mA and the temperature in degrees Celsius, as in
+	// openconfig.
+	channelLeaves = map[string][]string{
+		leafTxPower:     {"output-power", "instant"},
+		leafRxPower:     {"input-power", "instant"},
+		leafTxBias:      {"laser-bias-current", "instant"},
+		leafTemperature: {"laser-temperature", "instant"},
+	}
+)
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Arista transceiver functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	return translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:                   ftconsts.AristaTransceiverTranslator,
+			TranslateWithOptions: translate,
+			ValidateOptions:      validateOptions,
+			OutputToInputMap:     paths,
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorArista,
+				},
+			},
+		},
+	)
+}
+
+// laneLeaf is a parsed native lane DOM path.
+type laneLeaf struct {
+	// slot is the xcvr slot name, e.g. "Ethernet1" or "Ethernet3/1" on modular systems.
+	slot string
+	lane string
+	leaf string
+}
+
+// parsePath parses a native lane DOM path. As eos_native paths have no keys, slot names that
+// contain "/" span multiple path elements, so the slot is everything between the status container
+// and the laneDomInfo container.
+func parsePath(path *gnmipb.Path) (*laneLeaf, bool) {
+	if path.GetOrigin() != nativeOrigin {
+		return nil, false
+	}
+	elems := path.GetElem()
+	if len(elems) < len(statusPrefix)+4 {
+		return nil, false
+	}
+	for i, name := range statusPrefix {
+		if elems[i].GetName() != name {
+			return nil, false
+		}
+	}
+	var names []string
+	for _, e := range elems[len(statusPrefix):] {
+		names = append(names, e.GetName())
+	}
+	n := len(names)
+	if names[n-3] != laneDomInfo {
+		return nil, false
+	}
+	return &laneLeaf{slot: strings.Join(names[:n-3], "/"), lane: names[n-2], leaf: names[n-1]}, true
+}
+
+func channelPath(component, lane string, leaf ...string) *gnmipb.Path {
+	p := &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": component}},
+			{Name: "transceiver"},
+			{Name: "physical-channels"},
+			{Name: "channel", Key: map[string]string{"index": lane}},
+			{Name: "state"},
+		},
+	}
+	for _, l := range leaf {
+		p.Elem = append(p.Elem, &gnmipb.PathElem{Name: l})
+	}
+	return p
+}
+
+// buildUpdates translates a single native lane leaf to openconfig updates, with the analog
+// precision. includeIndex is false if the index of the channel is already translated.
+func buildUpdates(l *laneLeaf, val *gnmipb.TypedValue, includeIndex bool, precision uint32) ([]*gnmipb.Update, error) {
+	ocLeaf, ok := channelLeaves[l.leaf]
+	if !ok {
+		return nil, nil
+	}
+	index, err := strconv.ParseUint(l.lane, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lane %q: %v", l.lane, err)
+	}
+	v, err := ftutilities.ToDouble(val)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", l.leaf, err)
+	}
+	// EOS reports the power of a lane without light as -inf, which openconfig cannot represent.
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil, nil
+	}
+	var updates []*gnmipb.Update
+	if includeIndex {
+		updates = append(updates, &gnmipb.Update{
+			Path: channelPath(l.slot, l.lane, "index"),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: index}},
+		})
+	}
+	return append(updates, &gnmipb.Update{
+		Path: channelPath(l.slot, l.lane, ocLeaf...),
+		Val:  ftutilities.AnalogValue(v, precision),
+	}), nil
+}
+
+// validateOptions rejects an invalid analog precision.
+func validateOptions(opts translator.Options) error {
+	_, err := ftutilities.AnalogPrecision(opts)
+	return err
+}
+
+func translate(sr *gnmipb.SubscribeResponse, opts translator.Options) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	precision, err := ftutilities.AnalogPrecision(opts)
+	if err != nil {
+		return nil, err
+	}
+	var updates []*gnmipb.Update
+	// The index of a channel is emitted once per notification, even if several of its leaves are.
+	seenIndex := map[string]bool{}
+	for _, u := range n.GetUpdate() {
+		l, ok := parsePath(ftutilities.Join(n.GetPrefix(), u.GetPath()))
+		if !ok {
+			continue
+		}
+		key := l.slot + "/" + l.lane
+		ups, err := buildUpdates(l, u.GetVal(), !seenIndex[key], precision)
+		if err != nil {
+			log.Errorf("Failed to translate update %v: %v", u, err)
+			continue
+		}
+		if len(ups) > 0 {
+			seenIndex[key] = true
+		}
+		updates = append(updates, ups...)
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+			},
+		},
+	}, nil
+}
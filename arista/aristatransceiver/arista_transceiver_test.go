@@ -0,0 +1,73 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aristatransceiver
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/functional-translators/fttest"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/translator"
+)
+
+func TestTranslate(t *testing.T) {
+	fttest.RunGoldenTests(t, New(), "testdata")
+}
+
+func TestTranslateDecimal64(t *testing.T) {
+	ft := New()
+	opts := translator.Options{ftutilities.AnalogPrecisionOption: "2"}
+	if err := ft.SetOptions(opts); err != nil {
+		t.Fatalf("SetOptions(%v) got unexpected error: %v", opts, err)
+	}
+	fttest.RunGoldenTests(t, ft, "testdata/decimal64")
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path   string
+		want   *laneLeaf
+		wantOk bool
+	}{
+		{
+			path:   "/Sysdb/hardware/archer/xcvr/status/all/Ethernet1/laneDomInfo/1/txPower",
+			want:   &laneLeaf{slot: "Ethernet1", lane: "1", leaf: "txPower"},
+			wantOk: true,
+		},
+		{
+			path:   "/Sysdb/hardware/archer/xcvr/status/all/Ethernet3/1/laneDomInfo/2/rxPower",
+			want:   &laneLeaf{slot: "Ethernet3/1", lane: "2", leaf: "rxPower"},
+			wantOk: true,
+		},
+		{path: "/Sysdb/hardware/archer/xcvr/status/all/Ethernet1/presence"},
+		{path: "/Sysdb/hardware/archer/xcvr/status/all/Ethernet1/intfName/1/name"},
+		{path: "/Sysdb/hardware/archer/xcvr/config/all/Ethernet1/laneDomInfo/1/txPower"},
+	}
+	for _, tc := range tests {
+		p, err := ftutilities.StringToPath(tc.path)
+		if err != nil {
+			t.Fatalf("StringToPath(%q) got unexpected error: %v", tc.path, err)
+		}
+		p.Origin = "eos_native"
+		got, ok := parsePath(p)
+		if ok != tc.wantOk {
+			t.Errorf("parsePath(%q) returned ok %t, want %t", tc.path, ok, tc.wantOk)
+		}
+		if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(laneLeaf{})); diff != "" {
+			t.Errorf("parsePath(%q) returned unexpected diff (-want +got):\n%s", tc.path, diff)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aristaxcvrpresence translates the Arista transceiver presence from native to openconfig,
+// so that optic removal is reported explicitly instead of being inferred from missing samples.
+//
+// The transceiver component is named after the xcvr slot (e.g. "Ethernet1"), as done by the Arista
+// transceiver translators. When a transceiver is inserted, the component is reported as not empty
+// and the state/transceiver leaf of each interface of the slot is set to the component name. When
+// it is removed, or its status is deleted, the component and those leaves are deleted.
+package aristaxcvrpresence
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	leafPresence = "presence"
+	leafIntfName = "intfName"
+
+	// presencePresent is the native presence value of an inserted transceiver. All other values,
+	// e.g. xcvrNotPresent or xcvrPresenceUnknown, are treated as removed.
+	presencePresent = "xcvrPresent"
+)
+
+var (
+	// Arista does not support `*` subscription for the native paths.
+	// Therefore, we need to subscribe to the longest prefix/container of a path.
+	// Example:
+	// for native path: /eos_native/Sysdb/hardware/archer/xcvr/status/all/<slot>/presence
+	// Subscribe to: /eos_native/Sysdb/hardware/archer/xcvr/status/all
+	translateMap = map[string][]string{
+		"/openconfig/components/component/state/name": {
+			"/eos_native/Sysdb/hardware/archer/xcvr/status/all",
+		},
+		"/openconfig/components/component/state/empty": {
+			"/eos_native/Sysdb/hardware/archer/xcvr/status/all",
+		},
+		"/openconfig/interfaces/interface/state/transceiver": {
+			"/eos_native/Sysdb/hardware/archer/xcvr/status/all",
+		},
+	}
+	paths = ftutilities.MustStringMapPaths(translateMap)
+	// statusPrefix is the native container holding the status of all xcvr slots.
+	statusPrefix = []string{"Sysdb", "hardware", "archer", "xcvr", "status", "all"}
+
+	// slots caches the interfaces and presence of each xcvr slot, per target, so that the interfaces
+	// of a slot can be cleared when its transceiver is removed.
+	slotsMu sync.Mutex
+	slots   = map[string]map[string]*slotInfo{}
+)
+
+// slotInfo holds the state of an xcvr slot.
+type slotInfo struct {
+	present bool
+	// intfs maps the native lane index to the interface name.
+	intfs map[string]string
+}
+
+func init() {
+	registry.Register(ftconsts.AristaXcvrPresenceTranslator, NewE)
+}
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Arista xcvr presence functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	ft, err := translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:               ftconsts.AristaXcvrPresenceTranslator,
+			Translate:        translate,
+			OutputToInputMap: paths,
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorArista,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ft, nil
+}
+
+// nativeLeaf is a parsed native xcvr status path.
+type nativeLeaf struct {
+	// slot is the xcvr slot name, e.g. "Ethernet1" or "Ethernet3/1" on modular systems. It is empty
+	// if the path addresses the status container of all slots.
+	slot string
+	// leaf is the native leaf name, or empty if the path addresses a container of slots or another
+	// leaf.
+	leaf string
+	// lane is the lane index of intfName leaves.
+	lane string
+}
+
+// parsePath parses a native xcvr status path. As eos_native paths have no keys, slot names that
+// contain "/" span multiple path elements, so the slot is everything between the status container
+// and the known leaf names.
+func parsePath(path *gnmipb.Path) (*nativeLeaf, bool) {
+	if path.GetOrigin() != "eos_native" {
+		return nil, false
+	}
+	elems := path.GetElem()
+	if len(elems) < len(statusPrefix) {
+		return nil, false
+	}
+	for i, name := range statusPrefix {
+		if elems[i].GetName() != name {
+			return nil, false
+		}
+	}
+	if len(elems) == len(statusPrefix) {
+		return &nativeLeaf{}, true
+	}
+	var names []string
+	for _, e := range elems[len(statusPrefix):] {
+		names = append(names, e.GetName())
+	}
+	n := len(names)
+	switch {
+	case n >= 2 && names[n-1] == leafPresence:
+		return &nativeLeaf{slot: strings.Join(names[:n-1], "/"), leaf: leafPresence}, true
+	case n >= 3 && names[n-2] == leafIntfName:
+		return &nativeLeaf{slot: strings.Join(names[:n-2], "/"), leaf: leafIntfName, lane: names[n-1]}, true
+	case n >= 2 && names[n-1] == leafIntfName:
+		return &nativeLeaf{slot: strings.Join(names[:n-1], "/"), leaf: leafIntfName}, true
+	}
+	// Other leaves are ignored for updates, but a delete of a slot container removes the transceivers
+	// of the slots it contains.
+	return &nativeLeaf{slot: strings.Join(names, "/")}, true
+}
+
+func componentPath(slot string, leaf ...string) *gnmipb.Path {
+	p := &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": slot}},
+		},
+	}
+	for _, l := range leaf {
+		p.Elem = append(p.Elem, &gnmipb.PathElem{Name: l})
+	}
+	return p
+}
+
+func transceiverPath(intf string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": intf}},
+			{Name: "state"},
+			{Name: "transceiver"},
+		},
+	}
+}
+
+func stringUpdate(p *gnmipb.Path, v string) *gnmipb.Update {
+	return &gnmipb.Update{Path: p, Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: v}}}
+}
+
+// presentUpdates returns the updates reporting the transceiver of the slot as present.
+func presentUpdates(slot string, info *slotInfo) []*gnmipb.Update {
+	updates := []*gnmipb.Update{
+		stringUpdate(componentPath(slot, "state", "name"), slot),
+		{
+			Path: componentPath(slot, "state", "empty"),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: false}},
+		},
+	}
+	for _, intf := range info.intfs {
+		updates = append(updates, stringUpdate(transceiverPath(intf), slot))
+	}
+	return updates
+}
+
+// removedDeletes returns the deletes reporting the transceiver of the slot as removed.
+func removedDeletes(slot string, info *slotInfo) []*gnmipb.Path {
+	deletes := []*gnmipb.Path{componentPath(slot)}
+	for _, intf := range info.intfs {
+		deletes = append(deletes, transceiverPath(intf))
+	}
+	return deletes
+}
+
+// targetSlots returns the cached slots of the target. slotsMu must be held.
+func targetSlots(target string) map[string]*slotInfo {
+	s, ok := slots[target]
+	if !ok {
+		s = map[string]*slotInfo{}
+		slots[target] = s
+	}
+	return s
+}
+
+func getOrCreateSlot(s map[string]*slotInfo, slot string) *slotInfo {
+	info, ok := s[slot]
+	if !ok {
+		info = &slotInfo{intfs: map[string]string{}}
+		s[slot] = info
+	}
+	return info
+}
+
+// handleUpdate applies a native update to the slot cache and returns the openconfig updates and
+// deletes it results in.
+func handleUpdate(s map[string]*slotInfo, l *nativeLeaf, val *gnmipb.TypedValue) ([]*gnmipb.Update, []*gnmipb.Path, error) {
+	switch l.leaf {
+	case leafPresence:
+		v, ok := val.GetValue().(*gnmipb.TypedValue_StringVal)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected value type %T for %s presence", val.GetValue(), l.slot)
+		}
+		info := getOrCreateSlot(s, l.slot)
+		info.present = v.StringVal == presencePresent
+		if info.present {
+			return presentUpdates(l.slot, info), nil, nil
+		}
+		return nil, removedDeletes(l.slot, info), nil
+	case leafIntfName:
+		v, ok := val.GetValue().(*gnmipb.TypedValue_StringVal)
+		if !ok || l.lane == "" {
+			return nil, nil, fmt.Errorf("unexpected interface name %v for %s lane %q", val, l.slot, l.lane)
+		}
+		info := getOrCreateSlot(s, l.slot)
+		info.intfs[l.lane] = v.StringVal
+		if info.present {
+			return []*gnmipb.Update{stringUpdate(transceiverPath(v.StringVal), l.slot)}, nil, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// handleDelete applies a native delete to the slot cache and returns the openconfig deletes it
+// results in.
+func handleDelete(s map[string]*slotInfo, l *nativeLeaf) []*gnmipb.Path {
+	switch l.leaf {
+	case leafIntfName:
+		info, ok := s[l.slot]
+		if !ok {
+			return nil
+		}
+		var deletes []*gnmipb.Path
+		for lane, intf := range info.intfs {
+			if l.lane != "" && lane != l.lane {
+				continue
+			}
+			delete(info.intfs, lane)
+			if info.present {
+				deletes = append(deletes, transceiverPath(intf))
+			}
+		}
+		return deletes
+	case leafPresence:
+		info, ok := s[l.slot]
+		if !ok {
+			// The interfaces of the slot are unknown, e.g. after a restart, so only the component can
+			// be deleted.
+			return []*gnmipb.Path{componentPath(l.slot)}
+		}
+		info.present = false
+		return removedDeletes(l.slot, info)
+	}
+	// A slot, or a container of slots, was deleted: either all slots, or the slots of a linecard on
+	// modular systems.
+	var deletes []*gnmipb.Path
+	for slot, info := range s {
+		if l.slot == "" || slot == l.slot || strings.HasPrefix(slot, l.slot+"/") {
+			deletes = append(deletes, removedDeletes(slot, info)...)
+			delete(s, slot)
+		}
+	}
+	return deletes
+}
+
+func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	var (
+		updates []*gnmipb.Update
+		deletes []*gnmipb.Path
+	)
+	slotsMu.Lock()
+	s := targetSlots(n.GetPrefix().GetTarget())
+	// gNMI processes deletes before updates.
+	for _, d := range n.GetDelete() {
+		l, ok := parsePath(ftutilities.Join(n.GetPrefix(), d))
+		if !ok {
+			continue
+		}
+		deletes = append(deletes, handleDelete(s, l)...)
+	}
+	for _, u := range n.GetUpdate() {
+		l, ok := parsePath(ftutilities.Join(n.GetPrefix(), u.GetPath()))
+		if !ok {
+			continue
+		}
+		ups, dels, err := handleUpdate(s, l, u.GetVal())
+		if err != nil {
+			log.Errorf("Failed to translate update %v: %v", u, err)
+			continue
+		}
+		updates = append(updates, ups...)
+		deletes = append(deletes, dels...)
+	}
+	slotsMu.Unlock()
+	if len(updates) == 0 && len(deletes) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+				Delete: deletes,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aristaxcvrpresence
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/functional-translators/ftutilities"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// resetCache clears the cached slots of all targets.
+func resetCache() {
+	slotsMu.Lock()
+	defer slotsMu.Unlock()
+	slots = map[string]map[string]*slotInfo{}
+}
+
+// setupPresentSlot caches an inserted transceiver in slot Ethernet1 with two interfaces.
+func setupPresentSlot() {
+	resetCache()
+	slots["ar1"] = map[string]*slotInfo{
+		"Ethernet1": {present: true, intfs: map[string]string{"1": "Ethernet1/1", "2": "Ethernet1/2"}},
+	}
+}
+
+// setupModularSlots caches inserted transceivers in slots of two linecards.
+func setupModularSlots() {
+	resetCache()
+	slots["ar1"] = map[string]*slotInfo{
+		"Ethernet3/1": {present: true, intfs: map[string]string{"1": "Ethernet3/1/1"}},
+		"Ethernet3/2": {present: true, intfs: map[string]string{"1": "Ethernet3/2/1"}},
+		"Ethernet4/1": {present: true, intfs: map[string]string{"1": "Ethernet4/1/1"}},
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name           string
+		setup          func()
+		inputPath      string
+		wantOutputPath string
+	}{
+		{
+			name:           "insertion_reports_component_and_interfaces",
+			setup:          resetCache,
+			inputPath:      "testdata/insert_input.txt",
+			wantOutputPath: "testdata/insert_output.txt",
+		},
+		{
+			name:           "removal_deletes_component_and_interfaces",
+			setup:          setupPresentSlot,
+			inputPath:      "testdata/removal_input.txt",
+			wantOutputPath: "testdata/removal_output.txt",
+		},
+		{
+			name:           "slot_delete_deletes_component_and_interfaces",
+			setup:          setupPresentSlot,
+			inputPath:      "testdata/slot_delete_input.txt",
+			wantOutputPath: "testdata/removal_output.txt",
+		},
+		{
+			name:           "linecard_delete_deletes_its_slots_only",
+			setup:          setupModularSlots,
+			inputPath:      "testdata/linecard_delete_input.txt",
+			wantOutputPath: "testdata/linecard_delete_output.txt",
+		},
+		{
+			name:           "presence_delete_of_unknown_slot_deletes_component",
+			setup:          resetCache,
+			inputPath:      "testdata/unknown_slot_presence_delete_input.txt",
+			wantOutputPath: "testdata/unknown_slot_presence_delete_output.txt",
+		},
+		{
+			name:           "interface_added_to_present_slot",
+			setup:          setupPresentSlot,
+			inputPath:      "testdata/interface_added_input.txt",
+			wantOutputPath: "testdata/interface_added_output.txt",
+		},
+		{
+			name:      "unrelated_leaves_are_ignored",
+			setup:     setupPresentSlot,
+			inputPath: "testdata/unrelated_input.txt",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setup()
+			inputSR, err := ftutilities.LoadSubscribeResponse(tc.inputPath)
+			if err != nil {
+				t.Fatalf("failed to load input message: %v", err)
+			}
+			var wantSR *gnmipb.SubscribeResponse
+			if tc.wantOutputPath != "" {
+				wantSR, err = ftutilities.LoadSubscribeResponse(tc.wantOutputPath)
+				if err != nil {
+					t.Fatalf("failed to load want message: %v", err)
+				}
+			}
+			gotSR, err := New().Translate(inputSR)
+			if err != nil {
+				t.Fatalf("Translate() returned unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(wantSR, gotSR, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update", "delete")); diff != "" {
+				t.Errorf("Translate() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTranslateRemovalAfterInsertion(t *testing.T) {
+	resetCache()
+	for _, step := range []struct {
+		inputPath      string
+		wantOutputPath string
+	}{
+		{"testdata/insert_input.txt", "testdata/insert_output.txt"},
+		{"testdata/removal_input.txt", "testdata/removal_output.txt"},
+	} {
+		inputSR, err := ftutilities.LoadSubscribeResponse(step.inputPath)
+		if err != nil {
+			t.Fatalf("failed to load input message: %v", err)
+		}
+		wantSR, err := ftutilities.LoadSubscribeResponse(step.wantOutputPath)
+		if err != nil {
+			t.Fatalf("failed to load want message: %v", err)
+		}
+		gotSR, err := New().Translate(inputSR)
+		if err != nil {
+			t.Fatalf("Translate(%s) returned unexpected error: %v", step.inputPath, err)
+		}
+		if diff := cmp.Diff(wantSR, gotSR, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update", "delete")); diff != "" {
+			t.Errorf("Translate(%s) returned unexpected diff (-want +got):\n%s", step.inputPath, diff)
+		}
+	}
+}
@@ -19,10 +19,11 @@ import (
 	"fmt"
 	"strconv"
 
-	log "github.com/golang/glog"
 	ic "github.com/openconfig/functional-translators/ciscoxr/ciscoxr8000icresource/yang/openconfig"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -155,20 +156,21 @@ func buildResources(prefix *gnmipb.Path, leafs []*gnmipb.Update) map[string]*res
 				bankID:   uint64(bankID),
 			}
 		}
+		var value *uint64
 		switch elems[8].GetName() {
 		case "counter":
 			if elems[9].GetName() == "inuse-entries" {
-				resources[resourceKey].inUseEntries = leaf.GetVal().GetUintVal()
+				value = &resources[resourceKey].inUseEntries
 			} else if elems[9].GetName() == "max-entries" {
-				resources[resourceKey].maxEntries = leaf.GetVal().GetUintVal()
+				value = &resources[resourceKey].maxEntries
 			} else {
 				continue
 			}
 		case "oor-state":
 			if elems[9].GetName() == "red-oor-threshold" {
-				resources[resourceKey].redOORThreshold = leaf.GetVal().GetUintVal()
+				value = &resources[resourceKey].redOORThreshold
 			} else if elems[9].GetName() == "yellow-oor-threshold" {
-				resources[resourceKey].yellowOORThreshold = leaf.GetVal().GetUintVal()
+				value = &resources[resourceKey].yellowOORThreshold
 			} else {
 				// We do not need other leaves, so we skip them.
 				continue
@@ -177,12 +179,31 @@ func buildResources(prefix *gnmipb.Path, leafs []*gnmipb.Update) map[string]*res
 			// We do not need other leaves, so we skip them.
 			continue
 		}
+		v, err := ftutilities.ToUint64(leaf.GetVal())
+		if err != nil {
+			log.Errorf("failed to parse %s: %v", elems[9].GetName(), err)
+			continue
+		}
+		*value = v
 	}
 	return resources
 }
 
+func init() {
+	registry.Register(ftconsts.CiscoXR8000IntegratedCircuitResourceFunctionalTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco HW resource 7.10.2 functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
 			ID:               ftconsts.CiscoXR8000IntegratedCircuitResourceFunctionalTranslator,
@@ -196,9 +217,9 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Cisco HW resource 7.10.2 functional translator: %v", err)
+		return nil, err
 	}
-	return ft
+	return ft, nil
 }
 
 func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
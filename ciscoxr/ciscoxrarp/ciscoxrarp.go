@@ -17,13 +17,15 @@ package ciscoxrarp
 
 import (
 	"fmt"
+	"net/netip"
 
-	log "github.com/golang/glog"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/ygot/ytypes"
 	xr2431 "github.com/openconfig/functional-translators/ciscoxr/ciscoxrarp/yang/native"
 	lc "github.com/openconfig/functional-translators/ciscoxr/ciscoxrarp/yang/openconfig"
 	"github.com/openconfig/functional-translators/ftconsts"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -37,27 +39,173 @@ var (
 		"/openconfig/interfaces/interface/subinterfaces/subinterface/ipv4/neighbors/neighbor/state/link-layer-address": {
 			"/Cisco-IOS-XR-ipv4-arp-oper/arp/nodes/node/entries/entry/hardware-address",
 		},
+		"/openconfig/interfaces/interface/subinterfaces/subinterface/ipv4/neighbors/neighbor/state/origin": {
+			"/Cisco-IOS-XR-ipv4-arp-oper/arp/nodes/node/entries/entry/state",
+		},
 		"/openconfig/interfaces/interface/subinterfaces/subinterface/ipv6/neighbors/neighbor/state/ip": []string{
 			"/Cisco-IOS-XR-ipv6-nd-oper/ipv6-node-discovery/nodes/node/neighbor-interfaces/neighbor-interface/host-addresses/host-address/link-layer-address",
 		},
 		"/openconfig/interfaces/interface/subinterfaces/subinterface/ipv6/neighbors/neighbor/state/link-layer-address": {
-			"Cisco-IOS-XR-ipv6-nd-oper/ipv6-node-discovery/nodes/node/neighbor-interfaces/neighbor-interface/host-addresses/host-address/link-layer-address",
+			"/Cisco-IOS-XR-ipv6-nd-oper/ipv6-node-discovery/nodes/node/neighbor-interfaces/neighbor-interface/host-addresses/host-address/link-layer-address",
+		},
+		"/openconfig/interfaces/interface/subinterfaces/subinterface/ipv6/neighbors/neighbor/state/origin": {
+			"/Cisco-IOS-XR-ipv6-nd-oper/ipv6-node-discovery/nodes/node/neighbor-interfaces/neighbor-interface/host-addresses/host-address/origin-encapsulation",
+		},
+		"/openconfig/interfaces/interface/subinterfaces/subinterface/ipv6/neighbors/neighbor/state/is-router": {
+			"/Cisco-IOS-XR-ipv6-nd-oper/ipv6-node-discovery/nodes/node/neighbor-interfaces/neighbor-interface/host-addresses/host-address/is-router",
+		},
+		"/openconfig/interfaces/interface/subinterfaces/subinterface/ipv6/neighbors/neighbor/state/neighbor-state": {
+			"/Cisco-IOS-XR-ipv6-nd-oper/ipv6-node-discovery/nodes/node/neighbor-interfaces/neighbor-interface/host-addresses/host-address/reachability-state",
 		},
 	}
 	paths = ftutilities.MustStringMapPaths(translateMap)
+	// arpEntryPath is the native ARP entry, deleted when the entry ages out.
+	arpEntryPath = &gnmipb.Path{
+		Origin: "Cisco-IOS-XR-ipv4-arp-oper",
+		Elem: []*gnmipb.PathElem{
+			{Name: "arp"}, {Name: "nodes"}, {Name: "node"}, {Name: "entries"}, {Name: "entry"},
+		},
+	}
+	// ndHostAddressPath is the native ND neighbor entry, deleted when the entry ages out.
+	ndHostAddressPath = &gnmipb.Path{
+		Origin: "Cisco-IOS-XR-ipv6-nd-oper",
+		Elem: []*gnmipb.PathElem{
+			{Name: "ipv6-node-discovery"}, {Name: "nodes"}, {Name: "node"}, {Name: "neighbor-interfaces"},
+			{Name: "neighbor-interface"}, {Name: "host-addresses"}, {Name: "host-address"},
+		},
+	}
 	// schema is a package-level variable to optimize CiscoXR YANG schema
 	// initialization.
 	schema    *ytypes.Schema
 	schemaErr error
 )
 
+// arpOrigin returns the openconfig neighbor origin of an ARP entry state. States that have no
+// openconfig equivalent are left unset.
+func arpOrigin(state xr2431.E_Cisco_IOS_XRIpv4ArpOper_IpArpBagState) lc.E_OpenconfigIfIp_NeighborOrigin {
+	switch state {
+	case xr2431.Cisco_IOS_XRIpv4ArpOper_IpArpBagState_state_static:
+		return lc.OpenconfigIfIp_NeighborOrigin_STATIC
+	case xr2431.Cisco_IOS_XRIpv4ArpOper_IpArpBagState_state_dynamic:
+		return lc.OpenconfigIfIp_NeighborOrigin_DYNAMIC
+	case xr2431.Cisco_IOS_XRIpv4ArpOper_IpArpBagState_state_interface:
+		return lc.OpenconfigIfIp_NeighborOrigin_OTHER
+	default:
+		return lc.OpenconfigIfIp_NeighborOrigin_UNSET
+	}
+}
+
+// ndOrigin returns the openconfig neighbor origin of an ND entry origin.
+func ndOrigin(origin xr2431.E_Cisco_IOS_XRIpv6NdOper_Ipv6NdNeighborOrigin) lc.E_OpenconfigIfIp_NeighborOrigin {
+	switch origin {
+	case xr2431.Cisco_IOS_XRIpv6NdOper_Ipv6NdNeighborOrigin_static:
+		return lc.OpenconfigIfIp_NeighborOrigin_STATIC
+	case xr2431.Cisco_IOS_XRIpv6NdOper_Ipv6NdNeighborOrigin_dynamic:
+		return lc.OpenconfigIfIp_NeighborOrigin_DYNAMIC
+	case xr2431.Cisco_IOS_XRIpv6NdOper_Ipv6NdNeighborOrigin_other:
+		return lc.OpenconfigIfIp_NeighborOrigin_OTHER
+	default:
+		return lc.OpenconfigIfIp_NeighborOrigin_UNSET
+	}
+}
+
+// ndNeighborState returns the openconfig neighbor state of an ND entry reachability state. The
+// glean and delete states have no openconfig equivalent and are left unset.
+func ndNeighborState(state xr2431.E_Cisco_IOS_XRIpv6NdOper_Ipv6NdShState) lc.E_OpenconfigInterfaces_Interfaces_Interface_RoutedVlan_Ipv6_Neighbors_Neighbor_State_NeighborState {
+	switch state {
+	case xr2431.Cisco_IOS_XRIpv6NdOper_Ipv6NdShState_incomplete:
+		return lc.OpenconfigInterfaces_Interfaces_Interface_RoutedVlan_Ipv6_Neighbors_Neighbor_State_NeighborState_INCOMPLETE
+	case xr2431.Cisco_IOS_XRIpv6NdOper_Ipv6NdShState_reachable:
+		return lc.OpenconfigInterfaces_Interfaces_Interface_RoutedVlan_Ipv6_Neighbors_Neighbor_State_NeighborState_REACHABLE
+	case xr2431.Cisco_IOS_XRIpv6NdOper_Ipv6NdShState_stale:
+		return lc.OpenconfigInterfaces_Interfaces_Interface_RoutedVlan_Ipv6_Neighbors_Neighbor_State_NeighborState_STALE
+	case xr2431.Cisco_IOS_XRIpv6NdOper_Ipv6NdShState_delay:
+		return lc.OpenconfigInterfaces_Interfaces_Interface_RoutedVlan_Ipv6_Neighbors_Neighbor_State_NeighborState_DELAY
+	case xr2431.Cisco_IOS_XRIpv6NdOper_Ipv6NdShState_probe:
+		return lc.OpenconfigInterfaces_Interfaces_Interface_RoutedVlan_Ipv6_Neighbors_Neighbor_State_NeighborState_PROBE
+	default:
+		return lc.OpenconfigInterfaces_Interfaces_Interface_RoutedVlan_Ipv6_Neighbors_Neighbor_State_NeighborState_UNSET
+	}
+}
+
+// ndNeighborAddress returns the neighbor address without any zone suffix, which XR may append to
+// link-local addresses. Multicast entries are not neighbors and are reported as unwanted.
+func ndNeighborAddress(hostAddress string) (addr string, wanted bool) {
+	a, err := netip.ParseAddr(hostAddress)
+	if err != nil {
+		return hostAddress, true
+	}
+	if a.IsMulticast() {
+		return "", false
+	}
+	return a.WithZone("").String(), true
+}
+
+// neighborPath returns the openconfig path of the IPv4 or IPv6 neighbor of a native interface,
+// named with the bundle name format.
+func neighborPath(nativeIntfName, afi, ip, bundleNameFormat string) *gnmipb.Path {
+	intfName, subIndex := ftutilities.SplitSubinterface(ftutilities.CiscoXRBundleName(nativeIntfName, bundleNameFormat))
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": intfName}},
+			{Name: "subinterfaces"},
+			{Name: "subinterface", Key: map[string]string{"index": fmt.Sprint(subIndex)}},
+			{Name: afi},
+			{Name: "neighbors"},
+			{Name: "neighbor", Key: map[string]string{"ip": ip}},
+		},
+	}
+}
+
+// neighborDeletes returns the deletes of the openconfig neighbors whose native ARP or ND entries
+// are deleted by the notification, e.g. when they age out. Deletes of other paths are ignored.
+func neighborDeletes(n *gnmipb.Notification, bundleNameFormat string) []*gnmipb.Path {
+	var deletes []*gnmipb.Path
+	for _, d := range n.GetDelete() {
+		path := ftutilities.Join(n.GetPrefix(), d)
+		elems := path.GetElem()
+		switch {
+		case ftutilities.MatchPath(path, arpEntryPath):
+			addr, intf := elems[4].GetKey()["address"], elems[4].GetKey()["interface-name"]
+			if addr == "" || intf == "" {
+				continue
+			}
+			deletes = append(deletes, neighborPath(intf, "ipv4", addr, bundleNameFormat))
+		case ftutilities.MatchPath(path, ndHostAddressPath):
+			hostAddr, intf := elems[6].GetKey()["host-address"], elems[4].GetKey()["interface-name"]
+			if hostAddr == "" || intf == "" {
+				continue
+			}
+			if ip, wanted := ndNeighborAddress(hostAddr); wanted {
+				deletes = append(deletes, neighborPath(intf, "ipv6", ip, bundleNameFormat))
+			}
+		}
+	}
+	return deletes
+}
+
+func init() {
+	registry.Register(ftconsts.CiscoXRArpTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco ARP functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
-			ID:               ftconsts.CiscoXRArpTranslator,
-			Translate:        translate,
-			OutputToInputMap: paths,
+			ID:                   ftconsts.CiscoXRArpTranslator,
+			TranslateWithOptions: translate,
+			ValidateOptions:      validateOptions,
+			OutputToInputMap:     paths,
 			Metadata: []*translator.FTMetadata{
 				{
 					Vendor: ftconsts.VendorCiscoXR,
@@ -66,25 +214,65 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Cisco ARP functional translator: %v", err)
+		return nil, err
 	}
 	schema, schemaErr = xr2431.Schema()
 	if schemaErr != nil {
-		log.Fatalf("Failed to get schema: %v", schemaErr)
+		return nil, fmt.Errorf("failed to get schema: %v", schemaErr)
 	}
-	return ft
+	return ft, nil
 }
 
-func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
-	if sr.GetUpdate() == nil {
+// validateOptions rejects an invalid bundle name format.
+func validateOptions(opts translator.Options) error {
+	_, err := ftutilities.CiscoXRBundleNameFormat(opts)
+	return err
+}
+
+func translate(sr *gnmipb.SubscribeResponse, opts translator.Options) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
 		return nil, nil
 	}
+	bundleNameFormat, err := ftutilities.CiscoXRBundleNameFormat(opts)
+	if err != nil {
+		return nil, err
+	}
+	var out *gnmipb.SubscribeResponse
+	if len(n.GetUpdate()) > 0 {
+		out, err = translateUpdates(&gnmipb.Notification{Timestamp: n.GetTimestamp(), Prefix: n.GetPrefix(), Update: n.GetUpdate()}, bundleNameFormat)
+		if err != nil {
+			return nil, err
+		}
+	}
+	deletes := neighborDeletes(n, bundleNameFormat)
+	if len(deletes) == 0 {
+		return out, nil
+	}
+	if out == nil {
+		out = &gnmipb.SubscribeResponse{
+			Response: &gnmipb.SubscribeResponse_Update{
+				Update: &gnmipb.Notification{
+					Timestamp: n.GetTimestamp(),
+					Prefix: &gnmipb.Path{
+						Origin: "openconfig",
+						Target: n.GetPrefix().GetTarget(),
+					},
+				},
+			},
+		}
+	}
+	out.GetUpdate().Delete = deletes
+	return out, nil
+}
+
+// translateUpdates translates the ARP and ND entries updated by the notification.
+func translateUpdates(n *gnmipb.Notification, bundleNameFormat string) (*gnmipb.SubscribeResponse, error) {
 	// Make a shallow copy of the schema and replace the root. This prevents state from one
 	// unmarshal operation from leaking into subsequent operations.
 	schemaCopy := *schema
 	d := &xr2431.CiscoDevice{}
 	schemaCopy.Root = d
-	n := sr.GetUpdate()
 
 	if err := ytypes.UnmarshalNotifications(&schemaCopy, []*gnmipb.Notification{n}, nil); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal notifications: %v", err)
@@ -95,14 +283,16 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 		for _, node := range nodeMap {
 			entryMap := node.GetOrCreateEntries().GetOrCreateEntryMap()
 			for _, entry := range entryMap {
-				subState := lcRoot.GetOrCreateInterfaces().GetOrCreateInterface(*entry.InterfaceName).
+				intfName, subIndex := ftutilities.SplitSubinterface(ftutilities.CiscoXRBundleName(*entry.InterfaceName, bundleNameFormat))
+				subState := lcRoot.GetOrCreateInterfaces().GetOrCreateInterface(intfName).
 					GetOrCreateSubinterfaces().
-					GetOrCreateSubinterface(0).
+					GetOrCreateSubinterface(subIndex).
 					GetOrCreateIpv4().
 					GetOrCreateNeighbors().GetOrCreateNeighbor(*entry.Address).
 					GetOrCreateState()
 				subState.LinkLayerAddress = entry.HardwareAddress
 				subState.Ip = entry.Address
+				subState.Origin = arpOrigin(entry.State)
 			}
 		}
 	}
@@ -111,17 +301,25 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 		for _, node := range ndNodeMap {
 			neighborMap := node.GetNeighborInterfaces().GetOrCreateNeighborInterfaceMap()
 			for _, neighbor := range neighborMap {
+				intfName, subIndex := ftutilities.SplitSubinterface(ftutilities.CiscoXRBundleName(*neighbor.InterfaceName, bundleNameFormat))
 				addressMap := neighbor.GetHostAddresses().GetOrCreateHostAddressMap()
 				for _, address := range addressMap {
-					subState := lcRoot.GetOrCreateInterfaces().GetOrCreateInterface(*neighbor.InterfaceName).
+					ip, wanted := ndNeighborAddress(*address.HostAddress)
+					if !wanted {
+						continue
+					}
+					subState := lcRoot.GetOrCreateInterfaces().GetOrCreateInterface(intfName).
 						GetOrCreateSubinterfaces().
-						GetOrCreateSubinterface(0).
+						GetOrCreateSubinterface(subIndex).
 						GetOrCreateIpv6().
 						GetOrCreateNeighbors().
-						GetOrCreateNeighbor(*address.HostAddress).
+						GetOrCreateNeighbor(ip).
 						GetOrCreateState()
 					subState.LinkLayerAddress = address.LinkLayerAddress
-					subState.Ip = address.HostAddress
+					subState.Ip = &ip
+					subState.IsRouter = address.IsRouter
+					subState.Origin = ndOrigin(address.OriginEncapsulation)
+					subState.NeighborState = ndNeighborState(address.ReachabilityState)
 				}
 			}
 		}
@@ -36,11 +36,31 @@ func TestTranslate(t *testing.T) {
 			inputPath:      "testdata/arp_input.txt",
 			wantOutputPath: "testdata/arp_output.txt",
 		},
+		{
+			name:           "success_arp_origin",
+			inputPath:      "testdata/arp_origin_input.txt",
+			wantOutputPath: "testdata/arp_origin_output.txt",
+		},
 		{
 			name:           "success_np",
 			inputPath:      "testdata/nd_input.txt",
 			wantOutputPath: "testdata/nd_output.txt",
 		},
+		{
+			name:           "success_nd_state_subinterface_link_local",
+			inputPath:      "testdata/nd_state_input.txt",
+			wantOutputPath: "testdata/nd_state_output.txt",
+		},
+		{
+			name:           "success_arp_delete",
+			inputPath:      "testdata/arp_delete_input.txt",
+			wantOutputPath: "testdata/arp_delete_output.txt",
+		},
+		{
+			name:           "success_nd_delete",
+			inputPath:      "testdata/nd_delete_input.txt",
+			wantOutputPath: "testdata/nd_delete_output.txt",
+		},
 	}
 
 	for _, test := range tests {
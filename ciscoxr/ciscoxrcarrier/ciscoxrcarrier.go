@@ -18,11 +18,12 @@ package ciscoxrcarrier
 import (
 	"fmt"
 
-	log "github.com/golang/glog"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/ygot/ytypes"
 	xr2431 "github.com/openconfig/functional-translators/ciscoxr/ciscoxrcarrier/yang/native"
 	"github.com/openconfig/functional-translators/ftconsts"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -41,8 +42,21 @@ var (
 	schemaErr error
 )
 
+func init() {
+	registry.Register(ftconsts.CiscoXRCarrierTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco carrier functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
 			ID:               ftconsts.CiscoXRCarrierTranslator,
@@ -56,13 +70,13 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Cisco carrier functional translator: %v", err)
+		return nil, err
 	}
 	schema, schemaErr = xr2431.Schema()
 	if schemaErr != nil {
-		log.Fatalf("Failed to get schema: %v", schemaErr)
+		return nil, fmt.Errorf("failed to get schema: %v", schemaErr)
 	}
-	return ft
+	return ft, nil
 }
 
 func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
@@ -80,10 +94,17 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 	if err := ytypes.UnmarshalNotifications(&schemaCopy, []*gnmipb.Notification{n}, nil); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal notifications: %v", err)
 	}
-	carrierIntfMap := d.GetInfraStatistics().GetInterfaces().GetOrCreateInterfaceMap()
+	intfs := d.GetInfraStatistics().GetInterfaces()
+	if intfs == nil {
+		return nil, nil
+	}
 	updates := make([]*gnmipb.Update, 0)
-	for name, intf := range carrierIntfMap {
-		phyCarrierCounter := *intf.GetGenericCounters().CarrierTransitions
+	for name, intf := range intfs.GetOrCreateInterfaceMap() {
+		counters := intf.GetGenericCounters()
+		if counters == nil || counters.CarrierTransitions == nil {
+			continue
+		}
+		phyCarrierCounter := *counters.CarrierTransitions
 		update := &gnmipb.Update{
 			Path: &gnmipb.Path{
 				Elem: []*gnmipb.PathElem{
@@ -103,6 +124,9 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 		}
 		updates = append(updates, update)
 	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
 	outgoingSR := &gnmipb.SubscribeResponse{
 		Response: &gnmipb.SubscribeResponse_Update{
 			Update: &gnmipb.Notification{
@@ -0,0 +1,238 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ciscoxrenvmon translates Cisco envmon sensor readings to openconfig component paths.
+package ciscoxrenvmon
+
+import (
+	"fmt"
+
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	// CiscoXR native sensor value path.
+	ciscoSensorValue = "/Cisco-IOS-XR-envmon-oper/environmental-monitoring/rack/nodes/node/sensor-types/sensor-type/sensor-names/sensor-name/value-brief"
+
+	// CiscoXR native sensor types, used as the key of the sensor-type list.
+	sensorTypeTemperature = "temperature"
+	sensorTypeVoltage     = "voltage"
+	sensorTypeCurrent     = "current"
+	sensorTypeFan         = "fan"
+
+	// Voltage and current sensors report in milli-units while openconfig expects volts and amps.
+	milliFactor = 1000.0
+)
+
+var (
+	translateMap = map[string][]string{
+		"/openconfig/components/component/state/temperature/instant": {
+			ciscoSensorValue,
+		},
+		"/openconfig/components/component/fan/state/speed": {
+			ciscoSensorValue,
+		},
+		"/openconfig/components/component/properties/property/state/value": {
+			ciscoSensorValue,
+		},
+	}
+	nativeSensorPath = &gnmipb.Path{
+		Origin: "Cisco-IOS-XR-envmon-oper",
+		Elem: []*gnmipb.PathElem{
+			{Name: "environmental-monitoring"}, {Name: "rack"}, {Name: "nodes"}, {Name: "node"},
+			{Name: "sensor-types"}, {Name: "sensor-type"}, {Name: "sensor-names"}, {Name: "sensor-name"},
+			{Name: "value-brief"},
+		},
+	}
+)
+
+// sensor holds a single sensor reading collected from the native path.
+type sensor struct {
+	nodeName   string
+	sensorType string
+	sensorName string
+	value      *gnmipb.TypedValue
+}
+
+// componentName returns the openconfig component name of the sensor, which is the sensor name
+// qualified by the node it is located on.
+func (s *sensor) componentName() string {
+	return fmt.Sprintf("%s-%s", s.nodeName, s.sensorName)
+}
+
+func temperaturePath(componentName string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": componentName}},
+			{Name: "state"},
+			{Name: "temperature"},
+			{Name: "instant"},
+		},
+	}
+}
+
+func fanSpeedPath(componentName string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": componentName}},
+			{Name: "fan"},
+			{Name: "state"},
+			{Name: "speed"},
+		},
+	}
+}
+
+func propertyPath(componentName, property string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": componentName}},
+			{Name: "properties"},
+			{Name: "property", Key: map[string]string{"name": property}},
+			{Name: "state"},
+			{Name: "value"},
+		},
+	}
+}
+
+// toOpenConfig returns the openconfig update for the sensor, or nil if the sensor type is not
+// translated.
+func (s *sensor) toOpenConfig() (*gnmipb.Update, error) {
+	name := s.componentName()
+	switch s.sensorType {
+	case sensorTypeTemperature:
+		v, err := ftutilities.ToDouble(s.value)
+		if err != nil {
+			return nil, err
+		}
+		return &gnmipb.Update{
+			Path: temperaturePath(name),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: v}},
+		}, nil
+	case sensorTypeFan:
+		v, err := ftutilities.ToUint64(s.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fan speed: %v", err)
+		}
+		return &gnmipb.Update{
+			Path: fanSpeedPath(name),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}},
+		}, nil
+	case sensorTypeVoltage, sensorTypeCurrent:
+		v, err := ftutilities.ToDouble(s.value)
+		if err != nil {
+			return nil, err
+		}
+		return &gnmipb.Update{
+			Path: propertyPath(name, s.sensorType),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: v / milliFactor}},
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// buildSensors collects the sensor readings present in the notification.
+func buildSensors(prefix *gnmipb.Path, leaves []*gnmipb.Update) []*sensor {
+	var sensors []*sensor
+	for _, leaf := range leaves {
+		path := ftutilities.Join(prefix, leaf.GetPath())
+		if !ftutilities.MatchPath(path, nativeSensorPath) {
+			continue
+		}
+		elems := path.GetElem()
+		sensors = append(sensors, &sensor{
+			nodeName:   elems[3].GetKey()["node-name"],
+			sensorType: elems[5].GetKey()["type"],
+			sensorName: elems[7].GetKey()["name"],
+			value:      leaf.GetVal(),
+		})
+	}
+	return sensors
+}
+
+func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	// Silently ignore deletes and paths we don't care about.
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	var updates []*gnmipb.Update
+	for _, s := range buildSensors(n.GetPrefix(), n.GetUpdate()) {
+		u, err := s.toOpenConfig()
+		if err != nil {
+			log.Errorf("Failed to translate %s sensor %q on %q: %v", s.sensorType, s.sensorName, s.nodeName, err)
+			continue
+		}
+		if u != nil {
+			updates = append(updates, u)
+		}
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+			},
+		},
+	}, nil
+}
+
+func init() {
+	registry.Register(ftconsts.CiscoXREnvmonTranslator, NewE)
+}
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco envmon functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	ft, err := translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:               ftconsts.CiscoXREnvmonTranslator,
+			Translate:        translate,
+			OutputToInputMap: ftutilities.MustStringMapPaths(translateMap),
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorCiscoXR,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ft, nil
+}
@@ -18,12 +18,13 @@ package ciscoxrfabric
 import (
 	"fmt"
 
-	log "github.com/golang/glog"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/ygot/ytypes"
 	xr2431 "github.com/openconfig/functional-translators/ciscoxr/ciscoxrfabric/yang/native"
 	fc "github.com/openconfig/functional-translators/ciscoxr/ciscoxrfabric/yang/openconfig"
 	"github.com/openconfig/functional-translators/ftconsts"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -108,20 +109,42 @@ func buildPorts(prefix *gnmipb.Path, leaves []*gnmipb.Update) map[string]*errors
 			}
 			portsErrorsMap[fullPortName] = t
 		}
+		var counter *uint64
 		switch elems[8].GetName() {
 		case "rx-bad-crc":
-			t.rxCRCError = leaf.GetVal().GetUintVal()
+			counter = &t.rxCRCError
 		case "rx-errors":
-			t.rxError = leaf.GetVal().GetUintVal()
+			counter = &t.rxError
 		case "tx-fifo-unrun":
-			t.txFIFOUnrun = leaf.GetVal().GetUintVal()
+			counter = &t.txFIFOUnrun
+		default:
+			continue
 		}
+		v, err := ftutilities.ToUint64(leaf.GetVal())
+		if err != nil {
+			log.Errorf("Invalid %s of port %s: %v", elems[8].GetName(), fullPortName, err)
+			continue
+		}
+		*counter = v
 	}
 	return portsErrorsMap
 }
 
+func init() {
+	registry.Register(ftconsts.CiscoXRFabricTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco fabric functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
 			ID:               ftconsts.CiscoXRFabricTranslator,
@@ -135,13 +158,13 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Cisco fabric functional translator: %v", err)
+		return nil, err
 	}
 	schema, schemaErr = xr2431.Schema()
 	if schemaErr != nil {
-		log.Fatalf("Failed to get schema: %v", schemaErr)
+		return nil, fmt.Errorf("failed to get schema: %v", schemaErr)
 	}
-	return ft
+	return ft, nil
 }
 
 func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
@@ -167,25 +190,36 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 		if err := ytypes.UnmarshalNotifications(&schemaCopy, []*gnmipb.Notification{n}, nil); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal notifications: %v", err)
 		}
-		fabricPlaneIDMap := d.GetFabric().GetFabricPlaneIds().GetOrCreateFabricPlaneIdMap()
-		for fabricPlaneID, fabricPlane := range fabricPlaneIDMap {
+		for fabricPlaneID, fabricPlane := range fabricPlaneIDs(d) {
 			fabricPlaneStats := fabricPlane.GetFabricPlaneStats()
+			if fabricPlaneStats == nil {
+				continue
+			}
 			componentName := fmt.Sprintf("%d", fabricPlaneID)
 			fabricBlock := fcRoot.GetOrCreateComponents().GetOrCreateComponent(componentName).GetOrCreateIntegratedCircuit().GetOrCreatePipelineCounters().GetOrCreateErrors().GetOrCreateFabricBlock()
-			rxUceCellsError := fabricBlock.GetOrCreateFabricBlockError("uncorrectable-error-cells").GetOrCreateState()
-			rxUceCellsError.Count = fabricPlaneStats.RxUceCells
-			ucastLostCellsError := fabricBlock.GetOrCreateFabricBlockError("unicast-lost-cells").GetOrCreateState()
-			ucastErrors := uint64(*fabricPlaneStats.UcastLostCells)
-			ucastLostCellsError.Count = &ucastErrors
-			mcastLostCellsError := fabricBlock.GetOrCreateFabricBlockError("multicast-lost-cells").GetOrCreateState()
-			mcastErrors := uint64(*fabricPlaneStats.McastLostCells)
-			mcastLostCellsError.Count = &mcastErrors
-			rxPeCellsError := fabricBlock.GetOrCreateFabricBlockError("parity-error-cells").GetOrCreateState()
-			rxPeCellsError.Count = fabricPlaneStats.RxPeCells
-			aiDropsError := fabricBlock.GetOrCreateFabricBlockError("asic-internal-drops").GetOrCreateState()
-			aiDrops := uint64(*fabricPlaneStats.AsicInternalDrops)
-			aiDropsError.Count = &aiDrops
+			fabricBlock.GetOrCreateFabricBlockError("uncorrectable-error-cells").GetOrCreateState().Count = fabricPlaneStats.RxUceCells
+			if fabricPlaneStats.UcastLostCells != nil {
+				ucastErrors := uint64(*fabricPlaneStats.UcastLostCells)
+				fabricBlock.GetOrCreateFabricBlockError("unicast-lost-cells").GetOrCreateState().Count = &ucastErrors
+			}
+			if fabricPlaneStats.McastLostCells != nil {
+				mcastErrors := uint64(*fabricPlaneStats.McastLostCells)
+				fabricBlock.GetOrCreateFabricBlockError("multicast-lost-cells").GetOrCreateState().Count = &mcastErrors
+			}
+			fabricBlock.GetOrCreateFabricBlockError("parity-error-cells").GetOrCreateState().Count = fabricPlaneStats.RxPeCells
+			if fabricPlaneStats.AsicInternalDrops != nil {
+				aiDrops := uint64(*fabricPlaneStats.AsicInternalDrops)
+				fabricBlock.GetOrCreateFabricBlockError("asic-internal-drops").GetOrCreateState().Count = &aiDrops
+			}
 		}
 	}
 	return ftutilities.FilterStructToState(fcRoot, n.GetTimestamp(), "openconfig", n.GetPrefix().GetTarget())
 }
+
+// fabricPlaneIDs returns the fabric planes of the device, nil if the notification holds none.
+func fabricPlaneIDs(d *xr2431.CiscoDevice) map[uint32]*xr2431.Cisco_IOS_XRFabricPlaneHealthOper_Fabric_FabricPlaneIds_FabricPlaneId {
+	if ids := d.GetFabric().GetFabricPlaneIds(); ids != nil {
+		return ids.FabricPlaneId
+	}
+	return nil
+}
@@ -13,15 +13,21 @@
 // limitations under the License.
 
 // Package ciscoxrfpd translates fpd native path to openconfig.
+//
+// Each FPD is represented as a component named "<location>_<fpd-name>", with the running version as
+// its firmware-version and the FPD upgrade status (e.g. CURRENT, NEED UPGD) in the CiscoXR vendor
+// extension of the component. The status is not reported as a component property, since the
+// properties of the CiscoXR components are translated by ciscoxrenvmon.
 package ciscoxrfpd
 
 import (
 	"fmt"
 
-	log "github.com/golang/glog"
 	fc "github.com/openconfig/functional-translators/ciscoxr/ciscoxrfpd/yang/openconfig"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -31,12 +37,15 @@ type fpdStatus struct {
 	status   []string
 	name     []string
 	location []string
+	version  []string
 }
 
 var (
-	// TODO(team): Find replacement oc path for FPD status.
 	translateMap = map[string][]string{
-		"/openconfig/components/component/properties/property/state/value": {
+		"/openconfig/components/component/vendor/CiscoXR/fpd/state/status": {
+			"/Cisco-IOS-XR-show-fpd-loc-ng-oper/show-fpd/hw-module-fpd",
+		},
+		"/openconfig/components/component/state/firmware-version": {
 			"/Cisco-IOS-XR-show-fpd-loc-ng-oper/show-fpd/hw-module-fpd",
 		},
 	}
@@ -60,6 +69,12 @@ var (
 				{Name: "show-fpd"}, {Name: "hw-module-fpd"}, {Name: "fpd-info-detail"}, {Name: "status"},
 			},
 		},
+		{
+			Origin: "Cisco-IOS-XR-show-fpd-loc-ng-oper",
+			Elem: []*gnmipb.PathElem{
+				{Name: "show-fpd"}, {Name: "hw-module-fpd"}, {Name: "fpd-info-detail"}, {Name: "running-version"},
+			},
+		},
 	}
 )
 
@@ -73,6 +88,17 @@ func allEqual(nums ...int) bool {
 	return true
 }
 
+// statusPath returns the path of the upgrade status of the FPD component.
+func statusPath(componentName string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": componentName}},
+			{Name: "vendor"}, {Name: "CiscoXR"}, {Name: "fpd"}, {Name: "state"}, {Name: "status"},
+		},
+	}
+}
+
 // builds fpdStatus map
 func buildFPDStatus(prefix *gnmipb.Path, leaves []*gnmipb.Update) *fpdStatus {
 	componentFPDStatus := &fpdStatus{}
@@ -89,13 +115,28 @@ func buildFPDStatus(prefix *gnmipb.Path, leaves []*gnmipb.Update) *fpdStatus {
 			componentFPDStatus.name = append(componentFPDStatus.name, leaf.GetVal().GetStringVal())
 		case "location":
 			componentFPDStatus.location = append(componentFPDStatus.location, leaf.GetVal().GetStringVal())
+		case "running-version":
+			componentFPDStatus.version = append(componentFPDStatus.version, leaf.GetVal().GetStringVal())
 		}
 	}
 	return componentFPDStatus
 }
 
+func init() {
+	registry.Register(ftconsts.CiscoXRFpdTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco FPD functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
 			ID:               ftconsts.CiscoXRFpdTranslator,
@@ -109,9 +150,9 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Cisco FPD functional translator: %v", err)
+		return nil, err
 	}
-	return ft
+	return ft, nil
 }
 
 func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
@@ -124,10 +165,36 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 	if !allEqual(len(fpdStatusList.status), len(fpdStatusList.name), len(fpdStatusList.location)) {
 		return nil, fmt.Errorf("faulty response:fpdStatusList length mismatch: %v, %v, %v", len(fpdStatusList.status), len(fpdStatusList.name), len(fpdStatusList.location))
 	}
+	// Older releases do not stream the running version, so it is optional.
+	if len(fpdStatusList.version) != 0 && len(fpdStatusList.version) != len(fpdStatusList.location) {
+		return nil, fmt.Errorf("faulty response:fpdStatusList version length mismatch: %v, %v", len(fpdStatusList.version), len(fpdStatusList.location))
+	}
+	var statusUpdates []*gnmipb.Update
 	for i, location := range fpdStatusList.location {
 		componentName := fmt.Sprintf("%s_%s", location, fpdStatusList.name[i])
-		fpdProperty := fcRoot.GetOrCreateComponents().GetOrCreateComponent(componentName).GetOrCreateProperties().GetOrCreateProperty("fpd-status")
-		fpdProperty.GetOrCreateState().Value = fc.UnionString(fpdStatusList.status[i])
+		statusUpdates = append(statusUpdates, &gnmipb.Update{
+			Path: statusPath(componentName),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: fpdStatusList.status[i]}},
+		})
+		// Versions of FPDs that are not programmed are reported as empty.
+		if len(fpdStatusList.version) != 0 && fpdStatusList.version[i] != "" {
+			fcRoot.GetOrCreateComponents().GetOrCreateComponent(componentName).GetOrCreateState().FirmwareVersion = &fpdStatusList.version[i]
+		}
+	}
+	out, err := ftutilities.FilterStructToState(fcRoot, n.GetTimestamp(), "openconfig", n.GetPrefix().GetTarget())
+	if err != nil || len(statusUpdates) == 0 {
+		return out, err
+	}
+	if out == nil {
+		out = &gnmipb.SubscribeResponse{
+			Response: &gnmipb.SubscribeResponse_Update{
+				Update: &gnmipb.Notification{
+					Timestamp: n.GetTimestamp(),
+					Prefix:    &gnmipb.Path{Origin: "openconfig", Target: n.GetPrefix().GetTarget()},
+				},
+			},
+		}
 	}
-	return ftutilities.FilterStructToState(fcRoot, n.GetTimestamp(), "openconfig", n.GetPrefix().GetTarget())
+	out.GetUpdate().Update = append(out.GetUpdate().GetUpdate(), statusUpdates...)
+	return out, nil
 }
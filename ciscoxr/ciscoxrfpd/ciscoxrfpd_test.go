@@ -202,10 +202,188 @@ func TestTranslate(t *testing.T) {
 							Elem: []*gnmipb.PathElem{
 								{Name: "components"},
 								{Name: "component", Key: map[string]string{"name": "0/0/CPU0_Bios"}},
-								{Name: "properties"},
-								{Name: "property", Key: map[string]string{"name": "fpd-status"}},
+								{Name: "vendor"},
+								{Name: "CiscoXR"},
+								{Name: "fpd"},
 								{Name: "state"},
-								{Name: "value"},
+								{Name: "status"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "CURRENT",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	versionSR := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 123,
+				Prefix: &gnmipb.Path{
+					Origin: "Cisco-IOS-XR-show-fpd-loc-ng-oper",
+					Target: "dut",
+					Elem: []*gnmipb.PathElem{
+						{Name: "show-fpd"},
+						{Name: "hw-module-fpd"},
+					},
+				},
+				Update: []*gnmipb.Update{
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "fpd-info-detail"},
+								{Name: "location"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "0/RP0/CPU0",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "fpd-info-detail"},
+								{Name: "fpd-name"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "Bios",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "fpd-info-detail"},
+								{Name: "status"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "NEED UPGD",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "fpd-info-detail"},
+								{Name: "running-version"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "1.20",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "fpd-info-detail"},
+								{Name: "location"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "0/RP0/CPU0",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "fpd-info-detail"},
+								{Name: "fpd-name"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "IoFpga",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "fpd-info-detail"},
+								{Name: "status"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "NOT READY",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "fpd-info-detail"},
+								{Name: "running-version"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	versionMismatchSR := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 123,
+				Prefix: &gnmipb.Path{
+					Origin: "Cisco-IOS-XR-show-fpd-loc-ng-oper",
+					Target: "dut",
+					Elem: []*gnmipb.PathElem{
+						{Name: "show-fpd"},
+						{Name: "hw-module-fpd"},
+					},
+				},
+				Update: []*gnmipb.Update{
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "fpd-info-detail"},
+								{Name: "location"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "0/RP0/CPU0",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "fpd-info-detail"},
+								{Name: "fpd-name"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "Bios",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "fpd-info-detail"},
+								{Name: "status"},
 							},
 						},
 						Val: &gnmipb.TypedValue{
@@ -214,6 +392,122 @@ func TestTranslate(t *testing.T) {
 							},
 						},
 					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "fpd-info-detail"},
+								{Name: "running-version"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "1.20",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "fpd-info-detail"},
+								{Name: "location"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "0/RP0/CPU0",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "fpd-info-detail"},
+								{Name: "fpd-name"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "IoFpga",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "fpd-info-detail"},
+								{Name: "status"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "CURRENT",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	versionOutput := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 123,
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: "dut",
+				},
+				Update: []*gnmipb.Update{
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "components"},
+								{Name: "component", Key: map[string]string{"name": "0/RP0/CPU0_Bios"}},
+								{Name: "vendor"},
+								{Name: "CiscoXR"},
+								{Name: "fpd"},
+								{Name: "state"},
+								{Name: "status"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "NEED UPGD",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "components"},
+								{Name: "component", Key: map[string]string{"name": "0/RP0/CPU0_Bios"}},
+								{Name: "state"},
+								{Name: "firmware-version"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "1.20",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "components"},
+								{Name: "component", Key: map[string]string{"name": "0/RP0/CPU0_IoFpga"}},
+								{Name: "vendor"},
+								{Name: "CiscoXR"},
+								{Name: "fpd"},
+								{Name: "state"},
+								{Name: "status"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "NOT READY",
+							},
+						},
+					},
 				},
 			},
 		},
@@ -239,6 +533,16 @@ func TestTranslate(t *testing.T) {
 			input:   faultySR,
 			wantErr: true,
 		},
+		{
+			name:  "firmware version",
+			input: versionSR,
+			want:  versionOutput,
+		},
+		{
+			name:    "firmware version length mismatch",
+			input:   versionMismatchSR,
+			wantErr: true,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
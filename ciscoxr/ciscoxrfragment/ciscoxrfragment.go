@@ -19,10 +19,11 @@ import (
 	"fmt"
 	"strconv"
 
-	log "github.com/golang/glog"
 	ocfrag "github.com/openconfig/functional-translators/ciscoxr/ciscoxrfragment/yang/openconfig"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -88,9 +89,13 @@ func buildTraps(prefix *gnmipb.Path, leafs []*gnmipb.Update) (map[string]*trap,
 		}
 		switch elems[9].GetName() {
 		case "packet-dropped":
-			traps[fragmentKey].packetDropped = leaf.GetVal().GetUintVal()
+			if traps[fragmentKey].packetDropped, err = ftutilities.ToUint64(leaf.GetVal()); err != nil {
+				return nil, fmt.Errorf("invalid packet-dropped of trap %s: %v", fragmentKey, err)
+			}
 		case "packet-accepted":
-			traps[fragmentKey].packetAccepted = leaf.GetVal().GetUintVal()
+			if traps[fragmentKey].packetAccepted, err = ftutilities.ToUint64(leaf.GetVal()); err != nil {
+				return nil, fmt.Errorf("invalid packet-accepted of trap %s: %v", fragmentKey, err)
+			}
 		case "trap-string":
 			traps[fragmentKey].trapString = leaf.GetVal().GetStringVal()
 		default:
@@ -101,8 +106,21 @@ func buildTraps(prefix *gnmipb.Path, leafs []*gnmipb.Update) (map[string]*trap,
 	return traps, nil
 }
 
+func init() {
+	registry.Register(ftconsts.CiscoXRFragmentTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco fragment functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
 			ID:               ftconsts.CiscoXRFragmentTranslator,
@@ -116,9 +134,9 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Cisco fragment functional translator: %v", err)
+		return nil, err
 	}
-	return ft
+	return ft, nil
 }
 
 func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
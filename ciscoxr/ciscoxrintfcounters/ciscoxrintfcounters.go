@@ -0,0 +1,228 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ciscoxrintfcounters translates the Cisco infra-statsd generic counters of interfaces to
+// openconfig interface counters.
+//
+// It is a fallback for the platforms whose openconfig interface counters are unreliable, and is
+// not meant to be used alongside the openconfig counters of the device. It is therefore not
+// registered for the automatic selection of the translators of a device: consumers create it with
+// New for the affected devices, e.g. in the Translators of the functionaltranslators options.
+//
+// The Bundle-Ether interfaces are named with the ftutilities.BundleNameFormatOption option.
+package ciscoxrintfcounters
+
+import (
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	// CiscoXR native generic counters path.
+	ciscoGenericCounters = "/Cisco-IOS-XR-infra-statsd-oper/infra-statistics/interfaces/interface/generic-counters"
+	// OpenConfig interface counters path.
+	ocCounters = "/openconfig/interfaces/interface/state/counters"
+
+	packetsReceived   = "packets-received"
+	packetsSent       = "packets-sent"
+	multicastReceived = "multicast-packets-received"
+	multicastSent     = "multicast-packets-sent"
+	broadcastReceived = "broadcast-packets-received"
+	broadcastSent     = "broadcast-packets-sent"
+
+	inUnicastPkts  = "in-unicast-pkts"
+	outUnicastPkts = "out-unicast-pkts"
+)
+
+var (
+	// leafMap maps the native generic counters to the openconfig interface counters.
+	leafMap = map[string]string{
+		"bytes-received":                    "in-octets",
+		"bytes-sent":                        "out-octets",
+		packetsReceived:                     "in-pkts",
+		packetsSent:                         "out-pkts",
+		multicastReceived:                   "in-multicast-pkts",
+		multicastSent:                       "out-multicast-pkts",
+		broadcastReceived:                   "in-broadcast-pkts",
+		broadcastSent:                       "out-broadcast-pkts",
+		"input-drops":                       "in-discards",
+		"output-drops":                      "out-discards",
+		"input-errors":                      "in-errors",
+		"output-errors":                     "out-errors",
+		"crc-errors":                        "in-fcs-errors",
+		"unknown-protocol-packets-received": "in-unknown-protos",
+		"carrier-transitions":               "carrier-transitions",
+	}
+	// unicastCounters are the openconfig unicast counters, derived from the total, multicast and
+	// broadcast native counters.
+	unicastCounters = map[string][3]string{
+		inUnicastPkts:  {packetsReceived, multicastReceived, broadcastReceived},
+		outUnicastPkts: {packetsSent, multicastSent, broadcastSent},
+	}
+	translateMap   = buildTranslateMap()
+	nativeLeafPath = &gnmipb.Path{
+		Origin: "Cisco-IOS-XR-infra-statsd-oper",
+		Elem: []*gnmipb.PathElem{
+			{Name: "infra-statistics"}, {Name: "interfaces"}, {Name: "interface"},
+			{Name: "generic-counters"}, {Name: "*"},
+		},
+	}
+)
+
+func buildTranslateMap() map[string][]string {
+	m := map[string][]string{}
+	for native, oc := range leafMap {
+		m[ocCounters+"/"+oc] = []string{ciscoGenericCounters + "/" + native}
+	}
+	for oc, natives := range unicastCounters {
+		for _, native := range natives {
+			m[ocCounters+"/"+oc] = append(m[ocCounters+"/"+oc], ciscoGenericCounters+"/"+native)
+		}
+	}
+	return m
+}
+
+func counterPath(intf, leaf string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": intf}},
+			{Name: "state"},
+			{Name: "counters"},
+			{Name: leaf},
+		},
+	}
+}
+
+func uintUpdate(intf, leaf string, v uint64) *gnmipb.Update {
+	return &gnmipb.Update{
+		Path: counterPath(intf, leaf),
+		Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}},
+	}
+}
+
+// validateOptions rejects an invalid bundle name format.
+func validateOptions(opts translator.Options) error {
+	_, err := ftutilities.CiscoXRBundleNameFormat(opts)
+	return err
+}
+
+func translate(sr *gnmipb.SubscribeResponse, opts translator.Options) (*gnmipb.SubscribeResponse, error) {
+	// Silently ignore deletes and paths we don't care about.
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	bundleNameFormat, err := ftutilities.CiscoXRBundleNameFormat(opts)
+	if err != nil {
+		return nil, err
+	}
+	var updates []*gnmipb.Update
+	// counters holds the native counters of each interface, to derive the unicast counters.
+	counters := map[string]map[string]uint64{}
+	var intfs []string
+	for _, leaf := range n.GetUpdate() {
+		path := ftutilities.Join(n.GetPrefix(), leaf.GetPath())
+		if !ftutilities.MatchPath(path, nativeLeafPath) {
+			continue
+		}
+		name := path.GetElem()[4].GetName()
+		ocLeaf, ok := leafMap[name]
+		if !ok {
+			continue
+		}
+		intf := ftutilities.CiscoXRBundleName(path.GetElem()[2].GetKey()["interface-name"], bundleNameFormat)
+		if intf == "" {
+			log.Errorf("Failed to translate %s: no interface name in %v", name, path)
+			continue
+		}
+		// Subinterface counters are not translated.
+		if parent, _ := ftutilities.SplitSubinterface(intf); parent != intf {
+			continue
+		}
+		v, err := ftutilities.ToUint64(leaf.GetVal())
+		if err != nil {
+			log.Errorf("Failed to translate %s of interface %q: %v", name, intf, err)
+			continue
+		}
+		updates = append(updates, uintUpdate(intf, ocLeaf, v))
+		if counters[intf] == nil {
+			counters[intf] = map[string]uint64{}
+			intfs = append(intfs, intf)
+		}
+		counters[intf][name] = v
+	}
+	for _, intf := range intfs {
+		for _, oc := range []string{inUnicastPkts, outUnicastPkts} {
+			natives := unicastCounters[oc]
+			total, okTotal := counters[intf][natives[0]]
+			multicast, okMulticast := counters[intf][natives[1]]
+			broadcast, okBroadcast := counters[intf][natives[2]]
+			// The unicast counters are only derived from counters sampled together.
+			if !okTotal || !okMulticast || !okBroadcast || multicast+broadcast > total {
+				continue
+			}
+			updates = append(updates, uintUpdate(intf, oc, total-multicast-broadcast))
+		}
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+			},
+		},
+	}, nil
+}
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco interface counters functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	ft, err := translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:                   ftconsts.CiscoXRInterfaceCountersTranslator,
+			TranslateWithOptions: translate,
+			ValidateOptions:      validateOptions,
+			OutputToInputMap:     ftutilities.MustStringMapPaths(translateMap),
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorCiscoXR,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ft, nil
+}
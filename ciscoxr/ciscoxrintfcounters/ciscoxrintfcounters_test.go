@@ -0,0 +1,36 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciscoxrintfcounters
+
+import (
+	"testing"
+
+	"github.com/openconfig/functional-translators/fttest"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/translator"
+)
+
+func TestTranslate(t *testing.T) {
+	fttest.RunGoldenTests(t, New(), "testdata")
+}
+
+func TestTranslateBundleNameFormat(t *testing.T) {
+	ft := New()
+	opts := translator.Options{ftutilities.BundleNameFormatOption: "ae%d"}
+	if err := ft.SetOptions(opts); err != nil {
+		t.Fatalf("SetOptions(%v) got unexpected error: %v", opts, err)
+	}
+	fttest.RunGoldenTests(t, ft, "testdata/bundle_name_format")
+}
@@ -0,0 +1,309 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ciscoxripv4 translates the Cisco ipv4-io-oper primary and secondary IPv4 addresses of
+// the interfaces to openconfig subinterface addresses.
+//
+// The secondary addresses are an unkeyed native list, so their prefix length must follow their
+// address in the notification, as for the IPv6 addresses of the ciscoxripv6 translator.
+package ciscoxripv4
+
+import (
+	"fmt"
+
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	// CiscoXR native path of the IPv4 details of the interfaces.
+	ciscoDetail = "/Cisco-IOS-XR-ipv4-io-oper/ipv4-network/nodes/node/interface-data/vrfs/vrf/details/detail"
+	// OpenConfig IPv4 address state path.
+	ocAddressState = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv4/addresses/address/state"
+
+	primaryAddress   = "primary-address"
+	prefixLength     = "prefix-length"
+	secondaryAddress = "secondary-address"
+	address          = "address"
+
+	// unnumbered is the primary address of the interfaces without an IPv4 address.
+	unnumbered = "0.0.0.0"
+	// maxPrefixLength is the longest IPv4 prefix length.
+	maxPrefixLength = 32
+)
+
+var (
+	translateMap = map[string][]string{
+		ocAddressState + "/ip": {
+			ciscoDetail + "/" + primaryAddress,
+			ciscoDetail + "/" + secondaryAddress + "/" + address,
+		},
+		ocAddressState + "/prefix-length": {
+			ciscoDetail + "/" + primaryAddress,
+			ciscoDetail + "/" + prefixLength,
+			ciscoDetail + "/" + secondaryAddress + "/" + address,
+			ciscoDetail + "/" + secondaryAddress + "/" + prefixLength,
+		},
+		ocAddressState + "/type": {
+			ciscoDetail + "/" + primaryAddress,
+			ciscoDetail + "/" + secondaryAddress + "/" + address,
+		},
+	}
+	detailPath = &gnmipb.Path{
+		Origin: "Cisco-IOS-XR-ipv4-io-oper",
+		Elem: []*gnmipb.PathElem{
+			{Name: "ipv4-network"}, {Name: "nodes"}, {Name: "node"}, {Name: "interface-data"},
+			{Name: "vrfs"}, {Name: "vrf"}, {Name: "details"},
+			{Name: "detail", Key: map[string]string{"interface-name": "*"}},
+		},
+	}
+	primaryLeafPath = &gnmipb.Path{
+		Origin: "Cisco-IOS-XR-ipv4-io-oper",
+		Elem: []*gnmipb.PathElem{
+			{Name: "ipv4-network"}, {Name: "nodes"}, {Name: "node"}, {Name: "interface-data"},
+			{Name: "vrfs"}, {Name: "vrf"}, {Name: "details"},
+			{Name: "detail", Key: map[string]string{"interface-name": "*"}},
+			{Name: "*"},
+		},
+	}
+	secondaryLeafPath = &gnmipb.Path{
+		Origin: "Cisco-IOS-XR-ipv4-io-oper",
+		Elem: []*gnmipb.PathElem{
+			{Name: "ipv4-network"}, {Name: "nodes"}, {Name: "node"}, {Name: "interface-data"},
+			{Name: "vrfs"}, {Name: "vrf"}, {Name: "details"},
+			{Name: "detail", Key: map[string]string{"interface-name": "*"}},
+			{Name: secondaryAddress}, {Name: "*"},
+		},
+	}
+)
+
+// ipv4Address is an IPv4 address of an interface, with a zero prefix length if it is unknown.
+type ipv4Address struct {
+	ip           string
+	prefixLength uint64
+	secondary    bool
+}
+
+// detail holds the addresses of an interface updated by a notification.
+type detail struct {
+	primary     ipv4Address
+	secondaries []*ipv4Address
+}
+
+// addressesPath returns the openconfig path of the IPv4 addresses of a native interface, named with
+// the bundle name format.
+func addressesPath(nativeIntfName, bundleNameFormat string) *gnmipb.Path {
+	intfName, subIndex := ftutilities.SplitSubinterface(ftutilities.CiscoXRBundleName(nativeIntfName, bundleNameFormat))
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": intfName}},
+			{Name: "subinterfaces"},
+			{Name: "subinterface", Key: map[string]string{"index": fmt.Sprint(subIndex)}},
+			{Name: "ipv4"},
+			{Name: "addresses"},
+		},
+	}
+}
+
+// updates returns the openconfig updates of the address of a native interface.
+func (a *ipv4Address) updates(nativeIntfName, bundleNameFormat string) []*gnmipb.Update {
+	leaf := func(name string, v *gnmipb.TypedValue) *gnmipb.Update {
+		p := addressesPath(nativeIntfName, bundleNameFormat)
+		p.Elem = append(p.Elem,
+			&gnmipb.PathElem{Name: "address", Key: map[string]string{"ip": a.ip}},
+			&gnmipb.PathElem{Name: "state"},
+			&gnmipb.PathElem{Name: name},
+		)
+		return &gnmipb.Update{Path: p, Val: v}
+	}
+	addressType := "PRIMARY"
+	if a.secondary {
+		addressType = "SECONDARY"
+	}
+	updates := []*gnmipb.Update{
+		leaf("ip", &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: a.ip}}),
+		leaf("type", &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: addressType}}),
+	}
+	if a.prefixLength != 0 {
+		updates = append(updates, leaf("prefix-length", &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: a.prefixLength}}))
+	}
+	return updates
+}
+
+// prefixLengthValue returns the prefix length of a native value.
+func prefixLengthValue(v *gnmipb.TypedValue) (uint64, error) {
+	l, err := ftutilities.ToUint64(v)
+	if err != nil {
+		return 0, err
+	}
+	if l > maxPrefixLength {
+		return 0, fmt.Errorf("prefix length %d is longer than %d", l, maxPrefixLength)
+	}
+	return l, nil
+}
+
+// parse returns the details of the interfaces updated by the notification, and their names in
+// the order of their first update.
+func parse(n *gnmipb.Notification) (map[string]*detail, []string) {
+	details := map[string]*detail{}
+	var names []string
+	for _, u := range n.GetUpdate() {
+		path := ftutilities.Join(n.GetPrefix(), u.GetPath())
+		secondaryLeaf := ftutilities.MatchPath(path, secondaryLeafPath)
+		if !secondaryLeaf && !ftutilities.MatchPath(path, primaryLeafPath) {
+			continue
+		}
+		elems := path.GetElem()
+		name := elems[7].GetKey()["interface-name"]
+		leaf := elems[len(elems)-1].GetName()
+		if name == "" {
+			log.Errorf("Failed to translate %s: no interface name in %v", leaf, path)
+			continue
+		}
+		d, ok := details[name]
+		if !ok {
+			d = &detail{}
+			details[name] = d
+			names = append(names, name)
+		}
+		switch {
+		case !secondaryLeaf && leaf == primaryAddress:
+			d.primary.ip = u.GetVal().GetStringVal()
+		case !secondaryLeaf && leaf == prefixLength:
+			l, err := prefixLengthValue(u.GetVal())
+			if err != nil {
+				log.Errorf("Failed to translate the prefix length of interface %q: %v", name, err)
+				continue
+			}
+			d.primary.prefixLength = l
+		case secondaryLeaf && leaf == address:
+			d.secondaries = append(d.secondaries, &ipv4Address{ip: u.GetVal().GetStringVal(), secondary: true})
+		case secondaryLeaf && leaf == prefixLength:
+			if len(d.secondaries) == 0 {
+				log.Errorf("During translation of interface %q, secondary prefix length update received before address update", name)
+				continue
+			}
+			l, err := prefixLengthValue(u.GetVal())
+			if err != nil {
+				log.Errorf("Failed to translate a secondary prefix length of interface %q: %v", name, err)
+				continue
+			}
+			d.secondaries[len(d.secondaries)-1].prefixLength = l
+		}
+	}
+	return details, names
+}
+
+// addressDeletes returns the deletes of the openconfig addresses of the interfaces whose native
+// details are deleted by the notification. Deletes of other paths are ignored.
+func addressDeletes(n *gnmipb.Notification, bundleNameFormat string) []*gnmipb.Path {
+	var deletes []*gnmipb.Path
+	for _, d := range n.GetDelete() {
+		path := ftutilities.Join(n.GetPrefix(), d)
+		if !ftutilities.MatchPath(path, detailPath) {
+			continue
+		}
+		if name := path.GetElem()[7].GetKey()["interface-name"]; name != "" {
+			deletes = append(deletes, addressesPath(name, bundleNameFormat))
+		}
+	}
+	return deletes
+}
+
+// validateOptions rejects an invalid bundle name format.
+func validateOptions(opts translator.Options) error {
+	_, err := ftutilities.CiscoXRBundleNameFormat(opts)
+	return err
+}
+
+func translate(sr *gnmipb.SubscribeResponse, opts translator.Options) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	bundleNameFormat, err := ftutilities.CiscoXRBundleNameFormat(opts)
+	if err != nil {
+		return nil, err
+	}
+	var updates []*gnmipb.Update
+	details, names := parse(n)
+	for _, name := range names {
+		d := details[name]
+		if d.primary.ip != "" && d.primary.ip != unnumbered {
+			updates = append(updates, d.primary.updates(name, bundleNameFormat)...)
+		} else if d.primary.prefixLength != 0 {
+			log.V(2).Infof("Ignoring the prefix length of interface %q without a primary address", name)
+		}
+		for _, a := range d.secondaries {
+			updates = append(updates, a.updates(name, bundleNameFormat)...)
+		}
+	}
+	deletes := addressDeletes(n, bundleNameFormat)
+	if len(updates) == 0 && len(deletes) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+				Delete: deletes,
+			},
+		},
+	}, nil
+}
+
+func init() {
+	registry.Register(ftconsts.CiscoXRIPv4Translator, NewE)
+}
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco IPv4 functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	ft, err := translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:                   ftconsts.CiscoXRIPv4Translator,
+			TranslateWithOptions: translate,
+			ValidateOptions:      validateOptions,
+			OutputToInputMap:     ftutilities.MustStringMapPaths(translateMap),
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorCiscoXR,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ft, nil
+}
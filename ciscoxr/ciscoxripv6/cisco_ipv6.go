@@ -16,9 +16,10 @@
 package ciscoxripv6
 
 import (
-	log "github.com/golang/glog"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -186,14 +187,32 @@ func parse(n *gnmipb.Notification) []*interfaceData {
 				log.Errorf("During translation of interface %s, prefix length update received before address update", intfName(n))
 				continue
 			}
-			out[len(out)-1].ipPrefixLength = update.GetVal().GetUintVal()
+			length, err := ftutilities.ToUint64(update.GetVal())
+			if err != nil {
+				log.Errorf("During translation of interface %s, invalid prefix length: %v", intfName(n), err)
+				continue
+			}
+			out[len(out)-1].ipPrefixLength = length
 		}
 	}
 	return out
 }
 
+func init() {
+	registry.Register(ftconsts.CiscoXRIPv6Translator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco subinterface functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
 			ID:               ftconsts.CiscoXRIPv6Translator,
@@ -207,9 +226,9 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Cisco subinterface functional translator: %v", err)
+		return nil, err
 	}
-	return ft
+	return ft, nil
 }
 
 func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
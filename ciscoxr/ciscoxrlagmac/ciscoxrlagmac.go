@@ -16,12 +16,14 @@
 package ciscoxrlagmac
 
 import (
+	"fmt"
 	"strings"
 
-	log "github.com/golang/glog"
 	oc "github.com/openconfig/functional-translators/ciscoxr/ciscoxrlagmac/yang/openconfig"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/simplemapper"
 	"github.com/openconfig/functional-translators/translator"
 
@@ -72,8 +74,21 @@ func deleteHandler(n *gnmipb.Notification) ([]*gnmipb.Path, error) {
 	return returnDeletes, nil
 }
 
+func init() {
+	registry.Register(ftconsts.CiscoXRLagMacFunctionalTranslator, NewE)
+}
+
 // New returns a new FunctionalTranslator for Cisco interface descriptions.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco LAG MAC functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
 	m, err := simplemapper.NewSimpleMapper(oc.Schema, oc.Schema,
 		map[string]string{
 			"/openconfig/interfaces/interface[name=<lagIntfName>]/ethernet/state/mac-address":      "/openconfig/lacp/interfaces/interface[name=<lagIntfName>]/state/system-id-mac",
@@ -82,7 +97,7 @@ func New() *translator.FunctionalTranslator {
 		deleteHandler,
 	)
 	if err != nil {
-		log.Fatalf("Failed to create mapper: %v", err)
+		return nil, fmt.Errorf("failed to create mapper: %v", err)
 	}
 
 	p := ftutilities.MustStringMapPaths(m.OutputToInputSchemaStrings())
@@ -100,7 +115,7 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Cisco LAG MAC functional translator: %v", err)
+		return nil, err
 	}
-	return ft
+	return ft, nil
 }
@@ -13,12 +13,16 @@
 // limitations under the License.
 
 // Package ciscoxrlaser transslates laser native path to openconfig .
+//
+// The thresholds are also translated by the ciscoxrtransceiver translator, which owns them for the
+// automatic selection of the translators of a device. This translator is therefore not registered:
+// consumers create it with New, e.g. in the Translators of the functionaltranslators options.
 package ciscoxrlaser
 
 import (
 	"fmt"
 
-	log "github.com/golang/glog"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/ygot/ytypes"
 	"github.com/openconfig/functional-translators/ftconsts"
 	"github.com/openconfig/functional-translators/ftutilities"
@@ -75,11 +79,21 @@ var (
 
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco laser functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
-			ID:               ftconsts.CiscoXRLaserTranslator,
-			Translate:        translate,
-			OutputToInputMap: paths,
+			ID:                   ftconsts.CiscoXRLaserTranslator,
+			TranslateWithOptions: translate,
+			ValidateOptions:      validateOptions,
+			OutputToInputMap:     paths,
 			Metadata: []*translator.FTMetadata{
 				{
 					Vendor: ftconsts.VendorCiscoXR,
@@ -88,19 +102,29 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Cisco laser functional translator: %v", err)
+		return nil, err
 	}
 	schema, schemaErr = xr2431.Schema()
 	if schemaErr != nil {
-		log.Fatalf("Failed to get schema: %v", schemaErr)
+		return nil, fmt.Errorf("failed to get schema: %v", schemaErr)
 	}
-	return ft
+	return ft, nil
+}
+
+// validateOptions rejects invalid optics naming options.
+func validateOptions(opts translator.Options) error {
+	_, err := ftutilities.NewOpticsNaming(opts)
+	return err
 }
 
-func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+func translate(sr *gnmipb.SubscribeResponse, opts translator.Options) (*gnmipb.SubscribeResponse, error) {
 	if sr.GetUpdate() == nil {
 		return nil, nil
 	}
+	naming, err := ftutilities.NewOpticsNaming(opts)
+	if err != nil {
+		return nil, err
+	}
 	// Make a shallow copy of the schema and replace the root. This prevents state from one
 	// unmarshal operation from leaking into subsequent operations.
 	schemaCopy := *schema
@@ -112,20 +136,21 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 		return nil, fmt.Errorf("failed to unmarshal notifications: %v", err)
 	}
 
-	portMap := d.GetOpticsOper().GetOpticsPorts().GetOrCreateOpticsPortMap()
-	if portMap == nil {
-		return nil, fmt.Errorf("failed to get optics port map")
+	ports := d.GetOpticsOper().GetOpticsPorts()
+	if ports == nil {
+		return nil, nil
 	}
+	portMap := ports.GetOrCreateOpticsPortMap()
 	lcRoot := &lc.Device{}
 	for portName, port := range portMap {
 		if port == nil {
 			continue
 		}
 		opticsInfo := port.GetOpticsInfo()
-		if opticsInfo == nil {
+		if opticsInfo == nil || opticsInfo.DerivedOpticsType == nil {
 			continue
 		}
-		modifiedPortName, wanted := ftutilities.MaybeConvertOptical(portName, *opticsInfo.DerivedOpticsType)
+		modifiedPortName, wanted := ftutilities.MaybeConvertOpticalWithNaming(portName, *opticsInfo.DerivedOpticsType, naming)
 		if !wanted {
 			continue
 		}
@@ -18,10 +18,11 @@ package ciscoxrmount
 import (
 	"fmt"
 
-	log "github.com/golang/glog"
 	ocmount "github.com/openconfig/functional-translators/ciscoxr/ciscoxrmount/yang/openconfig"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -85,10 +86,16 @@ func buildFileSystems(prefix *gnmipb.Path, leaves []*gnmipb.Update) (map[string]
 			nodeFileSystems[nodeName] = t
 		}
 		switch elems[3].GetName() {
-		case "size":
-			t.size = append(t.size, leaf.GetVal().GetUintVal()/convertBytesToMB)
-		case "free":
-			t.free = append(t.free, leaf.GetVal().GetUintVal()/convertBytesToMB)
+		case "size", "free":
+			v, err := ftutilities.ToUint64(leaf.GetVal())
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s of node %s: %v", elems[3].GetName(), nodeName, err)
+			}
+			if elems[3].GetName() == "size" {
+				t.size = append(t.size, v/convertBytesToMB)
+			} else {
+				t.free = append(t.free, v/convertBytesToMB)
+			}
 		case "prefixes":
 			t.prefixes = append(t.prefixes, leaf.GetVal().GetStringVal())
 		default:
@@ -110,8 +117,21 @@ func buildFileSystems(prefix *gnmipb.Path, leaves []*gnmipb.Update) (map[string]
 	return nodeFileSystems, nil
 }
 
+func init() {
+	registry.Register(ftconsts.CiscoXRMountTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco mount functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
 			ID:               ftconsts.CiscoXRMountTranslator,
@@ -125,9 +145,9 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Cisco mount functional translator: %v", err)
+		return nil, err
 	}
-	return ft
+	return ft, nil
 }
 
 func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
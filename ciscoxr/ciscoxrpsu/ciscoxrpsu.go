@@ -0,0 +1,161 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ciscoxrpsu translates Cisco power module readings to openconfig power-supply state.
+//
+// Fan speeds are translated by the ciscoxrenvmon FT, and the oper-status of the power modules by
+// the ciscoxrpower FT.
+package ciscoxrpsu
+
+import (
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	// CiscoXR native power module path.
+	ciscoPEMInfo = "/Cisco-IOS-XR-envmon-oper/power-management/rack/producers/producer-nodes/producer-node/pem-info-array"
+
+	// pemNameLeaf is the native leaf holding the name of the power module. The pem-info-array
+	// list has no key, so the leaves following a name belong to that power module.
+	pemNameLeaf = "node-name"
+)
+
+var (
+	// leafMap maps the native power module leaves to the openconfig power-supply state leaves.
+	leafMap = map[string]string{
+		"input-voltage":  "input-voltage",
+		"input-current":  "input-current",
+		"output-voltage": "output-voltage",
+		"output-current": "output-current",
+		"output-power":   "output-power",
+	}
+	translateMap = map[string][]string{
+		"/openconfig/components/component/power-supply/state/input-voltage":  {ciscoPEMInfo},
+		"/openconfig/components/component/power-supply/state/input-current":  {ciscoPEMInfo},
+		"/openconfig/components/component/power-supply/state/output-voltage": {ciscoPEMInfo},
+		"/openconfig/components/component/power-supply/state/output-current": {ciscoPEMInfo},
+		"/openconfig/components/component/power-supply/state/output-power":   {ciscoPEMInfo},
+	}
+	nativePEMLeafPath = &gnmipb.Path{
+		Origin: "Cisco-IOS-XR-envmon-oper",
+		Elem: []*gnmipb.PathElem{
+			{Name: "power-management"}, {Name: "rack"}, {Name: "producers"}, {Name: "producer-nodes"},
+			{Name: "producer-node"}, {Name: "pem-info-array"}, {Name: "*"},
+		},
+	}
+)
+
+func powerSupplyPath(componentName, leaf string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": componentName}},
+			{Name: "power-supply"},
+			{Name: "state"},
+			{Name: leaf},
+		},
+	}
+}
+
+func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	// Silently ignore deletes and paths we don't care about.
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	var updates []*gnmipb.Update
+	var pemName string
+	for _, leaf := range n.GetUpdate() {
+		path := ftutilities.Join(n.GetPrefix(), leaf.GetPath())
+		if !ftutilities.MatchPath(path, nativePEMLeafPath) {
+			continue
+		}
+		name := path.GetElem()[6].GetName()
+		if name == pemNameLeaf {
+			pemName = leaf.GetVal().GetStringVal()
+			continue
+		}
+		ocLeaf, ok := leafMap[name]
+		if !ok {
+			continue
+		}
+		if pemName == "" {
+			log.Errorf("Failed to translate power module %s: no preceding %s", name, pemNameLeaf)
+			continue
+		}
+		v, err := ftutilities.ToDouble(leaf.GetVal())
+		if err != nil {
+			log.Errorf("Failed to translate %s of power module %q: %v", name, pemName, err)
+			continue
+		}
+		updates = append(updates, &gnmipb.Update{
+			Path: powerSupplyPath(pemName, ocLeaf),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: v}},
+		})
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+			},
+		},
+	}, nil
+}
+
+func init() {
+	registry.Register(ftconsts.CiscoXRPowerSupplyTranslator, NewE)
+}
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco power supply functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	ft, err := translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:               ftconsts.CiscoXRPowerSupplyTranslator,
+			Translate:        translate,
+			OutputToInputMap: ftutilities.MustStringMapPaths(translateMap),
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorCiscoXR,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ft, nil
+}
@@ -17,13 +17,18 @@ package ciscoxrqos
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"sync"
 
-	log "github.com/golang/glog"
 	ocqos "github.com/openconfig/functional-translators/ciscoxr/ciscoxrqos/yang/openconfig"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
+	"github.com/openconfig/ygot/ygot"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
 )
@@ -34,6 +39,28 @@ type outStats struct {
 	transmitOctets []uint64
 	transmitPkts   []uint64
 	className      []string
+	// bundle is the name of the Bundle-Ether interface of a member interface, empty for the other
+	// interfaces.
+	bundle string
+	// ownBundleStats is true for the statistics of a Bundle-Ether interface itself.
+	ownBundleStats bool
+}
+
+// complete returns true if each class name has its counters.
+func (s *outStats) complete() bool {
+	return allEqual(len(s.className), len(s.droppedOctets), len(s.droppedPkts), len(s.transmitOctets), len(s.transmitPkts))
+}
+
+// merge appends the class names and counters of a following fragment of the statistics.
+func (s *outStats) merge(o *outStats) {
+	s.droppedOctets = append(s.droppedOctets, o.droppedOctets...)
+	s.droppedPkts = append(s.droppedPkts, o.droppedPkts...)
+	s.transmitOctets = append(s.transmitOctets, o.transmitOctets...)
+	s.transmitPkts = append(s.transmitPkts, o.transmitPkts...)
+	s.className = append(s.className, o.className...)
+	if o.bundle != "" {
+		s.bundle = o.bundle
+	}
 }
 
 type inStats struct {
@@ -42,6 +69,66 @@ type inStats struct {
 	className     []string
 }
 
+// complete returns true if each class name has its counters.
+func (s *inStats) complete() bool {
+	return allEqual(len(s.className), len(s.matchedOctets), len(s.matchedPkts))
+}
+
+// merge appends the class names and counters of a following fragment of the statistics.
+func (s *inStats) merge(o *inStats) {
+	s.matchedOctets = append(s.matchedOctets, o.matchedOctets...)
+	s.matchedPkts = append(s.matchedPkts, o.matchedPkts...)
+	s.className = append(s.className, o.className...)
+}
+
+// fragment is the statistics of an interface, which devices may split across notifications, e.g.
+// the class names and the general statistics of the classes.
+type fragment[T any] interface {
+	complete() bool
+	merge(T)
+}
+
+// maxFragments is the number of notifications after which incomplete statistics are dropped.
+const maxFragments = 8
+
+// pendingStats holds the incomplete statistics of an interface, until their following fragments
+// are received.
+type pendingStats[T any] struct {
+	stats     T
+	fragments int
+}
+
+// correlate merges the incomplete statistics of the interfaces with their pending fragments, and
+// removes the statistics which are still incomplete, which are then pending. Complete statistics
+// replace the pending fragments of their interface.
+func correlate[T fragment[T]](pending map[string]*pendingStats[T], stats map[string]T) {
+	for intfName, s := range stats {
+		if s.complete() {
+			delete(pending, intfName)
+			continue
+		}
+		p, ok := pending[intfName]
+		if ok {
+			p.stats.merge(s)
+			p.fragments++
+		} else {
+			p = &pendingStats[T]{stats: s, fragments: 1}
+		}
+		if p.stats.complete() {
+			delete(pending, intfName)
+			stats[intfName] = p.stats
+			continue
+		}
+		delete(stats, intfName)
+		if p.fragments >= maxFragments {
+			log.Warningf("dropped the statistics of interface %s, incomplete after %d notifications", intfName, p.fragments)
+			delete(pending, intfName)
+			continue
+		}
+		pending[intfName] = p
+	}
+}
+
 var (
 	translateMap = map[string][]string{
 		"/openconfig/qos/interfaces/interface/output/queues/queue/state/dropped-octets": {
@@ -62,9 +149,11 @@ var (
 		},
 		"/openconfig/qos/interfaces/interface/input/classifiers/classifier/terms/term/state/matched-octets": {
 			"/Cisco-IOS-XR-qos-ma-oper/qos/interface-table/interface/input/service-policy-names/service-policy-instance/statistics",
+			"/Cisco-IOS-XR-qos-ma-oper/qos/interface-table/interface/member-interfaces/member-interface/input/service-policy-names/service-policy-instance/statistics",
 		},
 		"/openconfig/qos/interfaces/interface/input/classifiers/classifier/terms/term/state/matched-packets": {
 			"/Cisco-IOS-XR-qos-ma-oper/qos/interface-table/interface/input/service-policy-names/service-policy-instance/statistics",
+			"/Cisco-IOS-XR-qos-ma-oper/qos/interface-table/interface/member-interfaces/member-interface/input/service-policy-names/service-policy-instance/statistics",
 		},
 	}
 	paths       = ftutilities.MustStringMapPaths(translateMap)
@@ -183,24 +272,89 @@ var (
 				{Name: "pre-policy-matched-packets"},
 			},
 		},
+		{
+			Origin: "Cisco-IOS-XR-qos-ma-oper",
+			Elem: []*gnmipb.PathElem{
+				{Name: "qos"}, {Name: "interface-table"}, {Name: "interface"}, {Name: "member-interfaces"},
+				{Name: "member-interface"}, {Name: "input"}, {Name: "service-policy-names"}, {Name: "service-policy-instance"},
+				{Name: "statistics"}, {Name: "class-stats"}, {Name: "class-name"},
+			},
+		},
+		{
+			Origin: "Cisco-IOS-XR-qos-ma-oper",
+			Elem: []*gnmipb.PathElem{
+				{Name: "qos"}, {Name: "interface-table"}, {Name: "interface"}, {Name: "member-interfaces"},
+				{Name: "member-interface"}, {Name: "input"}, {Name: "service-policy-names"}, {Name: "service-policy-instance"},
+				{Name: "statistics"}, {Name: "class-stats"}, {Name: "general-stats"},
+				{Name: "pre-policy-matched-bytes"},
+			},
+		},
+		{
+			Origin: "Cisco-IOS-XR-qos-ma-oper",
+			Elem: []*gnmipb.PathElem{
+				{Name: "qos"}, {Name: "interface-table"}, {Name: "interface"}, {Name: "member-interfaces"},
+				{Name: "member-interface"}, {Name: "input"}, {Name: "service-policy-names"}, {Name: "service-policy-instance"},
+				{Name: "statistics"}, {Name: "class-stats"}, {Name: "general-stats"},
+				{Name: "pre-policy-matched-packets"},
+			},
+		},
+	}
+	nativeMatcher = ftutilities.PathMatcherFromPaths(nativePaths...)
+	// bundleDeletePatterns match the deletes of a bundle interface or of all its member interfaces,
+	// which remove the bundle from the aggregation cache.
+	bundleDeletePatterns = []*gnmipb.Path{
+		{
+			Elem: []*gnmipb.PathElem{
+				{Name: "qos"}, {Name: "interface-table"},
+				{Name: "interface", Key: map[string]string{"interface-name": "*"}},
+			},
+		},
+		{
+			Elem: []*gnmipb.PathElem{
+				{Name: "qos"}, {Name: "interface-table"},
+				{Name: "interface", Key: map[string]string{"interface-name": "*"}},
+				{Name: "member-interfaces"},
+			},
+		},
+	}
+	// memberDeletePatterns match the deletes of a member interface or of its output statistics,
+	// which remove the member from the aggregation cache.
+	memberDeletePatterns = []*gnmipb.Path{
+		{
+			Elem: []*gnmipb.PathElem{
+				{Name: "qos"}, {Name: "interface-table"},
+				{Name: "interface", Key: map[string]string{"interface-name": "*"}},
+				{Name: "member-interfaces"},
+				{Name: "member-interface", Key: map[string]string{"interface-name": "*"}},
+			},
+		},
+		{
+			Elem: []*gnmipb.PathElem{
+				{Name: "qos"}, {Name: "interface-table"},
+				{Name: "interface", Key: map[string]string{"interface-name": "*"}},
+				{Name: "member-interfaces"},
+				{Name: "member-interface", Key: map[string]string{"interface-name": "*"}},
+				{Name: "output"}, {Name: "..."},
+			},
+		},
 	}
 )
 
 // validates the leaves and builds stats structs
-func buildStats(prefix *gnmipb.Path, leaves []*gnmipb.Update) (map[string]*outStats, map[string]*inStats) {
+func buildStats(prefix *gnmipb.Path, leaves []*gnmipb.Update, bundleNameFormat string) (map[string]*outStats, map[string]*inStats) {
 	intfOutStats := make(map[string]*outStats)
 	intfInStats := make(map[string]*inStats)
 	for _, leaf := range leaves {
 		path := ftutilities.Join(prefix, leaf.GetPath())
-		if !ftutilities.PathInList(path, nativePaths) {
+		if !nativeMatcher.Match(path) {
 			continue
 		}
 		elems := path.GetElem()
 		switch {
 		case elems[3].GetName() == "output" || elems[5].GetName() == "output":
-			intfOutStats = buildOutputStats(prefix, leaf, intfOutStats)
-		case elems[3].GetName() == "input":
-			intfInStats = buildInputStats(prefix, leaf, intfInStats)
+			intfOutStats = buildOutputStats(prefix, leaf, intfOutStats, bundleNameFormat)
+		case elems[3].GetName() == "input" || elems[5].GetName() == "input":
+			intfInStats = buildInputStats(prefix, leaf, intfInStats, bundleNameFormat)
 		}
 	}
 	return intfOutStats, intfInStats
@@ -220,16 +374,18 @@ func allEqual(nums ...int) bool {
 }
 
 // build output stats structs
-func buildOutputStats(prefix *gnmipb.Path, leaf *gnmipb.Update, intfOutStats map[string]*outStats) map[string]*outStats {
+func buildOutputStats(prefix *gnmipb.Path, leaf *gnmipb.Update, intfOutStats map[string]*outStats, bundleNameFormat string) map[string]*outStats {
 	path := ftutilities.Join(prefix, leaf.GetPath())
 	elems := path.GetElem()
 	var intfName string
 	var startIndex int
+	var bundle string
 	if elems[4].GetName() == "member-interface" {
-		intfName = elems[4].GetKey()["interface-name"]
+		intfName = ftutilities.CiscoXRBundleName(elems[4].GetKey()["interface-name"], bundleNameFormat)
+		bundle = ftutilities.CiscoXRBundleName(elems[2].GetKey()["interface-name"], bundleNameFormat)
 		startIndex = 10
 	} else {
-		intfName = elems[2].GetKey()["interface-name"]
+		intfName = ftutilities.CiscoXRBundleName(elems[2].GetKey()["interface-name"], bundleNameFormat)
 		startIndex = 8
 	}
 	t, ok := intfOutStats[intfName]
@@ -240,6 +396,8 @@ func buildOutputStats(prefix *gnmipb.Path, leaf *gnmipb.Update, intfOutStats map
 			transmitOctets: []uint64{},
 			transmitPkts:   []uint64{},
 			className:      []string{},
+			bundle:         bundle,
+			ownBundleStats: bundle == "" && ftutilities.IsCiscoXRBundle(elems[2].GetKey()["interface-name"]),
 		}
 		intfOutStats[intfName] = t
 	}
@@ -249,25 +407,38 @@ func buildOutputStats(prefix *gnmipb.Path, leaf *gnmipb.Update, intfOutStats map
 		parts := strings.Split(fullClassName, ":")
 		t.className = append(t.className, parts[len(parts)-1])
 	case "general-stats":
+		v, err := ftutilities.ToUint64(leaf.GetVal())
+		if err != nil {
+			log.Errorf("Invalid %s of interface %s: %v", elems[startIndex+1].GetName(), intfName, err)
+			return intfOutStats
+		}
 		switch elems[startIndex+1].GetName() {
 		case "total-drop-bytes":
-			t.droppedOctets = append(t.droppedOctets, leaf.GetVal().GetUintVal())
+			t.droppedOctets = append(t.droppedOctets, v)
 		case "total-drop-packets":
-			t.droppedPkts = append(t.droppedPkts, leaf.GetVal().GetUintVal())
+			t.droppedPkts = append(t.droppedPkts, v)
 		case "transmit-bytes":
-			t.transmitOctets = append(t.transmitOctets, leaf.GetVal().GetUintVal())
+			t.transmitOctets = append(t.transmitOctets, v)
 		case "transmit-packets":
-			t.transmitPkts = append(t.transmitPkts, leaf.GetVal().GetUintVal())
+			t.transmitPkts = append(t.transmitPkts, v)
 		}
 	}
 	return intfOutStats
 }
 
 // build input stats structs
-func buildInputStats(prefix *gnmipb.Path, leaf *gnmipb.Update, intfInStats map[string]*inStats) map[string]*inStats {
+func buildInputStats(prefix *gnmipb.Path, leaf *gnmipb.Update, intfInStats map[string]*inStats, bundleNameFormat string) map[string]*inStats {
 	path := ftutilities.Join(prefix, leaf.GetPath())
 	elems := path.GetElem()
-	intfName := elems[2].GetKey()["interface-name"]
+	var intfName string
+	var startIndex int
+	if elems[4].GetName() == "member-interface" {
+		intfName = ftutilities.CiscoXRBundleName(elems[4].GetKey()["interface-name"], bundleNameFormat)
+		startIndex = 10
+	} else {
+		intfName = ftutilities.CiscoXRBundleName(elems[2].GetKey()["interface-name"], bundleNameFormat)
+		startIndex = 8
+	}
 	t, ok := intfInStats[intfName]
 	if !ok {
 		t = &inStats{
@@ -277,27 +448,83 @@ func buildInputStats(prefix *gnmipb.Path, leaf *gnmipb.Update, intfInStats map[s
 		}
 		intfInStats[intfName] = t
 	}
-	switch elems[8].GetName() {
+	switch elems[startIndex].GetName() {
 	case "class-name":
 		t.className = append(t.className, leaf.GetVal().GetStringVal())
 	case "general-stats":
-		switch elems[9].GetName() {
+		v, err := ftutilities.ToUint64(leaf.GetVal())
+		if err != nil {
+			log.Errorf("Invalid %s of interface %s: %v", elems[startIndex+1].GetName(), intfName, err)
+			return intfInStats
+		}
+		switch elems[startIndex+1].GetName() {
 		case "pre-policy-matched-bytes":
-			t.matchedOctets = append(t.matchedOctets, leaf.GetVal().GetUintVal())
+			t.matchedOctets = append(t.matchedOctets, v)
 		case "pre-policy-matched-packets":
-			t.matchedPkts = append(t.matchedPkts, leaf.GetVal().GetUintVal())
+			t.matchedPkts = append(t.matchedPkts, v)
 		}
 	}
 	return intfInStats
 }
 
+func init() {
+	registry.Register(ftconsts.CiscoXRQosTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco QoS functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	return NewWithOptions(QosOptions{})
+}
+
+// QosOptions contains the options of an FT created by NewWithOptions.
+type QosOptions struct {
+	// QueueNames maps the Cisco class names, without their policy prefix, to the names of the
+	// openconfig output queues, e.g. to match the queue names of the openconfig configuration.
+	// The queues of the classes without a mapping are named after the classes.
+	QueueNames map[string]string
+}
+
+// NewWithOptions is like NewE, but creates a translator with the options. It returns an error if
+// a class is mapped to an empty queue name, or if several classes are mapped to the same queue.
+func NewWithOptions(opts QosOptions) (*translator.FunctionalTranslator, error) {
+	classes := map[string]string{}
+	for class, queue := range opts.QueueNames {
+		if queue == "" {
+			return nil, fmt.Errorf("class %q is mapped to an empty queue name", class)
+		}
+		if other, ok := classes[queue]; ok {
+			return nil, fmt.Errorf("classes %q and %q are mapped to the same queue %q", min(class, other), max(class, other), queue)
+		}
+		classes[queue] = class
+	}
+	i := &impl{
+		cache:      ftutilities.NewQoSAggregationMapCache(),
+		queueNames: maps.Clone(opts.QueueNames),
+		pendingOut: map[string]map[string]*pendingStats[*outStats]{},
+		pendingIn:  map[string]map[string]*pendingStats[*inStats]{},
+	}
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
-			ID:               ftconsts.CiscoXRQosTranslator,
-			Translate:        translate,
-			OutputToInputMap: paths,
+			ID:                   ftconsts.CiscoXRQosTranslator,
+			TranslateWithOptions: i.translate,
+			ValidateOptions:      validateOptions,
+			OutputToInputMap:     paths,
+			State: &translator.StateOptions{
+				Reset:        i.reset,
+				State:        func() any { return i.cache.Clone() },
+				RestoreState: i.restoreState,
+				Store:        i.cache,
+				ResetTarget:  i.resetTarget,
+			},
 			Metadata: []*translator.FTMetadata{
 				{
 					Vendor: ftconsts.VendorCiscoXR,
@@ -306,40 +533,262 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Cisco QoS functional translator: %v", err)
+		return nil, err
 	}
-	return ft
+	return ft, nil
+}
+
+// impl aggregates the output queue counters of the member interfaces of the bundles into the
+// queues of the bundles, in the cache of the translator, for the bundles whose own statistics are
+// not reported by the device.
+type impl struct {
+	cache *ftutilities.QoSAggregationMapCache
+	// queueNames maps the class names to the names of their output queues.
+	queueNames map[string]string
+
+	mu sync.Mutex
+	// pendingOut and pendingIn map the targets to the incomplete output and input statistics of
+	// their interfaces, by interface name, whose following fragments are expected in the next
+	// notifications. They are not part of the state.
+	pendingOut map[string]map[string]*pendingStats[*outStats]
+	pendingIn  map[string]map[string]*pendingStats[*inStats]
+}
+
+// reset clears the cache and the pending statistics.
+func (i *impl) reset() {
+	i.cache.ClearAllTargetQoSInfo()
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.pendingOut = map[string]map[string]*pendingStats[*outStats]{}
+	i.pendingIn = map[string]map[string]*pendingStats[*inStats]{}
+}
+
+// resetTarget clears the cache and the pending statistics of the target.
+func (i *impl) resetTarget(target string) {
+	i.cache.ResetTarget(target)
+	i.forget([]string{target})
+}
+
+// forget removes the pending statistics of the targets.
+func (i *impl) forget(targets []string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, t := range targets {
+		delete(i.pendingOut, t)
+		delete(i.pendingIn, t)
+	}
+}
+
+// correlate completes the statistics of the target with their pending fragments, and keeps the
+// incomplete statistics pending.
+func (i *impl) correlate(target string, intfOutStats map[string]*outStats, intfInStats map[string]*inStats) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.pendingOut[target] == nil {
+		i.pendingOut[target] = map[string]*pendingStats[*outStats]{}
+	}
+	if i.pendingIn[target] == nil {
+		i.pendingIn[target] = map[string]*pendingStats[*inStats]{}
+	}
+	correlate(i.pendingOut[target], intfOutStats)
+	correlate(i.pendingIn[target], intfInStats)
+}
+
+// queueName returns the name of the output queue of a class.
+func (i *impl) queueName(className string) string {
+	if name, ok := i.queueNames[className]; ok {
+		return name
+	}
+	return className
+}
+
+// restoreState replaces the cache with a snapshot returned by State.
+func (i *impl) restoreState(snapshot any) error {
+	cache, ok := snapshot.(*ftutilities.QoSAggregationMapCache)
+	if !ok {
+		return fmt.Errorf("unexpected state type %T", snapshot)
+	}
+	i.cache.Restore(cache)
+	return nil
+}
+
+// matchAny returns true if the path matches one of the patterns.
+func matchAny(path *gnmipb.Path, patterns []*gnmipb.Path) bool {
+	for _, pattern := range patterns {
+		if ftutilities.MatchPath(path, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteHandler removes the deleted bundles and member interfaces from the cache, and returns the
+// bundles whose aggregates are changed.
+func (i *impl) deleteHandler(n *gnmipb.Notification, bundleNameFormat string) map[string]bool {
+	impacted := map[string]bool{}
+	targetInfo, ok := i.cache.RetrieveTargetQoSInfo(n.GetPrefix().GetTarget())
+	if !ok {
+		return impacted
+	}
+	for _, del := range n.GetDelete() {
+		path := ftutilities.Join(n.GetPrefix(), del)
+		switch {
+		case matchAny(path, bundleDeletePatterns):
+			bundle := ftutilities.CiscoXRBundleName(path.GetElem()[2].GetKey()["interface-name"], bundleNameFormat)
+			if len(path.GetElem()) == len(bundleDeletePatterns[0].GetElem()) {
+				targetInfo.ClearNativeQueueCounters(bundle)
+			}
+			if targetInfo.RemovePortChannel(bundle) {
+				impacted[bundle] = true
+			}
+		case matchAny(path, memberDeletePatterns):
+			bundle := ftutilities.CiscoXRBundleName(path.GetElem()[2].GetKey()["interface-name"], bundleNameFormat)
+			member := ftutilities.CiscoXRBundleName(path.GetElem()[4].GetKey()["interface-name"], bundleNameFormat)
+			// The member may have moved to another bundle before the delete.
+			if current, ok := targetInfo.RetrievePortChannelForMember(member); ok && current == bundle {
+				targetInfo.FindAndRemoveMember(member)
+				impacted[bundle] = true
+			}
+		}
+	}
+	return impacted
+}
+
+// updateMember stores the output queue counters of a member interface in the cache, and marks the
+// bundles whose aggregates are changed in impacted.
+func (i *impl) updateMember(target, member string, stats *outStats, impacted map[string]bool) {
+	targetInfo := i.cache.CreateOrUpdateTargetQoSInfo(target)
+	if current, ok := targetInfo.RetrievePortChannelForMember(member); ok && current != stats.bundle {
+		targetInfo.FindAndRemoveMember(member)
+		impacted[current] = true
+	}
+	targetInfo.SetPortChannelForMember(member, stats.bundle)
+	memberInfo := targetInfo.CreateOrRetrievePortChannel(stats.bundle).CreateOrRetrieveMember(member)
+	for j, className := range stats.className {
+		if className == "" {
+			continue
+		}
+		queue := i.queueName(className)
+		memberInfo.SetDroppedBytes(queue, stats.droppedOctets[j])
+		memberInfo.SetDroppedPackets(queue, stats.droppedPkts[j])
+		memberInfo.SetTxBytes(queue, stats.transmitOctets[j])
+		memberInfo.SetTxPackets(queue, stats.transmitPkts[j])
+	}
+	impacted[stats.bundle] = true
+}
+
+// markNativeBundles records the bundles whose own statistics are in the notification, which are no
+// longer aggregated, and returns the paths deleting the queues previously aggregated for them. The
+// deletes are applied before the statistics of the bundles in the same notification.
+func (i *impl) markNativeBundles(target string, intfOutStats map[string]*outStats) []*gnmipb.Path {
+	var deletes []*gnmipb.Path
+	for _, intfName := range slices.Sorted(maps.Keys(intfOutStats)) {
+		if !intfOutStats[intfName].ownBundleStats {
+			continue
+		}
+		targetInfo := i.cache.CreateOrUpdateTargetQoSInfo(target)
+		if !targetInfo.SetNativeQueueCounters(intfName) {
+			continue
+		}
+		if _, ok := targetInfo.PortChannelInfo(intfName); ok {
+			deletes = append(deletes, aggregateDeletePath(intfName))
+		}
+	}
+	return deletes
 }
 
-func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+// aggregate sets the sums of the queue counters of the members of the bundle in the queues of the
+// bundle, and returns the path deleting the aggregated queues if the bundle has no members left,
+// after removing it from the cache. The bundles whose own statistics are reported by the device
+// are not aggregated, since the sums and the statistics would take turns on the same leaves.
+func (i *impl) aggregate(qosRoot *ocqos.Device, target, bundle string) *gnmipb.Path {
+	targetInfo, ok := i.cache.RetrieveTargetQoSInfo(target)
+	if !ok {
+		return aggregateDeletePath(bundle)
+	}
+	native := targetInfo.HasNativeQueueCounters(bundle)
+	pcInfo, ok := targetInfo.PortChannelInfo(bundle)
+	if !ok || targetInfo.RemovePortChannelIfEmpty(bundle) {
+		if native {
+			return nil
+		}
+		return aggregateDeletePath(bundle)
+	}
+	if native {
+		return nil
+	}
+	queues := qosRoot.GetOrCreateQos().GetOrCreateInterfaces().GetOrCreateInterface(bundle).GetOrCreateOutput().GetOrCreateQueues()
+	counters := pcInfo.AggregateCounters()
+	for _, className := range slices.Sorted(maps.Keys(counters)) {
+		c := counters[className]
+		state := queues.GetOrCreateQueue(className).GetOrCreateState()
+		state.DroppedOctets = ygot.Uint64(c.DroppedBytes)
+		state.DroppedPkts = ygot.Uint64(c.DroppedPackets)
+		state.TransmitOctets = ygot.Uint64(c.TxBytes)
+		state.TransmitPkts = ygot.Uint64(c.TxPackets)
+	}
+	return nil
+}
+
+// aggregateDeletePath returns the path deleting the aggregated queues of a bundle.
+func aggregateDeletePath(bundle string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "qos"}, {Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"interface-id": bundle}},
+			{Name: "output"}, {Name: "queues"},
+		},
+	}
+}
+
+// validateOptions rejects an invalid bundle name format.
+func validateOptions(opts translator.Options) error {
+	_, err := ftutilities.CiscoXRBundleNameFormat(opts)
+	return err
+}
+
+func (i *impl) translate(sr *gnmipb.SubscribeResponse, opts translator.Options) (*gnmipb.SubscribeResponse, error) {
 	if sr.GetUpdate() == nil {
 		return nil, nil
 	}
-	intfOutStats, intfInStats := buildStats(sr.GetUpdate().GetPrefix(), sr.GetUpdate().GetUpdate())
+	bundleNameFormat, err := ftutilities.CiscoXRBundleNameFormat(opts)
+	if err != nil {
+		return nil, err
+	}
+	if evicted := i.cache.EvictStaleTargets(); len(evicted) > 0 {
+		i.forget(evicted)
+		log.V(1).Infof("evicted the state of stale targets %v", evicted)
+	}
+	intfOutStats, intfInStats := buildStats(sr.GetUpdate().GetPrefix(), sr.GetUpdate().GetUpdate(), bundleNameFormat)
 	n := sr.GetUpdate()
+	target := n.GetPrefix().GetTarget()
+	// Devices may split the class names and the general statistics of an interface across
+	// notifications, which are correlated by their positions once all the fragments are received.
+	i.correlate(target, intfOutStats, intfInStats)
+	impacted := i.deleteHandler(n, bundleNameFormat)
+	deletes := i.markNativeBundles(target, intfOutStats)
 	qosRoot := &ocqos.Device{}
 	for intfName, intfOutStat := range intfOutStats {
-		if !allEqual(len(intfOutStat.className), len(intfOutStat.droppedOctets), len(intfOutStat.droppedPkts), len(intfOutStat.transmitOctets), len(intfOutStat.transmitPkts)) {
-			return nil, fmt.Errorf("mismatch len for interface %s output queue stats class", intfName)
-		}
 		intfOutput := qosRoot.GetOrCreateQos().GetOrCreateInterfaces().GetOrCreateInterface(intfName).GetOrCreateOutput()
-		for i, className := range intfOutStat.className {
+		for j, className := range intfOutStat.className {
 			if className == "" {
 				continue
 			}
-			queue := intfOutput.GetOrCreateQueues().GetOrCreateQueue(className)
-			queue.GetOrCreateState().DroppedOctets = &intfOutStat.droppedOctets[i]
-			queue.GetOrCreateState().DroppedPkts = &intfOutStat.droppedPkts[i]
-			queue.GetOrCreateState().TransmitOctets = &intfOutStat.transmitOctets[i]
-			queue.GetOrCreateState().TransmitPkts = &intfOutStat.transmitPkts[i]
+			queue := intfOutput.GetOrCreateQueues().GetOrCreateQueue(i.queueName(className))
+			queue.GetOrCreateState().DroppedOctets = &intfOutStat.droppedOctets[j]
+			queue.GetOrCreateState().DroppedPkts = &intfOutStat.droppedPkts[j]
+			queue.GetOrCreateState().TransmitOctets = &intfOutStat.transmitOctets[j]
+			queue.GetOrCreateState().TransmitPkts = &intfOutStat.transmitPkts[j]
 		}
 	}
-	for intfName, intfInStat := range intfInStats {
-		if !allEqual(len(intfInStat.className), len(intfInStat.matchedOctets), len(intfInStat.matchedPkts)) {
-			return nil, fmt.Errorf("mismatch len for interface %s input queue stats class", intfName)
+	for _, intfName := range slices.Sorted(maps.Keys(intfOutStats)) {
+		if intfOutStats[intfName].bundle != "" {
+			i.updateMember(target, intfName, intfOutStats[intfName], impacted)
 		}
+	}
+	for intfName, intfInStat := range intfInStats {
 		intfinput := qosRoot.GetOrCreateQos().GetOrCreateInterfaces().GetOrCreateInterface(intfName).GetOrCreateInput()
-		for i, className := range intfInStat.className {
+		for j, className := range intfInStat.className {
 			nameParts := strings.Split(className, "-")
 			if len(nameParts) < 2 {
 				log.Warningf("wrong className %s does not have any parts separated by -", className)
@@ -348,22 +797,42 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 			switch nameParts[0] {
 			case "inet6":
 				classifier := intfinput.GetOrCreateClassifiers().GetOrCreateClassifier(ocqos.OpenconfigQos_Qos_Interfaces_Interface_Input_Classifiers_Classifier_Config_Type_IPV6)
-				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedOctets = &intfInStat.matchedOctets[i]
-				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedPackets = &intfInStat.matchedPkts[i]
+				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedOctets = &intfInStat.matchedOctets[j]
+				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedPackets = &intfInStat.matchedPkts[j]
 			case "inet":
 				classifier := intfinput.GetOrCreateClassifiers().GetOrCreateClassifier(ocqos.OpenconfigQos_Qos_Interfaces_Interface_Input_Classifiers_Classifier_Config_Type_IPV4)
-				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedOctets = &intfInStat.matchedOctets[i]
-				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedPackets = &intfInStat.matchedPkts[i]
+				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedOctets = &intfInStat.matchedOctets[j]
+				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedPackets = &intfInStat.matchedPkts[j]
 			case "exp":
 				classifier := intfinput.GetOrCreateClassifiers().GetOrCreateClassifier(ocqos.OpenconfigQos_Qos_Interfaces_Interface_Input_Classifiers_Classifier_Config_Type_MPLS)
-				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedOctets = &intfInStat.matchedOctets[i]
-				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedPackets = &intfInStat.matchedPkts[i]
+				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedOctets = &intfInStat.matchedOctets[j]
+				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedPackets = &intfInStat.matchedPkts[j]
 			default:
 				classifier := intfinput.GetOrCreateClassifiers().GetOrCreateClassifier(ocqos.OpenconfigQos_Qos_Interfaces_Interface_Input_Classifiers_Classifier_Config_Type_UNSET)
-				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedOctets = &intfInStat.matchedOctets[i]
-				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedPackets = &intfInStat.matchedPkts[i]
+				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedOctets = &intfInStat.matchedOctets[j]
+				classifier.GetOrCreateTerms().GetOrCreateTerm(nameParts[len(nameParts)-1]).GetOrCreateState().MatchedPackets = &intfInStat.matchedPkts[j]
 			}
 		}
 	}
-	return ftutilities.FilterStructToState(qosRoot, n.GetTimestamp(), "openconfig", n.GetPrefix().GetTarget())
+	for _, bundle := range slices.Sorted(maps.Keys(impacted)) {
+		if del := i.aggregate(qosRoot, target, bundle); del != nil {
+			deletes = append(deletes, del)
+		}
+	}
+	out, err := ftutilities.FilterStructToState(qosRoot, n.GetTimestamp(), "openconfig", target)
+	if err != nil || len(deletes) == 0 {
+		return out, err
+	}
+	if out == nil {
+		out = &gnmipb.SubscribeResponse{
+			Response: &gnmipb.SubscribeResponse_Update{
+				Update: &gnmipb.Notification{
+					Timestamp: n.GetTimestamp(),
+					Prefix:    &gnmipb.Path{Origin: "openconfig", Target: target},
+				},
+			},
+		}
+	}
+	out.GetUpdate().Delete = deletes
+	return out, nil
 }
@@ -15,10 +15,13 @@
 package ciscoxrqos
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/fttest"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
 )
@@ -997,6 +1000,82 @@ func TestTranslate(t *testing.T) {
 							},
 						},
 					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "qos"},
+								{Name: "interfaces"},
+								{Name: "interface", Key: map[string]string{"interface-id": "Bundle-Ether1"}},
+								{Name: "output"},
+								{Name: "queues"},
+								{Name: "queue", Key: map[string]string{"name": "inet-mplsogre-classifier-nc1"}},
+								{Name: "state"},
+								{Name: "dropped-octets"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_UintVal{
+								UintVal: 200,
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "qos"},
+								{Name: "interfaces"},
+								{Name: "interface", Key: map[string]string{"interface-id": "Bundle-Ether1"}},
+								{Name: "output"},
+								{Name: "queues"},
+								{Name: "queue", Key: map[string]string{"name": "inet-mplsogre-classifier-nc1"}},
+								{Name: "state"},
+								{Name: "dropped-pkts"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_UintVal{
+								UintVal: 20,
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "qos"},
+								{Name: "interfaces"},
+								{Name: "interface", Key: map[string]string{"interface-id": "Bundle-Ether1"}},
+								{Name: "output"},
+								{Name: "queues"},
+								{Name: "queue", Key: map[string]string{"name": "inet-mplsogre-classifier-nc1"}},
+								{Name: "state"},
+								{Name: "transmit-octets"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_UintVal{
+								UintVal: 100,
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "qos"},
+								{Name: "interfaces"},
+								{Name: "interface", Key: map[string]string{"interface-id": "Bundle-Ether1"}},
+								{Name: "output"},
+								{Name: "queues"},
+								{Name: "queue", Key: map[string]string{"name": "inet-mplsogre-classifier-nc1"}},
+								{Name: "state"},
+								{Name: "transmit-pkts"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_UintVal{
+								UintVal: 10,
+							},
+						},
+					},
 				},
 			},
 		},
@@ -1089,6 +1168,124 @@ func TestTranslate(t *testing.T) {
 			},
 		},
 	}
+	successMemberInputSR := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 123,
+				Prefix: &gnmipb.Path{
+					Origin: "Cisco-IOS-XR-qos-ma-oper",
+					Elem: []*gnmipb.PathElem{
+						{Name: "qos"},
+						{Name: "interface-table"},
+						{Name: "interface", Key: map[string]string{"interface-name": "Bundle-Ether1"}},
+						{Name: "member-interfaces"},
+						{Name: "member-interface", Key: map[string]string{"interface-name": "FourHundredGigE0/0/0/2"}},
+						{Name: "input"},
+						{Name: "service-policy-names"},
+						{Name: "service-policy-instance", Key: map[string]string{"service-policy-name": "INGRESS_POLICY"}},
+						{Name: "statistics"},
+					},
+				},
+				Update: []*gnmipb.Update{
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "class-stats"},
+								{Name: "class-name"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "inet-mplsogre-classifier-nc1",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "class-stats"},
+								{Name: "general-stats"},
+								{Name: "pre-policy-matched-bytes"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_UintVal{
+								UintVal: 300,
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "class-stats"},
+								{Name: "general-stats"},
+								{Name: "pre-policy-matched-packets"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_UintVal{
+								UintVal: 30,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	successMemberInputOutput := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 123,
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+				},
+				Update: []*gnmipb.Update{
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "qos"},
+								{Name: "interfaces"},
+								{Name: "interface", Key: map[string]string{"interface-id": "FourHundredGigE0/0/0/2"}},
+								{Name: "input"},
+								{Name: "classifiers"},
+								{Name: "classifier", Key: map[string]string{"type": "IPV4"}},
+								{Name: "terms"},
+								{Name: "term", Key: map[string]string{"id": "nc1"}},
+								{Name: "state"},
+								{Name: "matched-octets"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_UintVal{
+								UintVal: 300,
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "qos"},
+								{Name: "interfaces"},
+								{Name: "interface", Key: map[string]string{"interface-id": "FourHundredGigE0/0/0/2"}},
+								{Name: "input"},
+								{Name: "classifiers"},
+								{Name: "classifier", Key: map[string]string{"type": "IPV4"}},
+								{Name: "terms"},
+								{Name: "term", Key: map[string]string{"id": "nc1"}},
+								{Name: "state"},
+								{Name: "matched-packets"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_UintVal{
+								UintVal: 30,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
 	tests := []struct {
 		name    string
 		input   *gnmipb.SubscribeResponse
@@ -1106,14 +1303,19 @@ func TestTranslate(t *testing.T) {
 			want:  successMemberOutput,
 		},
 		{
-			name:    "mismatch_stats_len_for_output_qos",
-			input:   mismatchOpLenSR,
-			wantErr: true,
+			name:  "success_member_interface_input",
+			input: successMemberInputSR,
+			want:  successMemberInputOutput,
 		},
 		{
-			name:    "mismatch_stats_len_for_input_qos",
-			input:   mismatchInLenSR,
-			wantErr: true,
+			name:  "incomplete_output_stats_pending",
+			input: mismatchOpLenSR,
+			want:  nil,
+		},
+		{
+			name:  "incomplete_input_stats_pending",
+			input: mismatchInLenSR,
+			want:  nil,
 		},
 		{
 			name:  "class_name_does_not_have_any_parts_separated_by_",
@@ -1152,3 +1354,463 @@ func TestTranslate(t *testing.T) {
 		})
 	}
 }
+
+func FuzzTranslate(f *testing.F) {
+	seeds, err := ftutilities.LoadSubscribeResponses("testdata/fuzz_seeds.txt")
+	if err != nil {
+		f.Fatalf("Failed to load fuzz seeds: %v", err)
+	}
+	fttest.Fuzz(f, New(), seeds...)
+}
+
+func memberOutputPrefix(bundle, member string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Origin: "Cisco-IOS-XR-qos-ma-oper",
+		Target: "dut",
+		Elem: []*gnmipb.PathElem{
+			{Name: "qos"},
+			{Name: "interface-table"},
+			{Name: "interface", Key: map[string]string{"interface-name": bundle}},
+			{Name: "member-interfaces"},
+			{Name: "member-interface", Key: map[string]string{"interface-name": member}},
+		},
+	}
+}
+
+// classStats returns the updates of the output statistics of a class, relative to an interface or
+// a member interface.
+func classStats(className string, transmitBytes, transmitPackets, dropBytes, dropPackets uint64) []*gnmipb.Update {
+	stats := []*gnmipb.PathElem{
+		{Name: "output"},
+		{Name: "service-policy-names"},
+		{Name: "service-policy-instance", Key: map[string]string{"service-policy-name": "EGRESS_POLICY"}},
+		{Name: "statistics"},
+		{Name: "class-stats"},
+	}
+	leaf := func(names ...string) *gnmipb.Path {
+		p := &gnmipb.Path{Elem: slices.Clone(stats)}
+		for _, name := range names {
+			p.Elem = append(p.Elem, &gnmipb.PathElem{Name: name})
+		}
+		return p
+	}
+	uintVal := func(v uint64) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}}
+	}
+	return []*gnmipb.Update{
+		{Path: leaf("class-name"), Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: className}}},
+		{Path: leaf("general-stats", "transmit-bytes"), Val: uintVal(transmitBytes)},
+		{Path: leaf("general-stats", "transmit-packets"), Val: uintVal(transmitPackets)},
+		{Path: leaf("general-stats", "total-drop-bytes"), Val: uintVal(dropBytes)},
+		{Path: leaf("general-stats", "total-drop-packets"), Val: uintVal(dropPackets)},
+	}
+}
+
+// withPrefix prepends the elements to the paths of the updates.
+func withPrefix(updates []*gnmipb.Update, elems ...*gnmipb.PathElem) []*gnmipb.Update {
+	for _, u := range updates {
+		u.Path.Elem = append(slices.Clone(elems), u.GetPath().GetElem()...)
+	}
+	return updates
+}
+
+func qosNotification(prefix *gnmipb.Path, updates []*gnmipb.Update, deletes ...*gnmipb.Path) *gnmipb.SubscribeResponse {
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 123,
+				Prefix:    prefix,
+				Update:    updates,
+				Delete:    deletes,
+			},
+		},
+	}
+}
+
+// queueUpdates returns the translated updates of the counters of an output queue.
+func queueUpdates(intfName, queueName string, transmitOctets, transmitPkts, droppedOctets, droppedPkts uint64) []*gnmipb.Update {
+	leaf := func(name string, v uint64) *gnmipb.Update {
+		return &gnmipb.Update{
+			Path: &gnmipb.Path{
+				Elem: []*gnmipb.PathElem{
+					{Name: "qos"},
+					{Name: "interfaces"},
+					{Name: "interface", Key: map[string]string{"interface-id": intfName}},
+					{Name: "output"},
+					{Name: "queues"},
+					{Name: "queue", Key: map[string]string{"name": queueName}},
+					{Name: "state"},
+					{Name: name},
+				},
+			},
+			Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}},
+		}
+	}
+	return []*gnmipb.Update{
+		leaf("transmit-octets", transmitOctets),
+		leaf("transmit-pkts", transmitPkts),
+		leaf("dropped-octets", droppedOctets),
+		leaf("dropped-pkts", droppedPkts),
+	}
+}
+
+func TestBundleAggregation(t *testing.T) {
+	bundlePrefix := &gnmipb.Path{
+		Origin: "Cisco-IOS-XR-qos-ma-oper",
+		Target: "dut",
+		Elem:   []*gnmipb.PathElem{{Name: "qos"}, {Name: "interface-table"}},
+	}
+	bundleIntf := func(name string) *gnmipb.PathElem {
+		return &gnmipb.PathElem{Name: "interface", Key: map[string]string{"interface-name": name}}
+	}
+	memberIntf := func(name string) *gnmipb.PathElem {
+		return &gnmipb.PathElem{Name: "member-interface", Key: map[string]string{"interface-name": name}}
+	}
+	outPrefix := &gnmipb.Path{Origin: "openconfig", Target: "dut"}
+	aggregateDelete := &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "qos"},
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"interface-id": "Bundle-Ether1"}},
+			{Name: "output"},
+			{Name: "queues"},
+		},
+	}
+	tests := []struct {
+		name  string
+		steps []*gnmipb.SubscribeResponse
+		want  *gnmipb.SubscribeResponse
+	}{
+		{
+			name: "single member",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 100, 10, 200, 20)),
+			},
+			want: qosNotification(outPrefix, slices.Concat(
+				queueUpdates("HundredGigE0/0/0/1", "nc1", 100, 10, 200, 20),
+				queueUpdates("Bundle-Ether1", "nc1", 100, 10, 200, 20),
+			)),
+		},
+		{
+			name: "members summed across notifications",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 100, 10, 200, 20)),
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/2"), classStats("nc1", 50, 5, 0, 0)),
+			},
+			want: qosNotification(outPrefix, slices.Concat(
+				queueUpdates("HundredGigE0/0/0/2", "nc1", 50, 5, 0, 0),
+				queueUpdates("Bundle-Ether1", "nc1", 150, 15, 200, 20),
+			)),
+		},
+		{
+			name: "member update replaces its counters",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 100, 10, 200, 20)),
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/2"), classStats("nc1", 50, 5, 0, 0)),
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 300, 30, 200, 20)),
+			},
+			want: qosNotification(outPrefix, slices.Concat(
+				queueUpdates("HundredGigE0/0/0/1", "nc1", 300, 30, 200, 20),
+				queueUpdates("Bundle-Ether1", "nc1", 350, 35, 200, 20),
+			)),
+		},
+		{
+			name: "member moved to another bundle",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 100, 10, 200, 20)),
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/2"), classStats("nc1", 50, 5, 0, 0)),
+				qosNotification(memberOutputPrefix("Bundle-Ether2", "HundredGigE0/0/0/2"), classStats("nc1", 50, 5, 0, 0)),
+			},
+			want: qosNotification(outPrefix, slices.Concat(
+				queueUpdates("HundredGigE0/0/0/2", "nc1", 50, 5, 0, 0),
+				queueUpdates("Bundle-Ether1", "nc1", 100, 10, 200, 20),
+				queueUpdates("Bundle-Ether2", "nc1", 50, 5, 0, 0),
+			)),
+		},
+		{
+			name: "bundle statistics not aggregated",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(bundlePrefix, slices.Concat(
+					withPrefix(classStats("nc1", 1000, 100, 0, 0), bundleIntf("Bundle-Ether1")),
+					withPrefix(classStats("nc1", 100, 10, 200, 20), bundleIntf("Bundle-Ether1"), &gnmipb.PathElem{Name: "member-interfaces"}, memberIntf("HundredGigE0/0/0/1")),
+					withPrefix(classStats("nc2", 7, 1, 0, 0), bundleIntf("Bundle-Ether1"), &gnmipb.PathElem{Name: "member-interfaces"}, memberIntf("HundredGigE0/0/0/1")),
+				)),
+			},
+			want: qosNotification(outPrefix, slices.Concat(
+				queueUpdates("Bundle-Ether1", "nc1", 1000, 100, 0, 0),
+				queueUpdates("HundredGigE0/0/0/1", "nc1", 100, 10, 200, 20),
+				queueUpdates("HundredGigE0/0/0/1", "nc2", 7, 1, 0, 0),
+			)),
+		},
+		{
+			name: "member after bundle statistics",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(bundlePrefix, withPrefix(classStats("nc1", 1000, 100, 0, 0), bundleIntf("Bundle-Ether1"))),
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 100, 10, 200, 20)),
+			},
+			want: qosNotification(outPrefix, queueUpdates("HundredGigE0/0/0/1", "nc1", 100, 10, 200, 20)),
+		},
+		{
+			name: "bundle statistics after members",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 100, 10, 200, 20)),
+				qosNotification(bundlePrefix, withPrefix(classStats("nc1", 1000, 100, 0, 0), bundleIntf("Bundle-Ether1"))),
+			},
+			// The aggregated queues are replaced by the statistics of the bundle.
+			want: qosNotification(outPrefix, queueUpdates("Bundle-Ether1", "nc1", 1000, 100, 0, 0), aggregateDelete),
+		},
+		{
+			name: "member update after bundle statistics",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 100, 10, 200, 20)),
+				qosNotification(bundlePrefix, withPrefix(classStats("nc1", 1000, 100, 0, 0), bundleIntf("Bundle-Ether1"))),
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 300, 30, 200, 20)),
+			},
+			want: qosNotification(outPrefix, queueUpdates("HundredGigE0/0/0/1", "nc1", 300, 30, 200, 20)),
+		},
+		{
+			name: "last member of a bundle with statistics deleted",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(bundlePrefix, withPrefix(classStats("nc1", 1000, 100, 0, 0), bundleIntf("Bundle-Ether1"))),
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 100, 10, 200, 20)),
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), nil, &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "output"}}}),
+			},
+			want: nil,
+		},
+		{
+			name: "member of a recreated bundle without statistics",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(bundlePrefix, withPrefix(classStats("nc1", 1000, 100, 0, 0), bundleIntf("Bundle-Ether1"))),
+				qosNotification(bundlePrefix, nil, &gnmipb.Path{Elem: []*gnmipb.PathElem{bundleIntf("Bundle-Ether1")}}),
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 100, 10, 200, 20)),
+			},
+			want: qosNotification(outPrefix, slices.Concat(
+				queueUpdates("HundredGigE0/0/0/1", "nc1", 100, 10, 200, 20),
+				queueUpdates("Bundle-Ether1", "nc1", 100, 10, 200, 20),
+			)),
+		},
+		{
+			name: "member deleted",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 100, 10, 200, 20)),
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/2"), classStats("nc1", 50, 5, 0, 0)),
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/2"), nil, &gnmipb.Path{}),
+			},
+			want: qosNotification(outPrefix, queueUpdates("Bundle-Ether1", "nc1", 100, 10, 200, 20)),
+		},
+		{
+			name: "last member deleted",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 100, 10, 200, 20)),
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), nil, &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "output"}}}),
+			},
+			want: qosNotification(outPrefix, nil, aggregateDelete),
+		},
+		{
+			name: "bundle deleted",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 100, 10, 200, 20)),
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/2"), classStats("nc1", 50, 5, 0, 0)),
+				qosNotification(bundlePrefix, nil, &gnmipb.Path{Elem: []*gnmipb.PathElem{bundleIntf("Bundle-Ether1")}}),
+			},
+			want: qosNotification(outPrefix, nil, aggregateDelete),
+		},
+		{
+			name: "unknown member deleted",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 100, 10, 200, 20)),
+				qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/2"), nil, &gnmipb.Path{}),
+			},
+			want: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ft := New()
+			var got *gnmipb.SubscribeResponse
+			for _, step := range test.steps {
+				var err error
+				if got, err = ft.Translate(step); err != nil {
+					t.Fatalf("Translate() returned an unexpected error: %v", err)
+				}
+			}
+			if diff := cmp.Diff(test.want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update")); diff != "" {
+				t.Errorf("Translate() returned an unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFragmentedStats(t *testing.T) {
+	prefix := memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1")
+	// names and counters split the statistics of the classes after their class names.
+	names := func(classNames ...string) []*gnmipb.Update {
+		var updates []*gnmipb.Update
+		for _, c := range classNames {
+			updates = append(updates, classStats(c, 0, 0, 0, 0)[0])
+		}
+		return updates
+	}
+	counters := func(transmitBytes, transmitPackets, dropBytes, dropPackets uint64) []*gnmipb.Update {
+		return classStats("", transmitBytes, transmitPackets, dropBytes, dropPackets)[1:]
+	}
+	outPrefix := &gnmipb.Path{Origin: "openconfig", Target: "dut"}
+	tests := []struct {
+		name  string
+		steps []*gnmipb.SubscribeResponse
+		reset bool
+		want  *gnmipb.SubscribeResponse
+	}{
+		{
+			name: "class names before counters",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(prefix, names("nc1", "af1")),
+				qosNotification(prefix, slices.Concat(counters(100, 10, 200, 20), counters(50, 5, 0, 0))),
+			},
+			want: qosNotification(outPrefix, slices.Concat(
+				queueUpdates("HundredGigE0/0/0/1", "nc1", 100, 10, 200, 20),
+				queueUpdates("HundredGigE0/0/0/1", "af1", 50, 5, 0, 0),
+				queueUpdates("Bundle-Ether1", "nc1", 100, 10, 200, 20),
+				queueUpdates("Bundle-Ether1", "af1", 50, 5, 0, 0),
+			)),
+		},
+		{
+			name: "split across three notifications",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(prefix, slices.Concat(names("nc1", "af1"), counters(100, 10, 200, 20))),
+				qosNotification(prefix, counters(50, 5, 0, 0)[:2]),
+				qosNotification(prefix, counters(50, 5, 0, 0)[2:]),
+			},
+			want: qosNotification(outPrefix, slices.Concat(
+				queueUpdates("HundredGigE0/0/0/1", "nc1", 100, 10, 200, 20),
+				queueUpdates("HundredGigE0/0/0/1", "af1", 50, 5, 0, 0),
+				queueUpdates("Bundle-Ether1", "nc1", 100, 10, 200, 20),
+				queueUpdates("Bundle-Ether1", "af1", 50, 5, 0, 0),
+			)),
+		},
+		{
+			name: "complete statistics replace the pending fragments",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(prefix, names("af1")),
+				qosNotification(prefix, classStats("nc1", 100, 10, 200, 20)),
+				qosNotification(prefix, counters(50, 5, 0, 0)),
+			},
+			want: nil,
+		},
+		{
+			name: "target disconnected",
+			steps: []*gnmipb.SubscribeResponse{
+				qosNotification(prefix, names("nc1")),
+				qosNotification(prefix, counters(100, 10, 200, 20)),
+			},
+			reset: true,
+			want:  nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ft := New()
+			var got *gnmipb.SubscribeResponse
+			for j, step := range test.steps {
+				if test.reset && j == len(test.steps)-1 {
+					ft.OnTargetDisconnect("dut")
+				}
+				var err error
+				if got, err = ft.Translate(step); err != nil {
+					t.Fatalf("Translate() returned an unexpected error: %v", err)
+				}
+			}
+			if diff := cmp.Diff(test.want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update")); diff != "" {
+				t.Errorf("Translate() returned an unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIntCounters(t *testing.T) {
+	// Some software trains report the counters as int values.
+	updates := classStats("nc1", 100, 10, 200, 20)
+	for _, u := range updates[1:] {
+		u.Val = &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: int64(u.GetVal().GetUintVal())}}
+	}
+	got, err := New().Translate(qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), updates))
+	if err != nil {
+		t.Fatalf("Translate() returned an unexpected error: %v", err)
+	}
+	want := qosNotification(&gnmipb.Path{Origin: "openconfig", Target: "dut"}, slices.Concat(
+		queueUpdates("HundredGigE0/0/0/1", "nc1", 100, 10, 200, 20),
+		queueUpdates("Bundle-Ether1", "nc1", 100, 10, 200, 20),
+	))
+	if diff := cmp.Diff(want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update")); diff != "" {
+		t.Errorf("Translate() returned an unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestStateRestore(t *testing.T) {
+	ft := New()
+	if _, err := ft.Translate(qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), classStats("nc1", 100, 10, 200, 20))); err != nil {
+		t.Fatalf("Translate() returned an unexpected error: %v", err)
+	}
+	snapshot := ft.State()
+	ft.Reset()
+	if err := ft.RestoreState(snapshot); err != nil {
+		t.Fatalf("RestoreState() returned an unexpected error: %v", err)
+	}
+	got, err := ft.Translate(qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/2"), classStats("nc1", 50, 5, 0, 0)))
+	if err != nil {
+		t.Fatalf("Translate() returned an unexpected error: %v", err)
+	}
+	want := qosNotification(&gnmipb.Path{Origin: "openconfig", Target: "dut"}, slices.Concat(
+		queueUpdates("HundredGigE0/0/0/2", "nc1", 50, 5, 0, 0),
+		queueUpdates("Bundle-Ether1", "nc1", 150, 15, 200, 20),
+	))
+	if diff := cmp.Diff(want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update")); diff != "" {
+		t.Errorf("Translate() after RestoreState() returned an unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewWithOptions(t *testing.T) {
+	ft, err := NewWithOptions(QosOptions{QueueNames: map[string]string{"nc1": "NC1", "af1": "AF1"}})
+	if err != nil {
+		t.Fatalf("NewWithOptions() returned an unexpected error: %v", err)
+	}
+	got, err := ft.Translate(qosNotification(memberOutputPrefix("Bundle-Ether1", "HundredGigE0/0/0/1"), slices.Concat(
+		classStats("policy:nc1", 100, 10, 200, 20),
+		classStats("be1", 50, 5, 0, 0),
+	)))
+	if err != nil {
+		t.Fatalf("Translate() returned an unexpected error: %v", err)
+	}
+	want := qosNotification(&gnmipb.Path{Origin: "openconfig", Target: "dut"}, slices.Concat(
+		queueUpdates("HundredGigE0/0/0/1", "NC1", 100, 10, 200, 20),
+		queueUpdates("HundredGigE0/0/0/1", "be1", 50, 5, 0, 0),
+		queueUpdates("Bundle-Ether1", "NC1", 100, 10, 200, 20),
+		queueUpdates("Bundle-Ether1", "be1", 50, 5, 0, 0),
+	))
+	if diff := cmp.Diff(want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update")); diff != "" {
+		t.Errorf("Translate() returned an unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewWithOptionsErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		queueNames map[string]string
+	}{
+		{
+			name:       "empty queue name",
+			queueNames: map[string]string{"nc1": ""},
+		},
+		{
+			name:       "classes mapped to the same queue",
+			queueNames: map[string]string{"nc1": "NC", "nc2": "NC"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := NewWithOptions(QosOptions{QueueNames: test.queueNames}); err == nil {
+				t.Errorf("NewWithOptions(%v) returned nil error, want error", test.queueNames)
+			}
+		})
+	}
+}
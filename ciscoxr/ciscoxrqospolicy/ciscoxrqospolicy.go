@@ -0,0 +1,240 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ciscoxrqospolicy translates the service-policy attached to each ciscoxr interface and
+// direction to the openconfig scheduler-policy name, so that the counters translated by
+// ciscoxrqos can be correlated with the policy actually applied.
+package ciscoxrqospolicy
+
+import (
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	directionInput  = "input"
+	directionOutput = "output"
+)
+
+var (
+	translateMap = map[string][]string{
+		"/openconfig/qos/interfaces/interface/input/scheduler-policy/state/name": {
+			"/Cisco-IOS-XR-qos-ma-oper/qos/interface-table/interface/input/service-policy-names/service-policy-instance/statistics/policy-name",
+		},
+		"/openconfig/qos/interfaces/interface/output/scheduler-policy/state/name": {
+			"/Cisco-IOS-XR-qos-ma-oper/qos/interface-table/interface/output/service-policy-names/service-policy-instance/statistics/policy-name",
+			"/Cisco-IOS-XR-qos-ma-oper/qos/interface-table/interface/member-interfaces/member-interface/output/service-policy-names/service-policy-instance/statistics/policy-name",
+		},
+	}
+	paths       = ftutilities.MustStringMapPaths(translateMap)
+	nativePaths = []*gnmipb.Path{
+		{
+			Origin: "Cisco-IOS-XR-qos-ma-oper",
+			Elem: []*gnmipb.PathElem{
+				{Name: "qos"}, {Name: "interface-table"}, {Name: "interface"}, {Name: "*"},
+				{Name: "service-policy-names"}, {Name: "service-policy-instance"},
+				{Name: "statistics"}, {Name: "policy-name"},
+			},
+		},
+		{
+			Origin: "Cisco-IOS-XR-qos-ma-oper",
+			Elem: []*gnmipb.PathElem{
+				{Name: "qos"}, {Name: "interface-table"}, {Name: "interface"}, {Name: "member-interfaces"},
+				{Name: "member-interface"}, {Name: "*"}, {Name: "service-policy-names"}, {Name: "service-policy-instance"},
+				{Name: "statistics"}, {Name: "policy-name"},
+			},
+		},
+	}
+	interfaceTablePrefix = []string{"qos", "interface-table", "interface"}
+)
+
+// attachment identifies an interface and the direction a policy is attached in.
+type attachment struct {
+	intfName  string
+	direction string
+}
+
+// parseAttachment extracts the interface, named with the bundle name format, and direction from a
+// native qos path. The direction is empty if the path does not reach the input or output container.
+func parseAttachment(path *gnmipb.Path, bundleNameFormat string) (attachment, bool) {
+	elems := path.GetElem()
+	if len(elems) < len(interfaceTablePrefix) {
+		return attachment{}, false
+	}
+	for i, name := range interfaceTablePrefix {
+		if elems[i].GetName() != name {
+			return attachment{}, false
+		}
+	}
+	a := attachment{intfName: ftutilities.CiscoXRBundleName(elems[2].GetKey()["interface-name"], bundleNameFormat)}
+	dirIndex := 3
+	if len(elems) > 3 && elems[3].GetName() == "member-interfaces" {
+		if len(elems) < 5 {
+			// The delete covers the whole member list, which is not tied to a single interface.
+			return attachment{}, false
+		}
+		a.intfName = ftutilities.CiscoXRBundleName(elems[4].GetKey()["interface-name"], bundleNameFormat)
+		dirIndex = 5
+	}
+	if a.intfName == "" {
+		return attachment{}, false
+	}
+	if len(elems) > dirIndex {
+		a.direction = elems[dirIndex].GetName()
+		if a.direction != directionInput && a.direction != directionOutput {
+			return attachment{}, false
+		}
+	}
+	return a, true
+}
+
+func schedulerPolicyNamePath(intfName, direction string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "qos"},
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"interface-id": intfName}},
+			{Name: direction},
+			{Name: "scheduler-policy"},
+			{Name: "state"},
+			{Name: "name"},
+		},
+	}
+}
+
+// buildUpdates returns the scheduler-policy name updates for the policy-name leaves in the
+// notification.
+func buildUpdates(prefix *gnmipb.Path, leaves []*gnmipb.Update, bundleNameFormat string) []*gnmipb.Update {
+	var updates []*gnmipb.Update
+	for _, leaf := range leaves {
+		path := ftutilities.Join(prefix, leaf.GetPath())
+		if !ftutilities.PathInList(path, nativePaths) {
+			continue
+		}
+		a, ok := parseAttachment(path, bundleNameFormat)
+		if !ok || a.direction == "" {
+			continue
+		}
+		elems := path.GetElem()
+		policyName := leaf.GetVal().GetStringVal()
+		if policyName == "" {
+			// Fall back to the list key when the leaf is not populated.
+			policyName = elems[len(elems)-3].GetKey()["service-policy-name"]
+		}
+		if policyName == "" {
+			log.Warningf("No policy name found for interface %s direction %s", a.intfName, a.direction)
+			continue
+		}
+		updates = append(updates, &gnmipb.Update{
+			Path: schedulerPolicyNamePath(a.intfName, a.direction),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: policyName}},
+		})
+	}
+	return updates
+}
+
+// buildDeletes returns the scheduler-policy name deletes for the native deletes in the
+// notification. A delete above the direction container removes the policy for both directions.
+func buildDeletes(prefix *gnmipb.Path, deletes []*gnmipb.Path, bundleNameFormat string) []*gnmipb.Path {
+	var outDeletes []*gnmipb.Path
+	for _, d := range deletes {
+		a, ok := parseAttachment(ftutilities.Join(prefix, d), bundleNameFormat)
+		if !ok {
+			continue
+		}
+		switch a.direction {
+		case "":
+			outDeletes = append(outDeletes,
+				schedulerPolicyNamePath(a.intfName, directionInput),
+				schedulerPolicyNamePath(a.intfName, directionOutput))
+		default:
+			outDeletes = append(outDeletes, schedulerPolicyNamePath(a.intfName, a.direction))
+		}
+	}
+	return outDeletes
+}
+
+// validateOptions rejects an invalid bundle name format.
+func validateOptions(opts translator.Options) error {
+	_, err := ftutilities.CiscoXRBundleNameFormat(opts)
+	return err
+}
+
+func translate(sr *gnmipb.SubscribeResponse, opts translator.Options) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	bundleNameFormat, err := ftutilities.CiscoXRBundleNameFormat(opts)
+	if err != nil {
+		return nil, err
+	}
+	updates := buildUpdates(n.GetPrefix(), n.GetUpdate(), bundleNameFormat)
+	deletes := buildDeletes(n.GetPrefix(), n.GetDelete(), bundleNameFormat)
+	if len(updates) == 0 && len(deletes) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+				Delete: deletes,
+			},
+		},
+	}, nil
+}
+
+func init() {
+	registry.Register(ftconsts.CiscoXRQosPolicyTranslator, NewE)
+}
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco QoS policy functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	ft, err := translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:                   ftconsts.CiscoXRQosPolicyTranslator,
+			TranslateWithOptions: translate,
+			ValidateOptions:      validateOptions,
+			OutputToInputMap:     paths,
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorCiscoXR,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ft, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciscoxrqospolicy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/translator"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name             string
+		bundleNameFormat string
+		inputPath        string
+		wantOutputPath   string
+	}{
+		{
+			name:           "policies_attached",
+			inputPath:      "testdata/policy_attached_input.txt",
+			wantOutputPath: "testdata/policy_attached_output.txt",
+		},
+		{
+			name:             "renamed_bundle",
+			bundleNameFormat: "Port-Channel%d",
+			inputPath:        "testdata/policy_attached_input.txt",
+			wantOutputPath:   "testdata/policy_attached_renamed_output.txt",
+		},
+		{
+			name:           "policies_detached",
+			inputPath:      "testdata/policy_detached_input.txt",
+			wantOutputPath: "testdata/policy_detached_output.txt",
+		},
+		{
+			name:      "unrelated_leaves_are_ignored",
+			inputPath: "testdata/unrelated_input.txt",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			inputSR, err := ftutilities.LoadSubscribeResponse(tc.inputPath)
+			if err != nil {
+				t.Fatalf("failed to load input message: %v", err)
+			}
+			var wantSR *gnmipb.SubscribeResponse
+			if tc.wantOutputPath != "" {
+				wantSR, err = ftutilities.LoadSubscribeResponse(tc.wantOutputPath)
+				if err != nil {
+					t.Fatalf("failed to load want message: %v", err)
+				}
+			}
+			ft := New()
+			if tc.bundleNameFormat != "" {
+				opts := translator.Options{ftutilities.BundleNameFormatOption: tc.bundleNameFormat}
+				if err := ft.SetOptions(opts); err != nil {
+					t.Fatalf("SetOptions(%v) got unexpected error: %v", opts, err)
+				}
+			}
+			gotSR, err := ft.Translate(inputSR)
+			if err != nil {
+				t.Fatalf("Translate() returned unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(wantSR, gotSR, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update", "delete")); diff != "" {
+				t.Errorf("Translate() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInvalidBundleNameFormat(t *testing.T) {
+	opts := translator.Options{ftutilities.BundleNameFormatOption: "ae%s"}
+	if err := New().SetOptions(opts); err == nil {
+		t.Errorf("SetOptions(%v) got no error, want error", opts)
+	}
+}
@@ -0,0 +1,415 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ciscoxrsrtepolicy translates the Cisco XR segment-routing traffic-engineering policy
+// state, candidate paths and binding SID from native to openconfig.
+//
+// Native policies are keyed by an internal id, while openconfig policies are keyed by color and
+// endpoint, so the color and endpoint of each policy are cached per target to translate deletes.
+// The candidate paths of a policy are a list without keys in the native model, so, as for other XR
+// keyless lists, their leaves are matched by order.
+package ciscoxrsrtepolicy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	nativeOrigin = "Cisco-IOS-XR-infra-xtc-agent-oper"
+	// networkInstance is the network instance of the translated policies. XR SR-TE policies are
+	// only instantiated in the default VRF.
+	networkInstance = "DEFAULT"
+	// policyIdx is the index of the policy element in native paths.
+	policyIdx = 2
+)
+
+var (
+	translateMap = map[string][]string{
+		"/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/state/color": {
+			"/Cisco-IOS-XR-infra-xtc-agent-oper/xtc/policies/policy",
+		},
+		"/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/state/endpoint": {
+			"/Cisco-IOS-XR-infra-xtc-agent-oper/xtc/policies/policy",
+		},
+		"/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/state/name": {
+			"/Cisco-IOS-XR-infra-xtc-agent-oper/xtc/policies/policy",
+		},
+		"/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/state/active": {
+			"/Cisco-IOS-XR-infra-xtc-agent-oper/xtc/policies/policy",
+		},
+		"/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/state/bsid": {
+			"/Cisco-IOS-XR-infra-xtc-agent-oper/xtc/policies/policy",
+		},
+		"/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/candidate-paths/candidate-path/state/preference": {
+			"/Cisco-IOS-XR-infra-xtc-agent-oper/xtc/policies/policy",
+		},
+		"/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/candidate-paths/candidate-path/state/valid": {
+			"/Cisco-IOS-XR-infra-xtc-agent-oper/xtc/policies/policy",
+		},
+		"/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/candidate-paths/candidate-path/state/active": {
+			"/Cisco-IOS-XR-infra-xtc-agent-oper/xtc/policies/policy",
+		},
+	}
+	paths = ftutilities.MustStringMapPaths(translateMap)
+	// policyPattern matches a native policy and everything below it.
+	policyPattern = &gnmipb.Path{
+		Origin: nativeOrigin,
+		Elem: []*gnmipb.PathElem{
+			{Name: "xtc"}, {Name: "policies"}, {Name: "policy"},
+		},
+	}
+
+	// policyKeys caches the openconfig key of each native policy id, per target.
+	policyKeysMu sync.Mutex
+	policyKeys   = map[string]map[string]teKey{}
+)
+
+// teKey is the openconfig key of a te-policy.
+type teKey struct {
+	color    uint32
+	endpoint string
+}
+
+// candidatePaths holds the leaves of the candidate paths of a policy, in the order they were
+// received.
+type candidatePaths struct {
+	preference     []uint64
+	protocolOrigin []string
+	originatorID   []string
+	originatorASN  []uint64
+	discriminator  []uint64
+	valid          []bool
+	active         []bool
+}
+
+// policy holds the native leaves of a policy.
+type policy struct {
+	color    *uint32
+	endpoint string
+	name     string
+	active   *bool
+	bsid     *uint64
+	paths    candidatePaths
+}
+
+func init() {
+	registry.Register(ftconsts.CiscoXRSRTEPolicyTranslator, NewE)
+}
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco XR SR-TE policy functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	ft, err := translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:               ftconsts.CiscoXRSRTEPolicyTranslator,
+			Translate:        translate,
+			OutputToInputMap: paths,
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorCiscoXR,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ft, nil
+}
+
+// matchPolicy returns true if path is a native policy path, or a path below it.
+func matchPolicy(path *gnmipb.Path) bool {
+	if path.GetOrigin() != nativeOrigin || len(path.GetElem()) <= policyIdx {
+		return false
+	}
+	trimmed := &gnmipb.Path{Origin: path.GetOrigin(), Elem: path.GetElem()[:policyIdx+1]}
+	return ftutilities.MatchPath(trimmed, policyPattern)
+}
+
+// protocolOrigin returns the openconfig protocol-origin of a native candidate path originator.
+func protocolOrigin(native string) string {
+	switch strings.ToLower(native) {
+	case "pcep", "xtc-policy-cpath-proto-origin-pcep":
+		return "PCEP"
+	case "bgp", "xtc-policy-cpath-proto-origin-bgp":
+		return "BGP"
+	default:
+		return "LOCAL"
+	}
+}
+
+// setLeaf sets the native leaf addressed by elems, relative to the policy, in p. It returns an
+// error for numeric leaves whose values are not unsigned integers.
+func (p *policy) setLeaf(elems []string, val *gnmipb.TypedValue) error {
+	leaf := strings.Join(elems, "/")
+	switch leaf {
+	case "color", "binding-sid/value/label", "paths/preference", "paths/originator-asn", "paths/discriminator":
+		return p.setUintLeaf(leaf, val)
+	case "end-point-address/ipv4", "end-point-address/ipv6":
+		p.endpoint = val.GetStringVal()
+	case "policy-name":
+		p.name = val.GetStringVal()
+	case "operational-up":
+		a := val.GetBoolVal()
+		p.active = &a
+	case "paths/protocol-originator":
+		p.paths.protocolOrigin = append(p.paths.protocolOrigin, protocolOrigin(val.GetStringVal()))
+	case "paths/originator-address":
+		p.paths.originatorID = append(p.paths.originatorID, val.GetStringVal())
+	case "paths/is-valid":
+		p.paths.valid = append(p.paths.valid, val.GetBoolVal())
+	case "paths/is-active":
+		p.paths.active = append(p.paths.active, val.GetBoolVal())
+	}
+	return nil
+}
+
+// setUintLeaf sets the numeric native leaf of setLeaf in p.
+func (p *policy) setUintLeaf(leaf string, val *gnmipb.TypedValue) error {
+	v, err := ftutilities.ToUint64(val)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %v", leaf, err)
+	}
+	switch leaf {
+	case "color":
+		c := uint32(v)
+		p.color = &c
+	case "binding-sid/value/label":
+		p.bsid = &v
+	case "paths/preference":
+		p.paths.preference = append(p.paths.preference, v)
+	case "paths/originator-asn":
+		p.paths.originatorASN = append(p.paths.originatorASN, v)
+	case "paths/discriminator":
+		p.paths.discriminator = append(p.paths.discriminator, v)
+	}
+	return nil
+}
+
+// allEqual returns true if all the numbers are equal.
+func allEqual(nums ...int) bool {
+	for _, n := range nums {
+		if n != nums[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func tePolicyPath(k teKey, elems ...*gnmipb.PathElem) *gnmipb.Path {
+	p := &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "network-instances"},
+			{Name: "network-instance", Key: map[string]string{"name": networkInstance}},
+			{Name: "segment-routing"},
+			{Name: "te-policies"},
+			{
+				Name: "te-policy",
+				Key: map[string]string{
+					"color":    strconv.FormatUint(uint64(k.color), 10),
+					"endpoint": k.endpoint,
+				},
+			},
+		},
+	}
+	p.Elem = append(p.Elem, elems...)
+	return p
+}
+
+func leafElems(names ...string) []*gnmipb.PathElem {
+	var elems []*gnmipb.PathElem
+	for _, n := range names {
+		elems = append(elems, &gnmipb.PathElem{Name: n})
+	}
+	return elems
+}
+
+func uintVal(v uint64) *gnmipb.TypedValue {
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}}
+}
+
+func stringVal(v string) *gnmipb.TypedValue {
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: v}}
+}
+
+func boolVal(v bool) *gnmipb.TypedValue {
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: v}}
+}
+
+// updates returns the openconfig updates of the policy with key k.
+func (p *policy) updates(k teKey) ([]*gnmipb.Update, error) {
+	state := func(leaf string) *gnmipb.Path { return tePolicyPath(k, leafElems("state", leaf)...) }
+	updates := []*gnmipb.Update{
+		{Path: state("color"), Val: uintVal(uint64(k.color))},
+		{Path: state("endpoint"), Val: stringVal(k.endpoint)},
+	}
+	if p.name != "" {
+		updates = append(updates, &gnmipb.Update{Path: state("name"), Val: stringVal(p.name)})
+	}
+	if p.active != nil {
+		updates = append(updates, &gnmipb.Update{Path: state("active"), Val: boolVal(*p.active)})
+	}
+	if p.bsid != nil {
+		updates = append(updates, &gnmipb.Update{Path: state("bsid"), Val: uintVal(*p.bsid)})
+	}
+
+	cp := p.paths
+	if !allEqual(len(cp.preference), len(cp.protocolOrigin), len(cp.originatorID), len(cp.originatorASN), len(cp.discriminator), len(cp.valid), len(cp.active)) {
+		return nil, fmt.Errorf("mismatch len for candidate paths of policy color %d endpoint %s", k.color, k.endpoint)
+	}
+	for i := range cp.preference {
+		cpElems := []*gnmipb.PathElem{
+			{Name: "candidate-paths"},
+			{
+				Name: "candidate-path",
+				Key: map[string]string{
+					"protocol-origin": cp.protocolOrigin[i],
+					"originator-id":   cp.originatorID[i],
+					"originator-asn":  strconv.FormatUint(cp.originatorASN[i], 10),
+					"discriminator":   strconv.FormatUint(cp.discriminator[i], 10),
+				},
+			},
+			{Name: "state"},
+		}
+		cpState := func(leaf string) *gnmipb.Path {
+			return tePolicyPath(k, append(cpElems, &gnmipb.PathElem{Name: leaf})...)
+		}
+		updates = append(updates,
+			&gnmipb.Update{Path: cpState("protocol-origin"), Val: stringVal(cp.protocolOrigin[i])},
+			&gnmipb.Update{Path: cpState("originator-id"), Val: stringVal(cp.originatorID[i])},
+			&gnmipb.Update{Path: cpState("originator-asn"), Val: uintVal(cp.originatorASN[i])},
+			&gnmipb.Update{Path: cpState("discriminator"), Val: uintVal(cp.discriminator[i])},
+			&gnmipb.Update{Path: cpState("preference"), Val: uintVal(cp.preference[i])},
+			&gnmipb.Update{Path: cpState("valid"), Val: boolVal(cp.valid[i])},
+			&gnmipb.Update{Path: cpState("active"), Val: boolVal(cp.active[i])},
+		)
+	}
+	return updates, nil
+}
+
+// targetKeys returns the cached policy keys of the target. policyKeysMu must be held.
+func targetKeys(target string) map[string]teKey {
+	keys, ok := policyKeys[target]
+	if !ok {
+		keys = map[string]teKey{}
+		policyKeys[target] = keys
+	}
+	return keys
+}
+
+func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	policies := map[string]*policy{}
+	var ids []string
+	for _, u := range n.GetUpdate() {
+		path := ftutilities.Join(n.GetPrefix(), u.GetPath())
+		if !matchPolicy(path) {
+			continue
+		}
+		elems := path.GetElem()
+		id := elems[policyIdx].GetKey()["id"]
+		p, ok := policies[id]
+		if !ok {
+			p = &policy{}
+			policies[id] = p
+			ids = append(ids, id)
+		}
+		var names []string
+		for _, e := range elems[policyIdx+1:] {
+			names = append(names, e.GetName())
+		}
+		if err := p.setLeaf(names, u.GetVal()); err != nil {
+			log.Errorf("Failed to translate policy %s of target %s: %v", id, n.GetPrefix().GetTarget(), err)
+		}
+	}
+
+	var (
+		updates []*gnmipb.Update
+		deletes []*gnmipb.Path
+	)
+	policyKeysMu.Lock()
+	defer policyKeysMu.Unlock()
+	keys := targetKeys(n.GetPrefix().GetTarget())
+	for _, d := range n.GetDelete() {
+		path := ftutilities.Join(n.GetPrefix(), d)
+		switch {
+		case matchPolicy(path) && len(path.GetElem()) == policyIdx+1:
+			id := path.GetElem()[policyIdx].GetKey()["id"]
+			if k, ok := keys[id]; ok {
+				deletes = append(deletes, tePolicyPath(k))
+				delete(keys, id)
+			}
+		case path.GetOrigin() == nativeOrigin && len(path.GetElem()) <= policyIdx:
+			// A container of all policies was deleted.
+			for id, k := range keys {
+				deletes = append(deletes, tePolicyPath(k))
+				delete(keys, id)
+			}
+		}
+	}
+	for _, id := range ids {
+		p := policies[id]
+		k, cached := keys[id]
+		if p.color != nil && p.endpoint != "" {
+			k = teKey{color: *p.color, endpoint: p.endpoint}
+		} else if !cached {
+			log.Warningf("Policy %s of target %s has no color and endpoint, skipping", id, n.GetPrefix().GetTarget())
+			continue
+		}
+		ups, err := p.updates(k)
+		if err != nil {
+			return nil, err
+		}
+		keys[id] = k
+		updates = append(updates, ups...)
+	}
+	if len(updates) == 0 && len(deletes) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+				Delete: deletes,
+			},
+		},
+	}, nil
+}
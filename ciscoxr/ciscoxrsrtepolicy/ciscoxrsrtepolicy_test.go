@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciscoxrsrtepolicy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/functional-translators/ftutilities"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// resetCache clears the cached policy keys of all targets.
+func resetCache() {
+	policyKeysMu.Lock()
+	defer policyKeysMu.Unlock()
+	policyKeys = map[string]map[string]teKey{}
+}
+
+// setupCachedPolicy caches the key of policy 1 of target xr1.
+func setupCachedPolicy() {
+	resetCache()
+	policyKeys["xr1"] = map[string]teKey{"1": {color: 100, endpoint: "192.0.2.1"}}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name           string
+		setup          func()
+		inputPath      string
+		wantOutputPath string
+		wantErr        bool
+	}{
+		{
+			name:           "policies_with_candidate_paths",
+			setup:          resetCache,
+			inputPath:      "testdata/policies_input.txt",
+			wantOutputPath: "testdata/policies_output.txt",
+		},
+		{
+			name:           "update_without_color_uses_cached_key",
+			setup:          setupCachedPolicy,
+			inputPath:      "testdata/cached_key_input.txt",
+			wantOutputPath: "testdata/cached_key_output.txt",
+		},
+		{
+			name:           "policy_delete",
+			setup:          setupCachedPolicy,
+			inputPath:      "testdata/policy_delete_input.txt",
+			wantOutputPath: "testdata/policy_delete_output.txt",
+		},
+		{
+			name:      "policy_without_key_is_skipped",
+			setup:     resetCache,
+			inputPath: "testdata/missing_key_input.txt",
+		},
+		{
+			name:      "delete_of_unknown_policy_is_ignored",
+			setup:     resetCache,
+			inputPath: "testdata/policy_delete_input.txt",
+		},
+		{
+			name:      "candidate_path_leaves_mismatch",
+			setup:     resetCache,
+			inputPath: "testdata/candidate_path_mismatch_input.txt",
+			wantErr:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setup()
+			inputSR, err := ftutilities.LoadSubscribeResponse(tc.inputPath)
+			if err != nil {
+				t.Fatalf("failed to load input message: %v", err)
+			}
+			var wantSR *gnmipb.SubscribeResponse
+			if tc.wantOutputPath != "" {
+				wantSR, err = ftutilities.LoadSubscribeResponse(tc.wantOutputPath)
+				if err != nil {
+					t.Fatalf("failed to load want message: %v", err)
+				}
+			}
+			gotSR, err := New().Translate(inputSR)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Translate() returned error %v, want error: %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(wantSR, gotSR, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update", "delete")); diff != "" {
+				t.Errorf("Translate() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
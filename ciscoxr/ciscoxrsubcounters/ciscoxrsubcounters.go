@@ -21,10 +21,11 @@ import (
 	"math"
 	"strings"
 
-	log "github.com/golang/glog"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/ygot/ytypes"
 	"github.com/openconfig/functional-translators/ftconsts"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	xr "github.com/openconfig/functional-translators/ciscoxr/ciscoxrsubcounters/yang/native"
@@ -68,8 +69,21 @@ var (
 	schemaErr error
 )
 
+func init() {
+	registry.Register(ftconsts.CiscoXRSubinterfaceCounterTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco subinterface functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
 			ID:               ftconsts.CiscoXRSubinterfaceCounterTranslator,
@@ -83,13 +97,13 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Cisco subinterface functional translator: %v", err)
+		return nil, err
 	}
 	schema, schemaErr = xr.Schema()
 	if schemaErr != nil {
-		log.Fatalf("Failed to get schema: %v", schemaErr)
+		return nil, fmt.Errorf("failed to get schema: %v", schemaErr)
 	}
-	return ft
+	return ft, nil
 }
 
 func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
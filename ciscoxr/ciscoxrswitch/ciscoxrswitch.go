@@ -0,0 +1,193 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ciscoxrswitch translates the Cisco switch-oper summary statistics of the ports of the
+// internal control-ethernet switches to the CiscoXR vendor state of openconfig components.
+//
+// The ports are not interfaces of the device, so they are modeled as components named
+// "<node-id>:<port>" as the components of the fabric translator, e.g. "0/RP0/CPU0:3", with their
+// state under /components/component/vendor/CiscoXR/switch-port/state. The native leaves are kept
+// as they are, since they have no openconfig equivalent: e.g. the drops and errors are counted
+// together by the switch.
+package ciscoxrswitch
+
+import (
+	"fmt"
+
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	// CiscoXR native summary statistics path of the switch ports.
+	ciscoSummaryStats = "/Cisco-IOS-XR-switch-oper/show-switch/statistics/statistics-instances/statistics-instance/statistics-port-numbers/statistics-port-number/ethsw-summary-stat-info"
+	// OpenConfig vendor state path of the switch port components.
+	ocPortState = "/openconfig/components/component/vendor/CiscoXR/switch-port/state"
+)
+
+var (
+	// stringLeaves are the native string leaves of the ports.
+	stringLeaves = map[string]bool{
+		"port-state":  true,
+		"connects-to": true,
+	}
+	// counterLeaves are the native counters of the ports.
+	counterLeaves = map[string]bool{
+		"rx-packets":         true,
+		"tx-packets":         true,
+		"rx-drops-errors":    true,
+		"tx-drops-errors":    true,
+		"port-state-changes": true,
+	}
+	translateMap   = buildTranslateMap()
+	nativeLeafPath = &gnmipb.Path{
+		Origin: "Cisco-IOS-XR-switch-oper",
+		Elem: []*gnmipb.PathElem{
+			{Name: "show-switch"}, {Name: "statistics"}, {Name: "statistics-instances"},
+			{Name: "statistics-instance"}, {Name: "statistics-port-numbers"}, {Name: "statistics-port-number"},
+			{Name: "ethsw-summary-stat-info"}, {Name: "*"},
+		},
+	}
+)
+
+func buildTranslateMap() map[string][]string {
+	m := map[string][]string{}
+	for leaf := range stringLeaves {
+		m[ocPortState+"/"+leaf] = []string{ciscoSummaryStats + "/" + leaf}
+	}
+	for leaf := range counterLeaves {
+		m[ocPortState+"/"+leaf] = []string{ciscoSummaryStats + "/" + leaf}
+	}
+	return m
+}
+
+// portStatePath returns the path of a vendor state leaf of a switch port component.
+func portStatePath(component, leaf string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": component}},
+			{Name: "vendor"}, {Name: "CiscoXR"}, {Name: "switch-port"}, {Name: "state"}, {Name: leaf},
+		},
+	}
+}
+
+// translateLeaf returns the openconfig update of a native leaf of a port.
+func translateLeaf(component, name string, v *gnmipb.TypedValue) (*gnmipb.Update, error) {
+	switch {
+	case stringLeaves[name]:
+		s, err := ftutilities.ToString(v)
+		if err != nil {
+			return nil, err
+		}
+		return &gnmipb.Update{
+			Path: portStatePath(component, name),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: s}},
+		}, nil
+	case counterLeaves[name]:
+		c, err := ftutilities.ToUint64(v)
+		if err != nil {
+			return nil, err
+		}
+		return &gnmipb.Update{
+			Path: portStatePath(component, name),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: c}},
+		}, nil
+	}
+	return nil, nil
+}
+
+func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	// Silently ignore deletes and paths we don't care about.
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	var updates []*gnmipb.Update
+	for _, leaf := range n.GetUpdate() {
+		path := ftutilities.Join(n.GetPrefix(), leaf.GetPath())
+		if !ftutilities.MatchPath(path, nativeLeafPath) {
+			continue
+		}
+		elems := path.GetElem()
+		node, port := elems[3].GetKey()["node-id"], elems[5].GetKey()["port"]
+		name := elems[7].GetName()
+		if node == "" || port == "" {
+			log.Errorf("Failed to translate %s: no node or port in %v", name, path)
+			continue
+		}
+		component := fmt.Sprintf("%s:%s", node, port)
+		u, err := translateLeaf(component, name, leaf.GetVal())
+		if err != nil {
+			log.Errorf("Failed to translate %s of port %q: %v", name, component, err)
+			continue
+		}
+		if u != nil {
+			updates = append(updates, u)
+		}
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+			},
+		},
+	}, nil
+}
+
+func init() {
+	registry.Register(ftconsts.CiscoXRSwitchTranslator, NewE)
+}
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco switch functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	ft, err := translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:               ftconsts.CiscoXRSwitchTranslator,
+			Translate:        translate,
+			OutputToInputMap: ftutilities.MustStringMapPaths(translateMap),
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorCiscoXR,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ft, nil
+}
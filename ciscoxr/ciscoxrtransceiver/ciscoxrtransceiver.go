@@ -12,16 +12,23 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package ciscoxrtransceiver implements the translation of Cisco transceiver paths.
+// Package ciscoxrtransceiver implements the translation of Cisco transceiver paths. The openconfig
+// component names of the optics ports are cached per target, so that the deletes of the ports,
+// which do not carry the derived optics type the names depend on, are translated.
 package ciscoxrtransceiver
 
 import (
 	"fmt"
+	"maps"
 	"path"
+	"slices"
+	"sync"
 
-	log "github.com/golang/glog"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftstate"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -41,8 +48,29 @@ const (
 	vendorNameSuffix        = "transceiver-info/vendor-name"
 	vendorPartSuffix        = "transceiver-info/optics-vendor-part"
 	vendorRevSuffix         = "transceiver-info/optics-vendor-rev"
+	temperatureSuffix       = "temperature"
+	voltageSuffix           = "voltage"
+	alarmInfoSuffix         = "optics-alarm-info"
+	alarmDetectedLeaf       = "is-detected"
+
+	// Severities of the openconfig thresholds.
+	severityCritical = "CRITICAL"
+	severityWarning  = "WARNING"
 )
 
+// threshold is the openconfig threshold leaf a native threshold leaf is translated to.
+type threshold struct {
+	severity string
+	leaf     string
+	// factor is the number of native units in the openconfig unit.
+	factor float64
+}
+
+// alarmPath returns the native path of the detected flag of an alarm.
+func alarmPath(alarm string) string {
+	return path.Join(ciscoOpticsPrefix, alarmInfoSuffix, alarm, alarmDetectedLeaf)
+}
+
 var (
 	// CiscoXR native paths.
 	ciscoDerivedOpticsType = path.Join(ciscoOpticsPrefix, derivedOpticsTypeSuffix)
@@ -84,6 +112,110 @@ var (
 			ciscoDerivedOpticsType,
 			path.Join(ciscoOpticsPrefix, vendorRevSuffix),
 		},
+		"/openconfig/components/component/state/temperature/instant": {
+			ciscoDerivedOpticsType,
+			path.Join(ciscoOpticsPrefix, temperatureSuffix),
+		},
+		"/openconfig/components/component/transceiver/state/supply-voltage/instant": {
+			ciscoDerivedOpticsType,
+			path.Join(ciscoOpticsPrefix, voltageSuffix),
+		},
+		"/openconfig/components/component/transceiver/thresholds/threshold/vendor/Cisco/alarms/state/input-power-upper": {
+			ciscoDerivedOpticsType,
+			alarmPath("high-rx-power"),
+			alarmPath("high-rx-power-warn"),
+		},
+		"/openconfig/components/component/transceiver/thresholds/threshold/vendor/Cisco/alarms/state/input-power-lower": {
+			ciscoDerivedOpticsType,
+			alarmPath("low-rx-power"),
+			alarmPath("low-rx-power-warn"),
+		},
+		"/openconfig/components/component/transceiver/thresholds/threshold/vendor/Cisco/alarms/state/output-power-upper": {
+			ciscoDerivedOpticsType,
+			alarmPath("high-tx-power"),
+			alarmPath("high-tx-power-warn"),
+		},
+		"/openconfig/components/component/transceiver/thresholds/threshold/vendor/Cisco/alarms/state/output-power-lower": {
+			ciscoDerivedOpticsType,
+			alarmPath("low-tx-power"),
+			alarmPath("low-tx-power-warn"),
+		},
+		"/openconfig/components/component/transceiver/thresholds/threshold/vendor/Cisco/alarms/state/module-temperature-upper": {
+			ciscoDerivedOpticsType,
+			alarmPath("high-temp"),
+			alarmPath("high-temp-warn"),
+		},
+		"/openconfig/components/component/transceiver/thresholds/threshold/vendor/Cisco/alarms/state/module-temperature-lower": {
+			ciscoDerivedOpticsType,
+			alarmPath("low-temp"),
+			alarmPath("low-temp-warn"),
+		},
+		"/openconfig/components/component/transceiver/thresholds/threshold/state/severity": {
+			ciscoDerivedOpticsType,
+			path.Join(ciscoOpticsPrefix, "rx-high-threshold"),
+			path.Join(ciscoOpticsPrefix, "rx-low-threshold"),
+			path.Join(ciscoOpticsPrefix, "tx-high-threshold"),
+			path.Join(ciscoOpticsPrefix, "tx-low-threshold"),
+			path.Join(ciscoOpticsPrefix, "temp-high-threshold"),
+			path.Join(ciscoOpticsPrefix, "temp-low-threshold"),
+			path.Join(ciscoOpticsPrefix, "rx-high-warning-threshold"),
+			path.Join(ciscoOpticsPrefix, "rx-low-warning-threshold"),
+			path.Join(ciscoOpticsPrefix, "tx-high-warning-threshold"),
+			path.Join(ciscoOpticsPrefix, "tx-low-warning-threshold"),
+			path.Join(ciscoOpticsPrefix, "temp-high-warning-threshold"),
+			path.Join(ciscoOpticsPrefix, "temp-low-warning-threshold"),
+			alarmPath("high-rx-power"),
+			alarmPath("low-rx-power"),
+			alarmPath("high-tx-power"),
+			alarmPath("low-tx-power"),
+			alarmPath("high-temp"),
+			alarmPath("low-temp"),
+			alarmPath("high-rx-power-warn"),
+			alarmPath("low-rx-power-warn"),
+			alarmPath("high-tx-power-warn"),
+			alarmPath("low-tx-power-warn"),
+			alarmPath("high-temp-warn"),
+			alarmPath("low-temp-warn"),
+		},
+		"/openconfig/components/component/transceiver/thresholds/threshold/state/input-power-upper": {
+			ciscoDerivedOpticsType,
+			path.Join(ciscoOpticsPrefix, "rx-high-threshold"),
+			path.Join(ciscoOpticsPrefix, "rx-high-warning-threshold"),
+		},
+		"/openconfig/components/component/transceiver/thresholds/threshold/state/input-power-lower": {
+			ciscoDerivedOpticsType,
+			path.Join(ciscoOpticsPrefix, "rx-low-threshold"),
+			path.Join(ciscoOpticsPrefix, "rx-low-warning-threshold"),
+		},
+		"/openconfig/components/component/transceiver/thresholds/threshold/state/output-power-upper": {
+			ciscoDerivedOpticsType,
+			path.Join(ciscoOpticsPrefix, "tx-high-threshold"),
+			path.Join(ciscoOpticsPrefix, "tx-high-warning-threshold"),
+		},
+		"/openconfig/components/component/transceiver/thresholds/threshold/state/output-power-lower": {
+			ciscoDerivedOpticsType,
+			path.Join(ciscoOpticsPrefix, "tx-low-threshold"),
+			path.Join(ciscoOpticsPrefix, "tx-low-warning-threshold"),
+		},
+		"/openconfig/components/component/transceiver/thresholds/threshold/state/module-temperature-upper": {
+			ciscoDerivedOpticsType,
+			path.Join(ciscoOpticsPrefix, "temp-high-threshold"),
+			path.Join(ciscoOpticsPrefix, "temp-high-warning-threshold"),
+		},
+		"/openconfig/components/component/transceiver/thresholds/threshold/state/module-temperature-lower": {
+			ciscoDerivedOpticsType,
+			path.Join(ciscoOpticsPrefix, "temp-low-threshold"),
+			path.Join(ciscoOpticsPrefix, "temp-low-warning-threshold"),
+		},
+	}
+	// opticsPortsPath is the native path of the optics ports container. The deletes of the
+	// container, of a port or of its optics-info container are translated.
+	opticsPortsPath = &gnmipb.Path{
+		Origin: "Cisco-IOS-XR-controller-optics-oper",
+		Elem: []*gnmipb.PathElem{
+			{Name: "optics-oper"},
+			{Name: "optics-ports"},
+		},
 	}
 	expectedOpticsPrefix = &gnmipb.Path{
 		Origin: "Cisco-IOS-XR-controller-optics-oper",
@@ -102,8 +234,10 @@ var (
 	opticsVendorPart          = "optics-vendor-part"
 	opticsVendorRev           = "optics-vendor-rev"
 	receivePower              = "receive-power"
+	temperature               = "temperature"
 	transmitPower             = "transmit-power"
 	vendorName                = "vendor-name"
+	voltage                   = "voltage"
 	expectedLeaves            = map[string]bool{
 		derivedOpticsType:         true,
 		formFactor:                true,
@@ -112,8 +246,43 @@ var (
 		opticsVendorPart:          true,
 		opticsVendorRev:           true,
 		receivePower:              true,
+		temperature:               true,
 		transmitPower:             true,
 		vendorName:                true,
+		voltage:                   true,
+	}
+	// thresholds maps the native threshold leaves to openconfig. The native power thresholds are in
+	// 0.1 dBm and the temperature thresholds in 0.01 degrees Celsius.
+	thresholds = map[string]threshold{
+		"rx-high-threshold":           {severity: severityCritical, leaf: "input-power-upper", factor: 10},
+		"rx-low-threshold":            {severity: severityCritical, leaf: "input-power-lower", factor: 10},
+		"tx-high-threshold":           {severity: severityCritical, leaf: "output-power-upper", factor: 10},
+		"tx-low-threshold":            {severity: severityCritical, leaf: "output-power-lower", factor: 10},
+		"temp-high-threshold":         {severity: severityCritical, leaf: "module-temperature-upper", factor: 100},
+		"temp-low-threshold":          {severity: severityCritical, leaf: "module-temperature-lower", factor: 100},
+		"rx-high-warning-threshold":   {severity: severityWarning, leaf: "input-power-upper", factor: 10},
+		"rx-low-warning-threshold":    {severity: severityWarning, leaf: "input-power-lower", factor: 10},
+		"tx-high-warning-threshold":   {severity: severityWarning, leaf: "output-power-upper", factor: 10},
+		"tx-low-warning-threshold":    {severity: severityWarning, leaf: "output-power-lower", factor: 10},
+		"temp-high-warning-threshold": {severity: severityWarning, leaf: "module-temperature-upper", factor: 100},
+		"temp-low-warning-threshold":  {severity: severityWarning, leaf: "module-temperature-lower", factor: 100},
+	}
+	// alarms maps the native alarms under optics-alarm-info to the thresholds whose crossing they
+	// flag. The detected flags of the alarms are translated to vendor leaves of the thresholds, as
+	// openconfig has no alarm flags for the transceiver thresholds.
+	alarms = map[string]threshold{
+		"high-rx-power":      {severity: severityCritical, leaf: "input-power-upper"},
+		"low-rx-power":       {severity: severityCritical, leaf: "input-power-lower"},
+		"high-tx-power":      {severity: severityCritical, leaf: "output-power-upper"},
+		"low-tx-power":       {severity: severityCritical, leaf: "output-power-lower"},
+		"high-temp":          {severity: severityCritical, leaf: "module-temperature-upper"},
+		"low-temp":           {severity: severityCritical, leaf: "module-temperature-lower"},
+		"high-rx-power-warn": {severity: severityWarning, leaf: "input-power-upper"},
+		"low-rx-power-warn":  {severity: severityWarning, leaf: "input-power-lower"},
+		"high-tx-power-warn": {severity: severityWarning, leaf: "output-power-upper"},
+		"low-tx-power-warn":  {severity: severityWarning, leaf: "output-power-lower"},
+		"high-temp-warn":     {severity: severityWarning, leaf: "module-temperature-upper"},
+		"low-temp-warn":      {severity: severityWarning, leaf: "module-temperature-lower"},
 	}
 )
 
@@ -129,8 +298,28 @@ func hasPrefix(path *gnmipb.Path, prefix *gnmipb.Path) bool {
 	return true
 }
 
+// alarmOf returns the threshold flagged by the native alarm of the path, if the path is the detected
+// flag of a known alarm.
+func alarmOf(path *gnmipb.Path) (threshold, bool) {
+	elems := path.GetElem()
+	n := len(expectedOpticsPrefix.GetElem())
+	if len(elems) != n+3 || elems[n].GetName() != alarmInfoSuffix || elems[n+2].GetName() != alarmDetectedLeaf {
+		return threshold{}, false
+	}
+	a, ok := alarms[elems[n+1].GetName()]
+	return a, ok
+}
+
 func pathExpected(path *gnmipb.Path) bool {
-	return hasPrefix(path, expectedOpticsPrefix) && expectedLeaves[path.GetElem()[len(path.GetElem())-1].GetName()]
+	if !hasPrefix(path, expectedOpticsPrefix) {
+		return false
+	}
+	if _, isAlarm := alarmOf(path); isAlarm {
+		return true
+	}
+	leaf := path.GetElem()[len(path.GetElem())-1].GetName()
+	_, isThreshold := thresholds[leaf]
+	return expectedLeaves[leaf] || isThreshold
 }
 
 func index(componentName, laneID string) *gnmipb.Path {
@@ -242,10 +431,67 @@ func vendorRevPath(componentName string) *gnmipb.Path {
 	}
 }
 
+func thresholdPath(componentName, severity, leaf string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": componentName}},
+			{Name: "transceiver"},
+			{Name: "thresholds"},
+			{Name: "threshold", Key: map[string]string{"severity": severity}},
+			{Name: "state"},
+			{Name: leaf},
+		},
+	}
+}
+
+func alarmFlagPath(componentName, severity, leaf string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": componentName}},
+			{Name: "transceiver"},
+			{Name: "thresholds"},
+			{Name: "threshold", Key: map[string]string{"severity": severity}},
+			{Name: "vendor"},
+			{Name: "Cisco"},
+			{Name: "alarms"},
+			{Name: "state"},
+			{Name: leaf},
+		},
+	}
+}
+
+func temperaturePath(componentName string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": componentName}},
+			{Name: "state"},
+			{Name: "temperature"},
+			{Name: "instant"},
+		},
+	}
+}
+
+func supplyVoltagePath(componentName string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": componentName}},
+			{Name: "transceiver"},
+			{Name: "state"},
+			{Name: "supply-voltage"},
+			{Name: "instant"},
+		},
+	}
+}
+
 type update struct {
 	fullPath   *gnmipb.Path
 	laneIndex  string
 	opticsType string
+	naming     ftutilities.OpticsNaming
 	value      *gnmipb.TypedValue
 }
 
@@ -254,7 +500,7 @@ func (u *update) leaf() string {
 }
 
 func (u *update) componentName() (name string, wanted bool) {
-	return ftutilities.MaybeConvertOptical(u.fullPath.GetElem()[2].GetKey()["name"], u.opticsType)
+	return ftutilities.MaybeConvertOpticalWithNaming(u.fullPath.GetElem()[2].GetKey()["name"], u.opticsType, u.naming)
 }
 
 func (u *update) toOpenConfig() *gnmipb.Update {
@@ -280,9 +526,21 @@ func (u *update) toOpenConfig() *gnmipb.Update {
 		outgoingPath = vendorRevPath(name)
 	case opticsVendorPart:
 		outgoingPath = vendorPartPath(name)
+	case temperature:
+		outgoingPath = temperaturePath(name)
+	case voltage:
+		outgoingPath = supplyVoltagePath(name)
 	default:
-		// This should never happen, as we filter out unexpected paths.
-		return nil
+		if a, isAlarm := alarmOf(u.fullPath); isAlarm {
+			outgoingPath = alarmFlagPath(name, a.severity, a.leaf)
+			break
+		}
+		t, ok := thresholds[u.leaf()]
+		if !ok {
+			// This should never happen, as we filter out unexpected paths.
+			return nil
+		}
+		outgoingPath = thresholdPath(name, t.severity, t.leaf)
 	}
 	return &gnmipb.Update{
 		Path: outgoingPath,
@@ -290,69 +548,226 @@ func (u *update) toOpenConfig() *gnmipb.Update {
 	}
 }
 
-func scaleToDouble(u *gnmipb.Update, factor float64) (*gnmipb.TypedValue, error) {
+// scaleAnalog returns the value of the update divided by factor, as a DoubleVal or a Decimal64
+// with the precision, see ftutilities.AnalogValue.
+func scaleAnalog(u *gnmipb.Update, factor float64, precision uint32) (*gnmipb.TypedValue, error) {
 	switch t := u.GetVal().GetValue().(type) {
 	case *gnmipb.TypedValue_IntVal:
-		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: float64(t.IntVal) / factor}}, nil
+		return ftutilities.AnalogValue(float64(t.IntVal)/factor, precision), nil
 	case *gnmipb.TypedValue_UintVal:
-		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: float64(t.UintVal) / factor}}, nil
+		return ftutilities.AnalogValue(float64(t.UintVal)/factor, precision), nil
 	default:
 		return nil, fmt.Errorf("unexpected value type %T received in update %v", t, u)
 	}
 }
 
-func dbmValue(u *gnmipb.Update) (*gnmipb.TypedValue, error) {
+func dbmValue(u *gnmipb.Update, precision uint32) (*gnmipb.TypedValue, error) {
 	dbmFactor := 100.0
-	return scaleToDouble(u, dbmFactor)
+	return scaleAnalog(u, dbmFactor, precision)
 }
 
-func milliAmpsValue(u *gnmipb.Update) (*gnmipb.TypedValue, error) {
+func milliAmpsValue(u *gnmipb.Update, precision uint32) (*gnmipb.TypedValue, error) {
 	// Native path returns value in units of 0.01mA while OC path expects mA.
 	milliAmpsFactor := 100.0
-	return scaleToDouble(u, milliAmpsFactor)
+	return scaleAnalog(u, milliAmpsFactor, precision)
+}
+
+// boolValue returns the value of a boolean update. The precision of analog values is unused.
+func boolValue(u *gnmipb.Update, _ uint32) (*gnmipb.TypedValue, error) {
+	if _, ok := u.GetVal().GetValue().(*gnmipb.TypedValue_BoolVal); !ok {
+		return nil, fmt.Errorf("unexpected value type %T received in update %v", u.GetVal().GetValue(), u)
+	}
+	return u.GetVal(), nil
+}
+
+func celsiusValue(u *gnmipb.Update, precision uint32) (*gnmipb.TypedValue, error) {
+	// Native path returns value in units of 0.01 degree Celsius while OC path expects degrees.
+	celsiusFactor := 100.0
+	return scaleAnalog(u, celsiusFactor, precision)
+}
+
+func voltsValue(u *gnmipb.Update, precision uint32) (*gnmipb.TypedValue, error) {
+	// Native path returns value in units of 0.01V while OC path expects V.
+	voltsFactor := 100.0
+	return scaleAnalog(u, voltsFactor, precision)
+}
+
+func leafName(p *gnmipb.Path) string {
+	return p.GetElem()[len(p.GetElem())-1].GetName()
+}
+
+// impl holds the openconfig component names of the optics ports of each target, by native port
+// name.
+type impl struct {
+	mu         sync.Mutex
+	components *ftstate.TargetStore[map[string]string]
+}
+
+func (i *impl) reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.components.Reset()
+}
+
+func (i *impl) resetTarget(target string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.components.ResetTarget(target)
+}
+
+func (i *impl) state() any {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.components.Clone(maps.Clone)
+}
+
+func (i *impl) restoreState(snapshot any) error {
+	components, ok := snapshot.(*ftstate.TargetStore[map[string]string])
+	if !ok {
+		return fmt.Errorf("unexpected state type %T", snapshot)
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.components.Restore(components, maps.Clone)
+	return nil
+}
+
+// componentDeletes returns the openconfig paths of the transceiver leaves of a component.
+func componentDeletes(componentName string) []*gnmipb.Path {
+	return []*gnmipb.Path{
+		{
+			Elem: []*gnmipb.PathElem{
+				{Name: "components"},
+				{Name: "component", Key: map[string]string{"name": componentName}},
+				{Name: "transceiver"},
+			},
+		},
+		{
+			Elem: []*gnmipb.PathElem{
+				{Name: "components"},
+				{Name: "component", Key: map[string]string{"name": componentName}},
+				{Name: "state"},
+				{Name: "temperature"},
+			},
+		},
+	}
 }
 
-func isLaneIndex(u *gnmipb.Update) bool {
-	return u.GetPath().GetElem()[len(u.GetPath().GetElem())-1].GetName() == laneIndex
+// deletedPorts returns the sorted native names of the known ports removed by a native delete. A
+// delete of the optics ports container, or of a port without name or with a wildcard name, removes
+// all the ports. Deletes of the leaves of the ports are ignored.
+func deletedPorts(path *gnmipb.Path, components map[string]string) []string {
+	if !hasPrefix(path, opticsPortsPath) {
+		return nil
+	}
+	elems := path.GetElem()
+	switch {
+	case len(elems) == 2:
+	case len(elems) == 3 && elems[2].GetName() == "optics-port":
+	case len(elems) == 4 && elems[2].GetName() == "optics-port" && elems[3].GetName() == "optics-info":
+	default:
+		return nil
+	}
+	if len(elems) > 2 {
+		if name, ok := elems[2].GetKey()["name"]; ok && name != "*" {
+			if _, known := components[name]; !known {
+				return nil
+			}
+			return []string{name}
+		}
+	}
+	return slices.Sorted(maps.Keys(components))
 }
 
-func isOpticsType(u *gnmipb.Update) bool {
-	return u.GetPath().GetElem()[len(u.GetPath().GetElem())-1].GetName() == derivedOpticsType
+// validateOptions rejects an invalid analog precision or optics naming options.
+func validateOptions(opts translator.Options) error {
+	if _, err := ftutilities.AnalogPrecision(opts); err != nil {
+		return err
+	}
+	_, err := ftutilities.NewOpticsNaming(opts)
+	return err
 }
 
-func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
-	// Silently ignore deletes and paths we don't care about.
-	var outgoingUpdates []*gnmipb.Update
+func (i *impl) translate(sr *gnmipb.SubscribeResponse, opts translator.Options) (*gnmipb.SubscribeResponse, error) {
+	precision, err := ftutilities.AnalogPrecision(opts)
+	if err != nil {
+		return nil, err
+	}
+	naming, err := ftutilities.NewOpticsNaming(opts)
+	if err != nil {
+		return nil, err
+	}
+	// Silently ignore paths we don't care about.
+	var (
+		outgoingUpdates []*gnmipb.Update
+		outgoingDeletes []*gnmipb.Path
+	)
 	srPrefix := sr.GetUpdate().GetPrefix()
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	components := i.components.GetOrCreate(srPrefix.GetTarget(), func() map[string]string { return map[string]string{} })
+	// gNMI processes deletes before updates.
+	for _, d := range sr.GetUpdate().GetDelete() {
+		for _, port := range deletedPorts(ftutilities.Join(srPrefix, d), components) {
+			outgoingDeletes = append(outgoingDeletes, componentDeletes(components[port])...)
+			delete(components, port)
+		}
+	}
 	var (
 		extractedLaneValue  string
 		extractedOpticsType string
 	)
+	// severities holds the thresholds whose severity leaf is already translated, by component.
+	severities := map[string]map[string]bool{}
 	for _, u := range sr.GetUpdate().GetUpdate() {
 		fullPath := ftutilities.Join(srPrefix, u.GetPath())
 		if pathExpected(fullPath) {
-			if isOpticsType(u) {
+			if u.GetVal().GetValue() == nil {
+				log.Errorf("Ignoring update %v without value", u)
+				continue
+			}
+			leaf := leafName(fullPath)
+			if leaf == derivedOpticsType {
 				extractedOpticsType = u.GetVal().GetStringVal()
 				continue
 			}
-			if isLaneIndex(u) {
-				ix := fmt.Sprintf("%d", u.GetVal().GetUintVal())
-				extractedLaneValue = ix
+			if leaf == laneIndex {
+				ix, err := ftutilities.ToUint64(u.GetVal())
+				if err != nil {
+					log.Errorf("Ignoring invalid lane index %v: %v", u, err)
+					continue
+				}
+				extractedLaneValue = fmt.Sprintf("%d", ix)
 			}
 			var (
 				v   *gnmipb.TypedValue
 				err error
 			)
 			v = u.GetVal()
-			var converter func(*gnmipb.Update) (*gnmipb.TypedValue, error)
-			switch u.GetPath().GetElem()[len(u.GetPath().GetElem())-1].GetName() {
+			var converter func(*gnmipb.Update, uint32) (*gnmipb.TypedValue, error)
+			switch leaf {
 			case receivePower, transmitPower:
 				converter = dbmValue
 			case laserBiasCurrentMilliAmps:
 				converter = milliAmpsValue
+			case temperature:
+				converter = celsiusValue
+			case voltage:
+				converter = voltsValue
+			}
+			t, isThreshold := thresholds[leaf]
+			if isThreshold {
+				converter = func(u *gnmipb.Update, precision uint32) (*gnmipb.TypedValue, error) {
+					return scaleAnalog(u, t.factor, precision)
+				}
+			}
+			if a, isAlarm := alarmOf(fullPath); isAlarm {
+				// The severity of the threshold is translated with its alarm flag too.
+				t, isThreshold = a, true
+				converter = boolValue
 			}
 			if converter != nil {
-				v, err = converter(u)
+				v, err = converter(u, precision)
 				if err != nil {
 					log.Errorf("Failed to translate update %v: %v", u, err)
 					continue
@@ -362,6 +777,7 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 				fullPath:   fullPath,
 				laneIndex:  extractedLaneValue,
 				opticsType: extractedOpticsType,
+				naming:     naming,
 				value:      v,
 			}
 			// This assumes that we always get the lane index and optics type before we get the power data.
@@ -369,36 +785,73 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 			// lane index leaves.
 			// We also collect the lane index under the assumption that the optics type always comes
 			// before it.
-			if oc := up.toOpenConfig(); oc != nil {
-				outgoingUpdates = append(outgoingUpdates, oc)
+			oc := up.toOpenConfig()
+			if oc == nil {
+				continue
+			}
+			outgoingUpdates = append(outgoingUpdates, oc)
+			name, _ := up.componentName()
+			components[fullPath.GetElem()[2].GetKey()["name"]] = name
+			if isThreshold && !severities[name][t.severity] {
+				if severities[name] == nil {
+					severities[name] = map[string]bool{}
+				}
+				severities[name][t.severity] = true
+				outgoingUpdates = append(outgoingUpdates, &gnmipb.Update{
+					Path: thresholdPath(name, t.severity, "severity"),
+					Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: t.severity}},
+				})
 			}
 		}
 	}
-	if len(outgoingUpdates) == 0 {
+	if len(outgoingUpdates) == 0 && len(outgoingDeletes) == 0 {
 		return nil, nil
 	}
 	outgoingSR := &gnmipb.SubscribeResponse{
 		Response: &gnmipb.SubscribeResponse_Update{
-			Update: &gnmipb.Notification{
+			Update: ftutilities.CompactNotification(&gnmipb.Notification{
 				Prefix: &gnmipb.Path{
 					Origin: "openconfig",
 					Target: srPrefix.GetTarget(),
 				},
 				Update:    outgoingUpdates,
+				Delete:    outgoingDeletes,
 				Timestamp: sr.GetUpdate().GetTimestamp(),
-			},
+			}),
 		},
 	}
 	return outgoingSR, nil // End translate.
 }
 
+func init() {
+	registry.Register(ftconsts.CiscoXRTransceiverTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco transceiver functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	i := &impl{components: ftstate.NewTargetStore[map[string]string](ftstate.Options{})}
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
-			ID:               ftconsts.CiscoXRTransceiverTranslator,
-			Translate:        translate,
-			OutputToInputMap: ftutilities.MustStringMapPaths(translateMap),
+			ID:                   ftconsts.CiscoXRTransceiverTranslator,
+			TranslateWithOptions: i.translate,
+			ValidateOptions:      validateOptions,
+			OutputToInputMap:     ftutilities.MustStringMapPaths(translateMap),
+			State: &translator.StateOptions{
+				Reset:        i.reset,
+				State:        i.state,
+				RestoreState: i.restoreState,
+				Store:        i.components,
+				ResetTarget:  i.resetTarget,
+			},
 			Metadata: []*translator.FTMetadata{
 				{
 					Vendor:          ftconsts.VendorCiscoXR,
@@ -420,7 +873,7 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Cisco transceiver functional translator: %v", err)
+		return nil, err
 	}
-	return ft
+	return ft, nil
 }
@@ -19,6 +19,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/fttest"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
 )
@@ -1471,6 +1473,416 @@ func TestTranslate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Thresholds_Success",
+			input: &gnmipb.SubscribeResponse{
+				Response: &gnmipb.SubscribeResponse_Update{
+					Update: &gnmipb.Notification{
+						Timestamp: 1749043183927000000,
+						Prefix: &gnmipb.Path{
+							Origin: "Cisco-IOS-XR-controller-optics-oper",
+							Elem: []*gnmipb.PathElem{
+								{Name: "optics-oper"},
+								{Name: "optics-ports"},
+								{Name: "optics-port", Key: map[string]string{"name": "Optics0/0/0/0"}},
+								{Name: "optics-info"},
+							},
+							Target: "dx05.sql85-laarz",
+						},
+						Update: []*gnmipb.Update{
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "rx-high-threshold"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: 30}},
+							},
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "rx-low-threshold"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: -150}},
+							},
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "temp-high-warning-threshold"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: 7500}},
+							},
+						},
+					},
+				},
+			},
+			want: &gnmipb.SubscribeResponse{
+				Response: &gnmipb.SubscribeResponse_Update{
+					Update: &gnmipb.Notification{
+						Timestamp: 1749043183927000000,
+						Prefix: &gnmipb.Path{
+							Origin: "openconfig",
+							Target: "dx05.sql85-laarz",
+						},
+						Update: []*gnmipb.Update{
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "components"},
+										{Name: "component", Key: map[string]string{"name": "Optics0/0/0/0"}},
+										{Name: "transceiver"},
+										{Name: "thresholds"},
+										{Name: "threshold", Key: map[string]string{"severity": "CRITICAL"}},
+										{Name: "state"},
+										{Name: "input-power-upper"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: 3}},
+							},
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "components"},
+										{Name: "component", Key: map[string]string{"name": "Optics0/0/0/0"}},
+										{Name: "transceiver"},
+										{Name: "thresholds"},
+										{Name: "threshold", Key: map[string]string{"severity": "CRITICAL"}},
+										{Name: "state"},
+										{Name: "severity"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "CRITICAL"}},
+							},
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "components"},
+										{Name: "component", Key: map[string]string{"name": "Optics0/0/0/0"}},
+										{Name: "transceiver"},
+										{Name: "thresholds"},
+										{Name: "threshold", Key: map[string]string{"severity": "CRITICAL"}},
+										{Name: "state"},
+										{Name: "input-power-lower"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: -15}},
+							},
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "components"},
+										{Name: "component", Key: map[string]string{"name": "Optics0/0/0/0"}},
+										{Name: "transceiver"},
+										{Name: "thresholds"},
+										{Name: "threshold", Key: map[string]string{"severity": "WARNING"}},
+										{Name: "state"},
+										{Name: "module-temperature-upper"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: 75}},
+							},
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "components"},
+										{Name: "component", Key: map[string]string{"name": "Optics0/0/0/0"}},
+										{Name: "transceiver"},
+										{Name: "thresholds"},
+										{Name: "threshold", Key: map[string]string{"severity": "WARNING"}},
+										{Name: "state"},
+										{Name: "severity"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "WARNING"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Threshold_InvalidValueType",
+			input: &gnmipb.SubscribeResponse{
+				Response: &gnmipb.SubscribeResponse_Update{
+					Update: &gnmipb.Notification{
+						Timestamp: 1749043183927000000,
+						Prefix: &gnmipb.Path{
+							Origin: "Cisco-IOS-XR-controller-optics-oper",
+							Elem: []*gnmipb.PathElem{
+								{Name: "optics-oper"},
+								{Name: "optics-ports"},
+								{Name: "optics-port", Key: map[string]string{"name": "Optics0/0/0/0"}},
+								{Name: "optics-info"},
+							},
+							Target: "dx05.sql85-laarz",
+						},
+						Update: []*gnmipb.Update{
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "tx-high-threshold"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "3.0"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "TemperatureAndVoltage_Success",
+			input: &gnmipb.SubscribeResponse{
+				Response: &gnmipb.SubscribeResponse_Update{
+					Update: &gnmipb.Notification{
+						Timestamp: 1749043183927000000,
+						Prefix: &gnmipb.Path{
+							Origin: "Cisco-IOS-XR-controller-optics-oper",
+							Elem: []*gnmipb.PathElem{
+								{Name: "optics-oper"},
+								{Name: "optics-ports"},
+								{Name: "optics-port", Key: map[string]string{"name": "Optics0/0/0/0"}},
+								{Name: "optics-info"},
+							},
+							Target: "dx05.sql85-laarz",
+						},
+						Update: []*gnmipb.Update{
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "derived-optics-type"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "400G"}},
+							},
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "temperature"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: 4025}},
+							},
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "voltage"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: 329}},
+							},
+						},
+					},
+				},
+			},
+			want: &gnmipb.SubscribeResponse{
+				Response: &gnmipb.SubscribeResponse_Update{
+					Update: &gnmipb.Notification{
+						Timestamp: 1749043183927000000,
+						Prefix: &gnmipb.Path{
+							Origin: "openconfig",
+							Target: "dx05.sql85-laarz",
+						},
+						Update: []*gnmipb.Update{
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "components"},
+										{Name: "component", Key: map[string]string{"name": "FourHundredGigE0/0/0/0"}},
+										{Name: "state"},
+										{Name: "temperature"},
+										{Name: "instant"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: 40.25}},
+							},
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "components"},
+										{Name: "component", Key: map[string]string{"name": "FourHundredGigE0/0/0/0"}},
+										{Name: "transceiver"},
+										{Name: "state"},
+										{Name: "supply-voltage"},
+										{Name: "instant"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: 3.29}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AlarmFlags_Success",
+			input: &gnmipb.SubscribeResponse{
+				Response: &gnmipb.SubscribeResponse_Update{
+					Update: &gnmipb.Notification{
+						Timestamp: 1749043183927000000,
+						Prefix: &gnmipb.Path{
+							Origin: "Cisco-IOS-XR-controller-optics-oper",
+							Elem: []*gnmipb.PathElem{
+								{Name: "optics-oper"},
+								{Name: "optics-ports"},
+								{Name: "optics-port", Key: map[string]string{"name": "Optics0/0/0/0"}},
+								{Name: "optics-info"},
+							},
+							Target: "dx05.sql85-laarz",
+						},
+						Update: []*gnmipb.Update{
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "derived-optics-type"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "400G"}},
+							},
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "optics-alarm-info"},
+										{Name: "high-rx-power"},
+										{Name: "is-detected"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: true}},
+							},
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "optics-alarm-info"},
+										{Name: "low-temp-warn"},
+										{Name: "is-detected"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: false}},
+							},
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "optics-alarm-info"},
+										{Name: "rx-los"},
+										{Name: "is-detected"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: true}},
+							},
+						},
+					},
+				},
+			},
+			want: &gnmipb.SubscribeResponse{
+				Response: &gnmipb.SubscribeResponse_Update{
+					Update: &gnmipb.Notification{
+						Timestamp: 1749043183927000000,
+						Prefix: &gnmipb.Path{
+							Origin: "openconfig",
+							Target: "dx05.sql85-laarz",
+						},
+						Update: []*gnmipb.Update{
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "components"},
+										{Name: "component", Key: map[string]string{"name": "FourHundredGigE0/0/0/0"}},
+										{Name: "transceiver"},
+										{Name: "thresholds"},
+										{Name: "threshold", Key: map[string]string{"severity": "CRITICAL"}},
+										{Name: "vendor"},
+										{Name: "Cisco"},
+										{Name: "alarms"},
+										{Name: "state"},
+										{Name: "input-power-upper"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: true}},
+							},
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "components"},
+										{Name: "component", Key: map[string]string{"name": "FourHundredGigE0/0/0/0"}},
+										{Name: "transceiver"},
+										{Name: "thresholds"},
+										{Name: "threshold", Key: map[string]string{"severity": "CRITICAL"}},
+										{Name: "state"},
+										{Name: "severity"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "CRITICAL"}},
+							},
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "components"},
+										{Name: "component", Key: map[string]string{"name": "FourHundredGigE0/0/0/0"}},
+										{Name: "transceiver"},
+										{Name: "thresholds"},
+										{Name: "threshold", Key: map[string]string{"severity": "WARNING"}},
+										{Name: "vendor"},
+										{Name: "Cisco"},
+										{Name: "alarms"},
+										{Name: "state"},
+										{Name: "module-temperature-lower"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: false}},
+							},
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "components"},
+										{Name: "component", Key: map[string]string{"name": "FourHundredGigE0/0/0/0"}},
+										{Name: "transceiver"},
+										{Name: "thresholds"},
+										{Name: "threshold", Key: map[string]string{"severity": "WARNING"}},
+										{Name: "state"},
+										{Name: "severity"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "WARNING"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AlarmFlag_InvalidValueType",
+			input: &gnmipb.SubscribeResponse{
+				Response: &gnmipb.SubscribeResponse_Update{
+					Update: &gnmipb.Notification{
+						Timestamp: 1749043183927000000,
+						Prefix: &gnmipb.Path{
+							Origin: "Cisco-IOS-XR-controller-optics-oper",
+							Elem: []*gnmipb.PathElem{
+								{Name: "optics-oper"},
+								{Name: "optics-ports"},
+								{Name: "optics-port", Key: map[string]string{"name": "Optics0/0/0/0"}},
+								{Name: "optics-info"},
+							},
+							Target: "dx05.sql85-laarz",
+						},
+						Update: []*gnmipb.Update{
+							{
+								Path: &gnmipb.Path{
+									Elem: []*gnmipb.PathElem{
+										{Name: "optics-alarm-info"},
+										{Name: "high-tx-power"},
+										{Name: "is-detected"},
+									},
+								},
+								Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 1}},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -1483,9 +1895,170 @@ func TestTranslate(t *testing.T) {
 			if test.wantErr {
 				return
 			}
-			if diff := cmp.Diff(test.want, sr, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update")); diff != "" {
+			if diff := cmp.Diff(compacted(test.want), sr, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update")); diff != "" {
 				t.Fatalf("Unexpected diff from translate() = %v, want %v, diff(-want +got):\n%s", sr, test.want, diff)
 			}
 		})
 	}
 }
+
+// compacted returns the response with its expected fully-expanded paths compacted, as the
+// translator outputs them.
+func compacted(sr *gnmipb.SubscribeResponse) *gnmipb.SubscribeResponse {
+	if sr.GetUpdate() == nil {
+		return sr
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{Update: ftutilities.CompactNotification(sr.GetUpdate())},
+	}
+}
+
+func FuzzTranslate(f *testing.F) {
+	seeds, err := ftutilities.LoadSubscribeResponses("testdata/fuzz_seeds.txt")
+	if err != nil {
+		f.Fatalf("Failed to load fuzz seeds: %v", err)
+	}
+	fttest.Fuzz(f, New(), seeds...)
+}
+
+func opticsPortPath(elems ...*gnmipb.PathElem) *gnmipb.Path {
+	return &gnmipb.Path{
+		Origin: "Cisco-IOS-XR-controller-optics-oper",
+		Elem:   append([]*gnmipb.PathElem{{Name: "optics-oper"}, {Name: "optics-ports"}}, elems...),
+		Target: "dx05.sql85-laarz",
+	}
+}
+
+func opticsTypeUpdate(port, opticsType string) *gnmipb.SubscribeResponse {
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 1749043183927000000,
+				Prefix: opticsPortPath(
+					&gnmipb.PathElem{Name: "optics-port", Key: map[string]string{"name": port}},
+					&gnmipb.PathElem{Name: "optics-info"},
+				),
+				Update: []*gnmipb.Update{
+					{
+						Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "derived-optics-type"}}},
+						Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: opticsType}},
+					},
+					{
+						Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "temperature"}}},
+						Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: 4025}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func opticsDelete(paths ...*gnmipb.Path) *gnmipb.SubscribeResponse {
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 1749043184927000000,
+				Prefix:    opticsPortPath(),
+				Delete:    paths,
+			},
+		},
+	}
+}
+
+func componentDelete(name string) *gnmipb.SubscribeResponse {
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 1749043184927000000,
+				Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "dx05.sql85-laarz"},
+				Delete:    componentDeletes(name),
+			},
+		},
+	}
+}
+
+func TestTranslateDeletes(t *testing.T) {
+	port := func(name string) *gnmipb.PathElem {
+		return &gnmipb.PathElem{Name: "optics-port", Key: map[string]string{"name": name}}
+	}
+	tests := []struct {
+		name  string
+		input *gnmipb.SubscribeResponse
+		want  *gnmipb.SubscribeResponse
+	}{
+		{
+			name:  "port deleted",
+			input: opticsDelete(&gnmipb.Path{Elem: []*gnmipb.PathElem{port("Optics0/0/0/0")}}),
+			want:  componentDelete("FourHundredGigE0/0/0/0"),
+		},
+		{
+			name:  "optics info deleted",
+			input: opticsDelete(&gnmipb.Path{Elem: []*gnmipb.PathElem{port("Optics0/0/0/0"), {Name: "optics-info"}}}),
+			want:  componentDelete("FourHundredGigE0/0/0/0"),
+		},
+		{
+			name:  "unknown port deleted",
+			input: opticsDelete(&gnmipb.Path{Elem: []*gnmipb.PathElem{port("Optics0/0/0/1")}}),
+		},
+		{
+			name:  "leaf deleted",
+			input: opticsDelete(&gnmipb.Path{Elem: []*gnmipb.PathElem{port("Optics0/0/0/0"), {Name: "optics-info"}, {Name: "temperature"}}}),
+		},
+		{
+			name:  "ports container deleted",
+			input: opticsDelete(&gnmipb.Path{}),
+			want: &gnmipb.SubscribeResponse{
+				Response: &gnmipb.SubscribeResponse_Update{
+					Update: &gnmipb.Notification{
+						Timestamp: 1749043184927000000,
+						Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "dx05.sql85-laarz"},
+						Delete:    append(componentDeletes("FourHundredGigE0/0/0/0"), componentDeletes("HundredGigE0/0/0/2")...),
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ft := New()
+			for _, sr := range []*gnmipb.SubscribeResponse{opticsTypeUpdate("Optics0/0/0/0", "400G"), opticsTypeUpdate("Optics0/0/0/2", "100G")} {
+				if _, err := ft.Translate(sr); err != nil {
+					t.Fatalf("Translate(%v) returned an unexpected error: %v", sr, err)
+				}
+			}
+			got, err := ft.Translate(test.input)
+			if err != nil {
+				t.Fatalf("Translate() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(compacted(test.want), got, protocmp.Transform()); diff != "" {
+				t.Errorf("Translate() returned an unexpected diff (-want +got):\n%s", diff)
+			}
+			// The deleted ports are forgotten.
+			if test.want == nil {
+				return
+			}
+			got, err = ft.Translate(test.input)
+			if err != nil {
+				t.Fatalf("Translate() returned an unexpected error: %v", err)
+			}
+			if got != nil {
+				t.Errorf("Translate() of the same delete returned %v, want nil", got)
+			}
+		})
+	}
+}
+
+func TestOnTargetDisconnect(t *testing.T) {
+	ft := New()
+	if _, err := ft.Translate(opticsTypeUpdate("Optics0/0/0/0", "400G")); err != nil {
+		t.Fatalf("Translate() returned an unexpected error: %v", err)
+	}
+	ft.OnTargetDisconnect("dx05.sql85-laarz")
+	got, err := ft.Translate(opticsDelete(&gnmipb.Path{}))
+	if err != nil {
+		t.Fatalf("Translate() returned an unexpected error: %v", err)
+	}
+	if got != nil {
+		t.Errorf("Translate() after OnTargetDisconnect() returned %v, want nil", got)
+	}
+}
@@ -17,12 +17,17 @@ package ciscoxrvendordrops
 
 import (
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
-	log "github.com/golang/glog"
 	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
 	"github.com/openconfig/functional-translators/translator"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -36,8 +41,12 @@ type trap struct {
 	trapID        uint64
 	npuID         uint64
 	packetDropped uint64
+	// packetAccepted is nil if the device did not report the accepted packets of the trap.
+	packetAccepted *uint64
 }
 
+// stats holds the drop counters of a block, keyed by their (sanitized) field names. The block name
+// is the name reported by the device.
 type stats struct {
 	nodeName  string
 	npuID     uint64
@@ -45,12 +54,184 @@ type stats struct {
 	counters  map[string]uint64
 }
 
+// The translator options selecting the exported traps and blocks. The list options are
+// comma-separated names, e.g. "traps": "L3_ROUTE_LOOKUP_FAILED,L3_NULL_ADJ(D*)", and the regex
+// options are regular expressions matched against the names, e.g. "blocks-regex": "^IFG". A trap
+// or block is exported if it is in the list or matches the regex, and neither excluded by the
+// exclude list nor matched by the exclude regex. Without a list or regex, the default traps and
+// the summary blocks are exported. Names are matched as reported by the device, before
+// sanitization.
+const (
+	trapsOption              = "traps"
+	trapsRegexOption         = "traps-regex"
+	excludeTrapsOption       = "exclude-traps"
+	excludeTrapsRegexOption  = "exclude-traps-regex"
+	blocksOption             = "blocks"
+	blocksRegexOption        = "blocks-regex"
+	excludeBlocksOption      = "exclude-blocks"
+	excludeBlocksRegexOption = "exclude-blocks-regex"
+	// sanitizeOption controls whether the spaces in the block, counter and trap names are replaced
+	// by underscores in the output paths, e.g. "false". It defaults to true.
+	sanitizeOption = "sanitize-names"
+	// stripTrapSuffixOption controls whether the parenthesized suffix of the trap names, e.g. "(D*)"
+	// of "L3_NULL_ADJ(D*)", is removed in the output paths, e.g. "true". It defaults to false.
+	stripTrapSuffixOption = "strip-trap-suffix"
+)
+
+// trapSuffixRE matches the parenthesized suffix of a trap name, e.g. "(D*)".
+var trapSuffixRE = regexp.MustCompile(`\s*\([^()]*\)$`)
+
+// nameFilter selects the traps or blocks to export by name.
+type nameFilter struct {
+	names        map[string]bool
+	re           *regexp.Regexp
+	excludeNames map[string]bool
+	excludeRe    *regexp.Regexp
+	// def selects the names when neither names nor re is set.
+	def func(string) bool
+}
+
+func (f *nameFilter) match(name string) bool {
+	if f.excludeNames[name] || (f.excludeRe != nil && f.excludeRe.MatchString(name)) {
+		return false
+	}
+	if f.names == nil && f.re == nil {
+		return f.def(name)
+	}
+	return f.names[name] || (f.re != nil && f.re.MatchString(name))
+}
+
+// newNameFilter creates the filter from the list and regex options with the given names.
+func newNameFilter(opts translator.Options, list, re, excludeList, excludeRe string, def func(string) bool) (*nameFilter, error) {
+	f := &nameFilter{
+		names:        splitNames(opts, list),
+		excludeNames: splitNames(opts, excludeList),
+		def:          def,
+	}
+	var err error
+	if f.re, err = compileOption(opts, re); err != nil {
+		return nil, err
+	}
+	if f.excludeRe, err = compileOption(opts, excludeRe); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// splitNames returns the set of comma-separated names of the option, or nil if it is not set.
+func splitNames(opts translator.Options, name string) map[string]bool {
+	v, ok := opts[name]
+	if !ok {
+		return nil
+	}
+	names := map[string]bool{}
+	for _, n := range strings.Split(v, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names[n] = true
+		}
+	}
+	return names
+}
+
+// compileOption compiles the regex of the option, or returns nil if it is not set.
+func compileOption(opts translator.Options, name string) (*regexp.Regexp, error) {
+	v, ok := opts[name]
+	if !ok {
+		return nil, nil
+	}
+	re, err := regexp.Compile(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex option %s=%q: %v", name, v, err)
+	}
+	return re, nil
+}
+
+// config is the parsed form of the translator options.
+type config struct {
+	opts        translator.Options
+	traps       *nameFilter
+	blocks      *nameFilter
+	sanitize    bool
+	stripSuffix bool
+}
+
+func parseOptions(opts translator.Options) (*config, error) {
+	traps, err := newNameFilter(opts, trapsOption, trapsRegexOption, excludeTrapsOption, excludeTrapsRegexOption, func(name string) bool { return defaultTraps[name] })
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := newNameFilter(opts, blocksOption, blocksRegexOption, excludeBlocksOption, excludeBlocksRegexOption, func(name string) bool { return strings.Contains(name, "Summary") })
+	if err != nil {
+		return nil, err
+	}
+	sanitize, err := opts.Bool(sanitizeOption, true)
+	if err != nil {
+		return nil, err
+	}
+	stripSuffix, err := opts.Bool(stripTrapSuffixOption, false)
+	if err != nil {
+		return nil, err
+	}
+	return &config{opts: opts, traps: traps, blocks: blocks, sanitize: sanitize, stripSuffix: stripSuffix}, nil
+}
+
+// validateOptions rejects invalid regex or boolean options.
+func validateOptions(opts translator.Options) error {
+	_, err := parseOptions(opts)
+	return err
+}
+
+// name returns the name of a trap in the output paths.
+func (c *config) name(name string) string {
+	if c.stripSuffix {
+		name = trapSuffixRE.ReplaceAllString(name, "")
+	}
+	return sanitizeName(name, c.sanitize)
+}
+
+// sanitizeName replaces the spaces in the name by underscores if sanitize is set.
+func sanitizeName(name string, sanitize bool) string {
+	if !sanitize {
+		return name
+	}
+	return strings.ReplaceAll(name, " ", "_")
+}
+
+type impl struct {
+	// cfg caches the last parsed options, parsed again when they change.
+	cfg atomic.Pointer[config]
+}
+
+// config returns the parsed options.
+func (i *impl) config(opts translator.Options) (*config, error) {
+	if c := i.cfg.Load(); c != nil && maps.Equal(c.opts, opts) {
+		return c, nil
+	}
+	c, err := parseOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	i.cfg.Store(c)
+	return c, nil
+}
+
 var (
 	translateMap = map[string][]string{
 		"/openconfig/components/component/integrated-circuit/pipeline-counters/drop/vendor": {
 			"/Cisco-IOS-XR-platforms-ofa-oper/ofa/stats/nodes/node/Cisco-IOS-XR-ofa-npu-stats-oper:npu-numbers/npu-number/display/trap-ids/trap-id",
 			"/Cisco-IOS-XR-platforms-ofa-oper/ofa/stats/nodes/node/Cisco-IOS-XR-ofa-npu-stats-oper:asic-statistics/asic-statistics-for-npu-ids/asic-statistics-for-npu-id",
 		},
+		"/openconfig/components/component/integrated-circuit/pipeline-counters/packet/vendor": {
+			"/Cisco-IOS-XR-platforms-ofa-oper/ofa/stats/nodes/node/Cisco-IOS-XR-ofa-npu-stats-oper:npu-numbers/npu-number/display/trap-ids/trap-id",
+		},
+		"/openconfig/components/component/state/parent": {
+			"/Cisco-IOS-XR-platforms-ofa-oper/ofa/stats/nodes/node/Cisco-IOS-XR-ofa-npu-stats-oper:npu-numbers/npu-number/display/trap-ids/trap-id",
+			"/Cisco-IOS-XR-platforms-ofa-oper/ofa/stats/nodes/node/Cisco-IOS-XR-ofa-npu-stats-oper:asic-statistics/asic-statistics-for-npu-ids/asic-statistics-for-npu-id",
+		},
+		"/openconfig/components/component/state/type": {
+			"/Cisco-IOS-XR-platforms-ofa-oper/ofa/stats/nodes/node/Cisco-IOS-XR-ofa-npu-stats-oper:npu-numbers/npu-number/display/trap-ids/trap-id",
+			"/Cisco-IOS-XR-platforms-ofa-oper/ofa/stats/nodes/node/Cisco-IOS-XR-ofa-npu-stats-oper:asic-statistics/asic-statistics-for-npu-ids/asic-statistics-for-npu-id",
+		},
 	}
 	paths            = ftutilities.MustStringMapPaths(translateMap)
 	nativeTrapsPaths = []*gnmipb.Path{
@@ -95,6 +276,12 @@ var (
 			},
 		},
 	}
+	// defaultTraps are the traps exported without the traps options.
+	defaultTraps = map[string]bool{
+		"L3_ROUTE_LOOKUP_FAILED":               true,
+		"L3_NULL_ADJ(D*)":                      true,
+		"MPLS_TE_MIDPOINT_LDP_LABELS_MISS(D*)": true,
+	}
 	dropMap = map[string]bool{
 		"IFGB_RX 0 partial drop":    true,
 		"IFGB_RX 1 partial drop":    true,
@@ -165,7 +352,15 @@ func buildTraps(prefix *gnmipb.Path, leaves []*gnmipb.Update) (map[string]*trap,
 		}
 		switch elems[9].GetName() {
 		case "packet-dropped":
-			traps[trapKey].packetDropped = leaf.GetVal().GetUintVal()
+			if traps[trapKey].packetDropped, err = ftutilities.ToUint64(leaf.GetVal()); err != nil {
+				return nil, fmt.Errorf("invalid packet-dropped of trap %s: %v", trapKey, err)
+			}
+		case "packet-accepted":
+			accepted, err := ftutilities.ToUint64(leaf.GetVal())
+			if err != nil {
+				return nil, fmt.Errorf("invalid packet-accepted of trap %s: %v", trapKey, err)
+			}
+			traps[trapKey].packetAccepted = &accepted
 		case "trap-string":
 			traps[trapKey].trapString = leaf.GetVal().GetStringVal()
 		default:
@@ -177,8 +372,8 @@ func buildTraps(prefix *gnmipb.Path, leaves []*gnmipb.Update) (map[string]*trap,
 	return traps, nil
 }
 
-// validates the leaves and build stats structs
-func buildStats(prefix *gnmipb.Path, leaves []*gnmipb.Update) (map[string]*stats, error) {
+// validates the leaves and build stats structs, keyed by the component names of the blocks
+func buildStats(prefix *gnmipb.Path, leaves []*gnmipb.Update, sanitize bool) (map[string]*stats, error) {
 	var statsKey string
 	statsMap := make(map[string]*stats)
 	i := 0
@@ -195,13 +390,13 @@ func buildStats(prefix *gnmipb.Path, leaves []*gnmipb.Update) (map[string]*stats
 			return nil, fmt.Errorf("failed to convert npu-id to int: %v", err)
 		}
 		if elems[9].GetName() == "block-name" {
-			newBlockName := strings.ReplaceAll(leaves[i].GetVal().GetStringVal(), " ", "_")
-			statsKey = fmt.Sprintf("%s:%v:%s", nodeName, npuID, newBlockName)
+			blockName := leaves[i].GetVal().GetStringVal()
+			statsKey = fmt.Sprintf("%s:%v:%s", nodeName, npuID, sanitizeName(blockName, sanitize))
 			if _, ok := statsMap[statsKey]; !ok {
 				statsMap[statsKey] = &stats{
 					nodeName:  nodeName,
 					npuID:     uint64(npuID),
-					blockName: newBlockName,
+					blockName: blockName,
 					counters:  make(map[string]uint64),
 				}
 			}
@@ -214,10 +409,12 @@ func buildStats(prefix *gnmipb.Path, leaves []*gnmipb.Update) (map[string]*stats
 			if !ftutilities.MatchPath(fieldValuePath, nativeStatsPaths[2]) {
 				return nil, fmt.Errorf("field value path did not come directly after field name path in subscribe response")
 			}
-			fieldValue := leaves[i+1].GetVal().GetUintVal()
+			fieldValue, err := ftutilities.ToUint64(leaves[i+1].GetVal())
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of field %q: %v", fieldName, err)
+			}
 			if _, ok := dropMap[fieldName]; ok {
-				newFieldName := strings.ReplaceAll(fieldName, " ", "_")
-				statsMap[statsKey].counters[newFieldName] = fieldValue
+				statsMap[statsKey].counters[sanitizeName(fieldName, sanitize)] = fieldValue
 			}
 			i += 2
 			continue
@@ -227,13 +424,28 @@ func buildStats(prefix *gnmipb.Path, leaves []*gnmipb.Update) (map[string]*stats
 	return statsMap, nil
 }
 
+func init() {
+	registry.Register(ftconsts.CiscoXRVendorDropsTranslator, NewE)
+}
+
 // New creates a functional translator.
 func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Cisco vendor drops functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	i := &impl{}
 	ft, err := translator.NewFunctionalTranslator(
 		translator.FunctionalTranslatorOptions{
-			ID:               ftconsts.CiscoXRVendorDropsTranslator,
-			Translate:        translate,
-			OutputToInputMap: paths,
+			ID:                   ftconsts.CiscoXRVendorDropsTranslator,
+			TranslateWithOptions: i.translate,
+			ValidateOptions:      validateOptions,
+			OutputToInputMap:     paths,
 			Metadata: []*translator.FTMetadata{
 				{
 					Vendor: ftconsts.VendorCiscoXR,
@@ -242,16 +454,69 @@ func New() *translator.FunctionalTranslator {
 		},
 	)
 	if err != nil {
-		log.Fatalf("Failed to create Cisco vendor drops functional translator: %v", err)
+		return nil, err
+	}
+	return ft, nil
+}
+
+// npuComponentUpdates returns the parent and type of the npu component, attaching it to the
+// component of its node, e.g. "0/RP0/CPU0", in the platform tree.
+func npuComponentUpdates(name, nodeName string) []*gnmipb.Update {
+	leaf := func(leaf, val string) *gnmipb.Update {
+		return &gnmipb.Update{
+			Path: &gnmipb.Path{
+				Elem: []*gnmipb.PathElem{
+					{Name: "components"},
+					{Name: "component", Key: map[string]string{"name": name}},
+					{Name: "state"},
+					{Name: leaf},
+				},
+			},
+			Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: val}},
+		}
+	}
+	return []*gnmipb.Update{
+		leaf("parent", nodeName),
+		leaf("type", "INTEGRATED_CIRCUIT"),
+	}
+}
+
+// trapUpdate returns the update of a vendor counter of a trap, in the drop or packet pipeline
+// counters of the npu component.
+func trapUpdate(componentName, counters, name string, val uint64) *gnmipb.Update {
+	//  The path is build based on the rules defined in https://github.com/openconfig/public/blob/master/doc/vendor_counter_guide.md
+	return &gnmipb.Update{
+		Path: &gnmipb.Path{
+			Elem: []*gnmipb.PathElem{
+				{Name: "components"},
+				{Name: "component", Key: map[string]string{"name": componentName}},
+				{Name: "integrated-circuit"},
+				{Name: "pipeline-counters"},
+				{Name: counters},
+				{Name: "vendor"},
+				{Name: "CiscoXR"},
+				{Name: "spitfire"},
+				{Name: "packet-processing"},
+				{Name: "state"},
+				{Name: name},
+			},
+		},
+		Val: &gnmipb.TypedValue{
+			Value: &gnmipb.TypedValue_UintVal{
+				UintVal: val,
+			},
+		},
 	}
-	return ft
 }
 
-func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+func (i *impl) translate(sr *gnmipb.SubscribeResponse, opts translator.Options) (*gnmipb.SubscribeResponse, error) {
 	if sr.GetUpdate() == nil {
 		return nil, nil
 	}
-	var err error
+	cfg, err := i.config(opts)
+	if err != nil {
+		return nil, err
+	}
 	var traps map[string]*trap
 	var statsMap map[string]*stats
 	n := sr.GetUpdate()
@@ -261,96 +526,35 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate path: %v", err)
 	}
-	statsMap, err = buildStats(n.GetPrefix(), n.GetUpdate())
+	statsMap, err = buildStats(n.GetPrefix(), n.GetUpdate(), cfg.sanitize)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate path: %v", err)
 	}
 	updates := make([]*gnmipb.Update, 0)
+	// npus maps the names of the npu components with exported counters to their node names.
+	npus := map[string]string{}
 	for _, trap := range traps {
+		if !cfg.traps.match(trap.trapString) {
+			continue
+		}
 		componentName := fmt.Sprintf("%s:%d", trap.nodeName, trap.npuID)
-		//  The path is build based on the rules defined in https://github.com/openconfig/public/blob/master/doc/vendor_counter_guide.md
-		switch trap.trapString {
-		case "L3_ROUTE_LOOKUP_FAILED":
-			update := &gnmipb.Update{
-				Path: &gnmipb.Path{
-					Elem: []*gnmipb.PathElem{
-						{Name: "components"},
-						{Name: "component", Key: map[string]string{"name": componentName}},
-						{Name: "integrated-circuit"},
-						{Name: "pipeline-counters"},
-						{Name: "drop"},
-						{Name: "vendor"},
-						{Name: "CiscoXR"},
-						{Name: "spitfire"},
-						{Name: "packet-processing"},
-						{Name: "state"},
-						{Name: "L3_ROUTE_LOOKUP_FAILED"},
-					},
-				},
-				Val: &gnmipb.TypedValue{
-					Value: &gnmipb.TypedValue_UintVal{
-						UintVal: uint64(trap.packetDropped),
-					},
-				},
-			}
-			updates = append(updates, update)
-		case "L3_NULL_ADJ(D*)":
-			update := &gnmipb.Update{
-				Path: &gnmipb.Path{
-					Elem: []*gnmipb.PathElem{
-						{Name: "components"},
-						{Name: "component", Key: map[string]string{"name": componentName}},
-						{Name: "integrated-circuit"},
-						{Name: "pipeline-counters"},
-						{Name: "drop"},
-						{Name: "vendor"},
-						{Name: "CiscoXR"},
-						{Name: "spitfire"},
-						{Name: "packet-processing"},
-						{Name: "state"},
-						{Name: "L3_NULL_ADJ(D*)"},
-					},
-				},
-				Val: &gnmipb.TypedValue{
-					Value: &gnmipb.TypedValue_UintVal{
-						UintVal: uint64(trap.packetDropped),
-					},
-				},
-			}
-			updates = append(updates, update)
-		case "MPLS_TE_MIDPOINT_LDP_LABELS_MISS(D*)":
-			update := &gnmipb.Update{
-				Path: &gnmipb.Path{
-					Elem: []*gnmipb.PathElem{
-						{Name: "components"},
-						{Name: "component", Key: map[string]string{"name": componentName}},
-						{Name: "integrated-circuit"},
-						{Name: "pipeline-counters"},
-						{Name: "drop"},
-						{Name: "vendor"},
-						{Name: "CiscoXR"},
-						{Name: "spitfire"},
-						{Name: "packet-processing"},
-						{Name: "state"},
-						{Name: "MPLS_TE_MIDPOINT_LDP_LABELS_MISS(D*)"},
-					},
-				},
-				Val: &gnmipb.TypedValue{
-					Value: &gnmipb.TypedValue_UintVal{
-						UintVal: uint64(trap.packetDropped),
-					},
-				},
-			}
-			updates = append(updates, update)
+		npus[componentName] = trap.nodeName
+		name := cfg.name(trap.trapString)
+		updates = append(updates, trapUpdate(componentName, "drop", name, trap.packetDropped))
+		// The accepted packets are exported under the same name in the packet counters, so that the
+		// accept/drop ratio of a trap can be computed.
+		if trap.packetAccepted != nil {
+			updates = append(updates, trapUpdate(componentName, "packet", name, *trap.packetAccepted))
 		}
 	}
 
 	for key, stats := range statsMap {
+		if !cfg.blocks.match(stats.blockName) || len(stats.counters) == 0 {
+			continue
+		}
+		npus[fmt.Sprintf("%s:%d", stats.nodeName, stats.npuID)] = stats.nodeName
 		//  The path is build based on the rules defined in https://github.com/openconfig/public/blob/master/doc/vendor_counter_guide.md
 		for counter, value := range stats.counters {
-			if !strings.Contains(stats.blockName, "Summary") {
-				continue
-			}
 			update := &gnmipb.Update{
 				Path: &gnmipb.Path{
 					Elem: []*gnmipb.PathElem{
@@ -376,16 +580,22 @@ func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 			updates = append(updates, update)
 		}
 	}
+	for _, name := range slices.Sorted(maps.Keys(npus)) {
+		updates = append(updates, npuComponentUpdates(name, npus[name])...)
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
 	outgoingSR := &gnmipb.SubscribeResponse{
 		Response: &gnmipb.SubscribeResponse_Update{
-			Update: &gnmipb.Notification{
+			Update: ftutilities.CompactNotification(&gnmipb.Notification{
 				Timestamp: ts,
 				Prefix: &gnmipb.Path{
 					Target: target,
 					Origin: "openconfig",
 				},
 				Update: updates,
-			},
+			}),
 		},
 	}
 	return outgoingSR, nil
@@ -15,9 +15,12 @@
 package ciscoxrvendordrops
 
 import (
+	"sort"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/translator"
 	"google.golang.org/protobuf/testing/protocmp"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -690,6 +693,28 @@ func TestTranslate(t *testing.T) {
 							},
 						},
 					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "components"},
+								{Name: "component", Key: map[string]string{"name": "0/RP0/CPU0:0"}},
+								{Name: "integrated-circuit"},
+								{Name: "pipeline-counters"},
+								{Name: "packet"},
+								{Name: "vendor"},
+								{Name: "CiscoXR"},
+								{Name: "spitfire"},
+								{Name: "packet-processing"},
+								{Name: "state"},
+								{Name: "L3_ROUTE_LOOKUP_FAILED"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_UintVal{
+								UintVal: 1000000,
+							},
+						},
+					},
 					{
 						Path: &gnmipb.Path{
 							Elem: []*gnmipb.PathElem{
@@ -734,6 +759,36 @@ func TestTranslate(t *testing.T) {
 							},
 						},
 					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "components"},
+								{Name: "component", Key: map[string]string{"name": "0/RP0/CPU0:0"}},
+								{Name: "state"},
+								{Name: "parent"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "0/RP0/CPU0",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "components"},
+								{Name: "component", Key: map[string]string{"name": "0/RP0/CPU0:0"}},
+								{Name: "state"},
+								{Name: "type"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "INTEGRATED_CIRCUIT",
+							},
+						},
+					},
 				},
 			},
 		},
@@ -812,6 +867,36 @@ func TestTranslate(t *testing.T) {
 							},
 						},
 					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "components"},
+								{Name: "component", Key: map[string]string{"name": "0/RP0/CPU0:0"}},
+								{Name: "state"},
+								{Name: "parent"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "0/RP0/CPU0",
+							},
+						},
+					},
+					{
+						Path: &gnmipb.Path{
+							Elem: []*gnmipb.PathElem{
+								{Name: "components"},
+								{Name: "component", Key: map[string]string{"name": "0/RP0/CPU0:0"}},
+								{Name: "state"},
+								{Name: "type"},
+							},
+						},
+						Val: &gnmipb.TypedValue{
+							Value: &gnmipb.TypedValue_StringVal{
+								StringVal: "INTEGRATED_CIRCUIT",
+							},
+						},
+					},
 				},
 			},
 		},
@@ -1016,16 +1101,6 @@ func TestTranslate(t *testing.T) {
 			},
 		},
 	}
-	notMatchedOutput := &gnmipb.SubscribeResponse{
-		Response: &gnmipb.SubscribeResponse_Update{
-			Update: &gnmipb.Notification{
-				Timestamp: 123,
-				Prefix: &gnmipb.Path{
-					Origin: "openconfig",
-				},
-			},
-		},
-	}
 	tests := []struct {
 		name    string
 		input   *gnmipb.SubscribeResponse
@@ -1045,7 +1120,6 @@ func TestTranslate(t *testing.T) {
 		{
 			name:  "not matched path",
 			input: notMatchedSR,
-			want:  notMatchedOutput,
 		},
 		{
 			name:  "success stats",
@@ -1067,10 +1141,220 @@ func TestTranslate(t *testing.T) {
 				t.Fatalf("Unexpected error result returned from translate() = %v, want error %t", err, test.wantErr)
 			}
 			if !test.wantErr {
-				if diff := cmp.Diff(test.want, sr, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update")); diff != "" {
+				if diff := cmp.Diff(compacted(test.want), sr, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update")); diff != "" {
 					t.Fatalf("Unexpected diff from translate() = %v, want %v, diff(-want +got):\n%s", sr, test.want, diff)
 				}
 			}
 		})
 	}
 }
+
+// compacted returns the response with its expected fully-expanded paths compacted, as the
+// translator outputs them.
+func compacted(sr *gnmipb.SubscribeResponse) *gnmipb.SubscribeResponse {
+	if sr.GetUpdate() == nil {
+		return sr
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{Update: ftutilities.CompactNotification(sr.GetUpdate())},
+	}
+}
+
+// optionsSR returns a notification with the traps and the drop counters of the blocks of npu 0.
+func optionsSR(traps []string, blocks []string) *gnmipb.SubscribeResponse {
+	npu := []*gnmipb.PathElem{
+		{Name: "ofa"},
+		{Name: "stats"},
+		{Name: "nodes"},
+		{Name: "node", Key: map[string]string{"node-name": "0/RP0/CPU0"}},
+	}
+	var updates []*gnmipb.Update
+	leaf := func(base []*gnmipb.PathElem, val *gnmipb.TypedValue, elems ...*gnmipb.PathElem) {
+		path := &gnmipb.Path{Elem: append(append([]*gnmipb.PathElem{}, base...), elems...)}
+		updates = append(updates, &gnmipb.Update{Path: path, Val: val})
+	}
+	str := func(v string) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: v}}
+	}
+	uint := func(v uint64) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}}
+	}
+	for j, trap := range traps {
+		trapElems := append([]*gnmipb.PathElem{}, npu...)
+		trapElems = append(trapElems,
+			&gnmipb.PathElem{Name: "Cisco-IOS-XR-ofa-npu-stats-oper:npu-numbers"},
+			&gnmipb.PathElem{Name: "npu-number", Key: map[string]string{"npu-id": "0"}},
+			&gnmipb.PathElem{Name: "display"},
+			&gnmipb.PathElem{Name: "trap-ids"},
+			&gnmipb.PathElem{Name: "trap-id", Key: map[string]string{"trap-id": string(rune('0' + j))}},
+		)
+		leaf(trapElems, str(trap), &gnmipb.PathElem{Name: "trap-string"})
+		leaf(trapElems, uint(1), &gnmipb.PathElem{Name: "packet-dropped"})
+	}
+	blockElems := append([]*gnmipb.PathElem{}, npu...)
+	blockElems = append(blockElems,
+		&gnmipb.PathElem{Name: "Cisco-IOS-XR-ofa-npu-stats-oper:asic-statistics"},
+		&gnmipb.PathElem{Name: "asic-statistics-for-npu-ids"},
+		&gnmipb.PathElem{Name: "asic-statistics-for-npu-id", Key: map[string]string{"npu-id": "0"}},
+		&gnmipb.PathElem{Name: "npu-statistics"},
+		&gnmipb.PathElem{Name: "block-info"},
+	)
+	for _, block := range blocks {
+		leaf(blockElems, str(block), &gnmipb.PathElem{Name: "block-name"})
+		leaf(blockElems, str("TXCGM drop"), &gnmipb.PathElem{Name: "field-info"}, &gnmipb.PathElem{Name: "field-name"})
+		leaf(blockElems, uint(2), &gnmipb.PathElem{Name: "field-info"}, &gnmipb.PathElem{Name: "field-value"})
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 123,
+				Prefix:    &gnmipb.Path{Origin: "Cisco-IOS-XR-platforms-ofa-oper"},
+				Update:    updates,
+			},
+		},
+	}
+}
+
+// exported returns the sorted component names and counter names of the counter updates, e.g.
+// "0/RP0/CPU0:0 L3_NULL_ADJ(D*)".
+func exported(sr *gnmipb.SubscribeResponse) []string {
+	var got []string
+	for _, u := range sr.GetUpdate().GetUpdate() {
+		elems := ftutilities.Join(sr.GetUpdate().GetPrefix(), u.GetPath()).GetElem()
+		if elems[2].GetName() == "state" {
+			// The parent and type of the npu component.
+			continue
+		}
+		got = append(got, elems[1].GetKey()["name"]+" "+elems[len(elems)-1].GetName())
+	}
+	sort.Strings(got)
+	return got
+}
+
+func TestOptions(t *testing.T) {
+	input := optionsSR(
+		[]string{"L3_ROUTE_LOOKUP_FAILED", "L3_NULL_ADJ(D*)", "L3 ACL DROP", "MPLS_TTL_EXPIRED"},
+		[]string{"block 1 Summary", "block 1 "},
+	)
+	tests := []struct {
+		name    string
+		options translator.Options
+		want    []string
+	}{
+		{
+			name: "defaults",
+			want: []string{
+				"0/RP0/CPU0:0 L3_NULL_ADJ(D*)",
+				"0/RP0/CPU0:0 L3_ROUTE_LOOKUP_FAILED",
+				"0/RP0/CPU0:0:block_1_Summary TXCGM_drop",
+			},
+		},
+		{
+			name:    "traps list",
+			options: translator.Options{trapsOption: "L3 ACL DROP, MPLS_TTL_EXPIRED"},
+			want: []string{
+				"0/RP0/CPU0:0 L3_ACL_DROP",
+				"0/RP0/CPU0:0 MPLS_TTL_EXPIRED",
+				"0/RP0/CPU0:0:block_1_Summary TXCGM_drop",
+			},
+		},
+		{
+			name:    "traps regex and list",
+			options: translator.Options{trapsRegexOption: "^L3_", trapsOption: "MPLS_TTL_EXPIRED"},
+			want: []string{
+				"0/RP0/CPU0:0 L3_NULL_ADJ(D*)",
+				"0/RP0/CPU0:0 L3_ROUTE_LOOKUP_FAILED",
+				"0/RP0/CPU0:0 MPLS_TTL_EXPIRED",
+				"0/RP0/CPU0:0:block_1_Summary TXCGM_drop",
+			},
+		},
+		{
+			name: "excluded traps",
+			options: translator.Options{
+				trapsRegexOption:        ".",
+				excludeTrapsOption:      "L3_NULL_ADJ(D*)",
+				excludeTrapsRegexOption: "^MPLS",
+			},
+			want: []string{
+				"0/RP0/CPU0:0 L3_ACL_DROP",
+				"0/RP0/CPU0:0 L3_ROUTE_LOOKUP_FAILED",
+				"0/RP0/CPU0:0:block_1_Summary TXCGM_drop",
+			},
+		},
+		{
+			name:    "excluded default trap",
+			options: translator.Options{excludeTrapsOption: "L3_ROUTE_LOOKUP_FAILED"},
+			want: []string{
+				"0/RP0/CPU0:0 L3_NULL_ADJ(D*)",
+				"0/RP0/CPU0:0:block_1_Summary TXCGM_drop",
+			},
+		},
+		{
+			name:    "blocks",
+			options: translator.Options{blocksRegexOption: "^block", excludeBlocksOption: "block 1 Summary", trapsOption: ""},
+			want:    []string{"0/RP0/CPU0:0:block_1_ TXCGM_drop"},
+		},
+		{
+			name:    "not sanitized",
+			options: translator.Options{sanitizeOption: "false", trapsOption: "L3 ACL DROP", blocksOption: "block 1 Summary"},
+			want: []string{
+				"0/RP0/CPU0:0 L3 ACL DROP",
+				"0/RP0/CPU0:0:block 1 Summary TXCGM drop",
+			},
+		},
+		{
+			name:    "stripped trap suffix",
+			options: translator.Options{stripTrapSuffixOption: "true", trapsOption: "L3_NULL_ADJ(D*),L3 ACL DROP"},
+			want: []string{
+				"0/RP0/CPU0:0 L3_ACL_DROP",
+				"0/RP0/CPU0:0 L3_NULL_ADJ",
+				"0/RP0/CPU0:0:block_1_Summary TXCGM_drop",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ft := New()
+			if err := ft.SetOptions(test.options); err != nil {
+				t.Fatalf("SetOptions(%v) got unexpected error: %v", test.options, err)
+			}
+			sr, err := ft.Translate(input)
+			if err != nil {
+				t.Fatalf("Translate() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.want, exported(sr)); diff != "" {
+				t.Errorf("Translate() got unexpected exported counters, diff(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInvalidOptions(t *testing.T) {
+	ft := New()
+	for _, opts := range []translator.Options{
+		{trapsRegexOption: "("},
+		{excludeBlocksRegexOption: "[a-"},
+		{sanitizeOption: "maybe"},
+		{stripTrapSuffixOption: "yes please"},
+	} {
+		if err := ft.SetOptions(opts); err == nil {
+			t.Errorf("SetOptions(%v) got no error, want error", opts)
+		}
+	}
+}
+
+func TestNothingExported(t *testing.T) {
+	ft := New()
+	opts := translator.Options{trapsOption: "", blocksOption: ""}
+	if err := ft.SetOptions(opts); err != nil {
+		t.Fatalf("SetOptions(%v) got unexpected error: %v", opts, err)
+	}
+	sr, err := ft.Translate(optionsSR([]string{"L3_ROUTE_LOOKUP_FAILED"}, []string{"block 1 Summary"}))
+	if err != nil {
+		t.Fatalf("Translate() got unexpected error: %v", err)
+	}
+	// The npu component is not reported without counters.
+	if got := sr.GetUpdate().GetUpdate(); len(got) != 0 {
+		t.Errorf("Translate() got updates %v, want none", got)
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The ftnew command scaffolds a new functional translator package.
+//
+// It creates the vendor package with a New() constructor, a translate skeleton with path pattern
+// matching and target propagation, a golden-file test run by the fttest package and its testdata,
+// and adds the translator ID to ftconsts and the registrar. Example:
+//
+//	go run ./cmd/ftnew --vendor=ciscoxr --name=envmon --description="translates environmental monitoring sensors from native to openconfig"
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	log "github.com/golang/glog"
+)
+
+var (
+	vendor      = flag.String("vendor", "", "Vendor directory of the translator, one of arista, ciscoxr or juniper.")
+	name        = flag.String("name", "", "Short name of the translator, e.g. envmon or qos_policy.")
+	description = flag.String("description", "", "Package description, completing the sentence \"Package <pkg> ...\".")
+	root        = flag.String("root", ".", "Root directory of the functional-translators repository.")
+	register    = flag.Bool("register", true, "Add the translator ID to ftconsts and the registrar.")
+)
+
+// vendorInfo describes a vendor directory supported by the scaffolder.
+type vendorInfo struct {
+	// constPrefix is the prefix of the translator ID constant in ftconsts.
+	constPrefix string
+	// vendorConst is the name of the vendor constant in ftconsts.
+	vendorConst string
+	// humanName is the vendor name used in log messages.
+	humanName string
+	// origin is the origin of the native paths of the vendor.
+	origin string
+}
+
+var vendors = map[string]vendorInfo{
+	"arista": {
+		constPrefix: "Arista",
+		vendorConst: "VendorArista",
+		humanName:   "Arista",
+		origin:      "eos_native",
+	},
+	"ciscoxr": {
+		constPrefix: "CiscoXR",
+		vendorConst: "VendorCiscoXR",
+		humanName:   "Cisco XR",
+		origin:      "Cisco-IOS-XR-TODO-oper",
+	},
+	"juniper": {
+		constPrefix: "Juniper",
+		vendorConst: "VendorJuniper",
+		humanName:   "Juniper",
+		origin:      "junos",
+	},
+}
+
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9]*([-_][a-z0-9]+)*$`)
+
+// spec holds the values substituted into the templates.
+type spec struct {
+	Vendor      string
+	Package     string
+	Description string
+	ConstName   string
+	ID          string
+	VendorConst string
+	HumanName   string
+	Origin      string
+}
+
+// newSpec validates the command line values and derives the names used by the templates.
+func newSpec(vendor, name, description string) (*spec, error) {
+	v, ok := vendors[vendor]
+	if !ok {
+		return nil, fmt.Errorf("unsupported vendor %q", vendor)
+	}
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid name %q, want lower case words separated by - or _", name)
+	}
+	if description == "" {
+		return nil, fmt.Errorf("description must be set")
+	}
+	words := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' })
+	var camel strings.Builder
+	for _, w := range words {
+		camel.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return &spec{
+		Vendor:      vendor,
+		Package:     vendor + strings.Join(words, ""),
+		Description: strings.TrimSuffix(description, "."),
+		ConstName:   v.constPrefix + camel.String() + "Translator",
+		ID:          vendor + "-" + strings.Join(words, "-") + "-ft",
+		VendorConst: v.vendorConst,
+		HumanName:   v.humanName + " " + strings.Join(words, " "),
+		Origin:      v.origin,
+	}, nil
+}
+
+// render executes the template text with s. Go sources are parsed to check that the result is valid,
+// but not formatted as that would sort the import groups of the test.
+func render(text string, s *spec, goSource bool) ([]byte, error) {
+	t, err := template.New("").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, s); err != nil {
+		return nil, err
+	}
+	if !goSource {
+		return buf.Bytes(), nil
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "", buf.Bytes(), parser.AllErrors); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// packageFiles returns the contents of the files of the new package, keyed by their path relative
+// to the package directory.
+func packageFiles(s *spec) (map[string][]byte, error) {
+	files := map[string]struct {
+		text     string
+		goSource bool
+	}{
+		s.Package + ".go":              {translatorTemplate, true},
+		s.Package + "_test.go":         {testTemplate, true},
+		"testdata/unmatched_input.txt": {unmatchedInputTemplate, false},
+	}
+	out := map[string][]byte{}
+	for name, f := range files {
+		b, err := render(f.text, s, f.goSource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s: %v", name, err)
+		}
+		out[name] = b
+	}
+	return out, nil
+}
+
+// addConst returns the contents of ftconsts/consts.go with the translator ID constant of s added at
+// the end of the last const block.
+func addConst(src []byte, s *spec) ([]byte, error) {
+	if bytes.Contains(src, []byte("\t"+s.ConstName+" ")) {
+		return nil, fmt.Errorf("constant %s already exists", s.ConstName)
+	}
+	i := bytes.LastIndex(src, []byte("\n)"))
+	if i < 0 {
+		return nil, fmt.Errorf("no const block found")
+	}
+	decl := fmt.Sprintf("\n\n\t// %s is the name of a translator that %s.\n\t%s = %q", s.ConstName, s.Description, s.ConstName, s.ID)
+	out := slices.Concat(src[:i], []byte(decl), src[i:])
+	return format.Source(out)
+}
+
+// addRegistration returns the contents of registrar/registrar.go with the import of the new package
+// and its registry entry added in sorted order.
+func addRegistration(src []byte, s *spec) ([]byte, error) {
+	importLine := fmt.Sprintf("\t\"github.com/openconfig/functional-translators/%s/%s\"", s.Vendor, s.Package)
+	entry := fmt.Sprintf("\t\tftconsts.%s: %s.New(),", s.ConstName, s.Package)
+	lines := strings.Split(string(src), "\n")
+	if slices.Contains(lines, importLine) {
+		return nil, fmt.Errorf("package %s is already registered", s.Package)
+	}
+	lines, err := insertSorted(lines, "import (", ")", importLine)
+	if err != nil {
+		return nil, err
+	}
+	lines, err = insertSorted(lines, "// go/keep-sorted start", "// go/keep-sorted end", entry)
+	if err != nil {
+		return nil, err
+	}
+	return format.Source([]byte(strings.Join(lines, "\n")))
+}
+
+// insertSorted inserts line in the sorted block of lines between the lines starting with start and
+// end, ignoring indentation.
+func insertSorted(lines []string, start, end, line string) ([]string, error) {
+	begin := slices.IndexFunc(lines, func(l string) bool { return strings.TrimSpace(l) == start })
+	if begin < 0 {
+		return nil, fmt.Errorf("%q not found", start)
+	}
+	stop := slices.IndexFunc(lines[begin:], func(l string) bool { return strings.TrimSpace(l) == end })
+	if stop < 0 {
+		return nil, fmt.Errorf("%q not found", end)
+	}
+	stop += begin
+	at := stop
+	for i := begin + 1; i < stop; i++ {
+		fields := strings.Fields(lines[i])
+		if len(fields) > 0 && fields[0] > strings.Fields(line)[0] {
+			at = i
+			break
+		}
+	}
+	return slices.Insert(lines, at, line), nil
+}
+
+// updateFile applies f to the contents of the file at path.
+func updateFile(path string, f func([]byte) ([]byte, error)) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	out, err := f(src)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %v", path, err)
+	}
+	return os.WriteFile(path, out, 0644)
+}
+
+func run(s *spec, root string, register bool) error {
+	dir := filepath.Join(root, s.Vendor, s.Package)
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("package directory %s already exists", dir)
+	}
+	files, err := packageFiles(s)
+	if err != nil {
+		return err
+	}
+	for name, b := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, b, 0644); err != nil {
+			return err
+		}
+	}
+	if !register {
+		return nil
+	}
+	addConstFn := func(src []byte) ([]byte, error) { return addConst(src, s) }
+	if err := updateFile(filepath.Join(root, "ftconsts", "consts.go"), addConstFn); err != nil {
+		return err
+	}
+	addRegistrationFn := func(src []byte) ([]byte, error) { return addRegistration(src, s) }
+	return updateFile(filepath.Join(root, "registrar", "registrar.go"), addRegistrationFn)
+}
+
+func main() {
+	flag.Parse()
+	s, err := newSpec(*vendor, *name, *description)
+	if err != nil {
+		log.Exitf("Invalid translator spec: %v", err)
+	}
+	if err := run(s, *root, *register); err != nil {
+		log.Exitf("Failed to scaffold %s: %v", s.Package, err)
+	}
+	fmt.Printf("Created %s/%s with ID ftconsts.%s (%q).\n", s.Vendor, s.Package, s.ConstName, s.ID)
+	fmt.Println("Fill in the TODOs in the translateMap, pathPatterns and toOpenConfig, and add fixtures for the translated paths.")
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewSpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		vendor      string
+		ftName      string
+		description string
+		want        *spec
+		wantErr     bool
+	}{
+		{
+			name:        "cisco_multi_word",
+			vendor:      "ciscoxr",
+			ftName:      "qos_policy",
+			description: "translates the QoS policy attached to each interface.",
+			want: &spec{
+				Vendor:      "ciscoxr",
+				Package:     "ciscoxrqospolicy",
+				Description: "translates the QoS policy attached to each interface",
+				ConstName:   "CiscoXRQosPolicyTranslator",
+				ID:          "ciscoxr-qos-policy-ft",
+				VendorConst: "VendorCiscoXR",
+				HumanName:   "Cisco XR qos policy",
+				Origin:      "Cisco-IOS-XR-TODO-oper",
+			},
+		},
+		{
+			name:        "arista",
+			vendor:      "arista",
+			ftName:      "lldp",
+			description: "translates LLDP neighbors",
+			want: &spec{
+				Vendor:      "arista",
+				Package:     "aristalldp",
+				Description: "translates LLDP neighbors",
+				ConstName:   "AristaLldpTranslator",
+				ID:          "arista-lldp-ft",
+				VendorConst: "VendorArista",
+				HumanName:   "Arista lldp",
+				Origin:      "eos_native",
+			},
+		},
+		{
+			name:        "unknown_vendor",
+			vendor:      "nokia",
+			ftName:      "lldp",
+			description: "translates LLDP neighbors",
+			wantErr:     true,
+		},
+		{
+			name:        "invalid_name",
+			vendor:      "arista",
+			ftName:      "Lldp",
+			description: "translates LLDP neighbors",
+			wantErr:     true,
+		},
+		{
+			name:    "missing_description",
+			vendor:  "arista",
+			ftName:  "lldp",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := newSpec(tc.vendor, tc.ftName, tc.description)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("newSpec(%q, %q, %q) got error %v, want error: %v", tc.vendor, tc.ftName, tc.description, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("newSpec(%q, %q, %q) returned unexpected diff (-want +got):\n%s", tc.vendor, tc.ftName, tc.description, diff)
+			}
+		})
+	}
+}
+
+func TestPackageFiles(t *testing.T) {
+	s, err := newSpec("arista", "lldp", "translates LLDP neighbors")
+	if err != nil {
+		t.Fatalf("newSpec got unexpected error: %v", err)
+	}
+	files, err := packageFiles(s)
+	if err != nil {
+		t.Fatalf("packageFiles got unexpected error: %v", err)
+	}
+	wantContains := map[string][]string{
+		"aristalldp.go": {
+			"package aristalldp",
+			"ID:               ftconsts.AristaLldpTranslator,",
+			"Vendor: ftconsts.VendorArista,",
+			`log.Fatalf("Failed to create Arista lldp functional translator: %v", err)`,
+			"registry.Register(ftconsts.AristaLldpTranslator, NewE)",
+			"Target: n.GetPrefix().GetTarget(),",
+			"Timestamp: n.GetTimestamp(),",
+		},
+		"aristalldp_test.go": {
+			`fttest.RunGoldenTests(t, New(), "testdata")`,
+		},
+		"testdata/unmatched_input.txt": {
+			`origin: "eos_native"`,
+		},
+	}
+	if len(files) != len(wantContains) {
+		t.Errorf("packageFiles got %d files, want %d", len(files), len(wantContains))
+	}
+	for name, want := range wantContains {
+		got, ok := files[name]
+		if !ok {
+			t.Errorf("packageFiles did not return %s", name)
+			continue
+		}
+		for _, w := range want {
+			if !strings.Contains(string(got), w) {
+				t.Errorf("%s does not contain %q:\n%s", name, w, got)
+			}
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	s, err := newSpec("ciscoxr", "lldp", "provides LLDP neighbors")
+	if err != nil {
+		t.Fatalf("newSpec got unexpected error: %v", err)
+	}
+	consts, err := os.ReadFile("../../ftconsts/consts.go")
+	if err != nil {
+		t.Fatalf("failed to read consts: %v", err)
+	}
+	gotConsts, err := addConst(consts, s)
+	if err != nil {
+		t.Fatalf("addConst got unexpected error: %v", err)
+	}
+	wantConst := "\t// CiscoXRLldpTranslator is the name of a translator that provides LLDP neighbors.\n\tCiscoXRLldpTranslator = \"ciscoxr-lldp-ft\"\n)\n"
+	if !strings.HasSuffix(string(gotConsts), wantConst) {
+		t.Errorf("addConst did not append %q:\n%s", wantConst, gotConsts)
+	}
+	if _, err := addConst(gotConsts, s); err == nil {
+		t.Errorf("addConst of an existing constant got nil error, want error")
+	}
+
+	registrar, err := os.ReadFile("../../registrar/registrar.go")
+	if err != nil {
+		t.Fatalf("failed to read registrar: %v", err)
+	}
+	gotRegistrar, err := addRegistration(registrar, s)
+	if err != nil {
+		t.Fatalf("addRegistration got unexpected error: %v", err)
+	}
+	lines := strings.Split(string(gotRegistrar), "\n")
+	for _, want := range [][]string{
+		{
+			`	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrlagmac"`,
+			`	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrlldp"`,
+			`	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrmount"`,
+		},
+		{
+			"		ftconsts.CiscoXRLagMacFunctionalTranslator:                        ciscoxrlagmac.New(),",
+			"		ftconsts.CiscoXRLldpTranslator:                                    ciscoxrlldp.New(),",
+			"		ftconsts.CiscoXRMountTranslator:                                   ciscoxrmount.New(),",
+		},
+	} {
+		i := 0
+		for i < len(lines) && lines[i] != want[0] {
+			i++
+		}
+		if i+len(want) > len(lines) || !cmp.Equal(lines[i:i+len(want)], want) {
+			t.Errorf("addRegistration did not insert in sorted order, want lines:\n%s\ngot:\n%s", strings.Join(want, "\n"), gotRegistrar)
+		}
+	}
+	if _, err := addRegistration(gotRegistrar, s); err == nil {
+		t.Errorf("addRegistration of a registered package got nil error, want error")
+	}
+}
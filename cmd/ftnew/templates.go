@@ -0,0 +1,169 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+const licenseHeader = `// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+`
+
+const translatorTemplate = licenseHeader + `
+// Package {{.Package}} {{.Description}}.
+package {{.Package}}
+
+import (
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var (
+	// TODO: Replace with the openconfig paths emitted and the native paths they are built from.
+	translateMap = map[string][]string{
+		"/openconfig/TODO/output/path": {
+			"/{{.Origin}}/TODO/input/path",
+		},
+	}
+	paths = ftutilities.MustStringMapPaths(translateMap)
+	// pathPatterns are the native leaves handled by translate. "*" matches any element name; keys
+	// are ignored when matching.
+	pathPatterns = []*gnmipb.Path{
+		{
+			Origin: "{{.Origin}}",
+			Elem: []*gnmipb.PathElem{
+				{Name: "TODO"}, {Name: "input"}, {Name: "path"},
+			},
+		},
+	}
+)
+
+func init() {
+	registry.Register(ftconsts.{{.ConstName}}, NewE)
+}
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create {{.HumanName}} functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	return translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:               ftconsts.{{.ConstName}},
+			Translate:        translate,
+			OutputToInputMap: paths,
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.{{.VendorConst}},
+				},
+			},
+		},
+	)
+}
+
+// toOpenConfig translates a single native update to openconfig, or returns nil if the update is
+// not translated.
+func toOpenConfig(path *gnmipb.Path, val *gnmipb.TypedValue) *gnmipb.Update {
+	// TODO: Build the openconfig path from the native path. Do not set the origin or target, they
+	// are set in the prefix of the returned notification.
+	return nil
+}
+
+func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	var updates []*gnmipb.Update
+	for _, u := range n.GetUpdate() {
+		path := ftutilities.Join(n.GetPrefix(), u.GetPath())
+		if !ftutilities.PathInList(path, pathPatterns) {
+			continue
+		}
+		if oc := toOpenConfig(path, u.GetVal()); oc != nil {
+			updates = append(updates, oc)
+		}
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+			},
+		},
+	}, nil
+}
+`
+
+const testTemplate = licenseHeader + `
+package {{.Package}}
+
+import (
+	"testing"
+
+	"github.com/openconfig/functional-translators/fttest"
+)
+
+// TestTranslate runs the golden-file test cases of testdata. TODO: Add cases with fixtures for
+// the translated paths, as <name>_input.txt and <name>_output.txt.
+func TestTranslate(t *testing.T) {
+	fttest.RunGoldenTests(t, New(), "testdata")
+}
+`
+
+const unmatchedInputTemplate = `update: {
+  timestamp: 123
+  prefix: {
+    origin: "{{.Origin}}"
+    target: "dut"
+  }
+  update: {
+    path: {
+      elem: {
+        name: "unmatched"
+      }
+    }
+    val: {
+      string_val: "ignored"
+    }
+  }
+}
+`
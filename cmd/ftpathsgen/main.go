@@ -0,0 +1,185 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The ftpathsgen command generates the ftpaths package, which exports the openconfig output paths
+// of the registered functional translators as constants, so that Go consumers do not duplicate
+// them as strings. It is run from the root of the repository by generate.sh:
+//
+//	go run ./cmd/ftpathsgen -out ftpaths/paths.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/golang/glog"
+	"github.com/openconfig/functional-translators/registrar"
+	"github.com/openconfig/functional-translators/translator"
+)
+
+var (
+	outFile    = flag.String("out", "ftpaths/paths.go", "Path of the generated file.")
+	constsFile = flag.String("consts", "ftconsts/consts.go", "Path of the ftconsts source, used to refer to the translator IDs by name.")
+)
+
+const header = `// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by ftpathsgen. DO NOT EDIT.
+
+`
+
+// idConstNames returns the names of the string constants of the ftconsts source, keyed by value.
+func idConstNames(src []byte) (map[string]string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "consts.go", src, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ftconsts: %v", err)
+	}
+	names := map[string]string{}
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if i >= len(vs.Values) {
+					continue
+				}
+				lit, ok := vs.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				v, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					return nil, err
+				}
+				names[v] = name.Name
+			}
+		}
+	}
+	return names, nil
+}
+
+// pathConstName returns the name of the constant of an output path, e.g.
+// "InterfacesInterfaceStateCountersInPkts" for
+// "/openconfig/interfaces/interface/state/counters/in-pkts".
+func pathConstName(p string) string {
+	var b strings.Builder
+	elems := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	if elems[0] == "openconfig" {
+		elems = elems[1:]
+	}
+	for _, e := range elems {
+		for _, w := range strings.FieldsFunc(e, func(r rune) bool { return r == '-' || r == '_' || r == ':' }) {
+			b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+		}
+	}
+	return b.String()
+}
+
+// generate returns the source of the ftpaths package for the given translators.
+func generate(fts map[string]*translator.FunctionalTranslator, idNames map[string]string) ([]byte, error) {
+	pathNames := map[string]string{}
+	namePaths := map[string]string{}
+	byID := map[string][]string{}
+	for id, ft := range fts {
+		for p := range ft.OutputToInputMap() {
+			name := pathConstName(p)
+			if other, ok := namePaths[name]; ok && other != p {
+				return nil, fmt.Errorf("paths %q and %q have the same constant name %s", p, other, name)
+			}
+			pathNames[p] = name
+			namePaths[name] = p
+			byID[id] = append(byID[id], p)
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString("package ftpaths\n\n")
+	b.WriteString("import \"github.com/openconfig/functional-translators/ftconsts\"\n\n")
+	b.WriteString("// Output paths of the functional translators.\nconst (\n")
+	paths := make([]string, 0, len(pathNames))
+	for p := range pathNames {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		fmt.Fprintf(&b, "\t%s Path = %q\n", pathNames[p], p)
+	}
+	b.WriteString(")\n\n")
+
+	b.WriteString("// OutputPaths maps the ID of each registered functional translator to its sorted output paths.\n")
+	b.WriteString("var OutputPaths = map[string][]Path{\n")
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		key := strconv.Quote(id)
+		if name, ok := idNames[id]; ok {
+			key = "ftconsts." + name
+		}
+		fmt.Fprintf(&b, "\t%s: {\n", key)
+		sort.Strings(byID[id])
+		for _, p := range byID[id] {
+			fmt.Fprintf(&b, "\t\t%s,\n", pathNames[p])
+		}
+		b.WriteString("\t},\n")
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
+
+func main() {
+	flag.Parse()
+	src, err := os.ReadFile(*constsFile)
+	if err != nil {
+		log.Exitf("Failed to read %s: %v", *constsFile, err)
+	}
+	idNames, err := idConstNames(src)
+	if err != nil {
+		log.Exit(err)
+	}
+	out, err := generate(registrar.FunctionalTranslatorRegistry, idNames)
+	if err != nil {
+		log.Exitf("Failed to generate paths: %v", err)
+	}
+	if err := os.WriteFile(*outFile, out, 0o644); err != nil {
+		log.Exitf("Failed to write %s: %v", *outFile, err)
+	}
+}
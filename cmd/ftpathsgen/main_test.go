@@ -0,0 +1,72 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/functional-translators/registrar"
+)
+
+func TestPathConstName(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{
+			path: "/openconfig/interfaces/interface/state/counters/in-pkts",
+			want: "InterfacesInterfaceStateCountersInPkts",
+		},
+		{
+			path: "/openconfig/qos/interfaces/interface/output/queues/queue/state/dropped-pkts",
+			want: "QosInterfacesInterfaceOutputQueuesQueueStateDroppedPkts",
+		},
+		{
+			path: "/components/component/state/temperature/instant",
+			want: "ComponentsComponentStateTemperatureInstant",
+		},
+	}
+	for _, tc := range tests {
+		if got := pathConstName(tc.path); got != tc.want {
+			t.Errorf("pathConstName(%q) = %q, want %q", tc.path, got, tc.want)
+		}
+	}
+}
+
+// TestGeneratedInSync fails if ftpaths/paths.go does not match the output paths of the registered
+// translators. Run generate.sh to update it.
+func TestGeneratedInSync(t *testing.T) {
+	src, err := os.ReadFile("../../ftconsts/consts.go")
+	if err != nil {
+		t.Fatalf("Failed to read ftconsts: %v", err)
+	}
+	idNames, err := idConstNames(src)
+	if err != nil {
+		t.Fatalf("idConstNames() returned an unexpected error: %v", err)
+	}
+	want, err := generate(registrar.FunctionalTranslatorRegistry, idNames)
+	if err != nil {
+		t.Fatalf("generate() returned an unexpected error: %v", err)
+	}
+	got, err := os.ReadFile("../../ftpaths/paths.go")
+	if err != nil {
+		t.Fatalf("Failed to read generated paths: %v", err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("ftpaths/paths.go is out of date, run generate.sh (-want +got): %v", diff)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The fttranslate command replays captured gNMI SubscribeResponse streams through functional
+// translators offline, and writes the translated responses.
+//
+// The responses are read from the files given as arguments, in order, or from stdin if there are
+// none or for "-". In the text format, a stream is a sequence of prototext SubscribeResponses
+// separated by lines consisting of "---", as read by ftutilities.ReadSubscribeResponses.
+// In the binary format, a stream is a sequence of size-delimited wire-format SubscribeResponses.
+// The output is written in the same format unless -out_format is set.
+//
+// The translators are either given by ID, or selected from the registry by the vendor, software
+// version and hardware model of the device which produced the capture:
+//
+//	go run ./cmd/fttranslate -translators=ciscoxr-envmon-ft capture.txt
+//	go run ./cmd/fttranslate -vendor=CISCOXR -version=24.4.1 -format=binary < capture.bin
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	log "github.com/golang/glog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/functionaltranslators"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var (
+	translators = flag.String("translators", "", "Comma-separated IDs of the translators to apply. Selected from the registry by -vendor, -version and -hw_model if empty.")
+	vendor      = flag.String("vendor", "", "Vendor of the device, e.g. CISCOXR, used to select the translators.")
+	version     = flag.String("version", "", "Software version of the device, used to select the translators.")
+	hwModel     = flag.String("hw_model", "", "Hardware model of the device, used to select the translators.")
+	format      = flag.String("format", formatText, "Format of the input streams, text or binary.")
+	outFormat   = flag.String("out_format", "", "Format of the output stream, text or binary. Defaults to -format.")
+	outFile     = flag.String("out", "", "Path of the output file. Defaults to stdout.")
+)
+
+const (
+	formatText   = "text"
+	formatBinary = "binary"
+)
+
+// processor translates a response into any number of responses.
+type processor interface {
+	Process(*gnmipb.SubscribeResponse) []*gnmipb.SubscribeResponse
+}
+
+// translatorList applies the translators given by ID, in order.
+type translatorList struct {
+	fts []*translator.FunctionalTranslator
+}
+
+func newTranslatorList(ids []string) (*translatorList, error) {
+	l := &translatorList{}
+	for _, id := range ids {
+		ft, err := registry.New(id)
+		if err != nil {
+			return nil, err
+		}
+		l.fts = append(l.fts, ft)
+	}
+	return l, nil
+}
+
+// Process returns the translated responses of all the translators. A sync response is passed to
+// every translator and returned once.
+func (l *translatorList) Process(sr *gnmipb.SubscribeResponse) []*gnmipb.SubscribeResponse {
+	var out []*gnmipb.SubscribeResponse
+	for _, ft := range l.fts {
+		srs, err := ft.TranslateSplit(sr)
+		if err != nil {
+			log.Errorf("Functional translator %s failed to translate: %v", ft.ID(), err)
+			continue
+		}
+		if !translator.IsSyncResponse(sr) {
+			out = append(out, srs...)
+		}
+	}
+	if translator.IsSyncResponse(sr) {
+		out = append(out, sr)
+	}
+	return out
+}
+
+// readBinary returns the responses of a binary stream.
+func readBinary(r io.Reader) ([]*gnmipb.SubscribeResponse, error) {
+	var srs []*gnmipb.SubscribeResponse
+	br := bufio.NewReader(r)
+	for {
+		sr := &gnmipb.SubscribeResponse{}
+		err := protodelim.UnmarshalFrom(br, sr)
+		if errors.Is(err, io.EOF) {
+			return srs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response %d: %v", len(srs)+1, err)
+		}
+		srs = append(srs, sr)
+	}
+}
+
+func readResponses(r io.Reader, format string) ([]*gnmipb.SubscribeResponse, error) {
+	switch format {
+	case formatText:
+		return ftutilities.ReadSubscribeResponses(r)
+	case formatBinary:
+		return readBinary(r)
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func writeResponses(w io.Writer, srs []*gnmipb.SubscribeResponse, format string) error {
+	for i, sr := range srs {
+		switch format {
+		case formatText:
+			if i > 0 {
+				if _, err := fmt.Fprintln(w, ftutilities.SubscribeResponseSeparator); err != nil {
+					return err
+				}
+			}
+			b, err := prototext.MarshalOptions{Multiline: true}.Marshal(sr)
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(b); err != nil {
+				return err
+			}
+		case formatBinary:
+			if _, err := protodelim.MarshalTo(w, sr); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown format %q", format)
+		}
+	}
+	return nil
+}
+
+// run translates the responses of the input streams and writes them to w.
+func run(p processor, inputs []string, stdin io.Reader, w io.Writer, inFormat, outFormat string) error {
+	if len(inputs) == 0 {
+		inputs = []string{"-"}
+	}
+	var out []*gnmipb.SubscribeResponse
+	for _, in := range inputs {
+		r := stdin
+		if in != "-" {
+			f, err := os.Open(in)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		srs, err := readResponses(r, inFormat)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %v", in, err)
+		}
+		for _, sr := range srs {
+			out = append(out, p.Process(sr)...)
+		}
+	}
+	return writeResponses(w, out, outFormat)
+}
+
+func main() {
+	flag.Parse()
+	var p processor
+	if *translators != "" {
+		l, err := newTranslatorList(strings.Split(*translators, ","))
+		if err != nil {
+			log.Exit(err)
+		}
+		p = l
+	} else {
+		if *vendor == "" {
+			log.Exit("Either -translators or -vendor must be set")
+		}
+		pipeline, err := functionaltranslators.New(*vendor, *version, &functionaltranslators.Options{HardwareModel: *hwModel})
+		if err != nil {
+			log.Exit(err)
+		}
+		p = pipeline
+	}
+	if *outFormat == "" {
+		*outFormat = *format
+	}
+	w := os.Stdout
+	if *outFile != "" {
+		f, err := os.Create(*outFile)
+		if err != nil {
+			log.Exitf("Failed to create %s: %v", *outFile, err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := run(p, flag.Args(), os.Stdin, w, *format, *outFormat); err != nil {
+		log.Exit(err)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/functional-translators/ftconsts"
+	"github.com/openconfig/functional-translators/ftutilities"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	psuInput  = "../../ciscoxr/ciscoxrpsu/testdata/readings_input.txt"
+	psuOutput = "../../ciscoxr/ciscoxrpsu/testdata/readings_output.txt"
+)
+
+var syncSR = &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true}}
+
+func TestRun(t *testing.T) {
+	input, err := os.ReadFile(psuInput)
+	if err != nil {
+		t.Fatalf("failed to read input: %v", err)
+	}
+	wantSR, err := ftutilities.LoadSubscribeResponse(psuOutput)
+	if err != nil {
+		t.Fatalf("failed to load want message: %v", err)
+	}
+	// The stream holds the input, a sync response and the input again.
+	stream := string(input) + "---\nsync_response: true\n---\n" + string(input)
+	p, err := newTranslatorList([]string{ftconsts.CiscoXRPowerSupplyTranslator})
+	if err != nil {
+		t.Fatalf("newTranslatorList() got unexpected error: %v", err)
+	}
+
+	for _, outFormat := range []string{formatText, formatBinary} {
+		t.Run(outFormat, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := run(p, nil, strings.NewReader(stream), &out, formatText, outFormat); err != nil {
+				t.Fatalf("run() got unexpected error: %v", err)
+			}
+			got, err := readResponses(&out, outFormat)
+			if err != nil {
+				t.Fatalf("readResponses() got unexpected error: %v", err)
+			}
+			want := []*gnmipb.SubscribeResponse{wantSR, syncSR, wantSR}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("run() returned an unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRunFiles(t *testing.T) {
+	p, err := newTranslatorList([]string{ftconsts.CiscoXRPowerSupplyTranslator})
+	if err != nil {
+		t.Fatalf("newTranslatorList() got unexpected error: %v", err)
+	}
+	var out bytes.Buffer
+	if err := run(p, []string{psuInput, "-"}, strings.NewReader("sync_response: true\n"), &out, formatText, formatText); err != nil {
+		t.Fatalf("run() got unexpected error: %v", err)
+	}
+	got, err := readResponses(&out, formatText)
+	if err != nil {
+		t.Fatalf("readResponses() got unexpected error: %v", err)
+	}
+	if len(got) != 2 || !got[1].GetSyncResponse() {
+		t.Errorf("run() returned %v, want the translated input and a sync response", got)
+	}
+	if err := run(p, []string{"testdata/missing.txt"}, nil, &out, formatText, formatText); err == nil {
+		t.Errorf("run() of a missing file got nil error, want error")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := newTranslatorList([]string{"unknown-ft"}); err == nil {
+		t.Errorf("newTranslatorList() of an unknown translator got nil error, want error")
+	}
+	if _, err := readResponses(strings.NewReader("update: {"), formatText); err == nil {
+		t.Errorf("readResponses() of invalid prototext got nil error, want error")
+	}
+	if _, err := readResponses(strings.NewReader("\x05ab"), formatBinary); err == nil {
+		t.Errorf("readResponses() of a truncated binary stream got nil error, want error")
+	}
+	if _, err := readResponses(strings.NewReader(""), "json"); err == nil {
+		t.Errorf("readResponses() of an unknown format got nil error, want error")
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configtranslator builds functional translators from declarative rule files, so that
+// simple 1-1 leaf remaps can be added at deployment time without writing Go code.
+//
+// Like simplemapper, each rule maps an input path pattern to an output path template, and
+// variables such as "<name>" carry key values from the input to the output. Unlike simplemapper,
+// the rules do not need ygot schemas: paths are matched directly against the notifications, and
+// variables may also be used as element names, which is how names appear in eos_native paths.
+// Values may be converted with a Transform and remapped with a ValueMap, e.g. to translate native
+// enums to openconfig ones.
+//
+// Deletes are translated when the deleted path matches the input pattern of a rule.
+package configtranslator
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	log "github.com/openconfig/functional-translators/ftlog"
+	"google.golang.org/protobuf/proto"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+type compiledRule struct {
+	input     *gnmipb.Path
+	output    *gnmipb.Path
+	transform Transform
+	valueMap  map[string]string
+}
+
+type configTranslator struct {
+	rules []*compiledRule
+}
+
+// NewFromFile creates a functional translator from a textproto rule file. See ParseConfig.
+func NewFromFile(path string) (*translator.FunctionalTranslator, error) {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(cfg)
+}
+
+// New creates a functional translator from a Config.
+func New(cfg *Config) (*translator.FunctionalTranslator, error) {
+	if len(cfg.Rules) == 0 {
+		return nil, fmt.Errorf("%s has no rules", cfg.ID)
+	}
+	t := &configTranslator{}
+	outputToInput := map[string][]*gnmipb.Path{}
+	for _, r := range cfg.Rules {
+		cr, err := compile(r)
+		if err != nil {
+			return nil, fmt.Errorf("%s has an invalid rule %q -> %q: %v", cfg.ID, r.Input, r.Output, err)
+		}
+		t.rules = append(t.rules, cr)
+		out := ftutilities.GNMIPathToSchemaString(&gnmipb.Path{Origin: "openconfig", Elem: cr.output.GetElem()}, false)
+		outputToInput[out] = append(outputToInput[out], subscriptionPath(cr.input))
+	}
+	return translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:               cfg.ID,
+			Translate:        t.translate,
+			OutputToInputMap: outputToInput,
+			Metadata:         cfg.Metadata,
+		},
+	)
+}
+
+func isVar(s string) bool {
+	return len(s) > 2 && strings.HasPrefix(s, "<") && strings.HasSuffix(s, ">")
+}
+
+// parsePath converts a rule path to a gNMI path. The path must start with a valid origin.
+func parsePath(s string) (*gnmipb.Path, error) {
+	origin, elems, _ := strings.Cut(strings.TrimPrefix(s, "/"), "/")
+	if _, ok := ftutilities.ValidOrigins[origin]; !ok {
+		return nil, fmt.Errorf("path %q does not start with a valid origin", s)
+	}
+	path, err := ygot.StringToStructuredPath(elems)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %q to a gNMI path: %v", s, err)
+	}
+	if len(path.GetElem()) == 0 {
+		return nil, fmt.Errorf("path %q has no elements", s)
+	}
+	path.Origin = origin
+	return path, nil
+}
+
+func compile(r *Rule) (*compiledRule, error) {
+	if r.Transform < TransformNone || r.Transform > TransformBool {
+		return nil, fmt.Errorf("unknown transform %d", r.Transform)
+	}
+	input, err := parsePath(r.Input)
+	if err != nil {
+		return nil, err
+	}
+	output, err := parsePath(r.Output)
+	if err != nil {
+		return nil, err
+	}
+	if output.GetOrigin() != "openconfig" {
+		return nil, fmt.Errorf("output origin is %q, want openconfig", output.GetOrigin())
+	}
+	bound := map[string]bool{}
+	for _, e := range input.GetElem() {
+		vars := []string{e.GetName()}
+		for _, v := range e.GetKey() {
+			vars = append(vars, v)
+		}
+		for _, v := range vars {
+			if !isVar(v) {
+				continue
+			}
+			if bound[v] {
+				return nil, fmt.Errorf("input uses variable %s more than once", v)
+			}
+			bound[v] = true
+		}
+	}
+	for _, e := range output.GetElem() {
+		if isVar(e.GetName()) {
+			return nil, fmt.Errorf("output uses variable %s as an element name", e.GetName())
+		}
+		for _, v := range e.GetKey() {
+			if isVar(v) && !bound[v] {
+				return nil, fmt.Errorf("output variable %s is not bound by the input", v)
+			}
+		}
+	}
+	output.Origin = ""
+	return &compiledRule{
+		input:     input,
+		output:    output,
+		transform: r.Transform,
+		valueMap:  r.ValueMap,
+	}, nil
+}
+
+// subscriptionPath returns the input pattern with its variables replaced by wildcards.
+func subscriptionPath(input *gnmipb.Path) *gnmipb.Path {
+	ret := proto.Clone(input).(*gnmipb.Path)
+	for _, e := range ret.GetElem() {
+		if isVar(e.GetName()) {
+			e.Name = "*"
+		}
+		for k, v := range e.GetKey() {
+			if isVar(v) {
+				e.Key[k] = "*"
+			}
+		}
+	}
+	return ret
+}
+
+// match returns the variable bindings of path if it matches the input pattern. As in
+// ftutilities.MatchPath, the origin is ignored, since it is not set by all devices.
+func match(pattern, path *gnmipb.Path) (map[string]string, bool) {
+	if len(pattern.GetElem()) != len(path.GetElem()) {
+		return nil, false
+	}
+	bindings := map[string]string{}
+	for i, pe := range pattern.GetElem() {
+		e := path.GetElem()[i]
+		switch {
+		case isVar(pe.GetName()):
+			bindings[pe.GetName()] = e.GetName()
+		case pe.GetName() != "*" && pe.GetName() != e.GetName():
+			return nil, false
+		}
+		for k, v := range pe.GetKey() {
+			got, ok := e.GetKey()[k]
+			switch {
+			case !ok:
+				return nil, false
+			case isVar(v):
+				bindings[v] = got
+			case v != "*" && v != got:
+				return nil, false
+			}
+		}
+	}
+	return bindings, true
+}
+
+// apply returns the output template with its variables substituted.
+func apply(bindings map[string]string, output *gnmipb.Path) *gnmipb.Path {
+	ret := proto.Clone(output).(*gnmipb.Path)
+	for _, e := range ret.GetElem() {
+		for k, v := range e.GetKey() {
+			if isVar(v) {
+				e.Key[k] = bindings[v]
+			}
+		}
+	}
+	return ret
+}
+
+func toInt(v *gnmipb.TypedValue) (int64, error) {
+	switch t := v.GetValue().(type) {
+	case *gnmipb.TypedValue_IntVal:
+		return t.IntVal, nil
+	case *gnmipb.TypedValue_UintVal:
+		if t.UintVal > math.MaxInt64 {
+			return 0, fmt.Errorf("value %d overflows int64", t.UintVal)
+		}
+		return int64(t.UintVal), nil
+	case *gnmipb.TypedValue_StringVal:
+		return strconv.ParseInt(t.StringVal, 10, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", t)
+	}
+}
+
+func toBool(v *gnmipb.TypedValue) (bool, error) {
+	switch t := v.GetValue().(type) {
+	case *gnmipb.TypedValue_BoolVal:
+		return t.BoolVal, nil
+	case *gnmipb.TypedValue_StringVal:
+		return strconv.ParseBool(t.StringVal)
+	default:
+		return false, fmt.Errorf("cannot convert %T to bool", t)
+	}
+}
+
+// value returns the translated value of a native leaf.
+func (r *compiledRule) value(v *gnmipb.TypedValue) (*gnmipb.TypedValue, error) {
+	if r.valueMap != nil {
+		s, err := ftutilities.ToString(v)
+		if err != nil {
+			return nil, err
+		}
+		mapped, ok := r.valueMap[s]
+		if !ok {
+			return nil, fmt.Errorf("value %q is not in the value map", s)
+		}
+		v = &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: mapped}}
+	}
+	switch r.transform {
+	case TransformString:
+		s, err := ftutilities.ToString(v)
+		if err != nil {
+			return nil, err
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: s}}, nil
+	case TransformUint:
+		u, err := ftutilities.ToUint64(v)
+		if err != nil {
+			return nil, err
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: u}}, nil
+	case TransformInt:
+		i, err := toInt(v)
+		if err != nil {
+			return nil, err
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: i}}, nil
+	case TransformDouble:
+		d, err := ftutilities.ToDouble(v)
+		if err != nil {
+			return nil, err
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: d}}, nil
+	case TransformBool:
+		b, err := toBool(v)
+		if err != nil {
+			return nil, err
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: b}}, nil
+	default:
+		return v, nil
+	}
+}
+
+func (t *configTranslator) translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	var updates []*gnmipb.Update
+	var deletes []*gnmipb.Path
+	for _, u := range n.GetUpdate() {
+		path := ftutilities.Join(n.GetPrefix(), u.GetPath())
+		for _, r := range t.rules {
+			bindings, ok := match(r.input, path)
+			if !ok {
+				continue
+			}
+			val, err := r.value(u.GetVal())
+			if err != nil {
+				log.Errorf("Failed to translate update %v: %v", u, err)
+				continue
+			}
+			updates = append(updates, &gnmipb.Update{Path: apply(bindings, r.output), Val: val})
+		}
+	}
+	for _, d := range n.GetDelete() {
+		path := ftutilities.Join(n.GetPrefix(), d)
+		for _, r := range t.rules {
+			if bindings, ok := match(r.input, path); ok {
+				deletes = append(deletes, apply(bindings, r.output))
+			}
+		}
+	}
+	if len(updates) == 0 && len(deletes) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+				Delete: deletes,
+			},
+		},
+	}, nil
+}
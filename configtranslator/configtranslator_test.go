@@ -0,0 +1,239 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtranslator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestParseConfig(t *testing.T) {
+	got, err := LoadConfig("testdata/rules.textproto")
+	if err != nil {
+		t.Fatalf("LoadConfig() got unexpected error: %v", err)
+	}
+	want := &Config{
+		ID:       "arista-interface-rules-ft",
+		Metadata: []*translator.FTMetadata{{Vendor: "arista"}},
+		Rules: []*Rule{
+			{
+				Input:     "/eos_native/Sysdb/interface/status/eth/phy/slice/1/intfStatus/<name>/mtu",
+				Output:    "/openconfig/interfaces/interface[name=<name>]/state/mtu",
+				Transform: TransformUint,
+			},
+			{
+				Input:    "/eos_native/Sysdb/interface/status/eth/phy/slice/1/intfStatus/<name>/operStatus/Name",
+				Output:   "/openconfig/interfaces/interface[name=<name>]/state/oper-status",
+				ValueMap: map[string]string{"intfOperUp": "UP", "intfOperDown": "DOWN"},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("LoadConfig() returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	if _, err := ParseConfig([]byte(`rule { unknown_field: "x" }`)); err == nil {
+		t.Errorf("ParseConfig() of an unknown field got nil error, want error")
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *Rule
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			rule: &Rule{Input: "/eos_native/a/<x>/b", Output: "/openconfig/c/d[name=<x>]/e"},
+		},
+		{
+			name:    "input_without_origin",
+			rule:    &Rule{Input: "/a/<x>/b", Output: "/openconfig/c/d[name=<x>]/e"},
+			wantErr: true,
+		},
+		{
+			name:    "output_not_openconfig",
+			rule:    &Rule{Input: "/eos_native/a/<x>/b", Output: "/eos_native/c/d[name=<x>]/e"},
+			wantErr: true,
+		},
+		{
+			name:    "unbound_output_variable",
+			rule:    &Rule{Input: "/eos_native/a/<x>/b", Output: "/openconfig/c/d[name=<y>]/e"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate_input_variable",
+			rule:    &Rule{Input: "/eos_native/a/<x>/b[name=<x>]", Output: "/openconfig/c/d[name=<x>]/e"},
+			wantErr: true,
+		},
+		{
+			name:    "output_element_variable",
+			rule:    &Rule{Input: "/eos_native/a/<x>/b", Output: "/openconfig/c/<x>/e"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown_transform",
+			rule:    &Rule{Input: "/eos_native/a/<x>/b", Output: "/openconfig/c/d[name=<x>]/e", Transform: 42},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(&Config{ID: "test-ft", Rules: []*Rule{tc.rule}})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New() got error %v, want error: %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestOutputToInputMap(t *testing.T) {
+	ft, err := NewFromFile("testdata/rules.textproto")
+	if err != nil {
+		t.Fatalf("NewFromFile() got unexpected error: %v", err)
+	}
+	want := ftutilities.MustStringMapPaths(map[string][]string{
+		"/openconfig/interfaces/interface/state/mtu": {
+			"/eos_native/Sysdb/interface/status/eth/phy/slice/1/intfStatus/*/mtu",
+		},
+		"/openconfig/interfaces/interface/state/oper-status": {
+			"/eos_native/Sysdb/interface/status/eth/phy/slice/1/intfStatus/*/operStatus/Name",
+		},
+	})
+	if diff := cmp.Diff(want, ft.OutputToInputMap(), protocmp.Transform()); diff != "" {
+		t.Errorf("OutputToInputMap() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	ft, err := NewFromFile("testdata/rules.textproto")
+	if err != nil {
+		t.Fatalf("NewFromFile() got unexpected error: %v", err)
+	}
+	tests := []struct {
+		name           string
+		inputPath      string
+		wantOutputPath string
+	}{
+		{
+			name:           "updates_and_deletes",
+			inputPath:      "testdata/updates_input.txt",
+			wantOutputPath: "testdata/updates_output.txt",
+		},
+		{
+			name:      "unmatched",
+			inputPath: "testdata/unmatched_input.txt",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			inputSR, err := ftutilities.LoadSubscribeResponse(tc.inputPath)
+			if err != nil {
+				t.Fatalf("failed to load input message: %v", err)
+			}
+			var wantSR *gnmipb.SubscribeResponse
+			if tc.wantOutputPath != "" {
+				wantSR, err = ftutilities.LoadSubscribeResponse(tc.wantOutputPath)
+				if err != nil {
+					t.Fatalf("failed to load want message: %v", err)
+				}
+			}
+			gotSR, err := ft.Translate(inputSR)
+			if err != nil {
+				t.Fatalf("Translate() returned unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(wantSR, gotSR, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update", "delete")); diff != "" {
+				t.Errorf("Translate() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *compiledRule
+		in      *gnmipb.TypedValue
+		want    *gnmipb.TypedValue
+		wantErr bool
+	}{
+		{
+			name: "none",
+			rule: &compiledRule{},
+			in:   &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: -1}},
+			want: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: -1}},
+		},
+		{
+			name: "string_from_double",
+			rule: &compiledRule{transform: TransformString},
+			in:   &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: 1.5}},
+			want: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "1.5"}},
+		},
+		{
+			name: "uint_from_string",
+			rule: &compiledRule{transform: TransformUint},
+			in:   &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "42"}},
+			want: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 42}},
+		},
+		{
+			name:    "uint_from_negative_int",
+			rule:    &compiledRule{transform: TransformUint},
+			in:      &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: -1}},
+			wantErr: true,
+		},
+		{
+			name: "int_from_uint",
+			rule: &compiledRule{transform: TransformInt},
+			in:   &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 7}},
+			want: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: 7}},
+		},
+		{
+			name: "double_from_float",
+			rule: &compiledRule{transform: TransformDouble},
+			in:   &gnmipb.TypedValue{Value: &gnmipb.TypedValue_FloatVal{FloatVal: 0.5}},
+			want: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: 0.5}},
+		},
+		{
+			name: "mapped_bool",
+			rule: &compiledRule{transform: TransformBool, valueMap: map[string]string{"enabled": "true"}},
+			in:   &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "enabled"}},
+			want: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: true}},
+		},
+		{
+			name:    "unmapped_value",
+			rule:    &compiledRule{valueMap: map[string]string{"enabled": "true"}},
+			in:      &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "disabled"}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.rule.value(tc.in)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("value(%v) got error %v, want error: %t", tc.in, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("value(%v) returned unexpected diff (-want +got):\n%s", tc.in, diff)
+			}
+		})
+	}
+}
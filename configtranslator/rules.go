@@ -0,0 +1,239 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtranslator
+
+import (
+	"fmt"
+	"os"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"github.com/openconfig/functional-translators/translator"
+)
+
+// Transform is the conversion applied to the value of a native leaf before it is emitted.
+type Transform int32
+
+const (
+	// TransformNone emits the value unchanged.
+	TransformNone Transform = iota
+	// TransformString emits the value as a string.
+	TransformString
+	// TransformUint emits the value as an unsigned integer.
+	TransformUint
+	// TransformInt emits the value as a signed integer.
+	TransformInt
+	// TransformDouble emits the value as a double.
+	TransformDouble
+	// TransformBool emits the value as a bool.
+	TransformBool
+)
+
+// Rule maps a native leaf to an openconfig leaf.
+type Rule struct {
+	// Input is the native path pattern, starting with its origin. Variables, e.g. "<name>", may be
+	// used as key values or element names.
+	Input string
+	// Output is the openconfig path template. It may only use variables bound by Input.
+	Output string
+	// Transform is the conversion applied to the value, after ValueMap.
+	Transform Transform
+	// ValueMap, if set, maps the string representation of the native value to the emitted value.
+	// Values which are not in the map are dropped.
+	ValueMap map[string]string
+}
+
+// Config is the description of a functional translator.
+type Config struct {
+	ID       string
+	Metadata []*translator.FTMetadata
+	Rules    []*Rule
+}
+
+const rulesPackage = "functional_translators.configtranslator"
+
+// rulesDescriptor describes the textproto rule file format. It is built at init time rather than
+// generated from a .proto file so that the package does not need generated code:
+//
+//	enum Transform {
+//	  TRANSFORM_UNSPECIFIED = 0;
+//	  STRING = 1;
+//	  UINT = 2;
+//	  INT = 3;
+//	  DOUBLE = 4;
+//	  BOOL = 5;
+//	}
+//	message ValueMapping {
+//	  string from = 1;
+//	  string to = 2;
+//	}
+//	message Rule {
+//	  string input = 1;
+//	  string output = 2;
+//	  Transform transform = 3;
+//	  repeated ValueMapping value_map = 4;
+//	}
+//	message Metadata {
+//	  string vendor = 1;
+//	  string hardware_model = 2;
+//	  string software_version = 3;
+//	}
+//	message Rules {
+//	  string id = 1;
+//	  repeated Metadata metadata = 2;
+//	  repeated Rule rule = 3;
+//	}
+var rulesDescriptor = mustRulesDescriptor()
+
+func field(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string, repeated bool) *descriptorpb.FieldDescriptorProto {
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(number),
+		Type:   typ.Enum(),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	if repeated {
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	}
+	if typeName != "" {
+		f.TypeName = proto.String("." + rulesPackage + "." + typeName)
+	}
+	return f
+}
+
+func mustRulesDescriptor() protoreflect.MessageDescriptor {
+	const (
+		str = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		msg = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+		enm = descriptorpb.FieldDescriptorProto_TYPE_ENUM
+	)
+	var transforms []*descriptorpb.EnumValueDescriptorProto
+	for i, name := range []string{"TRANSFORM_UNSPECIFIED", "STRING", "UINT", "INT", "DOUBLE", "BOOL"} {
+		transforms = append(transforms, &descriptorpb.EnumValueDescriptorProto{Name: proto.String(name), Number: proto.Int32(int32(i))})
+	}
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("configtranslator/rules.proto"),
+		Package: proto.String(rulesPackage),
+		Syntax:  proto.String("proto3"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{
+			{Name: proto.String("Transform"), Value: transforms},
+		},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("ValueMapping"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("from", 1, str, "", false),
+					field("to", 2, str, "", false),
+				},
+			},
+			{
+				Name: proto.String("Rule"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("input", 1, str, "", false),
+					field("output", 2, str, "", false),
+					field("transform", 3, enm, "Transform", false),
+					field("value_map", 4, msg, "ValueMapping", true),
+				},
+			},
+			{
+				Name: proto.String("Metadata"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("vendor", 1, str, "", false),
+					field("hardware_model", 2, str, "", false),
+					field("software_version", 3, str, "", false),
+				},
+			},
+			{
+				Name: proto.String("Rules"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("id", 1, str, "", false),
+					field("metadata", 2, msg, "Metadata", true),
+					field("rule", 3, msg, "Rule", true),
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		panic(fmt.Sprintf("invalid rules descriptor: %v", err))
+	}
+	return fd.Messages().ByName("Rules")
+}
+
+// getString returns the string field of a message.
+func getString(m protoreflect.Message, name protoreflect.Name) string {
+	return m.Get(m.Descriptor().Fields().ByName(name)).String()
+}
+
+// getList returns the repeated message field of a message.
+func getList(m protoreflect.Message, name protoreflect.Name) []protoreflect.Message {
+	l := m.Get(m.Descriptor().Fields().ByName(name)).List()
+	ret := make([]protoreflect.Message, 0, l.Len())
+	for i := 0; i < l.Len(); i++ {
+		ret = append(ret, l.Get(i).Message())
+	}
+	return ret
+}
+
+// ParseConfig parses a textproto rule file, e.g.
+//
+//	id: "arista-interface-mtu-ft"
+//	metadata { vendor: "arista" }
+//	rule {
+//	  input: "/eos_native/Sysdb/interface/status/eth/phy/slice/1/intfStatus/<name>/mtu"
+//	  output: "/openconfig/interfaces/interface[name=<name>]/state/mtu"
+//	  transform: UINT
+//	}
+func ParseConfig(b []byte) (*Config, error) {
+	m := dynamicpb.NewMessage(rulesDescriptor)
+	if err := prototext.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("failed to parse rules: %v", err)
+	}
+	cfg := &Config{ID: getString(m, "id")}
+	for _, md := range getList(m, "metadata") {
+		cfg.Metadata = append(cfg.Metadata, &translator.FTMetadata{
+			Vendor:          getString(md, "vendor"),
+			HardwareModel:   getString(md, "hardware_model"),
+			SoftwareVersion: getString(md, "software_version"),
+		})
+	}
+	for _, r := range getList(m, "rule") {
+		rule := &Rule{
+			Input:     getString(r, "input"),
+			Output:    getString(r, "output"),
+			Transform: Transform(r.Get(r.Descriptor().Fields().ByName("transform")).Enum()),
+		}
+		for _, vm := range getList(r, "value_map") {
+			if rule.ValueMap == nil {
+				rule.ValueMap = map[string]string{}
+			}
+			rule.ValueMap[getString(vm, "from")] = getString(vm, "to")
+		}
+		cfg.Rules = append(cfg.Rules, rule)
+	}
+	return cfg, nil
+}
+
+// LoadConfig reads and parses a textproto rule file.
+func LoadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	return ParseConfig(b)
+}
@@ -40,6 +40,9 @@ const (
 	// AristaCFMPMFunctionalTranslator is the name of the Arista CFM PM functional translator.
 	AristaCFMPMFunctionalTranslator = "arista-cfm-pm-ft"
 
+	// AristaDropCountersTranslator is the name of the Arista hardware drop counters functional translator.
+	AristaDropCountersTranslator = "arista-drop-counters-ft"
+
 	// AristaInterfaceDescriptionFunctionalTranslator is the name of the Arista interface description functional translator.
 	AristaInterfaceDescriptionFunctionalTranslator = "arista-interface-description-ft"
 
@@ -49,6 +52,10 @@ const (
 	// AristaInterfaceMacFunctionalTranslator is the name of the Arista interface mac address functional translator.
 	AristaInterfaceMacFunctionalTranslator = "arista-interface-mac-ft"
 
+	// AristaLLDPTranslator is the name of the Arista LLDP neighbors functional translator. It is not
+	// registered, see aristalldp.
+	AristaLLDPTranslator = "arista-lldp-ft"
+
 	// AristaMacsecStateFunctionalTranslator is the name of the Arista MACSec ckn and status functional translator.
 	AristaMacsecStateFunctionalTranslator = "arista-macsec-state-ft"
 
@@ -58,12 +65,25 @@ const (
 	// AristaPWStateFunctionalTranslator is the name of the Arista pseudowire state functional translator.
 	AristaPWStateFunctionalTranslator = "arista-pw-state-ft"
 
+	// AristaPoETranslator is the name of the Arista power-over-ethernet port status functional translator.
+	AristaPoETranslator = "arista-poe-ft"
+
 	// AristaQoSAggregateCountersTranslator is the name of the Arista QoS aggregate counters functional translator.
 	AristaQoSAggregateCountersTranslator = "arista-qos-aggregate-counters-ft"
 
+	// AristaQoSMapsTranslator is the name of the Arista QoS dscp-to-tc and tc-to-queue maps functional translator.
+	AristaQoSMapsTranslator = "arista-qos-maps-ft"
+
+	// AristaTransceiverTranslator is the name of the Arista transceiver DOM functional translator. It
+	// is not registered, see aristatransceiver.
+	AristaTransceiverTranslator = "arista-transceiver-ft"
+
 	// AristaTransceiverPowerFunctionalTranslator is the name of the Arista transceiver input power functional translator.
 	AristaTransceiverPowerFunctionalTranslator = "arista-transceiver-input-power-ft"
 
+	// AristaXcvrPresenceTranslator is the name of the Arista transceiver presence functional translator.
+	AristaXcvrPresenceTranslator = "arista-xcvr-presence-ft"
+
 	// CiscoXR8000IntegratedCircuitResourceFunctionalTranslator is the name of the identity functional translator.
 	CiscoXR8000IntegratedCircuitResourceFunctionalTranslator = "ciscoxr-8000-integrated-circuit-resource-ft"
 
@@ -73,22 +93,36 @@ const (
 	// CiscoXRCarrierTranslator is the name of a translator that provides phy-carrier-transitions information.
 	CiscoXRCarrierTranslator = "ciscoxr-carrier-ft"
 
+	// CiscoXREnvmonTranslator is the name of a translator that provides environment sensor information.
+	CiscoXREnvmonTranslator = "ciscoxr-envmon-ft"
+
 	// CiscoXRFabricTranslator is the name of a translator that provides fabric information.
 	CiscoXRFabricTranslator = "ciscoxr-fabric-ft"
 
 	// CiscoXRFragmentTranslator is the name of a translator that provides fragment packet drops and packets accepted.
 	CiscoXRFragmentTranslator = "ciscoxr-fragment-ft"
 
-	// CiscoXRFpdTranslator is the name of a translator that provides fpd status translations.
+	// CiscoXRFpdTranslator is the name of a translator that provides fpd status and firmware version
+	// translations.
 	CiscoXRFpdTranslator = "ciscoxr-fpd-ft"
 
+	// CiscoXRIPv4Translator is the name of a translator that provides the primary and secondary IPv4
+	// addresses of the interfaces.
+	CiscoXRIPv4Translator = "ciscoxr-ipv4-ft"
+
 	// CiscoXRIPv6Translator is the name of a translator that provides IPv6 information.
 	CiscoXRIPv6Translator = "ciscoxr-ipv6-ft"
 
+	// CiscoXRInterfaceCountersTranslator is the name of a translator that provides interface
+	// counters from the infra-statsd generic counters. It is not registered, see
+	// ciscoxrintfcounters.
+	CiscoXRInterfaceCountersTranslator = "ciscoxr-interface-counters-ft"
+
 	// CiscoXRLagMacFunctionalTranslator is the name of a translator that provides lag mac address translations.
 	CiscoXRLagMacFunctionalTranslator = "ciscoxr-lagmac-ft"
 
-	// CiscoXRLaserTranslator is the name of a translator that provides laser information.
+	// CiscoXRLaserTranslator is the name of a translator that provides laser information. It is not
+	// registered, see ciscoxrlaser.
 	CiscoXRLaserTranslator = "ciscoxr-laser-ft"
 
 	// CiscoXRMountTranslator is the name of a translator that provides mount information.
@@ -97,16 +131,43 @@ const (
 	// CiscoXRPowerTranslator is the name of a translator that provides power supply state information.
 	CiscoXRPowerTranslator = "ciscoxr-power-ft"
 
+	// CiscoXRPowerSupplyTranslator is the name of a translator that provides power supply readings.
+	CiscoXRPowerSupplyTranslator = "ciscoxr-power-supply-ft"
+
 	// CiscoXRQosTranslator is the name of a translator that provides QOS information.
 	CiscoXRQosTranslator = "ciscoxr-qos-ft"
 
+	// CiscoXRQosPolicyTranslator is the name of a translator that provides the QOS policy attached to
+	// each interface.
+	CiscoXRQosPolicyTranslator = "ciscoxr-qos-policy-ft"
+
+	// CiscoXRSRTEPolicyTranslator is the name of a translator that provides segment-routing
+	// traffic-engineering policy state information.
+	CiscoXRSRTEPolicyTranslator = "ciscoxr-srte-policy-ft"
+
 	// CiscoXRSubinterfaceCounterTranslator is the name of a translator that provides subinterface
-	// counter information, as well as IPv4 address information.
+	// counter information, as well as the primary IPv4 addresses of the interfaces.
 	CiscoXRSubinterfaceCounterTranslator = "ciscoxr-subinterface-counter-ft"
 
+	// CiscoXRSwitchTranslator is the name of a translator that provides the state and counters of
+	// the ports of the internal control-ethernet switches.
+	CiscoXRSwitchTranslator = "ciscoxr-switch-ft"
+
 	// CiscoXRTransceiverTranslator is the name of a translator that provides transceiver information.
 	CiscoXRTransceiverTranslator = "ciscoxr-transceiver-ft"
 
 	// CiscoXRVendorTranslator is the name of a translator that provides Vendor information.
 	CiscoXRVendorDropsTranslator = "ciscoxr-vendordrops-ft"
+
+	// JuniperQueueTranslator is the name of a translator that provides egress queue counters from
+	// the Junos native interface sensor.
+	JuniperQueueTranslator = "juniper-queue-ft"
+
+	// JuniperTransceiverTranslator is the name of a translator that provides transceiver channel
+	// information from the Junos native optics sensor.
+	JuniperTransceiverTranslator = "juniper-transceiver-ft"
+
+	// NokiaTransceiverTranslator is the name of a translator that provides transceiver channel
+	// information from the Nokia SR Linux native interface transceiver state.
+	NokiaTransceiverTranslator = "nokia-transceiver-ft"
 )
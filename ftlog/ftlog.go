@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ftlog is the logger of the functional translators. It logs with glog by default, and
+// applications embedding the translators can replace it with SetLogger.
+//
+// Its functions mirror the subset of glog used by the translators, so that packages import it as:
+//
+//	log "github.com/openconfig/functional-translators/ftlog"
+package ftlog
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/golang/glog"
+)
+
+// Logger logs the messages of the functional translators.
+type Logger interface {
+	Infof(format string, args ...any)
+	Warningf(format string, args ...any)
+	Errorf(format string, args ...any)
+	// Fatalf logs a message on which the translators cannot continue, e.g. in the New function of
+	// an FT which cannot be created. It must not return: it may exit or panic.
+	Fatalf(format string, args ...any)
+	// V returns true if the informational messages of the verbosity level are logged.
+	V(level int) bool
+}
+
+// callerDepth is the number of frames between the caller of the ftlog functions and glog.
+const callerDepth = 2
+
+// glogLogger logs with glog, reporting the callers of the ftlog functions.
+type glogLogger struct{}
+
+func (glogLogger) Infof(format string, args ...any) {
+	glog.InfoDepthf(callerDepth, format, args...)
+}
+
+func (glogLogger) Warningf(format string, args ...any) {
+	glog.WarningDepthf(callerDepth, format, args...)
+}
+
+func (glogLogger) Errorf(format string, args ...any) {
+	glog.ErrorDepthf(callerDepth, format, args...)
+}
+
+func (glogLogger) Fatalf(format string, args ...any) {
+	glog.FatalDepthf(callerDepth, format, args...)
+}
+
+func (glogLogger) V(level int) bool {
+	return bool(glog.V(glog.Level(level)))
+}
+
+// holder wraps the logger, since atomic.Value requires values of a consistent concrete type.
+type holder struct {
+	l Logger
+}
+
+var logger atomic.Value
+
+func init() {
+	logger.Store(holder{glogLogger{}})
+}
+
+func current() Logger {
+	return logger.Load().(holder).l
+}
+
+// SetLogger sets the logger of the functional translators. A nil logger restores the default glog
+// logger.
+func SetLogger(l Logger) {
+	if l == nil {
+		l = glogLogger{}
+	}
+	logger.Store(holder{l})
+}
+
+// Infof logs an informational message.
+func Infof(format string, args ...any) {
+	current().Infof(format, args...)
+}
+
+// Warningf logs a warning.
+func Warningf(format string, args ...any) {
+	current().Warningf(format, args...)
+}
+
+// Errorf logs an error.
+func Errorf(format string, args ...any) {
+	current().Errorf(format, args...)
+}
+
+// Fatalf logs a fatal error. The default logger exits the program. If a custom logger returns,
+// Fatalf panics with the message.
+func Fatalf(format string, args ...any) {
+	current().Fatalf(format, args...)
+	panic(fmt.Sprintf(format, args...))
+}
+
+// Verbose logs informational messages if its verbosity level is enabled. See V.
+type Verbose bool
+
+// V returns a Verbose logging the informational messages of the verbosity level if it is enabled,
+// e.g. log.V(1).Infof("...").
+func V(level int) Verbose {
+	return Verbose(current().V(level))
+}
+
+// Infof logs an informational message if the verbosity level is enabled.
+func (v Verbose) Infof(format string, args ...any) {
+	if v {
+		current().Infof(format, args...)
+	}
+}
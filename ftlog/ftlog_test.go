@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ftlog
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// recorder records the messages it logs.
+type recorder struct {
+	level    int
+	messages []string
+}
+
+func (r *recorder) log(severity, format string, args ...any) {
+	r.messages = append(r.messages, severity+": "+fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Infof(format string, args ...any)    { r.log("I", format, args...) }
+func (r *recorder) Warningf(format string, args ...any) { r.log("W", format, args...) }
+func (r *recorder) Errorf(format string, args ...any)   { r.log("E", format, args...) }
+func (r *recorder) Fatalf(format string, args ...any)   { r.log("F", format, args...) }
+func (r *recorder) V(level int) bool                    { return level <= r.level }
+
+func TestSetLogger(t *testing.T) {
+	r := &recorder{level: 1}
+	SetLogger(r)
+	defer SetLogger(nil)
+
+	Infof("info %d", 1)
+	Warningf("warning %d", 2)
+	Errorf("error %d", 3)
+	V(1).Infof("verbose %d", 1)
+	V(2).Infof("verbose %d", 2)
+	func() {
+		defer func() {
+			if got := recover(); got != "fatal 4" {
+				t.Errorf("Fatalf() panicked with %v, want %q", got, "fatal 4")
+			}
+		}()
+		Fatalf("fatal %d", 4)
+	}()
+
+	want := []string{"I: info 1", "W: warning 2", "E: error 3", "I: verbose 1", "F: fatal 4"}
+	if diff := cmp.Diff(want, r.messages); diff != "" {
+		t.Errorf("SetLogger() logged unexpected messages (-want +got):\n%s", diff)
+	}
+
+	SetLogger(nil)
+	if _, ok := current().(glogLogger); !ok {
+		t.Errorf("SetLogger(nil) set logger %T, want the glog logger", current())
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ftpaths exports the openconfig output paths of the registered functional translators.
+// The paths are generated from the OutputToInputMap of each translator by cmd/ftpathsgen, and must
+// be regenerated when a translator changes its outputs.
+package ftpaths
+
+// Path is an openconfig schema path output by a functional translator, e.g.
+// "/openconfig/interfaces/interface/state/counters/in-pkts".
+type Path string
@@ -0,0 +1,391 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by ftpathsgen. DO NOT EDIT.
+
+package ftpaths
+
+import "github.com/openconfig/functional-translators/ftconsts"
+
+// Output paths of the functional translators.
+const (
+	ComponentsComponentFanStateSpeed                                                                                                                                          Path = "/openconfig/components/component/fan/state/speed"
+	ComponentsComponentIntegratedCircuitPipelineCountersDropHostInterfaceBlockStateFragmentPunt                                                                               Path = "/openconfig/components/component/integrated-circuit/pipeline-counters/drop/host-interface-block/state/fragment-punt"
+	ComponentsComponentIntegratedCircuitPipelineCountersDropVendor                                                                                                            Path = "/openconfig/components/component/integrated-circuit/pipeline-counters/drop/vendor"
+	ComponentsComponentIntegratedCircuitPipelineCountersErrors                                                                                                                Path = "/openconfig/components/component/integrated-circuit/pipeline-counters/errors"
+	ComponentsComponentIntegratedCircuitPipelineCountersPacketHostInterfaceBlockStateFragmentPuntPkts                                                                         Path = "/openconfig/components/component/integrated-circuit/pipeline-counters/packet/host-interface-block/state/fragment-punt-pkts"
+	ComponentsComponentIntegratedCircuitPipelineCountersPacketVendor                                                                                                          Path = "/openconfig/components/component/integrated-circuit/pipeline-counters/packet/vendor"
+	ComponentsComponentIntegratedCircuitUtilizationResourcesResourceStateMaxLimit                                                                                             Path = "/openconfig/components/component/integrated-circuit/utilization/resources/resource/state/max-limit"
+	ComponentsComponentIntegratedCircuitUtilizationResourcesResourceStateName                                                                                                 Path = "/openconfig/components/component/integrated-circuit/utilization/resources/resource/state/name"
+	ComponentsComponentIntegratedCircuitUtilizationResourcesResourceStateUsed                                                                                                 Path = "/openconfig/components/component/integrated-circuit/utilization/resources/resource/state/used"
+	ComponentsComponentIntegratedCircuitUtilizationResourcesResourceStateUsedThresholdUpper                                                                                   Path = "/openconfig/components/component/integrated-circuit/utilization/resources/resource/state/used-threshold-upper"
+	ComponentsComponentIntegratedCircuitUtilizationResourcesResourceStateUsedThresholdUpperClear                                                                              Path = "/openconfig/components/component/integrated-circuit/utilization/resources/resource/state/used-threshold-upper-clear"
+	ComponentsComponentPowerSupplyStateInputCurrent                                                                                                                           Path = "/openconfig/components/component/power-supply/state/input-current"
+	ComponentsComponentPowerSupplyStateInputVoltage                                                                                                                           Path = "/openconfig/components/component/power-supply/state/input-voltage"
+	ComponentsComponentPowerSupplyStateOutputCurrent                                                                                                                          Path = "/openconfig/components/component/power-supply/state/output-current"
+	ComponentsComponentPowerSupplyStateOutputPower                                                                                                                            Path = "/openconfig/components/component/power-supply/state/output-power"
+	ComponentsComponentPowerSupplyStateOutputVoltage                                                                                                                          Path = "/openconfig/components/component/power-supply/state/output-voltage"
+	ComponentsComponentPropertiesPropertyStateValue                                                                                                                           Path = "/openconfig/components/component/properties/property/state/value"
+	ComponentsComponentStateEmpty                                                                                                                                             Path = "/openconfig/components/component/state/empty"
+	ComponentsComponentStateFirmwareVersion                                                                                                                                   Path = "/openconfig/components/component/state/firmware-version"
+	ComponentsComponentStateName                                                                                                                                              Path = "/openconfig/components/component/state/name"
+	ComponentsComponentStateOperStatus                                                                                                                                        Path = "/openconfig/components/component/state/oper-status"
+	ComponentsComponentStateParent                                                                                                                                            Path = "/openconfig/components/component/state/parent"
+	ComponentsComponentStateTemperatureInstant                                                                                                                                Path = "/openconfig/components/component/state/temperature/instant"
+	ComponentsComponentStateType                                                                                                                                              Path = "/openconfig/components/component/state/type"
+	ComponentsComponentTransceiverPhysicalChannelsChannelStateIndex                                                                                                           Path = "/openconfig/components/component/transceiver/physical-channels/channel/state/index"
+	ComponentsComponentTransceiverPhysicalChannelsChannelStateInputPowerInstant                                                                                               Path = "/openconfig/components/component/transceiver/physical-channels/channel/state/input-power/instant"
+	ComponentsComponentTransceiverPhysicalChannelsChannelStateLaserBiasCurrentInstant                                                                                         Path = "/openconfig/components/component/transceiver/physical-channels/channel/state/laser-bias-current/instant"
+	ComponentsComponentTransceiverPhysicalChannelsChannelStateOutputPowerInstant                                                                                              Path = "/openconfig/components/component/transceiver/physical-channels/channel/state/output-power/instant"
+	ComponentsComponentTransceiverStateFormFactor                                                                                                                             Path = "/openconfig/components/component/transceiver/state/form-factor"
+	ComponentsComponentTransceiverStateSupplyVoltageInstant                                                                                                                   Path = "/openconfig/components/component/transceiver/state/supply-voltage/instant"
+	ComponentsComponentTransceiverStateVendor                                                                                                                                 Path = "/openconfig/components/component/transceiver/state/vendor"
+	ComponentsComponentTransceiverStateVendorPart                                                                                                                             Path = "/openconfig/components/component/transceiver/state/vendor-part"
+	ComponentsComponentTransceiverStateVendorRev                                                                                                                              Path = "/openconfig/components/component/transceiver/state/vendor-rev"
+	ComponentsComponentTransceiverThresholdsThresholdStateInputPowerLower                                                                                                     Path = "/openconfig/components/component/transceiver/thresholds/threshold/state/input-power-lower"
+	ComponentsComponentTransceiverThresholdsThresholdStateInputPowerUpper                                                                                                     Path = "/openconfig/components/component/transceiver/thresholds/threshold/state/input-power-upper"
+	ComponentsComponentTransceiverThresholdsThresholdStateModuleTemperatureLower                                                                                              Path = "/openconfig/components/component/transceiver/thresholds/threshold/state/module-temperature-lower"
+	ComponentsComponentTransceiverThresholdsThresholdStateModuleTemperatureUpper                                                                                              Path = "/openconfig/components/component/transceiver/thresholds/threshold/state/module-temperature-upper"
+	ComponentsComponentTransceiverThresholdsThresholdStateOutputPowerLower                                                                                                    Path = "/openconfig/components/component/transceiver/thresholds/threshold/state/output-power-lower"
+	ComponentsComponentTransceiverThresholdsThresholdStateOutputPowerUpper                                                                                                    Path = "/openconfig/components/component/transceiver/thresholds/threshold/state/output-power-upper"
+	ComponentsComponentTransceiverThresholdsThresholdStateSeverity                                                                                                            Path = "/openconfig/components/component/transceiver/thresholds/threshold/state/severity"
+	ComponentsComponentTransceiverThresholdsThresholdVendorCiscoAlarmsStateInputPowerLower                                                                                    Path = "/openconfig/components/component/transceiver/thresholds/threshold/vendor/Cisco/alarms/state/input-power-lower"
+	ComponentsComponentTransceiverThresholdsThresholdVendorCiscoAlarmsStateInputPowerUpper                                                                                    Path = "/openconfig/components/component/transceiver/thresholds/threshold/vendor/Cisco/alarms/state/input-power-upper"
+	ComponentsComponentTransceiverThresholdsThresholdVendorCiscoAlarmsStateModuleTemperatureLower                                                                             Path = "/openconfig/components/component/transceiver/thresholds/threshold/vendor/Cisco/alarms/state/module-temperature-lower"
+	ComponentsComponentTransceiverThresholdsThresholdVendorCiscoAlarmsStateModuleTemperatureUpper                                                                             Path = "/openconfig/components/component/transceiver/thresholds/threshold/vendor/Cisco/alarms/state/module-temperature-upper"
+	ComponentsComponentTransceiverThresholdsThresholdVendorCiscoAlarmsStateOutputPowerLower                                                                                   Path = "/openconfig/components/component/transceiver/thresholds/threshold/vendor/Cisco/alarms/state/output-power-lower"
+	ComponentsComponentTransceiverThresholdsThresholdVendorCiscoAlarmsStateOutputPowerUpper                                                                                   Path = "/openconfig/components/component/transceiver/thresholds/threshold/vendor/Cisco/alarms/state/output-power-upper"
+	ComponentsComponentVendorCiscoXRFpdStateStatus                                                                                                                            Path = "/openconfig/components/component/vendor/CiscoXR/fpd/state/status"
+	ComponentsComponentVendorCiscoXRSwitchPortStateConnectsTo                                                                                                                 Path = "/openconfig/components/component/vendor/CiscoXR/switch-port/state/connects-to"
+	ComponentsComponentVendorCiscoXRSwitchPortStatePortState                                                                                                                  Path = "/openconfig/components/component/vendor/CiscoXR/switch-port/state/port-state"
+	ComponentsComponentVendorCiscoXRSwitchPortStatePortStateChanges                                                                                                           Path = "/openconfig/components/component/vendor/CiscoXR/switch-port/state/port-state-changes"
+	ComponentsComponentVendorCiscoXRSwitchPortStateRxDropsErrors                                                                                                              Path = "/openconfig/components/component/vendor/CiscoXR/switch-port/state/rx-drops-errors"
+	ComponentsComponentVendorCiscoXRSwitchPortStateRxPackets                                                                                                                  Path = "/openconfig/components/component/vendor/CiscoXR/switch-port/state/rx-packets"
+	ComponentsComponentVendorCiscoXRSwitchPortStateTxDropsErrors                                                                                                              Path = "/openconfig/components/component/vendor/CiscoXR/switch-port/state/tx-drops-errors"
+	ComponentsComponentVendorCiscoXRSwitchPortStateTxPackets                                                                                                                  Path = "/openconfig/components/component/vendor/CiscoXR/switch-port/state/tx-packets"
+	InterfacesInterfaceEthernetPoeStateEnabled                                                                                                                                Path = "/openconfig/interfaces/interface/ethernet/poe/state/enabled"
+	InterfacesInterfaceEthernetPoeStatePowerClass                                                                                                                             Path = "/openconfig/interfaces/interface/ethernet/poe/state/power-class"
+	InterfacesInterfaceEthernetPoeStatePowerUsed                                                                                                                              Path = "/openconfig/interfaces/interface/ethernet/poe/state/power-used"
+	InterfacesInterfaceEthernetPoeStateVendorAristaPortStateFaultStatus                                                                                                       Path = "/openconfig/interfaces/interface/ethernet/poe/state/vendor/Arista/port/state/fault-status"
+	InterfacesInterfaceEthernetPoeStateVendorAristaPortStatePowerAllocated                                                                                                    Path = "/openconfig/interfaces/interface/ethernet/poe/state/vendor/Arista/port/state/power-allocated"
+	InterfacesInterfaceEthernetStateCountersPhyCarrierTransitions                                                                                                             Path = "/openconfig/interfaces/interface/ethernet/state/counters/phy-carrier-transitions"
+	InterfacesInterfaceEthernetStateMacAddress                                                                                                                                Path = "/openconfig/interfaces/interface/ethernet/state/mac-address"
+	InterfacesInterfaceStateDescription                                                                                                                                       Path = "/openconfig/interfaces/interface/state/description"
+	InterfacesInterfaceStateHardwarePort                                                                                                                                      Path = "/openconfig/interfaces/interface/state/hardware-port"
+	InterfacesInterfaceStateTransceiver                                                                                                                                       Path = "/openconfig/interfaces/interface/state/transceiver"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv4AddressesAddressStateIp                                                                                                   Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv4/addresses/address/state/ip"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv4AddressesAddressStatePrefixLength                                                                                         Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv4/addresses/address/state/prefix-length"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv4AddressesAddressStateType                                                                                                 Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv4/addresses/address/state/type"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv4NeighborsNeighborStateIp                                                                                                  Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv4/neighbors/neighbor/state/ip"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv4NeighborsNeighborStateLinkLayerAddress                                                                                    Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv4/neighbors/neighbor/state/link-layer-address"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv4NeighborsNeighborStateOrigin                                                                                              Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv4/neighbors/neighbor/state/origin"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv4StateCountersInPkts                                                                                                       Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv4/state/counters/in-pkts"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv4StateCountersOutPkts                                                                                                      Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv4/state/counters/out-pkts"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv6AddressesAddressStateIp                                                                                                   Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv6/addresses/address/state/ip"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv6AddressesAddressStatePrefixLength                                                                                         Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv6/addresses/address/state/prefix-length"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv6NeighborsNeighborStateIp                                                                                                  Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv6/neighbors/neighbor/state/ip"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv6NeighborsNeighborStateIsRouter                                                                                            Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv6/neighbors/neighbor/state/is-router"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv6NeighborsNeighborStateLinkLayerAddress                                                                                    Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv6/neighbors/neighbor/state/link-layer-address"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv6NeighborsNeighborStateNeighborState                                                                                       Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv6/neighbors/neighbor/state/neighbor-state"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv6NeighborsNeighborStateOrigin                                                                                              Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv6/neighbors/neighbor/state/origin"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv6StateCountersInPkts                                                                                                       Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv6/state/counters/in-pkts"
+	InterfacesInterfaceSubinterfacesSubinterfaceIpv6StateCountersOutPkts                                                                                                      Path = "/openconfig/interfaces/interface/subinterfaces/subinterface/ipv6/state/counters/out-pkts"
+	MacsecInterfacesInterfaceMkaSessionsSessionStateCkn                                                                                                                       Path = "/openconfig/macsec/interfaces/interface/mka/sessions/session/state/ckn"
+	MacsecInterfacesInterfaceMkaSessionsSessionStateStatus                                                                                                                    Path = "/openconfig/macsec/interfaces/interface/mka/sessions/session/state/status"
+	MacsecInterfacesInterfaceScsaRxScsaRxStateCountersSaInvalid                                                                                                               Path = "/openconfig/macsec/interfaces/interface/scsa-rx/scsa-rx/state/counters/sa-invalid"
+	MacsecInterfacesInterfaceScsaRxScsaRxStateCountersSaValid                                                                                                                 Path = "/openconfig/macsec/interfaces/interface/scsa-rx/scsa-rx/state/counters/sa-valid"
+	MacsecInterfacesInterfaceScsaRxScsaRxStateCountersScInvalid                                                                                                               Path = "/openconfig/macsec/interfaces/interface/scsa-rx/scsa-rx/state/counters/sc-invalid"
+	MacsecInterfacesInterfaceScsaRxScsaRxStateCountersScValid                                                                                                                 Path = "/openconfig/macsec/interfaces/interface/scsa-rx/scsa-rx/state/counters/sc-valid"
+	MacsecInterfacesInterfaceScsaRxScsaRxStateSciRx                                                                                                                           Path = "/openconfig/macsec/interfaces/interface/scsa-rx/scsa-rx/state/sci-rx"
+	MacsecInterfacesInterfaceScsaTxScsaTxStateCountersSaAuthOnly                                                                                                              Path = "/openconfig/macsec/interfaces/interface/scsa-tx/scsa-tx/state/counters/sa-auth-only"
+	MacsecInterfacesInterfaceScsaTxScsaTxStateCountersSaEncrypted                                                                                                             Path = "/openconfig/macsec/interfaces/interface/scsa-tx/scsa-tx/state/counters/sa-encrypted"
+	MacsecInterfacesInterfaceScsaTxScsaTxStateCountersScAuthOnly                                                                                                              Path = "/openconfig/macsec/interfaces/interface/scsa-tx/scsa-tx/state/counters/sc-auth-only"
+	MacsecInterfacesInterfaceScsaTxScsaTxStateCountersScEncrypted                                                                                                             Path = "/openconfig/macsec/interfaces/interface/scsa-tx/scsa-tx/state/counters/sc-encrypted"
+	MacsecInterfacesInterfaceScsaTxScsaTxStateSciTx                                                                                                                           Path = "/openconfig/macsec/interfaces/interface/scsa-tx/scsa-tx/state/sci-tx"
+	MacsecInterfacesInterfaceStateCkn                                                                                                                                         Path = "/openconfig/macsec/interfaces/interface/state/ckn"
+	MacsecInterfacesInterfaceStateCountersRxBadicvPkts                                                                                                                        Path = "/openconfig/macsec/interfaces/interface/state/counters/rx-badicv-pkts"
+	MacsecInterfacesInterfaceStateCountersRxPktsCtrl                                                                                                                          Path = "/openconfig/macsec/interfaces/interface/state/counters/rx-pkts-ctrl"
+	MacsecInterfacesInterfaceStateCountersRxPktsDropped                                                                                                                       Path = "/openconfig/macsec/interfaces/interface/state/counters/rx-pkts-dropped"
+	MacsecInterfacesInterfaceStateCountersRxUnrecognizedCkn                                                                                                                   Path = "/openconfig/macsec/interfaces/interface/state/counters/rx-unrecognized-ckn"
+	MacsecInterfacesInterfaceStateCountersTxPktsCtrl                                                                                                                          Path = "/openconfig/macsec/interfaces/interface/state/counters/tx-pkts-ctrl"
+	MacsecInterfacesInterfaceStateCountersTxPktsDropped                                                                                                                       Path = "/openconfig/macsec/interfaces/interface/state/counters/tx-pkts-dropped"
+	MacsecInterfacesInterfaceStateCountersTxPktsErrIn                                                                                                                         Path = "/openconfig/macsec/interfaces/interface/state/counters/tx-pkts-err-in"
+	MacsecInterfacesInterfaceStateStatus                                                                                                                                      Path = "/openconfig/macsec/interfaces/interface/state/status"
+	NetworkInstancesNetworkInstanceConnectionPointsConnectionPointStateStatus                                                                                                 Path = "/openconfig/network-instances/network-instance/connection-points/connection-point/state/status"
+	NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyCandidatePathsCandidatePathStateActive                                                                     Path = "/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/candidate-paths/candidate-path/state/active"
+	NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyCandidatePathsCandidatePathStatePreference                                                                 Path = "/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/candidate-paths/candidate-path/state/preference"
+	NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyCandidatePathsCandidatePathStateValid                                                                      Path = "/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/candidate-paths/candidate-path/state/valid"
+	NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyStateActive                                                                                                Path = "/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/state/active"
+	NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyStateBsid                                                                                                  Path = "/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/state/bsid"
+	NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyStateColor                                                                                                 Path = "/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/state/color"
+	NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyStateEndpoint                                                                                              Path = "/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/state/endpoint"
+	NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyStateName                                                                                                  Path = "/openconfig/network-instances/network-instance/segment-routing/te-policies/te-policy/state/name"
+	OamCfmDomainsMaintenanceDomainMaintenanceAssociationsMaintenanceAssociationMepEndpointsMepEndpointPmProfilesPmProfileStateDelayMeasurementStateFrameDelayTwoWayAverage    Path = "/openconfig/oam/cfm/domains/maintenance-domain/maintenance-associations/maintenance-association/mep-endpoints/mep-endpoint/pm-profiles/pm-profile/state/delay-measurement-state/frame-delay-two-way-average"
+	OamCfmDomainsMaintenanceDomainMaintenanceAssociationsMaintenanceAssociationMepEndpointsMepEndpointPmProfilesPmProfileStateLossMeasurementStateFarEndAverageFrameLossRatio Path = "/openconfig/oam/cfm/domains/maintenance-domain/maintenance-associations/maintenance-association/mep-endpoints/mep-endpoint/pm-profiles/pm-profile/state/loss-measurement-state/far-end-average-frame-loss-ratio"
+	OamCfmDomainsMaintenanceDomainMaintenanceAssociationsMaintenanceAssociationMepEndpointsMepEndpointStatePresentRdi                                                         Path = "/openconfig/oam/cfm/domains/maintenance-domain/maintenance-associations/maintenance-association/mep-endpoints/mep-endpoint/state/present-rdi"
+	QosClassifiersClassifierTermsTermActionsStateTargetGroup                                                                                                                  Path = "/openconfig/qos/classifiers/classifier/terms/term/actions/state/target-group"
+	QosClassifiersClassifierTermsTermConditionsIpv4StateDscp                                                                                                                  Path = "/openconfig/qos/classifiers/classifier/terms/term/conditions/ipv4/state/dscp"
+	QosForwardingGroupsForwardingGroupStateOutputQueue                                                                                                                        Path = "/openconfig/qos/forwarding-groups/forwarding-group/state/output-queue"
+	QosInterfacesInterfaceInputClassifiersClassifierTermsTermStateMatchedOctets                                                                                               Path = "/openconfig/qos/interfaces/interface/input/classifiers/classifier/terms/term/state/matched-octets"
+	QosInterfacesInterfaceInputClassifiersClassifierTermsTermStateMatchedPackets                                                                                              Path = "/openconfig/qos/interfaces/interface/input/classifiers/classifier/terms/term/state/matched-packets"
+	QosInterfacesInterfaceInputSchedulerPolicyStateName                                                                                                                       Path = "/openconfig/qos/interfaces/interface/input/scheduler-policy/state/name"
+	QosInterfacesInterfaceOutputQueuesQueueStateAvgQueueLen                                                                                                                   Path = "/openconfig/qos/interfaces/interface/output/queues/queue/state/avg-queue-len"
+	QosInterfacesInterfaceOutputQueuesQueueStateDroppedOctets                                                                                                                 Path = "/openconfig/qos/interfaces/interface/output/queues/queue/state/dropped-octets"
+	QosInterfacesInterfaceOutputQueuesQueueStateDroppedPkts                                                                                                                   Path = "/openconfig/qos/interfaces/interface/output/queues/queue/state/dropped-pkts"
+	QosInterfacesInterfaceOutputQueuesQueueStateMaxQueueLen                                                                                                                   Path = "/openconfig/qos/interfaces/interface/output/queues/queue/state/max-queue-len"
+	QosInterfacesInterfaceOutputQueuesQueueStateName                                                                                                                          Path = "/openconfig/qos/interfaces/interface/output/queues/queue/state/name"
+	QosInterfacesInterfaceOutputQueuesQueueStateTransmitOctets                                                                                                                Path = "/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-octets"
+	QosInterfacesInterfaceOutputQueuesQueueStateTransmitPkts                                                                                                                  Path = "/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts"
+	QosInterfacesInterfaceOutputSchedulerPolicyStateName                                                                                                                      Path = "/openconfig/qos/interfaces/interface/output/scheduler-policy/state/name"
+	QosInterfacesInterfaceOutputVendorAristaQueueFamiliesQueueFamilyStateDroppedOctets                                                                                        Path = "/openconfig/qos/interfaces/interface/output/vendor/Arista/queue-families/queue-family/state/dropped-octets"
+	QosInterfacesInterfaceOutputVendorAristaQueueFamiliesQueueFamilyStateDroppedPkts                                                                                          Path = "/openconfig/qos/interfaces/interface/output/vendor/Arista/queue-families/queue-family/state/dropped-pkts"
+	QosInterfacesInterfaceOutputVendorAristaQueueFamiliesQueueFamilyStateTransmitOctets                                                                                       Path = "/openconfig/qos/interfaces/interface/output/vendor/Arista/queue-families/queue-family/state/transmit-octets"
+	QosInterfacesInterfaceOutputVendorAristaQueueFamiliesQueueFamilyStateTransmitPkts                                                                                         Path = "/openconfig/qos/interfaces/interface/output/vendor/Arista/queue-families/queue-family/state/transmit-pkts"
+	SystemMountPointsMountPointStateAvailable                                                                                                                                 Path = "/openconfig/system/mount-points/mount-point/state/available"
+	SystemMountPointsMountPointStateName                                                                                                                                      Path = "/openconfig/system/mount-points/mount-point/state/name"
+	SystemMountPointsMountPointStateSize                                                                                                                                      Path = "/openconfig/system/mount-points/mount-point/state/size"
+	SystemMountPointsMountPointStateUtilized                                                                                                                                  Path = "/openconfig/system/mount-points/mount-point/state/utilized"
+)
+
+// OutputPaths maps the ID of each registered functional translator to its sorted output paths.
+var OutputPaths = map[string][]Path{
+	ftconsts.AristaCFMPMFunctionalTranslator: {
+		OamCfmDomainsMaintenanceDomainMaintenanceAssociationsMaintenanceAssociationMepEndpointsMepEndpointPmProfilesPmProfileStateDelayMeasurementStateFrameDelayTwoWayAverage,
+		OamCfmDomainsMaintenanceDomainMaintenanceAssociationsMaintenanceAssociationMepEndpointsMepEndpointPmProfilesPmProfileStateLossMeasurementStateFarEndAverageFrameLossRatio,
+	},
+	ftconsts.AristaCfmStateFunctionalTranslator: {
+		OamCfmDomainsMaintenanceDomainMaintenanceAssociationsMaintenanceAssociationMepEndpointsMepEndpointStatePresentRdi,
+	},
+	ftconsts.AristaDropCountersTranslator: {
+		ComponentsComponentIntegratedCircuitPipelineCountersDropVendor,
+	},
+	ftconsts.AristaInterfaceDescriptionFunctionalTranslator: {
+		InterfacesInterfaceStateDescription,
+	},
+	ftconsts.AristaInterfaceMacFunctionalTranslator: {
+		InterfacesInterfaceEthernetStateMacAddress,
+		InterfacesInterfaceStateHardwarePort,
+	},
+	ftconsts.AristaMacsecCountersTranslator: {
+		MacsecInterfacesInterfaceStateCountersRxBadicvPkts,
+		MacsecInterfacesInterfaceStateCountersRxPktsCtrl,
+		MacsecInterfacesInterfaceStateCountersRxPktsDropped,
+		MacsecInterfacesInterfaceStateCountersRxUnrecognizedCkn,
+		MacsecInterfacesInterfaceStateCountersTxPktsCtrl,
+		MacsecInterfacesInterfaceStateCountersTxPktsDropped,
+		MacsecInterfacesInterfaceStateCountersTxPktsErrIn,
+	},
+	ftconsts.AristaMacsecStateFunctionalTranslator: {
+		MacsecInterfacesInterfaceMkaSessionsSessionStateCkn,
+		MacsecInterfacesInterfaceMkaSessionsSessionStateStatus,
+		MacsecInterfacesInterfaceScsaRxScsaRxStateCountersSaInvalid,
+		MacsecInterfacesInterfaceScsaRxScsaRxStateCountersSaValid,
+		MacsecInterfacesInterfaceScsaRxScsaRxStateCountersScInvalid,
+		MacsecInterfacesInterfaceScsaRxScsaRxStateCountersScValid,
+		MacsecInterfacesInterfaceScsaRxScsaRxStateSciRx,
+		MacsecInterfacesInterfaceScsaTxScsaTxStateCountersSaAuthOnly,
+		MacsecInterfacesInterfaceScsaTxScsaTxStateCountersSaEncrypted,
+		MacsecInterfacesInterfaceScsaTxScsaTxStateCountersScAuthOnly,
+		MacsecInterfacesInterfaceScsaTxScsaTxStateCountersScEncrypted,
+		MacsecInterfacesInterfaceScsaTxScsaTxStateSciTx,
+		MacsecInterfacesInterfaceStateCkn,
+		MacsecInterfacesInterfaceStateStatus,
+	},
+	ftconsts.AristaPoETranslator: {
+		InterfacesInterfaceEthernetPoeStateEnabled,
+		InterfacesInterfaceEthernetPoeStatePowerClass,
+		InterfacesInterfaceEthernetPoeStatePowerUsed,
+		InterfacesInterfaceEthernetPoeStateVendorAristaPortStateFaultStatus,
+		InterfacesInterfaceEthernetPoeStateVendorAristaPortStatePowerAllocated,
+	},
+	ftconsts.AristaPWStateFunctionalTranslator: {
+		NetworkInstancesNetworkInstanceConnectionPointsConnectionPointStateStatus,
+	},
+	ftconsts.AristaQoSAggregateCountersTranslator: {
+		QosInterfacesInterfaceInputClassifiersClassifierTermsTermStateMatchedOctets,
+		QosInterfacesInterfaceInputClassifiersClassifierTermsTermStateMatchedPackets,
+		QosInterfacesInterfaceOutputQueuesQueueStateDroppedOctets,
+		QosInterfacesInterfaceOutputQueuesQueueStateDroppedPkts,
+		QosInterfacesInterfaceOutputQueuesQueueStateTransmitOctets,
+		QosInterfacesInterfaceOutputQueuesQueueStateTransmitPkts,
+		QosInterfacesInterfaceOutputVendorAristaQueueFamiliesQueueFamilyStateDroppedOctets,
+		QosInterfacesInterfaceOutputVendorAristaQueueFamiliesQueueFamilyStateDroppedPkts,
+		QosInterfacesInterfaceOutputVendorAristaQueueFamiliesQueueFamilyStateTransmitOctets,
+		QosInterfacesInterfaceOutputVendorAristaQueueFamiliesQueueFamilyStateTransmitPkts,
+	},
+	ftconsts.AristaQoSMapsTranslator: {
+		QosClassifiersClassifierTermsTermActionsStateTargetGroup,
+		QosClassifiersClassifierTermsTermConditionsIpv4StateDscp,
+		QosForwardingGroupsForwardingGroupStateOutputQueue,
+	},
+	ftconsts.AristaXcvrPresenceTranslator: {
+		ComponentsComponentStateEmpty,
+		ComponentsComponentStateName,
+		InterfacesInterfaceStateTransceiver,
+	},
+	ftconsts.CiscoXR8000IntegratedCircuitResourceFunctionalTranslator: {
+		ComponentsComponentIntegratedCircuitUtilizationResourcesResourceStateMaxLimit,
+		ComponentsComponentIntegratedCircuitUtilizationResourcesResourceStateName,
+		ComponentsComponentIntegratedCircuitUtilizationResourcesResourceStateUsed,
+		ComponentsComponentIntegratedCircuitUtilizationResourcesResourceStateUsedThresholdUpper,
+		ComponentsComponentIntegratedCircuitUtilizationResourcesResourceStateUsedThresholdUpperClear,
+	},
+	ftconsts.CiscoXRArpTranslator: {
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv4NeighborsNeighborStateIp,
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv4NeighborsNeighborStateLinkLayerAddress,
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv4NeighborsNeighborStateOrigin,
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv6NeighborsNeighborStateIp,
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv6NeighborsNeighborStateIsRouter,
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv6NeighborsNeighborStateLinkLayerAddress,
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv6NeighborsNeighborStateNeighborState,
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv6NeighborsNeighborStateOrigin,
+	},
+	ftconsts.CiscoXRCarrierTranslator: {
+		InterfacesInterfaceEthernetStateCountersPhyCarrierTransitions,
+	},
+	ftconsts.CiscoXREnvmonTranslator: {
+		ComponentsComponentFanStateSpeed,
+		ComponentsComponentPropertiesPropertyStateValue,
+		ComponentsComponentStateTemperatureInstant,
+	},
+	ftconsts.CiscoXRFabricTranslator: {
+		ComponentsComponentIntegratedCircuitPipelineCountersErrors,
+	},
+	ftconsts.CiscoXRFpdTranslator: {
+		ComponentsComponentStateFirmwareVersion,
+		ComponentsComponentVendorCiscoXRFpdStateStatus,
+	},
+	ftconsts.CiscoXRFragmentTranslator: {
+		ComponentsComponentIntegratedCircuitPipelineCountersDropHostInterfaceBlockStateFragmentPunt,
+		ComponentsComponentIntegratedCircuitPipelineCountersPacketHostInterfaceBlockStateFragmentPuntPkts,
+	},
+	ftconsts.CiscoXRIPv4Translator: {
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv4AddressesAddressStateIp,
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv4AddressesAddressStatePrefixLength,
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv4AddressesAddressStateType,
+	},
+	ftconsts.CiscoXRIPv6Translator: {
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv6AddressesAddressStateIp,
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv6AddressesAddressStatePrefixLength,
+	},
+	ftconsts.CiscoXRLagMacFunctionalTranslator: {
+		InterfacesInterfaceEthernetStateMacAddress,
+	},
+	ftconsts.CiscoXRMountTranslator: {
+		SystemMountPointsMountPointStateAvailable,
+		SystemMountPointsMountPointStateName,
+		SystemMountPointsMountPointStateSize,
+		SystemMountPointsMountPointStateUtilized,
+	},
+	ftconsts.CiscoXRPowerTranslator: {
+		ComponentsComponentStateOperStatus,
+	},
+	ftconsts.CiscoXRPowerSupplyTranslator: {
+		ComponentsComponentPowerSupplyStateInputCurrent,
+		ComponentsComponentPowerSupplyStateInputVoltage,
+		ComponentsComponentPowerSupplyStateOutputCurrent,
+		ComponentsComponentPowerSupplyStateOutputPower,
+		ComponentsComponentPowerSupplyStateOutputVoltage,
+	},
+	ftconsts.CiscoXRQosTranslator: {
+		QosInterfacesInterfaceInputClassifiersClassifierTermsTermStateMatchedOctets,
+		QosInterfacesInterfaceInputClassifiersClassifierTermsTermStateMatchedPackets,
+		QosInterfacesInterfaceOutputQueuesQueueStateDroppedOctets,
+		QosInterfacesInterfaceOutputQueuesQueueStateDroppedPkts,
+		QosInterfacesInterfaceOutputQueuesQueueStateTransmitOctets,
+		QosInterfacesInterfaceOutputQueuesQueueStateTransmitPkts,
+	},
+	ftconsts.CiscoXRQosPolicyTranslator: {
+		QosInterfacesInterfaceInputSchedulerPolicyStateName,
+		QosInterfacesInterfaceOutputSchedulerPolicyStateName,
+	},
+	ftconsts.CiscoXRSRTEPolicyTranslator: {
+		NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyCandidatePathsCandidatePathStateActive,
+		NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyCandidatePathsCandidatePathStatePreference,
+		NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyCandidatePathsCandidatePathStateValid,
+		NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyStateActive,
+		NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyStateBsid,
+		NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyStateColor,
+		NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyStateEndpoint,
+		NetworkInstancesNetworkInstanceSegmentRoutingTePoliciesTePolicyStateName,
+	},
+	ftconsts.CiscoXRSubinterfaceCounterTranslator: {
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv4AddressesAddressStateIp,
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv4AddressesAddressStatePrefixLength,
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv4StateCountersInPkts,
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv4StateCountersOutPkts,
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv6StateCountersInPkts,
+		InterfacesInterfaceSubinterfacesSubinterfaceIpv6StateCountersOutPkts,
+	},
+	ftconsts.CiscoXRSwitchTranslator: {
+		ComponentsComponentVendorCiscoXRSwitchPortStateConnectsTo,
+		ComponentsComponentVendorCiscoXRSwitchPortStatePortState,
+		ComponentsComponentVendorCiscoXRSwitchPortStatePortStateChanges,
+		ComponentsComponentVendorCiscoXRSwitchPortStateRxDropsErrors,
+		ComponentsComponentVendorCiscoXRSwitchPortStateRxPackets,
+		ComponentsComponentVendorCiscoXRSwitchPortStateTxDropsErrors,
+		ComponentsComponentVendorCiscoXRSwitchPortStateTxPackets,
+	},
+	ftconsts.CiscoXRTransceiverTranslator: {
+		ComponentsComponentStateTemperatureInstant,
+		ComponentsComponentTransceiverPhysicalChannelsChannelStateIndex,
+		ComponentsComponentTransceiverPhysicalChannelsChannelStateInputPowerInstant,
+		ComponentsComponentTransceiverPhysicalChannelsChannelStateLaserBiasCurrentInstant,
+		ComponentsComponentTransceiverPhysicalChannelsChannelStateOutputPowerInstant,
+		ComponentsComponentTransceiverStateFormFactor,
+		ComponentsComponentTransceiverStateSupplyVoltageInstant,
+		ComponentsComponentTransceiverStateVendor,
+		ComponentsComponentTransceiverStateVendorPart,
+		ComponentsComponentTransceiverStateVendorRev,
+		ComponentsComponentTransceiverThresholdsThresholdStateInputPowerLower,
+		ComponentsComponentTransceiverThresholdsThresholdStateInputPowerUpper,
+		ComponentsComponentTransceiverThresholdsThresholdStateModuleTemperatureLower,
+		ComponentsComponentTransceiverThresholdsThresholdStateModuleTemperatureUpper,
+		ComponentsComponentTransceiverThresholdsThresholdStateOutputPowerLower,
+		ComponentsComponentTransceiverThresholdsThresholdStateOutputPowerUpper,
+		ComponentsComponentTransceiverThresholdsThresholdStateSeverity,
+		ComponentsComponentTransceiverThresholdsThresholdVendorCiscoAlarmsStateInputPowerLower,
+		ComponentsComponentTransceiverThresholdsThresholdVendorCiscoAlarmsStateInputPowerUpper,
+		ComponentsComponentTransceiverThresholdsThresholdVendorCiscoAlarmsStateModuleTemperatureLower,
+		ComponentsComponentTransceiverThresholdsThresholdVendorCiscoAlarmsStateModuleTemperatureUpper,
+		ComponentsComponentTransceiverThresholdsThresholdVendorCiscoAlarmsStateOutputPowerLower,
+		ComponentsComponentTransceiverThresholdsThresholdVendorCiscoAlarmsStateOutputPowerUpper,
+	},
+	ftconsts.CiscoXRVendorDropsTranslator: {
+		ComponentsComponentIntegratedCircuitPipelineCountersDropVendor,
+		ComponentsComponentIntegratedCircuitPipelineCountersPacketVendor,
+		ComponentsComponentStateParent,
+		ComponentsComponentStateType,
+	},
+	ftconsts.JuniperQueueTranslator: {
+		QosInterfacesInterfaceOutputQueuesQueueStateAvgQueueLen,
+		QosInterfacesInterfaceOutputQueuesQueueStateDroppedPkts,
+		QosInterfacesInterfaceOutputQueuesQueueStateMaxQueueLen,
+		QosInterfacesInterfaceOutputQueuesQueueStateName,
+		QosInterfacesInterfaceOutputQueuesQueueStateTransmitOctets,
+		QosInterfacesInterfaceOutputQueuesQueueStateTransmitPkts,
+	},
+	ftconsts.JuniperTransceiverTranslator: {
+		ComponentsComponentTransceiverPhysicalChannelsChannelStateIndex,
+		ComponentsComponentTransceiverPhysicalChannelsChannelStateInputPowerInstant,
+		ComponentsComponentTransceiverPhysicalChannelsChannelStateLaserBiasCurrentInstant,
+		ComponentsComponentTransceiverPhysicalChannelsChannelStateOutputPowerInstant,
+	},
+	ftconsts.NokiaTransceiverTranslator: {
+		ComponentsComponentTransceiverPhysicalChannelsChannelStateIndex,
+		ComponentsComponentTransceiverPhysicalChannelsChannelStateInputPowerInstant,
+		ComponentsComponentTransceiverPhysicalChannelsChannelStateLaserBiasCurrentInstant,
+		ComponentsComponentTransceiverPhysicalChannelsChannelStateOutputPowerInstant,
+	},
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ftstate provides the per-target state stores of the stateful functional translators.
+// Each FT instance owns its stores, so that several collectors can run in one process, and
+// exposes them through translator.StateOptions to let the application manage the state of each
+// target, e.g. to reset it when the subscription to the target is torn down.
+package ftstate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Store is the state of a stateful FT, kept per target.
+type Store interface {
+	// Targets returns the sorted targets with state.
+	Targets() []string
+	// ResetTarget discards the state of the target.
+	ResetTarget(target string)
+	// Reset discards the state of all the targets.
+	Reset()
+	// SetTTL sets the duration after which the state of a target which was not seen is evicted.
+	// A TTL of 0 uses the default TTL. See SetDefaultTTL.
+	SetTTL(ttl time.Duration) error
+	// EvictStaleTargets removes the targets which were not seen for the TTL, and returns them
+	// sorted.
+	EvictStaleTargets() []string
+}
+
+var (
+	defaultTTLMu sync.RWMutex
+	// defaultTTL is the TTL of the stores without TTL, or 0 to never evict targets.
+	defaultTTL time.Duration
+)
+
+// SetDefaultTTL sets the TTL of the stores which have no TTL of their own, so that long-running
+// collectors do not accumulate the state of decommissioned devices. As the native paths are
+// typically subscribed on change, the TTL must be larger than the heartbeat interval of the
+// subscriptions. A TTL of 0, the default, disables eviction.
+func SetDefaultTTL(ttl time.Duration) error {
+	if ttl < 0 {
+		return fmt.Errorf("default TTL %v is negative", ttl)
+	}
+	defaultTTLMu.Lock()
+	defer defaultTTLMu.Unlock()
+	defaultTTL = ttl
+	return nil
+}
+
+// Options are the options of a TargetStore.
+type Options struct {
+	// Now returns the current time. It defaults to time.Now.
+	Now func() time.Time
+}
+
+// TargetStore is a thread-safe Store of values of type T per target. A target is seen when its
+// value is created, set or retrieved.
+type TargetStore[T any] struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	now      func() time.Time
+	values   map[string]T
+	lastSeen map[string]time.Time
+}
+
+var _ Store = (*TargetStore[int])(nil)
+
+// NewTargetStore returns an empty TargetStore using the default TTL.
+func NewTargetStore[T any](opts Options) *TargetStore[T] {
+	now := opts.Now
+	if now == nil {
+		now = time.Now
+	}
+	return &TargetStore[T]{
+		now:      now,
+		values:   make(map[string]T),
+		lastSeen: make(map[string]time.Time),
+	}
+}
+
+// Get returns the value of the target, and whether it was found.
+func (s *TargetStore[T]) Get(target string) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[target]
+	if ok {
+		s.lastSeen[target] = s.now()
+	}
+	return v, ok
+}
+
+// GetOrCreate returns the value of the target, setting it to the value returned by create if the
+// target has none.
+func (s *TargetStore[T]) GetOrCreate(target string, create func() T) T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[target]
+	if !ok {
+		v = create()
+		s.values[target] = v
+	}
+	s.lastSeen[target] = s.now()
+	return v
+}
+
+// Set sets the value of the target.
+func (s *TargetStore[T]) Set(target string, v T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[target] = v
+	s.lastSeen[target] = s.now()
+}
+
+// Range calls fn for each target and its value, in target order, without marking them seen. fn
+// must not call the methods of the store.
+func (s *TargetStore[T]) Range(fn func(target string, v T)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, target := range slices.Sorted(maps.Keys(s.values)) {
+		fn(target, s.values[target])
+	}
+}
+
+// Targets returns the sorted targets of the store.
+func (s *TargetStore[T]) Targets() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Sorted(maps.Keys(s.values))
+}
+
+// ResetTarget removes the value of the target.
+func (s *TargetStore[T]) ResetTarget(target string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values, target)
+	delete(s.lastSeen, target)
+}
+
+// Reset removes the values of all the targets.
+func (s *TargetStore[T]) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values = make(map[string]T)
+	s.lastSeen = make(map[string]time.Time)
+}
+
+// SetTTL sets the TTL of the store. A TTL of 0 uses the default TTL.
+func (s *TargetStore[T]) SetTTL(ttl time.Duration) error {
+	if ttl < 0 {
+		return fmt.Errorf("TTL %v is negative", ttl)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ttl = ttl
+	return nil
+}
+
+// EvictStaleTargets removes the targets which were not seen for the TTL, and returns them sorted.
+func (s *TargetStore[T]) EvictStaleTargets() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ttl := s.ttl
+	if ttl == 0 {
+		defaultTTLMu.RLock()
+		ttl = defaultTTL
+		defaultTTLMu.RUnlock()
+	}
+	if ttl == 0 {
+		return nil
+	}
+	now := s.now()
+	var stale []string
+	for target, seen := range s.lastSeen {
+		if now.Sub(seen) >= ttl {
+			stale = append(stale, target)
+		}
+	}
+	slices.Sort(stale)
+	for _, target := range stale {
+		delete(s.values, target)
+		delete(s.lastSeen, target)
+	}
+	return stale
+}
+
+// Clone returns a copy of the store, with the values copied by cloneValue and the same TTL and
+// times at which the targets were seen.
+func (s *TargetStore[T]) Clone(cloneValue func(T) T) *TargetStore[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := &TargetStore[T]{
+		ttl:      s.ttl,
+		now:      s.now,
+		values:   make(map[string]T, len(s.values)),
+		lastSeen: maps.Clone(s.lastSeen),
+	}
+	for target, v := range s.values {
+		c.values[target] = cloneValue(v)
+	}
+	return c
+}
+
+// Restore replaces the values of the store, and the times at which their targets were seen, with
+// copies of those of other made by cloneValue. The TTL of the store is kept.
+func (s *TargetStore[T]) Restore(other *TargetStore[T], cloneValue func(T) T) {
+	c := other.Clone(cloneValue)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values = c.values
+	s.lastSeen = c.lastSeen
+}
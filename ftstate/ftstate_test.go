@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package ftstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeClock returns a clock at the given time, which the returned function advances.
+func fakeClock(start time.Time) (func() time.Time, func(time.Duration)) {
+	now := start
+	return func() time.Time { return now }, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestTargetStore(t *testing.T) {
+	s := NewTargetStore[int](Options{})
+	if got := s.GetOrCreate("host1", func() int { return 1 }); got != 1 {
+		t.Errorf("GetOrCreate(%q) = %d, want 1", "host1", got)
+	}
+	if got := s.GetOrCreate("host1", func() int { return 2 }); got != 1 {
+		t.Errorf("GetOrCreate(%q) of an existing target = %d, want 1", "host1", got)
+	}
+	s.Set("host2", 2)
+	if got, ok := s.Get("host2"); !ok || got != 2 {
+		t.Errorf("Get(%q) = %d, %t, want 2, true", "host2", got, ok)
+	}
+	if diff := cmp.Diff([]string{"host1", "host2"}, s.Targets()); diff != "" {
+		t.Errorf("Targets() returned an unexpected diff (-want +got):\n%s", diff)
+	}
+
+	clone := s.Clone(func(v int) int { return v * 10 })
+	s.ResetTarget("host1")
+	if _, ok := s.Get("host1"); ok {
+		t.Errorf("Get(%q) after ResetTarget: ok = true, want false", "host1")
+	}
+	if got, ok := clone.Get("host1"); !ok || got != 10 {
+		t.Errorf("Clone().Get(%q) = %d, %t, want 10, true", "host1", got, ok)
+	}
+
+	s.Reset()
+	if got := s.Targets(); len(got) != 0 {
+		t.Errorf("Targets() after Reset() = %v, want none", got)
+	}
+	s.Restore(clone, func(v int) int { return v })
+	var got []int
+	s.Range(func(_ string, v int) { got = append(got, v) })
+	if diff := cmp.Diff([]int{10, 20}, got); diff != "" {
+		t.Errorf("Range() after Restore() returned an unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestEvictStaleTargets(t *testing.T) {
+	now, advance := fakeClock(time.Unix(1000, 0))
+	s := NewTargetStore[int](Options{Now: now})
+	s.Set("host1", 1)
+	advance(time.Minute)
+	s.Set("host2", 2)
+	advance(30 * time.Second)
+
+	// Eviction is disabled by default.
+	if got := s.EvictStaleTargets(); got != nil {
+		t.Errorf("EvictStaleTargets() without TTL = %v, want nil", got)
+	}
+
+	if err := SetDefaultTTL(time.Minute); err != nil {
+		t.Fatalf("SetDefaultTTL() got unexpected error: %v", err)
+	}
+	t.Cleanup(func() { SetDefaultTTL(0) })
+	if diff := cmp.Diff([]string{"host1"}, s.EvictStaleTargets()); diff != "" {
+		t.Errorf("EvictStaleTargets() with the default TTL returned an unexpected diff (-want +got):\n%s", diff)
+	}
+
+	// The TTL of the store overrides the default TTL.
+	if err := s.SetTTL(time.Hour); err != nil {
+		t.Fatalf("SetTTL() got unexpected error: %v", err)
+	}
+	advance(time.Minute)
+	if got := s.EvictStaleTargets(); got != nil {
+		t.Errorf("EvictStaleTargets() with the store TTL = %v, want nil", got)
+	}
+	advance(time.Hour)
+	if diff := cmp.Diff([]string{"host2"}, s.EvictStaleTargets()); diff != "" {
+		t.Errorf("EvictStaleTargets() after the store TTL returned an unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestNegativeTTL(t *testing.T) {
+	if err := SetDefaultTTL(-time.Second); err == nil {
+		t.Errorf("SetDefaultTTL(-1s) returned nil error, want error")
+	}
+	if err := NewTargetStore[int](Options{}).SetTTL(-time.Second); err == nil {
+		t.Errorf("SetTTL(-1s) returned nil error, want error")
+	}
+}
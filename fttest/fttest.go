@@ -0,0 +1,186 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fttest runs golden-file tests of functional translators.
+//
+// A test case is a set of files in a testdata directory sharing a name:
+//   - <name>_input.txt holds the prototext input SubscribeResponses, separated by "---" lines as
+//     read by ftutilities.ReadSubscribeResponses;
+//   - <name>_output.txt holds the expected non-nil outputs, in the same format. The FT is expected
+//     to return no output if the file does not exist;
+//   - <name>_error.txt, if it exists, holds a substring of the error the FT is expected to return
+//     for one of the inputs. The inputs following it are not translated.
+//
+// The paths of the outputs must also be covered by the OutputToInputMap of the FT, see
+// CheckCoverage.
+//
+// The inputs are translated in order, so that stateful FTs can be tested with sequences of
+// notifications:
+//
+//	func TestTranslate(t *testing.T) {
+//		fttest.RunGoldenTests(t, New(), "testdata")
+//	}
+package fttest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	inputSuffix  = "_input.txt"
+	outputSuffix = "_output.txt"
+	errorSuffix  = "_error.txt"
+)
+
+// Case is a golden-file test case.
+type Case struct {
+	Name string
+	// Inputs are the responses translated in order.
+	Inputs []*gnmipb.SubscribeResponse
+	// Want are the expected non-nil outputs.
+	Want []*gnmipb.SubscribeResponse
+	// WantErr is a substring of the expected error, or empty if no error is expected.
+	WantErr string
+}
+
+// LoadCases returns the golden-file test cases of a directory, sorted by name.
+func LoadCases(dir string) ([]*Case, error) {
+	inputs, err := filepath.Glob(filepath.Join(dir, "*"+inputSuffix))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(inputs)
+	var cases []*Case
+	for _, in := range inputs {
+		base := strings.TrimSuffix(in, inputSuffix)
+		c := &Case{Name: filepath.Base(base)}
+		if c.Inputs, err = ftutilities.LoadSubscribeResponses(in); err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(base + outputSuffix); err == nil {
+			if c.Want, err = ftutilities.LoadSubscribeResponses(base + outputSuffix); err != nil {
+				return nil, err
+			}
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		b, err := os.ReadFile(base + errorSuffix)
+		switch {
+		case err == nil:
+			c.WantErr = strings.TrimSpace(string(b))
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, err
+		}
+		cases = append(cases, c)
+	}
+	return cases, nil
+}
+
+// Run translates the inputs of the case with the FT, after resetting its state, and returns its
+// non-nil outputs and the first error.
+func (c *Case) Run(ft *translator.FunctionalTranslator) ([]*gnmipb.SubscribeResponse, error) {
+	ft.Reset()
+	var got []*gnmipb.SubscribeResponse
+	for _, in := range c.Inputs {
+		out, err := ft.Translate(in)
+		if err != nil {
+			return got, err
+		}
+		if out != nil {
+			got = append(got, out)
+		}
+	}
+	return got, nil
+}
+
+// RunGoldenTests runs a subtest for each golden-file test case of the directory. The outputs are
+// compared ignoring the order of the updates and deletes of the notifications. It fails if the
+// directory has no test case.
+func RunGoldenTests(t *testing.T, ft *translator.FunctionalTranslator, dir string) {
+	t.Helper()
+	cases, err := LoadCases(dir)
+	if err != nil {
+		t.Fatalf("Failed to load the test cases of %s: %v", dir, err)
+	}
+	if len(cases) == 0 {
+		t.Fatalf("No test case in %s", dir)
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			got, err := c.Run(ft)
+			switch {
+			case c.WantErr == "" && err != nil:
+				t.Fatalf("Translate() of %s returned unexpected error: %v", ft.ID(), err)
+			case c.WantErr != "" && err == nil:
+				t.Fatalf("Translate() of %s returned nil error, want error containing %q", ft.ID(), c.WantErr)
+			case c.WantErr != "" && !strings.Contains(err.Error(), c.WantErr):
+				t.Fatalf("Translate() of %s returned error %v, want error containing %q", ft.ID(), err, c.WantErr)
+			}
+			if diff := cmp.Diff(c.Want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update", "delete")); diff != "" {
+				t.Errorf("Translate() of %s returned unexpected diff (-want +got):\n%s", ft.ID(), diff)
+			}
+			for _, sr := range got {
+				if err := CheckCoverage(ft, sr); err != nil {
+					t.Errorf("Translate() of %s returned an uncovered output: %v", ft.ID(), err)
+				}
+			}
+		})
+	}
+}
+
+// CheckCoverage returns an error if a path of a response returned by the FT is not covered by its
+// OutputToInputMap. An updated path must be an output of the map, or under one of them, e.g. a
+// leaf of a container output. A deleted path may also be an ancestor of an output, e.g. the list
+// entry holding the outputs.
+func CheckCoverage(ft *translator.FunctionalTranslator, sr *gnmipb.SubscribeResponse) error {
+	n := sr.GetUpdate()
+	outputs := ft.SupportedOutputPaths()
+	for _, u := range n.GetUpdate() {
+		s := ftutilities.GNMIPathToSchemaString(ftutilities.Join(n.GetPrefix(), u.GetPath()), true)
+		if !covered(s, outputs, false) {
+			return fmt.Errorf("updated path %s is not an output of the OutputToInputMap", s)
+		}
+	}
+	for _, d := range n.GetDelete() {
+		s := ftutilities.GNMIPathToSchemaString(ftutilities.Join(n.GetPrefix(), d), true)
+		if !covered(s, outputs, true) {
+			return fmt.Errorf("deleted path %s is not an output of the OutputToInputMap", s)
+		}
+	}
+	return nil
+}
+
+// covered returns true if the schema string is equal to or under one of the outputs, or, if
+// ancestors are allowed, an ancestor of one of them.
+func covered(s string, outputs []string, ancestors bool) bool {
+	for _, out := range outputs {
+		if s == out || strings.HasPrefix(s, out+"/") || (ancestors && strings.HasPrefix(out, s+"/")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fttest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// newEchoFT returns an FT returning the notifications with updates unchanged, and failing for
+// notifications without timestamp.
+func newEchoFT(t *testing.T) *translator.FunctionalTranslator {
+	t.Helper()
+	ft, err := translator.NewFunctionalTranslator(translator.FunctionalTranslatorOptions{
+		ID: "echo-ft",
+		Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+			if sr.GetUpdate().GetTimestamp() == 0 {
+				return nil, fmt.Errorf("zero timestamp")
+			}
+			if len(sr.GetUpdate().GetUpdate()) == 0 {
+				return nil, nil
+			}
+			return sr, nil
+		},
+		OutputToInputMap: ftutilities.MustStringMapPaths(map[string][]string{
+			"/openconfig/system/state": {"/openconfig/system/state"},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+	}
+	return ft
+}
+
+func TestRunGoldenTests(t *testing.T) {
+	RunGoldenTests(t, newEchoFT(t), "testdata")
+}
+
+func TestLoadCases(t *testing.T) {
+	cases, err := LoadCases("testdata")
+	if err != nil {
+		t.Fatalf("LoadCases() got unexpected error: %v", err)
+	}
+	type summary struct {
+		name            string
+		inputs, outputs int
+		wantErr         string
+	}
+	var got []summary
+	for _, c := range cases {
+		got = append(got, summary{c.Name, len(c.Inputs), len(c.Want), c.WantErr})
+	}
+	want := []summary{
+		{name: "echo", inputs: 2, outputs: 1},
+		{name: "failure", inputs: 2, wantErr: "zero timestamp"},
+		{name: "no_output", inputs: 1},
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("LoadCases() = %v, want %v", got, want)
+	}
+	if cases, err := LoadCases("nonexistent"); err != nil || len(cases) != 0 {
+		t.Errorf("LoadCases() of a missing directory = %v, %v, want no case", cases, err)
+	}
+}
+
+func TestCaseRun(t *testing.T) {
+	cases, err := LoadCases("testdata")
+	if err != nil {
+		t.Fatalf("LoadCases() got unexpected error: %v", err)
+	}
+	for _, c := range cases {
+		got, err := c.Run(newEchoFT(t))
+		if (err != nil) != (c.WantErr != "") {
+			t.Errorf("Run() of %s got error %v, want error %q", c.Name, err, c.WantErr)
+		}
+		if len(got) != len(c.Want) {
+			t.Errorf("Run() of %s returned %d outputs, want %d", c.Name, len(got), len(c.Want))
+		}
+	}
+}
+
+func TestCheckCoverage(t *testing.T) {
+	ft := newEchoFT(t)
+	path := func(s string) *gnmipb.Path {
+		p, err := ftutilities.StringToPath(s)
+		if err != nil {
+			t.Fatalf("StringToPath(%q) got unexpected error: %v", s, err)
+		}
+		return p
+	}
+	notif := func(updates []string, deletes ...string) *gnmipb.SubscribeResponse {
+		n := &gnmipb.Notification{Prefix: &gnmipb.Path{Origin: "openconfig", Target: "dut"}}
+		for _, u := range updates {
+			n.Update = append(n.Update, &gnmipb.Update{
+				Path: path(u),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "v"}},
+			})
+		}
+		for _, d := range deletes {
+			n.Delete = append(n.Delete, path(d))
+		}
+		return &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_Update{Update: n}}
+	}
+	tests := []struct {
+		name    string
+		sr      *gnmipb.SubscribeResponse
+		wantErr bool
+	}{
+		{name: "leaf of output", sr: notif([]string{"/system/state/hostname"})},
+		{name: "deleted output", sr: notif(nil, "/system/state")},
+		{name: "deleted ancestor", sr: notif(nil, "/system")},
+		{name: "updated ancestor", sr: notif([]string{"/system"}), wantErr: true},
+		{name: "unknown update", sr: notif([]string{"/system/config/hostname"}), wantErr: true},
+		{name: "unknown delete", sr: notif(nil, "/interfaces"), wantErr: true},
+	}
+	for _, tc := range tests {
+		if err := CheckCoverage(ft, tc.sr); (err != nil) != tc.wantErr {
+			t.Errorf("%s: CheckCoverage() got error %v, want error %t", tc.name, err, tc.wantErr)
+		}
+	}
+}
@@ -0,0 +1,343 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fttest
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// mutationsPerSeed is the number of mutations of each seed response added to the corpus.
+const mutationsPerSeed = 8
+
+// Fuzz fuzzes the FT with mutations of the seed responses, e.g.
+//
+//	func FuzzTranslate(f *testing.F) {
+//		fttest.Fuzz(f, New(), seedSR)
+//	}
+//
+// The fuzzer mutates the wire encoding of the responses, and the responses are further mutated in
+// their paths, keys and value types. The FT may return errors, but it fails the fuzz test if it
+// panics or returns a malformed response. See CheckResponse. The state of the FT is reset before
+// each input.
+func Fuzz(f *testing.F, ft *translator.FunctionalTranslator, seeds ...*gnmipb.SubscribeResponse) {
+	f.Helper()
+	for _, sr := range seeds {
+		b, err := proto.Marshal(sr)
+		if err != nil {
+			f.Fatalf("Failed to marshal seed %v: %v", sr, err)
+		}
+		for m := uint64(0); m <= mutationsPerSeed; m++ {
+			f.Add(b, m)
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte, mutation uint64) {
+		translate(t, ft, data, mutation)
+	})
+}
+
+// FuzzAll is like Fuzz for several FTs, e.g. all the FTs of the registry, each seeded with its
+// InputSeeds, so that FTs without captured responses are fuzzed too.
+func FuzzAll(f *testing.F, fts []*translator.FunctionalTranslator) {
+	f.Helper()
+	for i, ft := range fts {
+		for _, sr := range InputSeeds(ft) {
+			b, err := proto.Marshal(sr)
+			if err != nil {
+				f.Fatalf("Failed to marshal seed %v: %v", sr, err)
+			}
+			for m := uint64(0); m <= mutationsPerSeed; m++ {
+				f.Add(b, m, uint(i))
+			}
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte, mutation uint64, i uint) {
+		if len(fts) == 0 {
+			t.Skip()
+		}
+		translate(t, fts[i%uint(len(fts))], data, mutation)
+	})
+}
+
+// translate translates the mutated response encoded in data, and fails if the FT panics or
+// returns a malformed response.
+func translate(t *testing.T, ft *translator.FunctionalTranslator, data []byte, mutation uint64) {
+	sr := &gnmipb.SubscribeResponse{}
+	if err := proto.Unmarshal(data, sr); err != nil {
+		t.Skip()
+	}
+	Mutate(sr, mutation)
+	ft.Reset()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Translate() of %s panicked for input %v: %v\n%s", ft.ID(), sr, r, debug.Stack())
+		}
+	}()
+	out, err := ft.Translate(sr)
+	if err != nil {
+		return
+	}
+	if err := CheckResponse(out); err != nil {
+		t.Errorf("Translate() of %s returned a malformed response for input %v: %v\noutput: %v", ft.ID(), sr, err, out)
+	}
+}
+
+// seedDepths are the numbers of elements appended to the input paths by InputSeeds, so that the
+// FTs expecting the leaves under their input containers are reached.
+var seedDepths = []int{0, 2, 4}
+
+// InputSeeds returns seed responses built from the input paths of the OutputToInputMap of the FT,
+// with their wildcards replaced: for each input, updates of the path extended with a few elements,
+// and a delete of the path.
+func InputSeeds(ft *translator.FunctionalTranslator) []*gnmipb.SubscribeResponse {
+	var seeds []*gnmipb.SubscribeResponse
+	seen := map[string]bool{}
+	for _, out := range ft.SupportedOutputPaths() {
+		inputs, _ := ft.InputsForOutput(out)
+		for _, in := range inputs {
+			key := ftutilities.GNMIPathToSchemaString(in, false)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			prefix := &gnmipb.Path{Origin: in.GetOrigin(), Target: "dut"}
+			notification := func(n *gnmipb.Notification) *gnmipb.SubscribeResponse {
+				n.Timestamp = 1
+				n.Prefix = proto.Clone(prefix).(*gnmipb.Path)
+				return &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_Update{Update: n}}
+			}
+			for _, depth := range seedDepths {
+				p := seedPath(in, depth)
+				seeds = append(seeds, notification(&gnmipb.Notification{
+					Update: []*gnmipb.Update{{Path: p, Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 1}}}},
+				}))
+			}
+			seeds = append(seeds, notification(&gnmipb.Notification{Delete: []*gnmipb.Path{seedPath(in, 0)}}))
+		}
+	}
+	return seeds
+}
+
+// seedPath returns the input path without origin, with its wildcard names and keys replaced by
+// "1", and extended with depth elements.
+func seedPath(in *gnmipb.Path, depth int) *gnmipb.Path {
+	p := &gnmipb.Path{}
+	for _, e := range in.GetElem() {
+		elem := &gnmipb.PathElem{Name: e.GetName()}
+		if elem.Name == "*" || elem.Name == "..." {
+			elem.Name = "1"
+		}
+		for k, v := range e.GetKey() {
+			if elem.Key == nil {
+				elem.Key = map[string]string{}
+			}
+			if v == "*" {
+				v = "1"
+			}
+			elem.Key[k] = v
+		}
+		p.Elem = append(p.Elem, elem)
+	}
+	for i := 0; i < depth; i++ {
+		p.Elem = append(p.Elem, &gnmipb.PathElem{Name: fmt.Sprint(i + 1)})
+	}
+	return p
+}
+
+// CheckResponse returns an error if a response returned by an FT is malformed. The response must
+// be nil, a sync response, or a notification with at least one update or delete, whose paths have
+// named elements and whose updates have values.
+func CheckResponse(sr *gnmipb.SubscribeResponse) error {
+	if sr == nil || translator.IsSyncResponse(sr) {
+		return nil
+	}
+	n := sr.GetUpdate()
+	if n == nil {
+		return fmt.Errorf("response is neither a notification nor a sync response")
+	}
+	if len(n.GetUpdate()) == 0 && len(n.GetDelete()) == 0 {
+		return fmt.Errorf("notification has no update or delete, the FT must return nil")
+	}
+	for _, u := range n.GetUpdate() {
+		if err := checkPath(ftutilities.Join(n.GetPrefix(), u.GetPath())); err != nil {
+			return fmt.Errorf("update %v: %v", u, err)
+		}
+		if u.GetVal().GetValue() == nil {
+			return fmt.Errorf("update %v has no value", u)
+		}
+	}
+	for _, d := range n.GetDelete() {
+		if err := checkPath(ftutilities.Join(n.GetPrefix(), d)); err != nil {
+			return fmt.Errorf("delete %v: %v", d, err)
+		}
+	}
+	return nil
+}
+
+func checkPath(p *gnmipb.Path) error {
+	if len(p.GetElem()) == 0 {
+		return fmt.Errorf("empty path")
+	}
+	for _, e := range p.GetElem() {
+		if e.GetName() == "" {
+			return fmt.Errorf("path %v has an element without name", p)
+		}
+	}
+	return nil
+}
+
+// oddStrings are the strings used by the mutations of names, keys and values.
+var oddStrings = []string{"", "*", "...", "a/b", "[x=y]", "0", "-1", "1.5", "NaN", "\x00", "é", strings.Repeat("x", 1024)}
+
+// Mutate applies up to four random mutations, chosen by the seed, to the paths, keys and values of
+// the response. A seed of 0 leaves the response unchanged.
+func Mutate(sr *gnmipb.SubscribeResponse, seed uint64) {
+	if seed == 0 {
+		return
+	}
+	n := sr.GetUpdate()
+	if n == nil {
+		return
+	}
+	r := rand.New(rand.NewPCG(seed, seed>>32))
+	for i := 1 + r.IntN(4); i > 0; i-- {
+		var paths []*gnmipb.Path
+		if n.GetPrefix() != nil {
+			paths = append(paths, n.GetPrefix())
+		}
+		for _, u := range n.GetUpdate() {
+			if u.GetPath() != nil {
+				paths = append(paths, u.GetPath())
+			}
+		}
+		paths = append(paths, n.GetDelete()...)
+		switch r.IntN(4) {
+		case 0:
+			if len(paths) > 0 {
+				mutatePath(r, paths[r.IntN(len(paths))])
+			}
+		case 1:
+			if len(paths) > 0 {
+				mutateKeys(r, paths[r.IntN(len(paths))])
+			}
+		case 2:
+			if len(n.GetUpdate()) > 0 {
+				n.GetUpdate()[r.IntN(len(n.GetUpdate()))].Val = randomValue(r)
+			}
+		case 3:
+			mutateUpdates(r, n)
+		}
+	}
+}
+
+func mutatePath(r *rand.Rand, p *gnmipb.Path) {
+	elems := p.GetElem()
+	if len(elems) == 0 {
+		p.Elem = []*gnmipb.PathElem{{Name: oddStrings[r.IntN(len(oddStrings))]}}
+		return
+	}
+	i := r.IntN(len(elems))
+	switch r.IntN(4) {
+	case 0: // Remove an element.
+		p.Elem = append(elems[:i:i], elems[i+1:]...)
+	case 1: // Duplicate an element.
+		p.Elem = append(elems[:i+1:i+1], elems[i:]...)
+	case 2: // Rename an element, to an odd name or to the name of another element.
+		if r.IntN(2) == 0 {
+			elems[i].Name = oddStrings[r.IntN(len(oddStrings))]
+		} else {
+			elems[i].Name = elems[r.IntN(len(elems))].GetName()
+		}
+	case 3: // Truncate the path.
+		p.Elem = elems[:i]
+	}
+}
+
+func mutateKeys(r *rand.Rand, p *gnmipb.Path) {
+	elems := p.GetElem()
+	if len(elems) == 0 {
+		return
+	}
+	e := elems[r.IntN(len(elems))]
+	var keys []string
+	for k := range e.GetKey() {
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 || r.IntN(3) == 0 {
+		if e.Key == nil {
+			e.Key = map[string]string{}
+		}
+		e.Key[oddStrings[r.IntN(len(oddStrings))]] = oddStrings[r.IntN(len(oddStrings))]
+		return
+	}
+	// Map iteration order is random, sort to keep the mutation deterministic.
+	sort.Strings(keys)
+	k := keys[r.IntN(len(keys))]
+	if r.IntN(2) == 0 {
+		delete(e.Key, k)
+	} else {
+		e.Key[k] = oddStrings[r.IntN(len(oddStrings))]
+	}
+}
+
+func mutateUpdates(r *rand.Rand, n *gnmipb.Notification) {
+	updates := n.GetUpdate()
+	if len(updates) == 0 {
+		return
+	}
+	i := r.IntN(len(updates))
+	switch r.IntN(3) {
+	case 0: // Remove an update.
+		n.Update = append(updates[:i:i], updates[i+1:]...)
+	case 1: // Duplicate an update, with another value.
+		u := proto.Clone(updates[i]).(*gnmipb.Update)
+		u.Val = randomValue(r)
+		n.Update = append(updates, u)
+	case 2: // Drop the path of an update.
+		updates[i].Path = nil
+	}
+}
+
+// randomValue returns a value of a random type, or nil.
+func randomValue(r *rand.Rand) *gnmipb.TypedValue {
+	s := oddStrings[r.IntN(len(oddStrings))]
+	values := []*gnmipb.TypedValue{
+		nil,
+		{},
+		{Value: &gnmipb.TypedValue_StringVal{StringVal: s}},
+		{Value: &gnmipb.TypedValue_IntVal{IntVal: []int64{0, -1, math.MinInt64, math.MaxInt64}[r.IntN(4)]}},
+		{Value: &gnmipb.TypedValue_UintVal{UintVal: []uint64{0, 1, math.MaxUint64}[r.IntN(3)]}},
+		{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: []float64{0, -1.5, math.NaN(), math.Inf(1)}[r.IntN(4)]}},
+		{Value: &gnmipb.TypedValue_FloatVal{FloatVal: float32(math.Inf(-1))}},
+		{Value: &gnmipb.TypedValue_BoolVal{BoolVal: r.IntN(2) == 0}},
+		{Value: &gnmipb.TypedValue_BytesVal{BytesVal: []byte(s)}},
+		{Value: &gnmipb.TypedValue_JsonVal{JsonVal: []byte(s)}},
+		{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"a":` + s + `}`)}},
+		{Value: &gnmipb.TypedValue_DecimalVal{DecimalVal: &gnmipb.Decimal64{Digits: -12345, Precision: uint32(r.IntN(20))}}},
+		{Value: &gnmipb.TypedValue_LeaflistVal{LeaflistVal: &gnmipb.ScalarArray{}}},
+	}
+	return values[r.IntN(len(values))]
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fttest
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/openconfig/functional-translators/ftutilities"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func fuzzSeed(t testing.TB) *gnmipb.SubscribeResponse {
+	t.Helper()
+	srs, err := ftutilities.LoadSubscribeResponses("testdata/echo_input.txt")
+	if err != nil {
+		t.Fatalf("Failed to load seed: %v", err)
+	}
+	return srs[0]
+}
+
+func TestMutate(t *testing.T) {
+	seed := fuzzSeed(t)
+	sr := proto.Clone(seed).(*gnmipb.SubscribeResponse)
+	Mutate(sr, 0)
+	if !proto.Equal(sr, seed) {
+		t.Errorf("Mutate() with seed 0 changed the response to %v", sr)
+	}
+	changed := false
+	for s := uint64(1); s < 100; s++ {
+		a := proto.Clone(seed).(*gnmipb.SubscribeResponse)
+		b := proto.Clone(seed).(*gnmipb.SubscribeResponse)
+		Mutate(a, s)
+		Mutate(b, s)
+		if !proto.Equal(a, b) {
+			t.Errorf("Mutate() with seed %d is not deterministic: %v and %v", s, a, b)
+		}
+		changed = changed || !proto.Equal(a, seed)
+	}
+	if !changed {
+		t.Errorf("Mutate() never changed the response")
+	}
+	sync := &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true}}
+	Mutate(sync, 1)
+	if !sync.GetSyncResponse() {
+		t.Errorf("Mutate() changed a sync response to %v", sync)
+	}
+}
+
+func TestCheckResponse(t *testing.T) {
+	path := &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a"}}}
+	val := &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 1}}
+	notification := func(n *gnmipb.Notification) *gnmipb.SubscribeResponse {
+		return &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_Update{Update: n}}
+	}
+	tests := []struct {
+		name    string
+		sr      *gnmipb.SubscribeResponse
+		wantErr bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "sync",
+			sr:   &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true}},
+		},
+		{
+			name: "update",
+			sr:   notification(&gnmipb.Notification{Update: []*gnmipb.Update{{Path: path, Val: val}}}),
+		},
+		{
+			name: "delete under prefix",
+			sr:   notification(&gnmipb.Notification{Prefix: path, Delete: []*gnmipb.Path{{}}}),
+		},
+		{
+			name:    "no response",
+			sr:      &gnmipb.SubscribeResponse{},
+			wantErr: true,
+		},
+		{
+			name:    "empty notification",
+			sr:      notification(&gnmipb.Notification{Prefix: path}),
+			wantErr: true,
+		},
+		{
+			name:    "no value",
+			sr:      notification(&gnmipb.Notification{Update: []*gnmipb.Update{{Path: path, Val: &gnmipb.TypedValue{}}}}),
+			wantErr: true,
+		},
+		{
+			name:    "empty path",
+			sr:      notification(&gnmipb.Notification{Update: []*gnmipb.Update{{Val: val}}}),
+			wantErr: true,
+		},
+		{
+			name:    "unnamed element",
+			sr:      notification(&gnmipb.Notification{Delete: []*gnmipb.Path{{Elem: []*gnmipb.PathElem{{Name: "a"}, {}}}}}),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := CheckResponse(tc.sr); (err != nil) != tc.wantErr {
+				t.Errorf("CheckResponse() got error %v, want error %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestInputSeeds(t *testing.T) {
+	seeds := InputSeeds(newEchoFT(t))
+	if len(seeds) != len(seedDepths)+1 {
+		t.Fatalf("InputSeeds() returned %d seeds, want %d", len(seeds), len(seedDepths)+1)
+	}
+	for i, depth := range seedDepths {
+		n := seeds[i].GetUpdate()
+		if got := len(n.GetUpdate()[0].GetPath().GetElem()); got != 2+depth {
+			t.Errorf("InputSeeds() returned seed %v with %d elements, want %d", seeds[i], got, 2+depth)
+		}
+		if n.GetPrefix().GetOrigin() != "openconfig" || n.GetPrefix().GetTarget() == "" {
+			t.Errorf("InputSeeds() returned seed %v without origin or target", seeds[i])
+		}
+	}
+	if len(seeds[len(seedDepths)].GetUpdate().GetDelete()) != 1 {
+		t.Errorf("InputSeeds() returned last seed %v, want a delete", seeds[len(seedDepths)])
+	}
+}
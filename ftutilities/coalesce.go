@@ -0,0 +1,164 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ftutilities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/openconfig/ygot/ygot"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// batch is a coalesced notification being built.
+type batch struct {
+	n     *gnmipb.Notification
+	start int64
+	// updates indexes the updates of n by path.
+	updates map[string]int
+	deletes map[string]bool
+}
+
+// Coalesce merges the notifications with the same prefix, including origin and target, into fewer
+// notifications, e.g. to batch the many small notifications of stateful translators.
+//
+// A notification is merged into the previous one with the same prefix if its timestamp is at most
+// window after the timestamp of the first notification merged into it; a window of zero merges
+// notifications with the same timestamp. The merged notification has the latest timestamp.
+// The notifications are applied in order: a later update of a path replaces the earlier one, and a
+// later delete drops the earlier updates of the deleted path and the paths under it. Atomic
+// notifications are not merged, and the notifications are returned in the order they were started.
+//
+// The input notifications are not modified, but the returned notifications share their updates.
+func Coalesce(notifs []*gnmipb.Notification, window time.Duration) []*gnmipb.Notification {
+	var out []*gnmipb.Notification
+	open := map[string]*batch{}
+	// built holds the notifications built by merging, as opposed to the input notifications.
+	built := map[*gnmipb.Notification]bool{}
+	for _, n := range notifs {
+		if n == nil {
+			continue
+		}
+		key, ok := prefixKey(n.GetPrefix())
+		if !ok || n.GetAtomic() {
+			// Close the batch with the same prefix, to keep the order of the notifications.
+			delete(open, key)
+			out = append(out, n)
+			continue
+		}
+		b := open[key]
+		if b == nil || n.GetTimestamp()-b.start > window.Nanoseconds() {
+			b = &batch{
+				n:       &gnmipb.Notification{Prefix: n.GetPrefix(), Timestamp: n.GetTimestamp()},
+				start:   n.GetTimestamp(),
+				updates: map[string]int{},
+				deletes: map[string]bool{},
+			}
+			open[key] = b
+			built[b.n] = true
+			out = append(out, b.n)
+		}
+		if !b.add(n) {
+			// A path of the notification cannot be keyed, it is returned unchanged.
+			delete(open, key)
+			out = append(out, n)
+		}
+	}
+	coalesced := out[:0]
+	for _, n := range out {
+		if built[n] {
+			// Remove the updates dropped by later deletes.
+			updates := n.GetUpdate()[:0]
+			for _, u := range n.GetUpdate() {
+				if u != nil {
+					updates = append(updates, u)
+				}
+			}
+			n.Update = updates
+			if len(n.GetUpdate()) == 0 && len(n.GetDelete()) == 0 {
+				continue
+			}
+		}
+		coalesced = append(coalesced, n)
+	}
+	return coalesced
+}
+
+// add merges the notification into the batch. It returns false, leaving the batch unchanged, if a
+// path of the notification cannot be keyed.
+func (b *batch) add(n *gnmipb.Notification) bool {
+	deleteKeys := make([]string, len(n.GetDelete()))
+	for i, d := range n.GetDelete() {
+		k, ok := pathKey(d)
+		if !ok {
+			return false
+		}
+		deleteKeys[i] = k
+	}
+	updateKeys := make([]string, len(n.GetUpdate()))
+	for i, u := range n.GetUpdate() {
+		k, ok := pathKey(u.GetPath())
+		if !ok {
+			return false
+		}
+		updateKeys[i] = k
+	}
+	if n.GetTimestamp() > b.n.GetTimestamp() {
+		b.n.Timestamp = n.GetTimestamp()
+	}
+	// gNMI applies the deletes of a notification before its updates.
+	for i, d := range n.GetDelete() {
+		k := deleteKeys[i]
+		for uk, ix := range b.updates {
+			if uk == k || strings.HasPrefix(uk, k+"/") || k == "/" {
+				b.n.Update[ix] = nil
+				delete(b.updates, uk)
+			}
+		}
+		if !b.deletes[k] {
+			b.deletes[k] = true
+			b.n.Delete = append(b.n.Delete, d)
+		}
+	}
+	for i, u := range n.GetUpdate() {
+		k := updateKeys[i]
+		if ix, ok := b.updates[k]; ok {
+			b.n.Update[ix] = u
+			continue
+		}
+		b.updates[k] = len(b.n.Update)
+		b.n.Update = append(b.n.Update, u)
+	}
+	return true
+}
+
+// prefixKey returns the key of the prefix of a notification, including its origin and target.
+func prefixKey(p *gnmipb.Path) (string, bool) {
+	k, ok := pathKey(p)
+	return p.GetOrigin() + "\x00" + p.GetTarget() + "\x00" + k, ok
+}
+
+func pathKey(p *gnmipb.Path) (string, bool) {
+	// Paths using the deprecated element field are not merged.
+	if len(p.GetElement()) > 0 {
+		return "", false
+	}
+	if len(p.GetElem()) == 0 {
+		return "/", true
+	}
+	s, err := ygot.PathToString(&gnmipb.Path{Elem: p.GetElem()})
+	return s, err == nil
+}
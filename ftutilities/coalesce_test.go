@@ -0,0 +1,159 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ftutilities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestCoalesce(t *testing.T) {
+	prefix := func(target string) *gnmipb.Path {
+		return &gnmipb.Path{Origin: "openconfig", Target: target, Elem: []*gnmipb.PathElem{{Name: "interfaces"}}}
+	}
+	path := func(intf, leaf string) *gnmipb.Path {
+		return &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interface", Key: map[string]string{"name": intf}},
+			{Name: "state"}, {Name: "counters"}, {Name: leaf},
+		}}
+	}
+	intfPath := func(intf string) *gnmipb.Path {
+		return &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": intf}}}}
+	}
+	update := func(p *gnmipb.Path, v uint64) *gnmipb.Update {
+		return &gnmipb.Update{Path: p, Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}}}
+	}
+	notif := func(target string, ts int64, updates []*gnmipb.Update, deletes ...*gnmipb.Path) *gnmipb.Notification {
+		return &gnmipb.Notification{Prefix: prefix(target), Timestamp: ts, Update: updates, Delete: deletes}
+	}
+	tests := []struct {
+		name   string
+		notifs []*gnmipb.Notification
+		window time.Duration
+		want   []*gnmipb.Notification
+	}{
+		{
+			name: "no notification",
+		},
+		{
+			name: "merge within window, last write wins",
+			notifs: []*gnmipb.Notification{
+				notif("dut", 100, []*gnmipb.Update{update(path("eth0", "in-pkts"), 1)}),
+				notif("dut", 150, []*gnmipb.Update{update(path("eth0", "out-pkts"), 2)}),
+				notif("dut", 200, []*gnmipb.Update{update(path("eth0", "in-pkts"), 3)}),
+			},
+			window: 100,
+			want: []*gnmipb.Notification{
+				notif("dut", 200, []*gnmipb.Update{update(path("eth0", "in-pkts"), 3), update(path("eth0", "out-pkts"), 2)}),
+			},
+		},
+		{
+			name: "split by window",
+			notifs: []*gnmipb.Notification{
+				notif("dut", 100, []*gnmipb.Update{update(path("eth0", "in-pkts"), 1)}),
+				notif("dut", 150, []*gnmipb.Update{update(path("eth0", "in-pkts"), 2)}),
+				notif("dut", 250, []*gnmipb.Update{update(path("eth0", "in-pkts"), 3)}),
+			},
+			window: 100,
+			want: []*gnmipb.Notification{
+				notif("dut", 150, []*gnmipb.Update{update(path("eth0", "in-pkts"), 2)}),
+				notif("dut", 250, []*gnmipb.Update{update(path("eth0", "in-pkts"), 3)}),
+			},
+		},
+		{
+			name: "zero window merges the same timestamp",
+			notifs: []*gnmipb.Notification{
+				notif("dut", 100, []*gnmipb.Update{update(path("eth0", "in-pkts"), 1)}),
+				notif("dut", 100, []*gnmipb.Update{update(path("eth1", "in-pkts"), 2)}),
+				notif("dut", 101, []*gnmipb.Update{update(path("eth2", "in-pkts"), 3)}),
+			},
+			want: []*gnmipb.Notification{
+				notif("dut", 100, []*gnmipb.Update{update(path("eth0", "in-pkts"), 1), update(path("eth1", "in-pkts"), 2)}),
+				notif("dut", 101, []*gnmipb.Update{update(path("eth2", "in-pkts"), 3)}),
+			},
+		},
+		{
+			name: "targets are not merged",
+			notifs: []*gnmipb.Notification{
+				notif("dut1", 100, []*gnmipb.Update{update(path("eth0", "in-pkts"), 1)}),
+				notif("dut2", 100, []*gnmipb.Update{update(path("eth0", "in-pkts"), 2)}),
+				notif("dut1", 100, []*gnmipb.Update{update(path("eth1", "in-pkts"), 3)}),
+			},
+			window: time.Second,
+			want: []*gnmipb.Notification{
+				notif("dut1", 100, []*gnmipb.Update{update(path("eth0", "in-pkts"), 1), update(path("eth1", "in-pkts"), 3)}),
+				notif("dut2", 100, []*gnmipb.Update{update(path("eth0", "in-pkts"), 2)}),
+			},
+		},
+		{
+			name: "delete drops earlier updates",
+			notifs: []*gnmipb.Notification{
+				notif("dut", 100, []*gnmipb.Update{update(path("eth0", "in-pkts"), 1), update(path("eth1", "in-pkts"), 2)}),
+				notif("dut", 110, nil, intfPath("eth0")),
+				notif("dut", 120, []*gnmipb.Update{update(path("eth1", "in-pkts"), 3)}, intfPath("eth0")),
+			},
+			window: time.Second,
+			want: []*gnmipb.Notification{
+				notif("dut", 120, []*gnmipb.Update{update(path("eth1", "in-pkts"), 3)}, intfPath("eth0")),
+			},
+		},
+		{
+			name: "update after delete",
+			notifs: []*gnmipb.Notification{
+				notif("dut", 100, []*gnmipb.Update{update(path("eth0", "in-pkts"), 1)}),
+				notif("dut", 110, nil, intfPath("eth0")),
+				notif("dut", 120, []*gnmipb.Update{update(path("eth0", "in-pkts"), 0)}),
+			},
+			window: time.Second,
+			want: []*gnmipb.Notification{
+				notif("dut", 120, []*gnmipb.Update{update(path("eth0", "in-pkts"), 0)}, intfPath("eth0")),
+			},
+		},
+		{
+			name: "atomic notifications are not merged",
+			notifs: []*gnmipb.Notification{
+				notif("dut", 100, []*gnmipb.Update{update(path("eth0", "in-pkts"), 1)}),
+				{Prefix: prefix("dut"), Timestamp: 110, Atomic: true, Update: []*gnmipb.Update{update(path("eth1", "in-pkts"), 2)}},
+				notif("dut", 120, []*gnmipb.Update{update(path("eth0", "in-pkts"), 3)}),
+			},
+			window: time.Second,
+			want: []*gnmipb.Notification{
+				notif("dut", 100, []*gnmipb.Update{update(path("eth0", "in-pkts"), 1)}),
+				{Prefix: prefix("dut"), Timestamp: 110, Atomic: true, Update: []*gnmipb.Update{update(path("eth1", "in-pkts"), 2)}},
+				notif("dut", 120, []*gnmipb.Update{update(path("eth0", "in-pkts"), 3)}),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var inputs []*gnmipb.Notification
+			for _, n := range tc.notifs {
+				inputs = append(inputs, proto.Clone(n).(*gnmipb.Notification))
+			}
+			got := Coalesce(tc.notifs, tc.window)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Coalesce() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(inputs, tc.notifs, protocmp.Transform()); diff != "" {
+				t.Errorf("Coalesce() modified its input (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ftutilities
+
+import (
+	"maps"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// CompactNotification returns a notification equivalent to n with the longest common prefix of the
+// paths of its updates and deletes moved into its prefix, e.g. the component elements of the
+// updates of a single component, to reduce the size of the serialized notification. Each path
+// keeps at least its last element. n is returned unchanged if its paths have no common prefix, or
+// if it is atomic, since the prefix of an atomic notification is significant.
+//
+// The input notification is not modified, but the returned notification shares its values and
+// path elements.
+func CompactNotification(n *gnmipb.Notification) *gnmipb.Notification {
+	if n.GetAtomic() {
+		return n
+	}
+	common := commonPrefixLen(n)
+	if common == 0 {
+		return n
+	}
+	first := n.GetUpdate()
+	var elems []*gnmipb.PathElem
+	if len(first) > 0 {
+		elems = first[0].GetPath().GetElem()
+	} else {
+		elems = n.GetDelete()[0].GetElem()
+	}
+	prefix := Join(n.GetPrefix(), &gnmipb.Path{Elem: elems[:common]})
+	trim := func(p *gnmipb.Path) *gnmipb.Path {
+		return &gnmipb.Path{Origin: p.GetOrigin(), Target: p.GetTarget(), Elem: p.GetElem()[common:]}
+	}
+	out := &gnmipb.Notification{
+		Timestamp: n.GetTimestamp(),
+		Prefix:    prefix,
+	}
+	for _, u := range n.GetUpdate() {
+		out.Update = append(out.Update, &gnmipb.Update{
+			Path:       trim(u.GetPath()),
+			Val:        u.GetVal(),
+			Duplicates: u.GetDuplicates(),
+		})
+	}
+	for _, d := range n.GetDelete() {
+		out.Delete = append(out.Delete, trim(d))
+	}
+	return out
+}
+
+// commonPrefixLen returns the number of leading elements shared by the paths of the updates and
+// deletes of n, leaving at least one element in each path.
+func commonPrefixLen(n *gnmipb.Notification) int {
+	var (
+		common []*gnmipb.PathElem
+		seen   bool
+	)
+	visit := func(p *gnmipb.Path) {
+		elems := p.GetElem()
+		if len(elems) > 0 {
+			// Keep the last element of the path.
+			elems = elems[:len(elems)-1]
+		}
+		if !seen {
+			common, seen = elems, true
+			return
+		}
+		l := 0
+		for l < len(common) && l < len(elems) && elemEqual(common[l], elems[l]) {
+			l++
+		}
+		common = common[:l]
+	}
+	for _, u := range n.GetUpdate() {
+		visit(u.GetPath())
+	}
+	for _, d := range n.GetDelete() {
+		visit(d)
+	}
+	return len(common)
+}
+
+func elemEqual(a, b *gnmipb.PathElem) bool {
+	return a.GetName() == b.GetName() && maps.Equal(a.GetKey(), b.GetKey())
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ftutilities
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestCompactNotification(t *testing.T) {
+	path := func(s string) *gnmipb.Path {
+		p, err := ygot.StringToStructuredPath(s)
+		if err != nil {
+			t.Fatalf("StringToStructuredPath(%q) got unexpected error: %v", s, err)
+		}
+		return p
+	}
+	prefix := func(s string) *gnmipb.Path {
+		p := path(s)
+		p.Origin, p.Target = "openconfig", "dut"
+		return p
+	}
+	update := func(s string, v uint64) *gnmipb.Update {
+		return &gnmipb.Update{Path: path(s), Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}}}
+	}
+	tests := []struct {
+		name  string
+		notif *gnmipb.Notification
+		want  *gnmipb.Notification
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "common prefix",
+			notif: &gnmipb.Notification{
+				Timestamp: 100,
+				Prefix:    prefix("/"),
+				Update: []*gnmipb.Update{
+					update("/components/component[name=c1]/state/temperature/instant", 1),
+					update("/components/component[name=c1]/state/temperature/max", 2),
+				},
+			},
+			want: &gnmipb.Notification{
+				Timestamp: 100,
+				Prefix:    prefix("/components/component[name=c1]/state/temperature"),
+				Update: []*gnmipb.Update{
+					update("/instant", 1),
+					update("/max", 2),
+				},
+			},
+		},
+		{
+			name: "keys differ",
+			notif: &gnmipb.Notification{
+				Prefix: prefix("/"),
+				Update: []*gnmipb.Update{
+					update("/components/component[name=c1]/state/used-power", 1),
+					update("/components/component[name=c2]/state/used-power", 2),
+				},
+				Delete: []*gnmipb.Path{path("/components/component[name=c3]")},
+			},
+			want: &gnmipb.Notification{
+				Prefix: prefix("/components"),
+				Update: []*gnmipb.Update{
+					update("/component[name=c1]/state/used-power", 1),
+					update("/component[name=c2]/state/used-power", 2),
+				},
+				Delete: []*gnmipb.Path{path("/component[name=c3]")},
+			},
+		},
+		{
+			name: "appended to prefix",
+			notif: &gnmipb.Notification{
+				Prefix: prefix("/components"),
+				Update: []*gnmipb.Update{update("/component[name=c1]/state/used-power", 1)},
+			},
+			want: &gnmipb.Notification{
+				Prefix: prefix("/components/component[name=c1]/state"),
+				Update: []*gnmipb.Update{update("/used-power", 1)},
+			},
+		},
+		{
+			name: "deletes only",
+			notif: &gnmipb.Notification{
+				Prefix: prefix("/"),
+				Delete: []*gnmipb.Path{
+					path("/interfaces/interface[name=eth0]/state/counters"),
+					path("/interfaces/interface[name=eth0]/state/oper-status"),
+				},
+			},
+			want: &gnmipb.Notification{
+				Prefix: prefix("/interfaces/interface[name=eth0]/state"),
+				Delete: []*gnmipb.Path{path("/counters"), path("/oper-status")},
+			},
+		},
+		{
+			name: "no common prefix",
+			notif: &gnmipb.Notification{
+				Prefix: prefix("/"),
+				Update: []*gnmipb.Update{
+					update("/components/component[name=c1]/state/used-power", 1),
+					update("/interfaces/interface[name=eth0]/state/counters/in-pkts", 2),
+				},
+			},
+			want: &gnmipb.Notification{
+				Prefix: prefix("/"),
+				Update: []*gnmipb.Update{
+					update("/components/component[name=c1]/state/used-power", 1),
+					update("/interfaces/interface[name=eth0]/state/counters/in-pkts", 2),
+				},
+			},
+		},
+		{
+			name: "leaf is kept",
+			notif: &gnmipb.Notification{
+				Prefix: prefix("/"),
+				Update: []*gnmipb.Update{
+					update("/system/state/boot-time", 1),
+					update("/system/state/boot-time", 2),
+				},
+			},
+			want: &gnmipb.Notification{
+				Prefix: prefix("/system/state"),
+				Update: []*gnmipb.Update{
+					update("/boot-time", 1),
+					update("/boot-time", 2),
+				},
+			},
+		},
+		{
+			name: "atomic",
+			notif: &gnmipb.Notification{
+				Prefix: prefix("/"),
+				Atomic: true,
+				Update: []*gnmipb.Update{update("/system/state/boot-time", 1)},
+			},
+			want: &gnmipb.Notification{
+				Prefix: prefix("/"),
+				Atomic: true,
+				Update: []*gnmipb.Update{update("/system/state/boot-time", 1)},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var in *gnmipb.Notification
+			if tc.notif != nil {
+				in = proto.Clone(tc.notif).(*gnmipb.Notification)
+			}
+			got := CompactNotification(in)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("CompactNotification() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.notif, in, protocmp.Transform()); diff != "" {
+				t.Errorf("CompactNotification() modified its input, diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
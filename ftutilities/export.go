@@ -0,0 +1,296 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ftutilities
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// cacheExportVersion is the version of the JSON format of the exported caches. Import rejects
+// other versions.
+const cacheExportVersion = 1
+
+// macSecExport is the JSON format of an exported AristaMACSecMapCache.
+type macSecExport struct {
+	Version int `json:"version"`
+	// Targets maps the targets to their interfaces.
+	Targets map[string]map[string]*interfaceMacSecExport `json:"targets"`
+}
+
+// interfaceMacSecExport is the JSON format of an InterfaceMacSecInfo. Unset values are omitted.
+type interfaceMacSecExport struct {
+	CPStatus *bool                 `json:"cpStatus,omitempty"`
+	CKNs     map[string]*cknExport `json:"ckns,omitempty"`
+	// SecureChannels maps the directions of the secure channels to their SCIs.
+	SecureChannels map[string][]string `json:"secureChannels,omitempty"`
+}
+
+// cknExport is the JSON format of a CKNInfo. Unset values are omitted.
+type cknExport struct {
+	Principal *bool `json:"principal,omitempty"`
+	Success   *bool `json:"success,omitempty"`
+}
+
+// qosExport is the JSON format of an exported QoSAggregationMapCache.
+type qosExport struct {
+	Version int                         `json:"version"`
+	Targets map[string]*targetQoSExport `json:"targets"`
+}
+
+// targetQoSExport is the JSON format of a TargetQoSInfo.
+type targetQoSExport struct {
+	// PortChannels maps the port-channels to the queues of their members.
+	PortChannels        map[string]map[string]map[string]*QueueCounters `json:"portChannels,omitempty"`
+	MemberToPortChannel map[string]string                               `json:"memberToPortChannel,omitempty"`
+	UnassociatedMembers map[string]map[string]*QueueCounters            `json:"unassociatedMembers,omitempty"`
+	// PortChannelClassifiers maps the port-channels to the input classifiers of their members.
+	PortChannelClassifiers  map[string]map[string]classifiersExport `json:"portChannelClassifiers,omitempty"`
+	UnassociatedClassifiers map[string]classifiersExport            `json:"unassociatedClassifiers,omitempty"`
+}
+
+// classifiersExport is the JSON format of the input classifiers of a member, mapping the
+// classifier types to the counters of their terms.
+type classifiersExport map[string]map[string]*TermCounters
+
+func boolPtr(b, set bool) *bool {
+	if !set {
+		return nil
+	}
+	return &b
+}
+
+// Export returns the content of the cache as JSON, e.g. to be restored by Import after a restart
+// of the collector, instead of waiting for all the native leaves to be streamed again. The cache
+// of an FT is the snapshot returned by its State method, and an imported cache is restored with
+// its RestoreState method.
+func (c *AristaMACSecMapCache) Export() ([]byte, error) {
+	clone := c.Clone()
+	e := &macSecExport{
+		Version: cacheExportVersion,
+		Targets: make(map[string]map[string]*interfaceMacSecExport),
+	}
+	clone.Range(func(target string, info *TargetMacSecInfo) {
+		intfs := make(map[string]*interfaceMacSecExport, len(info.Interfaces))
+		for name, intf := range info.Interfaces {
+			ie := &interfaceMacSecExport{CPStatus: boolPtr(intf.cpStatus, intf.cpStatusSet)}
+			for ckn, s := range intf.cknStatuses {
+				if ie.CKNs == nil {
+					ie.CKNs = make(map[string]*cknExport, len(intf.cknStatuses))
+				}
+				ie.CKNs[ckn] = &cknExport{
+					Principal: boolPtr(s.principal, s.principalSet),
+					Success:   boolPtr(s.success, s.successSet),
+				}
+			}
+			for direction := range intf.secureChannels {
+				if ie.SecureChannels == nil {
+					ie.SecureChannels = make(map[string][]string, len(intf.secureChannels))
+				}
+				ie.SecureChannels[direction] = intf.SecureChannels(direction)
+			}
+			intfs[name] = ie
+		}
+		e.Targets[target] = intfs
+	})
+	return json.Marshal(e)
+}
+
+// Import replaces the content of the cache with the JSON returned by Export. The imported targets
+// are considered seen at the time of the import.
+func (c *AristaMACSecMapCache) Import(data []byte) error {
+	var e macSecExport
+	if err := json.Unmarshal(data, &e); err != nil {
+		return fmt.Errorf("cannot parse MACsec cache: %v", err)
+	}
+	if e.Version != cacheExportVersion {
+		return fmt.Errorf("unsupported MACsec cache version %d, want %d", e.Version, cacheExportVersion)
+	}
+	imported := NewAristaMACSecMapCache()
+	for target, intfs := range e.Targets {
+		info := NewTargetMacSecInfo(target)
+		for name, ie := range intfs {
+			if ie == nil {
+				return fmt.Errorf("interface %s of target %s has no status", name, target)
+			}
+			intf := info.CreateOrGetInterface(name)
+			if ie.CPStatus != nil {
+				intf.SetIntfCPStatus(*ie.CPStatus)
+			}
+			for ckn, s := range ie.CKNs {
+				if s == nil {
+					return fmt.Errorf("CKN %s of interface %s of target %s has no status", ckn, name, target)
+				}
+				intf.CreateOrGetCKN(ckn)
+				if s.Principal != nil {
+					intf.SetIntfPrincipal(ckn, *s.Principal)
+				}
+				if s.Success != nil {
+					intf.SetIntfSuccess(ckn, *s.Success)
+				}
+			}
+			for direction, scis := range ie.SecureChannels {
+				for _, sci := range scis {
+					intf.AddSecureChannel(direction, sci)
+				}
+			}
+		}
+		imported.Set(target, info)
+	}
+	c.Restore(imported)
+	return nil
+}
+
+// importMember returns a member with a copy of the given queues.
+func importMember(name string, queues map[string]*QueueCounters) (*MemberInterfaceInfo, error) {
+	m := NewMemberInterfaceInfo(name)
+	for id, q := range queues {
+		if q == nil {
+			return nil, fmt.Errorf("queue %s of member %s has no counters", id, name)
+		}
+		qCopy := *q
+		m.Queues[id] = &qCopy
+	}
+	return m, nil
+}
+
+// importClassifiers copies the given input classifiers to the member.
+func importClassifiers(m *MemberInterfaceInfo, classifiers classifiersExport) error {
+	for classifierType, terms := range classifiers {
+		for id, t := range terms {
+			if t == nil {
+				return fmt.Errorf("term %s of classifier %s of member %s has no counters", id, classifierType, m.interfaceName)
+			}
+			*m.createOrGetTerm(classifierType, id) = *t
+		}
+	}
+	return nil
+}
+
+// Export returns the content of the cache as JSON, e.g. to be restored by Import after a restart
+// of the collector, instead of waiting for all the member counters to be streamed again.
+func (c *QoSAggregationMapCache) Export() ([]byte, error) {
+	clone := c.Clone()
+	e := &qosExport{
+		Version: cacheExportVersion,
+		Targets: make(map[string]*targetQoSExport),
+	}
+	clone.Range(func(target string, info *TargetQoSInfo) {
+		te := &targetQoSExport{
+			MemberToPortChannel: info.MemberToPCMap,
+		}
+		for pcName, pc := range info.PortChannels {
+			if te.PortChannels == nil {
+				te.PortChannels = make(map[string]map[string]map[string]*QueueCounters, len(info.PortChannels))
+			}
+			members := make(map[string]map[string]*QueueCounters, len(pc.Members))
+			for name, m := range pc.Members {
+				members[name] = m.Queues
+				if len(m.Classifiers) == 0 {
+					continue
+				}
+				if te.PortChannelClassifiers == nil {
+					te.PortChannelClassifiers = make(map[string]map[string]classifiersExport)
+				}
+				if te.PortChannelClassifiers[pcName] == nil {
+					te.PortChannelClassifiers[pcName] = make(map[string]classifiersExport)
+				}
+				te.PortChannelClassifiers[pcName][name] = m.Classifiers
+			}
+			te.PortChannels[pcName] = members
+		}
+		for name, m := range info.UnassociatedMembers {
+			if te.UnassociatedMembers == nil {
+				te.UnassociatedMembers = make(map[string]map[string]*QueueCounters, len(info.UnassociatedMembers))
+			}
+			te.UnassociatedMembers[name] = m.Queues
+			if len(m.Classifiers) == 0 {
+				continue
+			}
+			if te.UnassociatedClassifiers == nil {
+				te.UnassociatedClassifiers = make(map[string]classifiersExport)
+			}
+			te.UnassociatedClassifiers[name] = m.Classifiers
+		}
+		e.Targets[target] = te
+	})
+	return json.Marshal(e)
+}
+
+// Import replaces the content of the cache with the JSON returned by Export. The imported targets
+// are considered seen at the time of the import.
+func (c *QoSAggregationMapCache) Import(data []byte) error {
+	var e qosExport
+	if err := json.Unmarshal(data, &e); err != nil {
+		return fmt.Errorf("cannot parse QoS aggregation cache: %v", err)
+	}
+	if e.Version != cacheExportVersion {
+		return fmt.Errorf("unsupported QoS aggregation cache version %d, want %d", e.Version, cacheExportVersion)
+	}
+	imported := NewQoSAggregationMapCache()
+	for target, te := range e.Targets {
+		if te == nil {
+			return fmt.Errorf("target %s has no QoS information", target)
+		}
+		info := newTargetQoSInfo(target)
+		for pcName, members := range te.PortChannels {
+			pc := info.CreateOrRetrievePortChannel(pcName)
+			for name, queues := range members {
+				m, err := importMember(name, queues)
+				if err != nil {
+					return fmt.Errorf("target %s: %v", target, err)
+				}
+				pc.Members[name] = m
+			}
+		}
+		for pcName, members := range te.PortChannelClassifiers {
+			pc, ok := info.PortChannelInfo(pcName)
+			if !ok {
+				return fmt.Errorf("target %s: classifiers of unknown port-channel %s", target, pcName)
+			}
+			for name, classifiers := range members {
+				m, ok := pc.Members[name]
+				if !ok {
+					return fmt.Errorf("target %s: classifiers of unknown member %s of port-channel %s", target, name, pcName)
+				}
+				if err := importClassifiers(m, classifiers); err != nil {
+					return fmt.Errorf("target %s: %v", target, err)
+				}
+			}
+		}
+		for member, pcName := range te.MemberToPortChannel {
+			info.MemberToPCMap[member] = pcName
+		}
+		for name, queues := range te.UnassociatedMembers {
+			m, err := importMember(name, queues)
+			if err != nil {
+				return fmt.Errorf("target %s: %v", target, err)
+			}
+			info.UnassociatedMembers[name] = m
+		}
+		for name, classifiers := range te.UnassociatedClassifiers {
+			m, ok := info.UnassociatedMembers[name]
+			if !ok {
+				return fmt.Errorf("target %s: classifiers of unknown unassociated member %s", target, name)
+			}
+			if err := importClassifiers(m, classifiers); err != nil {
+				return fmt.Errorf("target %s: %v", target, err)
+			}
+		}
+		imported.Set(target, info)
+	}
+	c.Restore(imported)
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ftutilities
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAristaMACSecMapCacheExport(t *testing.T) {
+	cache := NewAristaMACSecMapCache()
+	info := cache.CreateOrUpdateTargetMacSecInfo("host1")
+	intf := info.CreateOrGetInterface("Ethernet1")
+	intf.SetIntfCPStatus(true)
+	intf.SetIntfSuccess("ckn1", true)
+	intf.SetIntfPrincipal("ckn2", false)
+	intf.AddSecureChannel("txSc", "sci1")
+	intf.AddSecureChannel("rxSc", "sci2")
+	info.CreateOrGetInterface("Ethernet2")
+
+	data, err := cache.Export()
+	if err != nil {
+		t.Fatalf("Export() got unexpected error: %v", err)
+	}
+	imported := NewAristaMACSecMapCache()
+	imported.CreateOrUpdateTargetMacSecInfo("host2")
+	if err := imported.Import(data); err != nil {
+		t.Fatalf("Import() got unexpected error: %v", err)
+	}
+	if _, ok := imported.RetrieveTargetMacSecInfo("host2"); ok {
+		t.Errorf("RetrieveTargetMacSecInfo(%q) after Import: ok = true, want false", "host2")
+	}
+	got, ok := imported.RetrieveTargetMacSecInfo("host1")
+	if !ok {
+		t.Fatalf("RetrieveTargetMacSecInfo(%q) after Import: ok = false, want true", "host1")
+	}
+	gotIntf, ok := got.InterfaceInfo("Ethernet1")
+	if !ok {
+		t.Fatalf("InterfaceInfo(%q) after Import: ok = false, want true", "Ethernet1")
+	}
+	if cp, set := gotIntf.IntfCPStatus(); !cp || !set {
+		t.Errorf("IntfCPStatus() after Import = %t, %t, want true, true", cp, set)
+	}
+	if s, set := gotIntf.IntfSuccess("ckn1"); !s || !set {
+		t.Errorf("IntfSuccess(%q) after Import = %t, %t, want true, true", "ckn1", s, set)
+	}
+	if _, set := gotIntf.IntfPrincipal("ckn1"); set {
+		t.Errorf("IntfPrincipal(%q) after Import is set, want unset", "ckn1")
+	}
+	if p, set := gotIntf.IntfPrincipal("ckn2"); p || !set {
+		t.Errorf("IntfPrincipal(%q) after Import = %t, %t, want false, true", "ckn2", p, set)
+	}
+	if diff := cmp.Diff([]string{"sci1"}, gotIntf.SecureChannels("txSc")); diff != "" {
+		t.Errorf("SecureChannels(%q) after Import returned an unexpected diff (-want +got):\n%s", "txSc", diff)
+	}
+	if diff := cmp.Diff([]string{"sci2"}, gotIntf.SecureChannels("rxSc")); diff != "" {
+		t.Errorf("SecureChannels(%q) after Import returned an unexpected diff (-want +got):\n%s", "rxSc", diff)
+	}
+	gotIntf2, ok := got.InterfaceInfo("Ethernet2")
+	if !ok {
+		t.Fatalf("InterfaceInfo(%q) after Import: ok = false, want true", "Ethernet2")
+	}
+	if _, set := gotIntf2.IntfCPStatus(); set {
+		t.Errorf("IntfCPStatus() of %q after Import is set, want unset", "Ethernet2")
+	}
+}
+
+func TestQoSAggregationMapCacheExport(t *testing.T) {
+	cache := NewQoSAggregationMapCache()
+	info := cache.CreateOrUpdateTargetQoSInfo("host1")
+	pc := info.CreateOrRetrievePortChannel("Port-Channel10")
+	info.SetPortChannelForMember("Ethernet1", "Port-Channel10")
+	member := pc.CreateOrRetrieveMember("Ethernet1")
+	member.SetTxBytes("0", 1000)
+	member.SetDroppedPackets("1", 2)
+	member.SetMatchedOctets("IPV4", "10", 3000)
+	waiting := NewMemberInterfaceInfo("Ethernet2")
+	waiting.SetTxPackets("0", 10)
+	waiting.SetMatchedPackets("IPV6", "20", 30)
+	info.UnassociatedMembers["Ethernet2"] = waiting
+
+	data, err := cache.Export()
+	if err != nil {
+		t.Fatalf("Export() got unexpected error: %v", err)
+	}
+	imported := NewQoSAggregationMapCache()
+	if err := imported.Import(data); err != nil {
+		t.Fatalf("Import() got unexpected error: %v", err)
+	}
+	roundTrip, err := imported.Export()
+	if err != nil {
+		t.Fatalf("Export() of the imported cache got unexpected error: %v", err)
+	}
+	if string(roundTrip) != string(data) {
+		t.Errorf("Export() of the imported cache = %s, want %s", roundTrip, data)
+	}
+	got, ok := imported.RetrieveTargetQoSInfo("host1")
+	if !ok {
+		t.Fatalf("RetrieveTargetQoSInfo(%q) after Import: ok = false, want true", "host1")
+	}
+	if got.MemberToPCMap["Ethernet1"] != "Port-Channel10" {
+		t.Errorf("MemberToPCMap[%q] after Import = %q, want %q", "Ethernet1", got.MemberToPCMap["Ethernet1"], "Port-Channel10")
+	}
+	q := got.PortChannels["Port-Channel10"].Members["Ethernet1"].Queues["0"]
+	if q.TxBytes != 1000 || !q.TxBytesSet || q.TxPacketsSet {
+		t.Errorf("queue 0 of Ethernet1 after Import = %+v, want TxBytes 1000", q)
+	}
+	term := got.PortChannels["Port-Channel10"].Members["Ethernet1"].Classifiers["IPV4"]["10"]
+	if term.MatchedOctets != 3000 || !term.MatchedOctetsSet || term.MatchedPacketsSet {
+		t.Errorf("term 10 of the IPV4 classifier of Ethernet1 after Import = %+v, want MatchedOctets 3000", term)
+	}
+	waitingGot, ok := got.UnassociatedMembers["Ethernet2"]
+	if !ok {
+		t.Fatalf("UnassociatedMembers[%q] after Import is missing", "Ethernet2")
+	}
+	if term := waitingGot.Classifiers["IPV6"]["20"]; term == nil || term.MatchedPackets != 30 {
+		t.Errorf("term 20 of the IPV6 classifier of Ethernet2 after Import = %+v, want MatchedPackets 30", term)
+	}
+}
+
+func TestCacheImportErrors(t *testing.T) {
+	for _, data := range []string{
+		`not json`,
+		`{"version":2,"targets":{}}`,
+		`{"version":1,"targets":{"host1":null}}`,
+		`{"version":1,"targets":{"host1":{"unassociatedMembers":{"Ethernet1":{"0":null}}}}}`,
+		`{"version":1,"targets":{"host1":{"unassociatedClassifiers":{"Ethernet1":{"IPV4":{"10":{}}}}}}}`,
+		`{"version":1,"targets":{"host1":{"unassociatedMembers":{"Ethernet1":{}},"unassociatedClassifiers":{"Ethernet1":{"IPV4":{"10":null}}}}}}`,
+		`{"version":1,"targets":{"host1":{"portChannelClassifiers":{"Port-Channel10":{"Ethernet1":{}}}}}}`,
+	} {
+		if err := NewQoSAggregationMapCache().Import([]byte(data)); err == nil {
+			t.Errorf("QoSAggregationMapCache.Import(%s) returned nil error, want error", data)
+		}
+	}
+	for _, data := range []string{
+		`not json`,
+		`{"version":0}`,
+		`{"version":1,"targets":{"host1":{"Ethernet1":null}}}`,
+		`{"version":1,"targets":{"host1":{"Ethernet1":{"ckns":{"ckn1":null}}}}}`,
+	} {
+		if err := NewAristaMACSecMapCache().Import([]byte(data)); err == nil {
+			t.Errorf("AristaMACSecMapCache.Import(%s) returned nil error, want error", data)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ftutilities
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// JSONOption is an option of ExplodeJSON and ExplodeNotification.
+type JSONOption func(*jsonOptions)
+
+type jsonOptions struct {
+	listKeys map[string][]string
+}
+
+// JSONListKeys sets the key leaves of the lists of the JSON values, by list name, e.g.
+// {"interface": {"name"}}. The names are looked up as encoded in the JSON value, e.g.
+// "openconfig-interfaces:interface", and then without their module qualifier.
+func JSONListKeys(keys map[string][]string) JSONOption {
+	return func(o *jsonOptions) {
+		o.listKeys = keys
+	}
+}
+
+// keys returns the key leaves of the list with the given name.
+func (o *jsonOptions) keys(name string) ([]string, bool) {
+	if k, ok := o.listKeys[name]; ok {
+		return k, true
+	}
+	k, ok := o.listKeys[localName(name)]
+	return k, ok
+}
+
+// isJSON returns true if the value is JSON or JSON_IETF encoded.
+func isJSON(val *gnmipb.TypedValue) bool {
+	switch val.GetValue().(type) {
+	case *gnmipb.TypedValue_JsonIetfVal, *gnmipb.TypedValue_JsonVal:
+		return true
+	}
+	return false
+}
+
+// ExplodeJSON returns the updates of the leaves of a JSON or JSON_IETF encoded value at the path,
+// e.g. an update of "/interfaces/interface[name=Ethernet1]/state/counters" with value
+// {"in-pkts": 1} is exploded into an update of
+// "/interfaces/interface[name=Ethernet1]/state/counters/in-pkts" with value 1. Other values are
+// returned as a single update.
+//
+// JSON objects are containers, arrays of objects are lists, whose keys are set with JSONListKeys,
+// and other arrays are leaf-lists. Numbers are decoded as uint, int or double values, and strings
+// as string values, including the 64-bit numbers encoded as strings by JSON_IETF. Null values,
+// e.g. the [null] of empty leaves, are skipped.
+func ExplodeJSON(path *gnmipb.Path, val *gnmipb.TypedValue, opts ...JSONOption) ([]*gnmipb.Update, error) {
+	var data []byte
+	switch v := val.GetValue().(type) {
+	case *gnmipb.TypedValue_JsonIetfVal:
+		data = v.JsonIetfVal
+	case *gnmipb.TypedValue_JsonVal:
+		data = v.JsonVal
+	default:
+		return []*gnmipb.Update{{Path: path, Val: val}}, nil
+	}
+	o := &jsonOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid JSON value at %v: %v", path, err)
+	}
+	e := &jsonExploder{opts: o, origin: path.GetOrigin(), target: path.GetTarget()}
+	if err := e.explode(path.GetElem(), v); err != nil {
+		return nil, err
+	}
+	return e.updates, nil
+}
+
+// ExplodeNotification returns the notification with the JSON and JSON_IETF encoded values of its
+// updates exploded by ExplodeJSON. The notification is returned unchanged if it has no JSON value.
+func ExplodeNotification(n *gnmipb.Notification, opts ...JSONOption) (*gnmipb.Notification, error) {
+	if !slices.ContainsFunc(n.GetUpdate(), func(u *gnmipb.Update) bool { return isJSON(u.GetVal()) }) {
+		return n, nil
+	}
+	out := &gnmipb.Notification{
+		Timestamp: n.GetTimestamp(),
+		Prefix:    n.GetPrefix(),
+		Delete:    n.GetDelete(),
+		Atomic:    n.GetAtomic(),
+	}
+	for _, u := range n.GetUpdate() {
+		if !isJSON(u.GetVal()) {
+			out.Update = append(out.Update, u)
+			continue
+		}
+		updates, err := ExplodeJSON(u.GetPath(), u.GetVal(), opts...)
+		if err != nil {
+			return nil, err
+		}
+		out.Update = append(out.Update, updates...)
+	}
+	return out, nil
+}
+
+// jsonExploder accumulates the updates of the leaves of a decoded JSON value.
+type jsonExploder struct {
+	opts    *jsonOptions
+	origin  string
+	target  string
+	updates []*gnmipb.Update
+}
+
+func (e *jsonExploder) add(elems []*gnmipb.PathElem, val *gnmipb.TypedValue) {
+	e.updates = append(e.updates, &gnmipb.Update{
+		Path: &gnmipb.Path{Origin: e.origin, Target: e.target, Elem: elems},
+		Val:  val,
+	})
+}
+
+func (e *jsonExploder) explode(elems []*gnmipb.PathElem, v any) error {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		for _, name := range slices.Sorted(maps.Keys(v)) {
+			if err := e.explode(append(slices.Clip(elems), &gnmipb.PathElem{Name: name}), v[name]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []any:
+		if slices.ContainsFunc(v, func(entry any) bool { _, ok := entry.(map[string]any); return ok }) {
+			return e.explodeList(elems, v)
+		}
+		return e.explodeLeafList(elems, v)
+	default:
+		val, err := jsonScalar(v)
+		if err != nil {
+			return fmt.Errorf("invalid JSON value at %v: %v", elems, err)
+		}
+		e.add(elems, val)
+		return nil
+	}
+}
+
+// explodeList explodes the entries of the list whose last element is the list name.
+func (e *jsonExploder) explodeList(elems []*gnmipb.PathElem, entries []any) error {
+	if len(elems) == 0 {
+		return fmt.Errorf("JSON list without name")
+	}
+	name := elems[len(elems)-1].GetName()
+	keys, ok := e.opts.keys(name)
+	if !ok {
+		return fmt.Errorf("no keys for JSON list %q", name)
+	}
+	for _, entry := range entries {
+		fields, ok := entry.(map[string]any)
+		if !ok {
+			return fmt.Errorf("JSON list %q has a non-object entry %v", name, entry)
+		}
+		keyVals := map[string]string{}
+		for _, k := range keys {
+			kv, err := jsonKey(fields, k)
+			if err != nil {
+				return fmt.Errorf("JSON list %q: %v", name, err)
+			}
+			keyVals[k] = kv
+		}
+		entryElems := append(slices.Clone(elems[:len(elems)-1]), &gnmipb.PathElem{Name: name, Key: keyVals})
+		if err := e.explode(entryElems, fields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// explodeLeafList adds the update of a leaf-list, skipping the [null] of empty leaves.
+func (e *jsonExploder) explodeLeafList(elems []*gnmipb.PathElem, v []any) error {
+	if len(v) == 1 && v[0] == nil {
+		return nil
+	}
+	list := &gnmipb.ScalarArray{}
+	for _, item := range v {
+		val, err := jsonScalar(item)
+		if err != nil {
+			return fmt.Errorf("invalid JSON leaf-list value at %v: %v", elems, err)
+		}
+		list.Element = append(list.Element, val)
+	}
+	e.add(elems, &gnmipb.TypedValue{Value: &gnmipb.TypedValue_LeaflistVal{LeaflistVal: list}})
+	return nil
+}
+
+// jsonKey returns the value of the key leaf of a list entry as a path key value. The key may be
+// module qualified in the entry.
+func jsonKey(fields map[string]any, key string) (string, error) {
+	v, ok := fields[key]
+	if !ok {
+		for name, fv := range fields {
+			if localName(name) == key {
+				v, ok = fv, true
+				break
+			}
+		}
+	}
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	if !ok {
+		return "", fmt.Errorf("missing key %q", key)
+	}
+	return "", fmt.Errorf("invalid value %v of key %q", v, key)
+}
+
+// jsonScalar returns the typed value of a decoded JSON scalar.
+func jsonScalar(v any) (*gnmipb.TypedValue, error) {
+	switch v := v.(type) {
+	case string:
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: v}}, nil
+	case bool:
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: v}}, nil
+	case json.Number:
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: u}}, nil
+		}
+		if i, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: i}}, nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: f}}, nil
+	}
+	return nil, fmt.Errorf("unsupported value %v", v)
+}
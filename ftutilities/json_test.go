@@ -0,0 +1,184 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ftutilities
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestExplodeJSON(t *testing.T) {
+	path := func(s string) *gnmipb.Path {
+		p, err := ygot.StringToStructuredPath(s)
+		if err != nil {
+			t.Fatalf("StringToStructuredPath(%q) got unexpected error: %v", s, err)
+		}
+		return p
+	}
+	jsonIETF := func(s string) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(s)}}
+	}
+	uintVal := func(u uint64) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: u}}
+	}
+	stringVal := func(s string) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: s}}
+	}
+	keys := JSONListKeys(map[string][]string{"interface": {"name"}, "queue": {"name", "id"}})
+	tests := []struct {
+		name    string
+		path    *gnmipb.Path
+		val     *gnmipb.TypedValue
+		opts    []JSONOption
+		want    []*gnmipb.Update
+		wantErr bool
+	}{
+		{
+			name: "scalar value",
+			path: path("/interfaces/interface[name=Ethernet1]/state/mtu"),
+			val:  uintVal(1500),
+			want: []*gnmipb.Update{
+				{Path: path("/interfaces/interface[name=Ethernet1]/state/mtu"), Val: uintVal(1500)},
+			},
+		},
+		{
+			name: "container",
+			path: path("/interfaces/interface[name=Ethernet1]/state"),
+			val:  jsonIETF(`{"mtu": 1500, "enabled": true, "counters": {"in-pkts": "18446744073709551615", "in-errors": 0}, "rate": -1.5, "offset": -3}`),
+			want: []*gnmipb.Update{
+				{Path: path("/interfaces/interface[name=Ethernet1]/state/counters/in-errors"), Val: uintVal(0)},
+				{Path: path("/interfaces/interface[name=Ethernet1]/state/counters/in-pkts"), Val: stringVal("18446744073709551615")},
+				{Path: path("/interfaces/interface[name=Ethernet1]/state/enabled"), Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: true}}},
+				{Path: path("/interfaces/interface[name=Ethernet1]/state/mtu"), Val: uintVal(1500)},
+				{Path: path("/interfaces/interface[name=Ethernet1]/state/offset"), Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: -3}}},
+				{Path: path("/interfaces/interface[name=Ethernet1]/state/rate"), Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: -1.5}}},
+			},
+		},
+		{
+			name: "json value",
+			path: path("/system/state"),
+			val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonVal{JsonVal: []byte(`{"hostname": "dut"}`)}},
+			want: []*gnmipb.Update{
+				{Path: path("/system/state/hostname"), Val: stringVal("dut")},
+			},
+		},
+		{
+			name: "list",
+			path: path("/interfaces"),
+			val:  jsonIETF(`{"openconfig-interfaces:interface": [{"name": "Ethernet1", "state": {"mtu": 1500}}, {"name": "Ethernet2"}]}`),
+			opts: []JSONOption{keys},
+			want: []*gnmipb.Update{
+				{Path: path("/interfaces/openconfig-interfaces:interface[name=Ethernet1]/name"), Val: stringVal("Ethernet1")},
+				{Path: path("/interfaces/openconfig-interfaces:interface[name=Ethernet1]/state/mtu"), Val: uintVal(1500)},
+				{Path: path("/interfaces/openconfig-interfaces:interface[name=Ethernet2]/name"), Val: stringVal("Ethernet2")},
+			},
+		},
+		{
+			name: "list value with multiple keys",
+			path: path("/qos/queues/queue"),
+			val:  jsonIETF(`[{"name": "q1", "id": 1}]`),
+			opts: []JSONOption{keys},
+			want: []*gnmipb.Update{
+				{Path: path("/qos/queues/queue[name=q1][id=1]/id"), Val: uintVal(1)},
+				{Path: path("/qos/queues/queue[name=q1][id=1]/name"), Val: stringVal("q1")},
+			},
+		},
+		{
+			name: "leaf-list and empty leaf",
+			path: path("/system/state"),
+			val:  jsonIETF(`{"servers": ["a", "b"], "empty": [null], "unset": null}`),
+			want: []*gnmipb.Update{
+				{Path: path("/system/state/servers"), Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_LeaflistVal{LeaflistVal: &gnmipb.ScalarArray{
+					Element: []*gnmipb.TypedValue{stringVal("a"), stringVal("b")},
+				}}}},
+			},
+		},
+		{
+			name:    "list without keys",
+			path:    path("/interfaces"),
+			val:     jsonIETF(`{"interface": [{"name": "Ethernet1"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "missing key",
+			path:    path("/interfaces"),
+			val:     jsonIETF(`{"interface": [{"mtu": 1500}]}`),
+			opts:    []JSONOption{keys},
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			path:    path("/interfaces"),
+			val:     jsonIETF(`{"interface": `),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ExplodeJSON(tc.path, tc.val, tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ExplodeJSON() got error %v, want error %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ExplodeJSON() returned an unexpected diff (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestExplodeNotification(t *testing.T) {
+	mtu := &gnmipb.Update{
+		Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "mtu"}}},
+		Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 1500}},
+	}
+	n := &gnmipb.Notification{
+		Timestamp: 1,
+		Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "dut"},
+		Update:    []*gnmipb.Update{mtu},
+	}
+	got, err := ExplodeNotification(n)
+	if err != nil || got != n {
+		t.Errorf("ExplodeNotification() = %v, %v, want the unchanged notification", got, err)
+	}
+
+	n.Update = append(n.Update, &gnmipb.Update{
+		Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "counters"}}},
+		Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"in-pkts": 1}`)}},
+	})
+	want := &gnmipb.Notification{
+		Timestamp: 1,
+		Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "dut"},
+		Update: []*gnmipb.Update{
+			mtu,
+			{
+				Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "counters"}, {Name: "in-pkts"}}},
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 1}},
+			},
+		},
+	}
+	got, err = ExplodeNotification(n)
+	if err != nil {
+		t.Fatalf("ExplodeNotification() got unexpected error: %v", err)
+	}
+	if !proto.Equal(want, got) {
+		t.Errorf("ExplodeNotification() = %v, want %v", got, want)
+	}
+}
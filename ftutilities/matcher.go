@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ftutilities
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/ygot/ygot"
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// PathMatcher matches paths against a set of patterns with the semantics of MatchPath, in time
+// proportional to the depth of the path rather than to the number of patterns. It is built once,
+// typically in a package variable, and is safe for concurrent use.
+type PathMatcher struct {
+	root *matcherNode
+}
+
+// matcherNode is a node of the trie of a PathMatcher. The patterns sharing a prefix share the
+// nodes of the prefix.
+type matcherNode struct {
+	// name is the name of the pattern element leading to the node, which may be "*".
+	name string
+	// keys are the key glob constraints of the pattern element leading to the node.
+	keys map[string]string
+	// children are the nodes of the next pattern elements. Elements with the same name but
+	// different key constraints have different nodes. The fanout of the nodes is small, so they are
+	// scanned rather than looked up in a map.
+	children []*matcherNode
+	// multi is the node of a "..." next pattern element.
+	multi *matcherNode
+	// terminal is true if a pattern ends at the node.
+	terminal bool
+}
+
+// NewPathMatcher returns a PathMatcher for the given string patterns, e.g.
+// "/eos_native/Sysdb/macsec/status/cpStatus/*" or "/interfaces/interface[name=Ethernet*]/...".
+// A leading valid origin is ignored, as origins are by MatchPath.
+func NewPathMatcher(patterns ...string) (*PathMatcher, error) {
+	paths := make([]*gnmipb.Path, 0, len(patterns))
+	for _, s := range patterns {
+		p, err := ygot.StringToStructuredPath(strings.TrimPrefix(s, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %v", s, err)
+		}
+		if elems := p.GetElem(); len(elems) > 0 && len(elems[0].GetKey()) == 0 {
+			if _, ok := ValidOrigins[elems[0].GetName()]; ok {
+				p.Elem = elems[1:]
+			}
+		}
+		paths = append(paths, p)
+	}
+	return PathMatcherFromPaths(paths...), nil
+}
+
+// MustPathMatcher returns a PathMatcher for the given string patterns, and fails if a pattern is
+// invalid.
+func MustPathMatcher(patterns ...string) *PathMatcher {
+	m, err := NewPathMatcher(patterns...)
+	if err != nil {
+		log.Fatalf("cannot build path matcher: %v", err)
+	}
+	return m
+}
+
+// PathMatcherFromPaths returns a PathMatcher for the given patterns.
+func PathMatcherFromPaths(patterns ...*gnmipb.Path) *PathMatcher {
+	m := &PathMatcher{root: &matcherNode{}}
+	for _, p := range patterns {
+		m.root.insert(p.GetElem())
+	}
+	return m
+}
+
+func (n *matcherNode) insert(elems []*gnmipb.PathElem) {
+	if len(elems) == 0 {
+		n.terminal = true
+		return
+	}
+	e := elems[0]
+	if e.GetName() == multiWildcardMarker {
+		if n.multi == nil {
+			n.multi = &matcherNode{}
+		}
+		n.multi.insert(elems[1:])
+		return
+	}
+	var keys map[string]string
+	for k, v := range e.GetKey() {
+		if v != wildcardMarker && strings.Contains(v, wildcardMarker) {
+			if keys == nil {
+				keys = map[string]string{}
+			}
+			keys[k] = v
+		}
+	}
+	for _, c := range n.children {
+		if c.name == e.GetName() && maps.Equal(c.keys, keys) {
+			c.insert(elems[1:])
+			return
+		}
+	}
+	c := &matcherNode{name: e.GetName(), keys: keys}
+	n.children = append(n.children, c)
+	c.insert(elems[1:])
+}
+
+// Match returns true if the path matches one of the patterns of the matcher.
+func (m *PathMatcher) Match(path *gnmipb.Path) bool {
+	return m.root.match(path.GetElem())
+}
+
+func (n *matcherNode) match(elems []*gnmipb.PathElem) bool {
+	if len(elems) == 0 && n.terminal {
+		return true
+	}
+	if n.multi != nil {
+		for skip := 0; skip <= len(elems); skip++ {
+			if n.multi.match(elems[skip:]) {
+				return true
+			}
+		}
+	}
+	if len(elems) == 0 {
+		return false
+	}
+	e := elems[0]
+	for _, c := range n.children {
+		if (c.name == e.GetName() || c.name == wildcardMarker) && c.keysMatch(e) && c.match(elems[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *matcherNode) keysMatch(e *gnmipb.PathElem) bool {
+	if n.keys == nil {
+		return true
+	}
+	for k, v := range n.keys {
+		got, ok := e.GetKey()[k]
+		if !ok || !matchGlob(got, v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ftutilities
+
+import (
+	"testing"
+
+	"github.com/openconfig/ygot/ygot"
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var matcherPatterns = []string{
+	"/eos_native/Sysdb/macsec/status/cpStatus/*/controlledPortEnabled",
+	"/eos_native/Sysdb/macsec/mkaStatus/portStatus/*/actorStatus/*/success",
+	"/eos_native/Sysdb/macsec/mkaStatus/portStatus/*/actorStatus/*/principal",
+	"/interfaces/interface[name=Ethernet*]/state/counters/in-pkts",
+	"/interfaces/interface[name=Port-Channel*]/state/counters/out-pkts",
+	"/components/component/.../state/instant",
+}
+
+func TestPathMatcher(t *testing.T) {
+	m, err := NewPathMatcher(matcherPatterns...)
+	if err != nil {
+		t.Fatalf("NewPathMatcher() returned an unexpected error: %v", err)
+	}
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "/Sysdb/macsec/status/cpStatus/Ethernet1/controlledPortEnabled", want: true},
+		{path: "/Sysdb/macsec/status/cpStatus/Ethernet1", want: false},
+		{path: "/Sysdb/macsec/mkaStatus/portStatus/Ethernet1/actorStatus/abcd/principal", want: true},
+		{path: "/Sysdb/macsec/mkaStatus/portStatus/Ethernet1/actorStatus/abcd/other", want: false},
+		{path: "/interfaces/interface[name=Ethernet1]/state/counters/in-pkts", want: true},
+		{path: "/interfaces/interface[name=Ethernet1]/state/counters/out-pkts", want: false},
+		{path: "/interfaces/interface[name=Port-Channel1]/state/counters/out-pkts", want: true},
+		{path: "/interfaces/interface/state/counters/in-pkts", want: false},
+		{path: "/components/component[name=FAN1]/state/instant", want: true},
+		{path: "/components/component[name=CHASSIS]/fan/state/temperature/state/instant", want: true},
+		{path: "/components/component[name=FAN1]/state/average", want: false},
+	}
+	var patterns []*gnmipb.Path
+	for _, s := range matcherPatterns {
+		p, err := ygot.StringToStructuredPath(s)
+		if err != nil {
+			t.Fatalf("Failed to parse pattern %q: %v", s, err)
+		}
+		if p.GetElem()[0].GetName() == "eos_native" {
+			p.Elem = p.Elem[1:]
+		}
+		patterns = append(patterns, p)
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			p, err := ygot.StringToStructuredPath(tc.path)
+			if err != nil {
+				t.Fatalf("Failed to parse path: %v", err)
+			}
+			if got := m.Match(p); got != tc.want {
+				t.Errorf("Match(%s) = %t, want %t", tc.path, got, tc.want)
+			}
+			if got := PathInList(p, patterns); got != tc.want {
+				t.Errorf("PathInList(%s) = %t, want %t", tc.path, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewPathMatcherError(t *testing.T) {
+	if _, err := NewPathMatcher("/interfaces/interface[=Ethernet1]/state"); err == nil {
+		t.Errorf("NewPathMatcher() with an invalid pattern returned nil error, want error")
+	}
+}
+
+// benchmarkPatterns are patterns with a long common prefix, like the native paths of a translator.
+var benchmarkPatterns = func() []string {
+	var patterns []string
+	for _, dir := range []string{"input", "output"} {
+		for _, leaf := range []string{"class-name", "general-stats/transmit-bytes", "general-stats/transmit-packets", "general-stats/total-drop-bytes", "general-stats/total-drop-packets", "general-stats/pre-policy-matched-bytes"} {
+			patterns = append(patterns, "/Cisco-IOS-XR-qos-ma-oper/qos/interface-table/interface/"+dir+"/service-policy-names/service-policy-instance/statistics/class-stats/"+leaf)
+		}
+	}
+	return patterns
+}()
+
+const benchmarkPath = "/qos/interface-table/interface[interface-name=Bundle-Ether1]/output/service-policy-names/service-policy-instance[service-policy-name=P]/statistics/class-stats/general-stats/pre-policy-matched-bytes"
+
+func BenchmarkPathMatcher(b *testing.B) {
+	m := MustPathMatcher(benchmarkPatterns...)
+	p, err := ygot.StringToStructuredPath(benchmarkPath)
+	if err != nil {
+		b.Fatalf("Failed to parse path: %v", err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Match(p)
+	}
+}
+
+func BenchmarkPathInList(b *testing.B) {
+	var patterns []*gnmipb.Path
+	for _, s := range benchmarkPatterns {
+		p, err := StringToPath(s)
+		if err != nil {
+			b.Fatalf("Failed to parse pattern %q: %v", s, err)
+		}
+		patterns = append(patterns, p)
+	}
+	p, err := ygot.StringToStructuredPath(benchmarkPath)
+	if err != nil {
+		b.Fatalf("Failed to parse path: %v", err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		PathInList(p, patterns)
+	}
+}
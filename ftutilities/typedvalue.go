@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ftutilities
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// The same native leaf may be reported with different value types across software trains, e.g.
+// a counter as an int or a uint value, or as a string by JSON_IETF for 64-bit numbers. The To
+// functions convert the values of the numeric and string types, instead of the zero value
+// returned by the getters of the other types.
+
+// ToUint64 returns the value as an unsigned integer. Int, double, float and decimal values must be
+// non-negative integers, and strings decimal unsigned integers.
+func ToUint64(v *gnmipb.TypedValue) (uint64, error) {
+	switch t := v.GetValue().(type) {
+	case *gnmipb.TypedValue_UintVal:
+		return t.UintVal, nil
+	case *gnmipb.TypedValue_IntVal:
+		if t.IntVal < 0 {
+			return 0, fmt.Errorf("negative value %d", t.IntVal)
+		}
+		return uint64(t.IntVal), nil
+	case *gnmipb.TypedValue_DoubleVal:
+		return floatToUint64(t.DoubleVal)
+	case *gnmipb.TypedValue_FloatVal:
+		return floatToUint64(float64(t.FloatVal))
+	case *gnmipb.TypedValue_DecimalVal:
+		d := t.DecimalVal
+		if d.GetPrecision() > 18 {
+			return 0, fmt.Errorf("invalid decimal precision %d", d.GetPrecision())
+		}
+		scale := int64(math.Pow10(int(d.GetPrecision())))
+		if d.GetDigits() < 0 || d.GetDigits()%scale != 0 {
+			return 0, fmt.Errorf("decimal value %s is not an unsigned integer", decimalString(d))
+		}
+		return uint64(d.GetDigits() / scale), nil
+	case *gnmipb.TypedValue_StringVal:
+		return strconv.ParseUint(strings.TrimSpace(t.StringVal), 10, 64)
+	default:
+		return 0, fmt.Errorf("unexpected value type %T", t)
+	}
+}
+
+// floatToUint64 returns the float as an unsigned integer, if it is one.
+func floatToUint64(f float64) (uint64, error) {
+	if f < 0 || f >= math.Exp2(64) || math.Trunc(f) != f {
+		return 0, fmt.Errorf("value %v is not an unsigned integer", f)
+	}
+	return uint64(f), nil
+}
+
+// ToDouble returns the value as a double, e.g. for the sensor values reported as decimal64 by
+// some devices. Strings must be decimal numbers.
+func ToDouble(v *gnmipb.TypedValue) (float64, error) {
+	switch t := v.GetValue().(type) {
+	case *gnmipb.TypedValue_DoubleVal:
+		return t.DoubleVal, nil
+	case *gnmipb.TypedValue_FloatVal:
+		return float64(t.FloatVal), nil
+	case *gnmipb.TypedValue_DecimalVal:
+		return float64(t.DecimalVal.GetDigits()) / math.Pow10(int(t.DecimalVal.GetPrecision())), nil
+	case *gnmipb.TypedValue_IntVal:
+		return float64(t.IntVal), nil
+	case *gnmipb.TypedValue_UintVal:
+		return float64(t.UintVal), nil
+	case *gnmipb.TypedValue_StringVal:
+		return strconv.ParseFloat(strings.TrimSpace(t.StringVal), 64)
+	default:
+		return 0, fmt.Errorf("unexpected value type %T", t)
+	}
+}
+
+// ToString returns the value as a string, numbers and booleans being formatted as in JSON, e.g.
+// "1.5" or "true".
+func ToString(v *gnmipb.TypedValue) (string, error) {
+	switch t := v.GetValue().(type) {
+	case *gnmipb.TypedValue_StringVal:
+		return t.StringVal, nil
+	case *gnmipb.TypedValue_AsciiVal:
+		return t.AsciiVal, nil
+	case *gnmipb.TypedValue_UintVal:
+		return strconv.FormatUint(t.UintVal, 10), nil
+	case *gnmipb.TypedValue_IntVal:
+		return strconv.FormatInt(t.IntVal, 10), nil
+	case *gnmipb.TypedValue_DoubleVal:
+		return strconv.FormatFloat(t.DoubleVal, 'g', -1, 64), nil
+	case *gnmipb.TypedValue_FloatVal:
+		return strconv.FormatFloat(float64(t.FloatVal), 'g', -1, 32), nil
+	case *gnmipb.TypedValue_DecimalVal:
+		return decimalString(t.DecimalVal), nil
+	case *gnmipb.TypedValue_BoolVal:
+		return strconv.FormatBool(t.BoolVal), nil
+	default:
+		return "", fmt.Errorf("unexpected value type %T", t)
+	}
+}
+
+// decimalString returns the decimal with its precision, e.g. "-1.50" for digits -150 and
+// precision 2.
+func decimalString(d *gnmipb.Decimal64) string {
+	digits := d.GetDigits()
+	sign := ""
+	abs := strconv.FormatUint(uint64(digits), 10)
+	if digits < 0 {
+		sign = "-"
+		abs = strconv.FormatUint(-uint64(digits), 10)
+	}
+	precision := int(d.GetPrecision())
+	if precision == 0 {
+		return sign + abs
+	}
+	if len(abs) <= precision {
+		abs = strings.Repeat("0", precision-len(abs)+1) + abs
+	}
+	return sign + abs[:len(abs)-precision] + "." + abs[len(abs)-precision:]
+}
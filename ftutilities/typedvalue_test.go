@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ftutilities
+
+import (
+	"testing"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var (
+	uintTV = func(v uint64) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}}
+	}
+	intTV = func(v int64) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: v}}
+	}
+	doubleTV = func(v float64) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: v}}
+	}
+	floatTV = func(v float32) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_FloatVal{FloatVal: v}}
+	}
+	stringTV = func(v string) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: v}}
+	}
+	boolTV = func(v bool) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: v}}
+	}
+	decimalTV = func(digits int64, precision uint32) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DecimalVal{DecimalVal: &gnmipb.Decimal64{Digits: digits, Precision: precision}}}
+	}
+)
+
+func TestToUint64(t *testing.T) {
+	tests := []struct {
+		name    string
+		val     *gnmipb.TypedValue
+		want    uint64
+		wantErr bool
+	}{
+		{name: "uint", val: uintTV(42), want: 42},
+		{name: "int", val: intTV(42), want: 42},
+		{name: "negative int", val: intTV(-1), wantErr: true},
+		{name: "double", val: doubleTV(42), want: 42},
+		{name: "fractional double", val: doubleTV(4.2), wantErr: true},
+		{name: "double out of range", val: doubleTV(1e20), wantErr: true},
+		{name: "float", val: floatTV(42), want: 42},
+		{name: "decimal", val: decimalTV(4200, 2), want: 42},
+		{name: "fractional decimal", val: decimalTV(4210, 2), wantErr: true},
+		{name: "string", val: stringTV("18446744073709551615"), want: 18446744073709551615},
+		{name: "invalid string", val: stringTV("forty-two"), wantErr: true},
+		{name: "bool", val: boolTV(true), wantErr: true},
+		{name: "nil", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToUint64(tc.val)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToUint64(%v) got error %v, want error %v", tc.val, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ToUint64(%v) = %d, want %d", tc.val, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToDouble(t *testing.T) {
+	tests := []struct {
+		name    string
+		val     *gnmipb.TypedValue
+		want    float64
+		wantErr bool
+	}{
+		{name: "double", val: doubleTV(-4.5), want: -4.5},
+		{name: "float", val: floatTV(1.5), want: 1.5},
+		{name: "decimal", val: decimalTV(-450, 2), want: -4.5},
+		{name: "int", val: intTV(-3), want: -3},
+		{name: "uint", val: uintTV(3), want: 3},
+		{name: "string", val: stringTV("-4.5"), want: -4.5},
+		{name: "invalid string", val: stringTV("n/a"), wantErr: true},
+		{name: "bool", val: boolTV(true), wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToDouble(tc.val)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToDouble(%v) got error %v, want error %v", tc.val, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ToDouble(%v) = %v, want %v", tc.val, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToString(t *testing.T) {
+	tests := []struct {
+		name    string
+		val     *gnmipb.TypedValue
+		want    string
+		wantErr bool
+	}{
+		{name: "string", val: stringTV("Ethernet1"), want: "Ethernet1"},
+		{name: "ascii", val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_AsciiVal{AsciiVal: "up"}}, want: "up"},
+		{name: "uint", val: uintTV(18446744073709551615), want: "18446744073709551615"},
+		{name: "int", val: intTV(-3), want: "-3"},
+		{name: "double", val: doubleTV(1.5), want: "1.5"},
+		{name: "float", val: floatTV(0.1), want: "0.1"},
+		{name: "decimal", val: decimalTV(-150, 2), want: "-1.50"},
+		{name: "small decimal", val: decimalTV(5, 3), want: "0.005"},
+		{name: "integer decimal", val: decimalTV(15, 0), want: "15"},
+		{name: "bool", val: boolTV(true), want: "true"},
+		{name: "bytes", val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BytesVal{BytesVal: []byte{1}}}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToString(tc.val)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToString(%v) got error %v, want error %v", tc.val, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ToString(%v) = %q, want %q", tc.val, got, tc.want)
+			}
+		})
+	}
+}
@@ -16,27 +16,57 @@
 package ftutilities
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"maps"
+	"math"
 	"os"
 	"path"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
-	log "github.com/golang/glog"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftstate"
 	"google.golang.org/protobuf/encoding/prototext"
 	"github.com/openconfig/ygot/ygot"
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
-// StripPathPrefix strips the prefix from the path.
+// StripPathPrefix strips the prefix from the path. Both arguments are parsed as gNMI paths, so
+// keys containing "/" are handled, and an element name matches its module-qualified form (e.g.
+// "oper:foo" matches "foo"). If the prefix does not match the leading elements of the path, the
+// path is returned unchanged, without a leading "/".
 func StripPathPrefix(s, prefix string) string {
 	if s == "" {
 		return ""
 	}
 	s = strings.TrimPrefix(s, "/")
-	s = strings.TrimPrefix(s, prefix)
-	return strings.TrimPrefix(s, "/")
+	p, err := ygot.StringToStructuredPath("/" + s)
+	if err != nil {
+		return s
+	}
+	pre, err := ygot.StringToStructuredPath("/" + strings.Trim(prefix, "/"))
+	if err != nil {
+		return s
+	}
+	rest, ok := trimElemPrefix(p.GetElem(), pre.GetElem())
+	if !ok {
+		return s
+	}
+	if len(rest) == 0 {
+		return ""
+	}
+	restStr, err := ygot.PathToString(&gnmipb.Path{Elem: rest})
+	if err != nil {
+		return s
+	}
+	return strings.TrimPrefix(restStr, "/")
 }
 
 // ForcePathPrefix adds the prefix to the string if it is not already present, even if the string
@@ -50,6 +80,31 @@ func ForcePathPrefix(s, prefix string) string {
 	return fmt.Sprintf("/%s/%s", prefix, s)
 }
 
+// trimElemPrefix returns the elements of elems following prefix, and whether prefix matched the
+// leading elements of elems.
+func trimElemPrefix(elems, prefix []*gnmipb.PathElem) ([]*gnmipb.PathElem, bool) {
+	if len(prefix) > len(elems) {
+		return nil, false
+	}
+	for i, pe := range prefix {
+		if localName(pe.GetName()) != localName(elems[i].GetName()) {
+			return nil, false
+		}
+		if !maps.Equal(pe.GetKey(), elems[i].GetKey()) {
+			return nil, false
+		}
+	}
+	return elems[len(prefix):], true
+}
+
+// localName returns the element name without its module qualifier, if any.
+func localName(name string) string {
+	if _, local, ok := strings.Cut(name, ":"); ok {
+		return local
+	}
+	return name
+}
+
 // ConfigToState replaces "config" elements with "state" elements.
 func ConfigToState(p *gnmipb.Path) *gnmipb.Path {
 	returnPath := &gnmipb.Path{
@@ -69,42 +124,54 @@ func ConfigToState(p *gnmipb.Path) *gnmipb.Path {
 }
 
 // Join returns a new gNMI path with the elements of p1 and p2 concatenated. The origin and target
-// of p1 are used, if present, and replaced with the values from p2 otherwise.
+// of p1 are used, if present, and replaced with the values from p2 otherwise. The returned path
+// never shares its element slice with p1 or p2, so appending to it does not modify them; the
+// elements themselves are shared.
 func Join(p1, p2 *gnmipb.Path) *gnmipb.Path {
-	if p1 == nil {
-		return p2
-	}
-	origin := p1.GetOrigin()
-	target := p1.GetTarget()
-	if origin == "" {
-		origin = p2.GetOrigin()
-	}
-	if target == "" {
-		target = p2.GetTarget()
+	if p1 == nil && p2 == nil {
+		return nil
 	}
+	return JoinInto(&gnmipb.Path{Elem: make([]*gnmipb.PathElem, 0, len(p1.GetElem())+len(p2.GetElem()))}, p1, p2)
+}
 
-	elems := make([]*gnmipb.PathElem, 0, len(p1.GetElem())+len(p2.GetElem()))
-	elems = append(elems, p1.GetElem()...)
-	elems = append(elems, p2.GetElem()...)
-
-	return &gnmipb.Path{
-		Origin: origin,
-		Target: target,
-		Elem:   elems,
+// JoinInto is Join writing the result into dst, reusing the storage of its element slice, and
+// returns dst. It allows loops over the updates of a notification to join each update path to the
+// prefix without allocating, e.g.:
+//
+//	var scratch gnmipb.Path
+//	for _, u := range n.GetUpdate() {
+//		path := ftutilities.JoinInto(&scratch, n.GetPrefix(), u.GetPath())
+//		...
+//	}
+//
+// dst must not be retained or shared between goroutines while it is reused.
+func JoinInto(dst, p1, p2 *gnmipb.Path) *gnmipb.Path {
+	dst.Origin = p1.GetOrigin()
+	if dst.Origin == "" {
+		dst.Origin = p2.GetOrigin()
+	}
+	dst.Target = p1.GetTarget()
+	if dst.Target == "" {
+		dst.Target = p2.GetTarget()
 	}
+	dst.Elem = append(dst.Elem[:0], p1.GetElem()...)
+	dst.Elem = append(dst.Elem, p2.GetElem()...)
+	return dst
 }
 
 // Filter returns a new notification with only the updates that return true from the provided fn.
+// The path passed to fn is only valid during the call, and must be cloned to be retained.
 func Filter(notification *gnmipb.Notification, fn func(path *gnmipb.Path, isDelete bool) bool) *gnmipb.Notification {
+	var scratch gnmipb.Path
 	var updates []*gnmipb.Update
 	for _, update := range notification.GetUpdate() {
-		if fn(Join(notification.GetPrefix(), update.GetPath()), false) {
+		if fn(JoinInto(&scratch, notification.GetPrefix(), update.GetPath()), false) {
 			updates = append(updates, update)
 		}
 	}
 	var deletes []*gnmipb.Path
 	for _, delete := range notification.GetDelete() {
-		if fn(Join(notification.GetPrefix(), delete), true) {
+		if fn(JoinInto(&scratch, notification.GetPrefix(), delete), true) {
 			deletes = append(deletes, delete)
 		}
 	}
@@ -119,23 +186,98 @@ func Filter(notification *gnmipb.Notification, fn func(path *gnmipb.Path, isDele
 	}
 }
 
+// MatchOption is an option of MatchPath and PathInList.
+type MatchOption func(*matchOptions)
+
+type matchOptions struct {
+	keys bool
+}
+
+// MatchPathWithKeys makes all the key values of a pattern significant: the path must have each key
+// of the pattern, with the same value. Key values of "*" or containing "*" match as without the
+// option.
+func MatchPathWithKeys() MatchOption {
+	return func(o *matchOptions) {
+		o.keys = true
+	}
+}
+
 // MatchPath returns true if path matches against the provided pattern.
-// A wildcard character "*" in the pattern matches all path elements.
-func MatchPath(path, pattern *gnmipb.Path) bool {
-	wildcardMarker := "*"
-	if len(path.GetElem()) != len(pattern.GetElem()) {
-		return false
+// A wildcard character "*" in the pattern matches all path elements, and a "..." element matches
+// any number of path elements, including none. Keys are ignored, except for pattern key values
+// containing a "*" (e.g. name=Ethernet*), which the path key value must match, with "*" matching
+// any sequence of characters. A key value of just "*" matches any value. With MatchPathWithKeys,
+// other key values must be equal.
+func MatchPath(path, pattern *gnmipb.Path, opts ...MatchOption) bool {
+	var o matchOptions
+	if len(opts) > 0 {
+		// Only built when there are options, since o escapes to the heap when passed to them.
+		o = newMatchOptions(opts)
 	}
-	for ix, pathElem := range path.GetElem() {
-		patternElem := pattern.GetElem()[ix]
-		if patternElem.GetName() == wildcardMarker {
-			continue
+	return matchElems(path.GetElem(), pattern.GetElem(), o)
+}
+
+func newMatchOptions(opts []MatchOption) matchOptions {
+	o := &matchOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return *o
+}
+
+const (
+	wildcardMarker      = "*"
+	multiWildcardMarker = "..."
+)
+
+func matchElems(elems, patternElems []*gnmipb.PathElem, o matchOptions) bool {
+	for ix, patternElem := range patternElems {
+		if patternElem.GetName() == multiWildcardMarker {
+			for skip := 0; skip <= len(elems)-ix; skip++ {
+				if matchElems(elems[ix+skip:], patternElems[ix+1:], o) {
+					return true
+				}
+			}
+			return false
 		}
-		if pathElem.GetName() != patternElem.GetName() {
+		if ix >= len(elems) {
 			return false
 		}
+		pathElem := elems[ix]
+		if patternElem.GetName() != wildcardMarker && pathElem.GetName() != patternElem.GetName() {
+			return false
+		}
+		for k, v := range patternElem.GetKey() {
+			isGlob := strings.Contains(v, wildcardMarker)
+			if v == wildcardMarker || !isGlob && !o.keys {
+				continue
+			}
+			got, ok := pathElem.GetKey()[k]
+			if !ok || isGlob && !matchGlob(got, v) || !isGlob && got != v {
+				return false
+			}
+		}
 	}
-	return true
+	return len(elems) == len(patternElems)
+}
+
+// matchGlob returns true if s matches the glob pattern, in which "*" matches any sequence of
+// characters, including "/".
+func matchGlob(s, pattern string) bool {
+	parts := strings.Split(pattern, wildcardMarker)
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		ix := strings.Index(s, part)
+		if ix < 0 {
+			return false
+		}
+		s = s[ix+len(part):]
+	}
+	return len(s) >= len(last) && strings.HasSuffix(s, last)
 }
 
 // SortByYgotString returns a function to sort gnmi paths by their stringified value.
@@ -161,12 +303,21 @@ var ValidOrigins = map[string]struct{}{
 	// Arista
 	"eos_native": {},
 
+	// Juniper
+	"junos": {},
+
+	// Nokia SR Linux
+	"srl_nokia": {},
+
 	// Cisco XR-controller-optics-oper
 	"Cisco-IOS-XR-controller-optics-oper": {},
 
 	// Cisco XR-fabric-plane-health-oper
 	"Cisco-IOS-XR-fabric-plane-health-oper": {},
 
+	// Cisco XR-infra-xtc-agent-oper
+	"Cisco-IOS-XR-infra-xtc-agent-oper": {},
+
 	// Cisco XR-infra-statsd-oper
 	"Cisco-IOS-XR-infra-statsd-oper": {},
 
@@ -255,10 +406,10 @@ func MustStringMapPaths(m map[string][]string) map[string][]*gnmipb.Path {
 	return p
 }
 
-// PathInList returns True if the path is in the list of paths.
-func PathInList(p *gnmipb.Path, paths []*gnmipb.Path) bool {
+// PathInList returns True if the path is in the list of paths, matched with MatchPath.
+func PathInList(p *gnmipb.Path, paths []*gnmipb.Path, opts ...MatchOption) bool {
 	for _, path := range paths {
-		if MatchPath(p, path) {
+		if MatchPath(p, path, opts...) {
 			return true
 		}
 	}
@@ -355,34 +506,240 @@ func FilterStructToState(s ygot.GoStruct, ts int64, origin, target string) (*gnm
 	return sr, nil
 }
 
+// BreakoutChildNamesOption is the boolean option of the Cisco XR optics translators converting the
+// names of breakout child optics ports, of the form "Optics0/0/0/0/1", instead of ignoring them. It
+// is disabled by default, as the telemetry of breakout interfaces is usually provided through the
+// parent interface.
+const BreakoutChildNamesOption = "breakout-child-names"
+
+// OpticsPrefixesOption is the option of the Cisco XR optics translators mapping optics type
+// prefixes to the interface name prefixes of their ports, as a JSON object, e.g.
+// {"2x400G": "FourHundredGigE"}, so that new optics types do not require code changes. The
+// mappings take precedence over the default ones.
+const OpticsPrefixesOption = "optics-prefixes"
+
+// defaultOpticsPrefixes maps the optics type prefixes to the interface name prefixes of their ports.
+var defaultOpticsPrefixes = map[string]string{
+	"100g":   "HundredGigE",
+	"10g":    "TenGigE",
+	"2x100g": "TwoHundredGigE",
+	"2x400g": "EightHundredGigE",
+	"400g":   "FourHundredGigE",
+	"40g":    "FortyGigE",
+	"4x100g": "FourHundredGigE",
+	"4x10g":  "FortyGigE",
+	"800g":   "EightHundredGigE",
+}
+
+// OpticsNaming holds the options of a translator naming the optics ports, see
+// MaybeConvertOpticalWithNaming.
+// The zero value is the default naming.
+type OpticsNaming struct {
+	// BreakoutChildNames converts the names of the breakout child optics ports instead of ignoring
+	// them.
+	BreakoutChildNames bool
+	// Prefixes maps lower case optics type prefixes to interface name prefixes, taking precedence
+	// over the default ones.
+	Prefixes map[string]string
+}
+
+// NewOpticsNaming returns the optics naming set by the options of a translator, i.e. by
+// BreakoutChildNamesOption and OpticsPrefixesOption. It returns an error if an option is invalid.
+func NewOpticsNaming(opts map[string]string) (OpticsNaming, error) {
+	var naming OpticsNaming
+	if v, ok := opts[BreakoutChildNamesOption]; ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return OpticsNaming{}, fmt.Errorf("invalid boolean option %s=%q: %v", BreakoutChildNamesOption, v, err)
+		}
+		naming.BreakoutChildNames = b
+	}
+	if v, ok := opts[OpticsPrefixesOption]; ok {
+		var prefixes map[string]string
+		if err := json.Unmarshal([]byte(v), &prefixes); err != nil {
+			return OpticsNaming{}, fmt.Errorf("invalid option %s=%q: %v", OpticsPrefixesOption, v, err)
+		}
+		naming.Prefixes = map[string]string{}
+		for opticsType, prefix := range prefixes {
+			if opticsType == "" || prefix == "" {
+				return OpticsNaming{}, fmt.Errorf("invalid option %s: optics type prefix %q and interface prefix %q must not be empty", OpticsPrefixesOption, opticsType, prefix)
+			}
+			naming.Prefixes[strings.ToLower(opticsType)] = prefix
+		}
+	}
+	return naming, nil
+}
+
+// longestPrefixMatch returns the value of the longest key of m which is a prefix of s.
+func longestPrefixMatch(m map[string]string, s string) (string, bool) {
+	var key, value string
+	found := false
+	for k, v := range m {
+		if strings.HasPrefix(s, k) && (!found || len(k) > len(key)) {
+			key, value, found = k, v, true
+		}
+	}
+	return value, found
+}
+
+// opticsPrefix returns the interface name prefix of a port with the lower case optics type: the
+// prefix mapped to the longest matching optics type prefix of the naming, or else of the default
+// mappings.
+func (n OpticsNaming) opticsPrefix(opticsType string) string {
+	if p, ok := longestPrefixMatch(n.Prefixes, opticsType); ok {
+		return p
+	}
+	if p, ok := longestPrefixMatch(defaultOpticsPrefixes, opticsType); ok {
+		return p
+	}
+	return "Optics"
+}
+
 // MaybeConvertOptical returns the modified port name based on the optics type. Breakout child
 // interfaces are ignored, as telemetry is provided through the parent interface.
 // This is used by CISCOXR WBB devices when using the native path, which is of the form
 // "Optics0/0/0/0" and the openconfig path is of the form "HundredGigE0/0/0/0", etc.
 func MaybeConvertOptical(portName string, opticsType string) (newPortName string, wanted bool) {
-	portSplits := strings.Split(portName, "/")
-	if len(portSplits) != 4 {
-		return portName, false
-	}
-	prefix := "Optics"
+	return MaybeConvertOpticalWithNaming(portName, opticsType, OpticsNaming{})
+}
+
+// MaybeConvertOpticalWithNaming is like MaybeConvertOptical, with the naming of a translator.
+// Breakout child interfaces are converted if enabled by the BreakoutChildNames of the naming: the
+// child ports are then named after the speed of a lane of the breakout optics type, e.g.
+// "HundredGigE0/0/0/0/1" for "Optics0/0/0/0/1" with a 4x100G optics type.
+// The interface name prefixes of the optics types can be overridden by the Prefixes of the naming.
+func MaybeConvertOpticalWithNaming(portName string, opticsType string, naming OpticsNaming) (newPortName string, wanted bool) {
 	opticsType = strings.ToLower(opticsType)
-	switch {
-	case strings.HasPrefix(opticsType, "100g"):
-		prefix = "HundredGigE"
-	case strings.HasPrefix(opticsType, "10g"):
-		prefix = "TenGigE"
-	case strings.HasPrefix(opticsType, "2x100g"):
-		prefix = "TwoHundredGigE"
-	case strings.HasPrefix(opticsType, "400g"):
-		prefix = "FourHundredGigE"
-	case strings.HasPrefix(opticsType, "40g"):
-		prefix = "FortyGigE"
-	case strings.HasPrefix(opticsType, "4x100g"):
-		prefix = "FourHundredGigE"
-	case strings.HasPrefix(opticsType, "4x10g"):
-		prefix = "FortyGigE"
-	}
-	return strings.Replace(portName, "Optics", prefix, 1), true
+	switch len(strings.Split(portName, "/")) {
+	case 4:
+		return strings.Replace(portName, "Optics", naming.opticsPrefix(opticsType), 1), true
+	case 5:
+		if !naming.BreakoutChildNames {
+			return portName, false
+		}
+		// The lane type of a breakout optics type, e.g. 100g for 4x100g.
+		if lanes, laneType, ok := strings.Cut(opticsType, "x"); ok {
+			if _, err := strconv.Atoi(lanes); err == nil {
+				opticsType = laneType
+			}
+		}
+		return strings.Replace(portName, "Optics", naming.opticsPrefix(opticsType), 1), true
+	}
+	return portName, false
+}
+
+// SplitSubinterface splits an interface name of the form "<parent>.<index>" into the parent
+// interface name and the subinterface index. Names without a numeric suffix are returned unchanged
+// with index 0.
+func SplitSubinterface(name string) (parent string, index uint32) {
+	i := strings.LastIndex(name, ".")
+	if i <= 0 {
+		return name, 0
+	}
+	ix, err := strconv.ParseUint(name[i+1:], 10, 32)
+	if err != nil {
+		return name, 0
+	}
+	return name[:i], uint32(ix)
+}
+
+const ciscoXRBundlePrefix = "Bundle-Ether"
+
+// BundleNameFormatOption is the option of the Cisco XR translators setting the format used to name
+// the "Bundle-Ether<id>" aggregate interfaces, so they can be emitted with the aggregate interface
+// names used by the rest of the pipeline, e.g. "Port-Channel%d" or "ae%d". The format must contain
+// a single %d verb, which is replaced by the bundle id. The default keeps the native name.
+const BundleNameFormatOption = "bundle-name-format"
+
+// CiscoXRBundleNameFormat returns the bundle name format set by the BundleNameFormatOption of the
+// options of a translator, or "" to keep the native names if it is not set. It returns an error if
+// the format does not contain a single %d verb.
+func CiscoXRBundleNameFormat(opts map[string]string) (string, error) {
+	format, ok := opts[BundleNameFormatOption]
+	if !ok {
+		return "", nil
+	}
+	if strings.Count(format, "%") != 1 || !strings.Contains(format, "%d") {
+		return "", fmt.Errorf("bundle name format %q must contain a single %%d verb", format)
+	}
+	return format, nil
+}
+
+// IsCiscoXRBundle returns true for the native name of a Cisco XR "Bundle-Ether<id>" interface,
+// and false for its subinterfaces and the other interfaces.
+func IsCiscoXRBundle(name string) bool {
+	id, ok := strings.CutPrefix(name, ciscoXRBundlePrefix)
+	if !ok {
+		return false
+	}
+	_, err := strconv.ParseUint(id, 10, 32)
+	return err == nil
+}
+
+// CiscoXRBundleName returns the name of a Cisco XR interface with the bundle id of a
+// "Bundle-Ether<id>" interface or subinterface formatted with the format returned by
+// CiscoXRBundleNameFormat. Other interface names, and all names for an empty format, are returned
+// unchanged.
+func CiscoXRBundleName(name, format string) string {
+	if format == "" {
+		return name
+	}
+	rest, ok := strings.CutPrefix(name, ciscoXRBundlePrefix)
+	if !ok {
+		return name
+	}
+	id, suffix, _ := strings.Cut(rest, ".")
+	n, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return name
+	}
+	out := fmt.Sprintf(format, n)
+	if suffix != "" {
+		out += "." + suffix
+	}
+	return out
+}
+
+// maxDecimal64Precision is the largest precision of a gNMI Decimal64, as for the decimal64 YANG
+// type.
+const maxDecimal64Precision = 18
+
+// AnalogPrecisionOption is the option of the translators of analog values, such as optical power
+// or laser bias current, emitting them as Decimal64 with the given number of fractional digits
+// instead of DoubleVal, for consumers which reject DoubleVal for leaves of type decimal64. The
+// default, or a precision of 0, emits DoubleVal.
+const AnalogPrecisionOption = "analog-decimal64-precision"
+
+// AnalogPrecision returns the precision set by the AnalogPrecisionOption of the options of a
+// translator, or 0 if it is not set. It returns an error if the precision is not a number of at
+// most 18 digits.
+func AnalogPrecision(opts map[string]string) (uint32, error) {
+	v, ok := opts[AnalogPrecisionOption]
+	if !ok {
+		return 0, nil
+	}
+	precision, err := strconv.ParseUint(v, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid decimal64 precision %q: %v", v, err)
+	}
+	if precision > maxDecimal64Precision {
+		return 0, fmt.Errorf("decimal64 precision %d is larger than %d", precision, maxDecimal64Precision)
+	}
+	return uint32(precision), nil
+}
+
+// AnalogValue returns the TypedValue of an analog value, as a Decimal64 with the precision returned
+// by AnalogPrecision, or as a DoubleVal for a precision of 0. Values which cannot be represented
+// with the precision are returned as a DoubleVal.
+func AnalogValue(v float64, precision uint32) *gnmipb.TypedValue {
+	if precision != 0 {
+		digits := math.Round(v * math.Pow10(int(precision)))
+		// float64(math.MaxInt64) rounds up to 2^63, which is out of range.
+		if digits >= math.MinInt64 && digits < math.MaxInt64 {
+			return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DecimalVal{DecimalVal: &gnmipb.Decimal64{Digits: int64(digits), Precision: precision}}}
+		}
+	}
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: v}}
 }
 
 // LoadSubscribeResponse loads a subscribe response from a file.
@@ -398,6 +755,60 @@ func LoadSubscribeResponse(path string) (*gnmipb.SubscribeResponse, error) {
 	return sr, nil
 }
 
+// SubscribeResponseSeparator separates the prototext SubscribeResponses of a stream read by
+// ReadSubscribeResponses.
+const SubscribeResponseSeparator = "---"
+
+// ReadSubscribeResponses reads a stream of prototext subscribe responses separated by lines
+// consisting of SubscribeResponseSeparator, so that a file holding a single response is a stream
+// of one response.
+func ReadSubscribeResponses(r io.Reader) ([]*gnmipb.SubscribeResponse, error) {
+	var srs []*gnmipb.SubscribeResponse
+	var msg bytes.Buffer
+	flush := func() error {
+		defer msg.Reset()
+		if len(bytes.TrimSpace(msg.Bytes())) == 0 {
+			return nil
+		}
+		sr := &gnmipb.SubscribeResponse{}
+		if err := prototext.Unmarshal(msg.Bytes(), sr); err != nil {
+			return fmt.Errorf("failed to unmarshal SubscribeResponse %d: %v", len(srs)+1, err)
+		}
+		srs = append(srs, sr)
+		return nil
+	}
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 64<<20)
+	for s.Scan() {
+		if strings.TrimSpace(s.Text()) == SubscribeResponseSeparator {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		msg.Write(s.Bytes())
+		msg.WriteByte('\n')
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return srs, nil
+}
+
+// LoadSubscribeResponses loads a stream of subscribe responses from a file. See
+// ReadSubscribeResponses.
+func LoadSubscribeResponses(path string) ([]*gnmipb.SubscribeResponse, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %v", err)
+	}
+	defer f.Close()
+	return ReadSubscribeResponses(f)
+}
+
 // InterfaceMacSecInfo holds MACsec status information for a specific interface.
 type InterfaceMacSecInfo struct {
 	mu            sync.Mutex
@@ -408,6 +819,9 @@ type InterfaceMacSecInfo struct {
 
 	// Stores principal and success status per CKN.
 	cknStatuses map[string]*CKNInfo // map[CKN_string]*CKNInfo
+
+	// Stores the SCIs of the secure channels whose counters are translated, per direction.
+	secureChannels map[string]map[string]bool // map[direction]map[SCI]bool
 }
 
 // CKNInfo holds principal and success status for a specific CKN.
@@ -515,6 +929,75 @@ func (i *InterfaceMacSecInfo) IsComplete(ckn string) bool {
 	return false
 }
 
+// AddSecureChannel records a secure channel of the interface, identified by its direction, e.g. the
+// native container of its counters, and its SCI.
+func (i *InterfaceMacSecInfo) AddSecureChannel(direction, sci string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.secureChannels == nil {
+		i.secureChannels = make(map[string]map[string]bool)
+	}
+	if i.secureChannels[direction] == nil {
+		i.secureChannels[direction] = make(map[string]bool)
+	}
+	i.secureChannels[direction][sci] = true
+}
+
+// RemoveSecureChannel removes a secure channel of the interface.
+func (i *InterfaceMacSecInfo) RemoveSecureChannel(direction, sci string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.secureChannels[direction], sci)
+	if len(i.secureChannels[direction]) == 0 {
+		delete(i.secureChannels, direction)
+	}
+}
+
+// ClearSecureChannels removes all the secure channels of the interface.
+func (i *InterfaceMacSecInfo) ClearSecureChannels() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.secureChannels = nil
+}
+
+// SecureChannels returns the sorted SCIs of the secure channels of the interface in the direction.
+func (i *InterfaceMacSecInfo) SecureChannels(direction string) []string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return slices.Sorted(maps.Keys(i.secureChannels[direction]))
+}
+
+// HasSecureChannels returns true if the interface has secure channels in any direction.
+func (i *InterfaceMacSecInfo) HasSecureChannels() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return len(i.secureChannels) > 0
+}
+
+// timeNow returns the current time, and is replaced in tests.
+var timeNow = time.Now
+
+// SetTargetCacheTTL sets the default TTL of the target stores of the stateful translators, such as
+// AristaMACSecMapCache and QoSAggregationMapCache, which evict the state of a target which sent no
+// notification to the translator for the TTL. It is equivalent to ftstate.SetDefaultTTL, whose
+// documentation has the details, and the TTL of a store can be overridden with its SetTTL method.
+func SetTargetCacheTTL(ttl time.Duration) error {
+	return ftstate.SetDefaultTTL(ttl)
+}
+
+// newTargetStore returns an empty target store using timeNow.
+func newTargetStore[T any]() *ftstate.TargetStore[T] {
+	return ftstate.NewTargetStore[T](ftstate.Options{Now: func() time.Time { return timeNow() }})
+}
+
+// isEmpty returns true if neither the cpStatus nor any CKN status has been set, and the interface
+// has no secure channel.
+func (i *InterfaceMacSecInfo) isEmpty() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return !i.cpStatusSet && len(i.cknStatuses) == 0 && len(i.secureChannels) == 0
+}
+
 // TargetMacSecInfo holds MACsec information for all interfaces on a target.
 type TargetMacSecInfo struct {
 	mu             sync.Mutex
@@ -559,65 +1042,110 @@ func (t *TargetMacSecInfo) ClearInterfaceInfo(intf string) {
 	delete(t.Interfaces, intf)
 }
 
-// AristaMACSecMapCache is a thread-safe cache for AristaMACSecMap.
+// AristaMACSecMapCache is a thread-safe cache of MACsec status per target/interface/CKN.
 // It stores cached boolean values from distinct native Arista MACsec paths per target/interface/CKN.
 // Although Functional Translators (FTs) are typically stateless, this map is required as an exception
 // to hold values from these multiple source paths, necessary for deriving the single OpenConfig MACsec status.
-// Declaring it here allows access by both the FT logic and the FT registration process,
-// where it is cleared to prevent using stale information between registrations or updates.
+// Each FT instance owns its cache, which it resets, snapshots and restores as a
+// translator.StatefulTranslator.
 type AristaMACSecMapCache struct {
-	mu   sync.Mutex
-	data map[string]*TargetMacSecInfo
+	*ftstate.TargetStore[*TargetMacSecInfo]
+}
+
+// NewAristaMACSecMapCache returns an empty AristaMACSecMapCache.
+func NewAristaMACSecMapCache() *AristaMACSecMapCache {
+	return &AristaMACSecMapCache{newTargetStore[*TargetMacSecInfo]()}
 }
 
-// Global instance of the AristaMACSecMapCache.
-var (
-	AristaMACSecMap = &AristaMACSecMapCache{
-		data: make(map[string]*TargetMacSecInfo),
+// clone returns a deep copy of the interface info.
+func (i *InterfaceMacSecInfo) clone() *InterfaceMacSecInfo {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	c := &InterfaceMacSecInfo{
+		interfaceName: i.interfaceName,
+		cpStatus:      i.cpStatus,
+		cpStatusSet:   i.cpStatusSet,
+		cknStatuses:   make(map[string]*CKNInfo, len(i.cknStatuses)),
 	}
-)
+	for ckn, info := range i.cknStatuses {
+		infoCopy := *info
+		c.cknStatuses[ckn] = &infoCopy
+	}
+	for direction, scis := range i.secureChannels {
+		if c.secureChannels == nil {
+			c.secureChannels = make(map[string]map[string]bool, len(i.secureChannels))
+		}
+		c.secureChannels[direction] = maps.Clone(scis)
+	}
+	return c
+}
+
+// clone returns a deep copy of the target info.
+func (t *TargetMacSecInfo) clone() *TargetMacSecInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	c := NewTargetMacSecInfo(t.TargetHostname)
+	for name, intf := range t.Interfaces {
+		c.Interfaces[name] = intf.clone()
+	}
+	return c
+}
+
+// Clone returns a deep copy of the cache, which is not modified by later changes to the cache.
+func (c *AristaMACSecMapCache) Clone() *AristaMACSecMapCache {
+	return &AristaMACSecMapCache{c.TargetStore.Clone((*TargetMacSecInfo).clone)}
+}
+
+// Restore replaces the content of the cache with a deep copy of the content of other.
+func (c *AristaMACSecMapCache) Restore(other *AristaMACSecMapCache) {
+	c.TargetStore.Restore(other.TargetStore, (*TargetMacSecInfo).clone)
+}
 
 // SetTargetMacSecInfo adds or updates the TargetMacSecInfo for a given target hostname.
 func (c *AristaMACSecMapCache) SetTargetMacSecInfo(targetHostname string, info *TargetMacSecInfo) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.data[targetHostname] = info
+	c.Set(targetHostname, info)
 }
 
 // RetrieveTargetMacSecInfo fetches the TargetMacSecInfo for a given target hostname.
 // It returns the info and a boolean indicating if the target was found.
 func (c *AristaMACSecMapCache) RetrieveTargetMacSecInfo(targetHostname string) (*TargetMacSecInfo, bool) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	info, ok := c.data[targetHostname]
-	return info, ok
+	return c.Get(targetHostname)
 }
 
 // DeleteTargetMacSecInfo removes the TargetMacSecInfo for a given target hostname.
 func (c *AristaMACSecMapCache) DeleteTargetMacSecInfo(targetHostname string) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	delete(c.data, targetHostname)
+	c.ResetTarget(targetHostname)
 }
 
 // ClearAllTargetMacSecInfo removes all entries from the cache.
 func (c *AristaMACSecMapCache) ClearAllTargetMacSecInfo() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.data = make(map[string]*TargetMacSecInfo)
+	c.TargetStore.Reset()
+}
+
+// PruneEmptyInterfaces removes the interfaces of the target which hold no MACsec status, e.g.
+// after their cpStatus was deleted, and removes the target if it is left without interfaces.
+func (c *AristaMACSecMapCache) PruneEmptyInterfaces(target string) {
+	info, ok := c.Get(target)
+	if !ok {
+		return
+	}
+	info.mu.Lock()
+	for name, intf := range info.Interfaces {
+		if intf.isEmpty() {
+			delete(info.Interfaces, name)
+		}
+	}
+	empty := len(info.Interfaces) == 0
+	info.mu.Unlock()
+	if empty {
+		c.ResetTarget(target)
+	}
 }
 
 // CreateOrUpdateTargetMacSecInfo retrieves an existing TargetMacSecInfo for the given target
 // or creates a new one if it doesn't exist, then stores it in the cache.
 func (c *AristaMACSecMapCache) CreateOrUpdateTargetMacSecInfo(targetHostname string) *TargetMacSecInfo {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	info, ok := c.data[targetHostname]
-	if !ok {
-		info = NewTargetMacSecInfo(targetHostname)
-		c.data[targetHostname] = info
-	}
-	return info
+	return c.GetOrCreate(targetHostname, func() *TargetMacSecInfo { return NewTargetMacSecInfo(targetHostname) })
 }
 
 // TargetQoSInfo holds QoS information for all port-channels on a target.
@@ -627,6 +1155,9 @@ type TargetQoSInfo struct {
 	PortChannels        map[string]*PortChannelInfo     // map[PortChannelName]*PortChannelInfo
 	MemberToPCMap       map[string]string               // map[InterfaceName]PortChannelName
 	UnassociatedMembers map[string]*MemberInterfaceInfo // "Waiting room"
+	// nativeQueueCounters holds the port-channels whose own queue counters are reported by the
+	// device, which are not aggregated from their members.
+	nativeQueueCounters map[string]bool
 }
 
 // PortChannelInfo holds QoS information for a specific port-channel,
@@ -637,11 +1168,14 @@ type PortChannelInfo struct {
 	Members         map[string]*MemberInterfaceInfo // map[InterfaceName]*MemberInterfaceInfo
 }
 
-// MemberInterfaceInfo holds QoS queue information for a specific member interface.
+// MemberInterfaceInfo holds QoS queue and input classifier information for a specific member
+// interface.
 type MemberInterfaceInfo struct {
 	mu            sync.Mutex
 	interfaceName string
 	Queues        map[string]*QueueCounters // map[QueueID]*QueueCounters
+	// Classifiers maps the types of the input classifiers to the counters of their terms.
+	Classifiers map[string]map[string]*TermCounters // map[ClassifierType]map[TermID]*TermCounters
 }
 
 // NewMemberInterfaceInfo creates a new MemberInterfaceInfo instance.
@@ -653,7 +1187,8 @@ func NewMemberInterfaceInfo(interfaceName string) *MemberInterfaceInfo {
 }
 
 // QueueCounters holds the 4 counters for a specific QoS queue.
-// It also tracks whether each counter has been explicitly set.
+// It also tracks whether each counter has been explicitly set, and the offset of each counter
+// accumulated over its resets. See Total.
 type QueueCounters struct {
 	TxPackets      uint64
 	TxBytes        uint64
@@ -664,22 +1199,69 @@ type QueueCounters struct {
 	TxBytesSet        bool
 	DroppedPacketsSet bool
 	DroppedBytesSet   bool
+
+	TxPacketsOffset      uint64
+	TxBytesOffset        uint64
+	DroppedPacketsOffset uint64
+	DroppedBytesOffset   uint64
+}
+
+// Total returns the counters of the queue since they were first set, i.e. their values plus their
+// offsets.
+func (q *QueueCounters) Total() QueueCounters {
+	return QueueCounters{
+		TxPackets:      q.TxPackets + q.TxPacketsOffset,
+		TxBytes:        q.TxBytes + q.TxBytesOffset,
+		DroppedPackets: q.DroppedPackets + q.DroppedPacketsOffset,
+		DroppedBytes:   q.DroppedBytes + q.DroppedBytesOffset,
+	}
+}
+
+// TermCounters holds the matched counters of a term of an input classifier.
+// It also tracks whether each counter has been explicitly set, and the offset of each counter
+// accumulated over its resets. See Total.
+type TermCounters struct {
+	MatchedPackets uint64
+	MatchedOctets  uint64
+
+	MatchedPacketsSet bool
+	MatchedOctetsSet  bool
+
+	MatchedPacketsOffset uint64
+	MatchedOctetsOffset  uint64
+}
+
+// Total returns the counters of the term since they were first set, i.e. their values plus their
+// offsets.
+func (t *TermCounters) Total() TermCounters {
+	return TermCounters{
+		MatchedPackets: t.MatchedPackets + t.MatchedPacketsOffset,
+		MatchedOctets:  t.MatchedOctets + t.MatchedOctetsOffset,
+	}
+}
+
+// setCounter sets a counter to a value reported by the device. A value lower than the previous one
+// is a reset of the counter, e.g. on the reload of a line card, or its wraparound, whose previous
+// value is added to the offset of the counter, so that its total does not decrease.
+func setCounter(counter, offset *uint64, set *bool, val uint64) {
+	if *set && val < *counter {
+		*offset += *counter
+	}
+	*counter = val
+	*set = true
 }
 
 // QoSAggregationMapCache is a thread-safe cache for TargetQoSInfo.
 // It stores cached QoS counter values from distinct OC paths
-// per target/port-channel/interface/queue.
+// per target/port-channel/interface/queue. Each FT instance owns its cache.
 type QoSAggregationMapCache struct {
-	mu   sync.Mutex
-	data map[string]*TargetQoSInfo // map[TargetHostname]*TargetQoSInfo
+	*ftstate.TargetStore[*TargetQoSInfo]
 }
 
-// QoSAggMap is the global instance of the QoSAggregationMapCache.
-var (
-	QoSAggMap = &QoSAggregationMapCache{
-		data: make(map[string]*TargetQoSInfo),
-	}
-)
+// NewQoSAggregationMapCache returns an empty QoSAggregationMapCache.
+func NewQoSAggregationMapCache() *QoSAggregationMapCache {
+	return &QoSAggregationMapCache{newTargetStore[*TargetQoSInfo]()}
+}
 
 // createOrGetQueue is an internal helper that assumes the lock is held.
 func (m *MemberInterfaceInfo) createOrGetQueue(queueID string) *QueueCounters {
@@ -692,13 +1274,14 @@ func (m *MemberInterfaceInfo) createOrGetQueue(queueID string) *QueueCounters {
 	return m.Queues[queueID]
 }
 
-// SetTxPackets sets the TxPackets counter for a given queue and marks it as set.
+// SetTxPackets sets the TxPackets counter for a given queue and marks it as set. A value lower than
+// the previous one is a reset of the counter, whose previous value is added to its offset; so are
+// the values of the other setters.
 func (m *MemberInterfaceInfo) SetTxPackets(queueID string, val uint64) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	q := m.createOrGetQueue(queueID)
-	q.TxPackets = val
-	q.TxPacketsSet = true
+	setCounter(&q.TxPackets, &q.TxPacketsOffset, &q.TxPacketsSet, val)
 }
 
 // SetTxBytes sets the TxBytes counter for a given queue and marks it as set.
@@ -706,8 +1289,7 @@ func (m *MemberInterfaceInfo) SetTxBytes(queueID string, val uint64) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	q := m.createOrGetQueue(queueID)
-	q.TxBytes = val
-	q.TxBytesSet = true
+	setCounter(&q.TxBytes, &q.TxBytesOffset, &q.TxBytesSet, val)
 }
 
 // SetDroppedPackets sets the DroppedPackets counter for a given queue and marks it as set.
@@ -715,8 +1297,7 @@ func (m *MemberInterfaceInfo) SetDroppedPackets(queueID string, val uint64) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	q := m.createOrGetQueue(queueID)
-	q.DroppedPackets = val
-	q.DroppedPacketsSet = true
+	setCounter(&q.DroppedPackets, &q.DroppedPacketsOffset, &q.DroppedPacketsSet, val)
 }
 
 // SetDroppedBytes sets the DroppedBytes counter for a given queue and marks it as set.
@@ -724,8 +1305,39 @@ func (m *MemberInterfaceInfo) SetDroppedBytes(queueID string, val uint64) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	q := m.createOrGetQueue(queueID)
-	q.DroppedBytes = val
-	q.DroppedBytesSet = true
+	setCounter(&q.DroppedBytes, &q.DroppedBytesOffset, &q.DroppedBytesSet, val)
+}
+
+// createOrGetTerm is an internal helper that assumes the lock is held.
+func (m *MemberInterfaceInfo) createOrGetTerm(classifierType, termID string) *TermCounters {
+	if m.Classifiers == nil {
+		m.Classifiers = make(map[string]map[string]*TermCounters)
+	}
+	terms, ok := m.Classifiers[classifierType]
+	if !ok {
+		terms = make(map[string]*TermCounters)
+		m.Classifiers[classifierType] = terms
+	}
+	if _, ok := terms[termID]; !ok {
+		terms[termID] = new(TermCounters)
+	}
+	return terms[termID]
+}
+
+// SetMatchedPackets sets the MatchedPackets counter for a given classifier term and marks it as set.
+func (m *MemberInterfaceInfo) SetMatchedPackets(classifierType, termID string, val uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t := m.createOrGetTerm(classifierType, termID)
+	setCounter(&t.MatchedPackets, &t.MatchedPacketsOffset, &t.MatchedPacketsSet, val)
+}
+
+// SetMatchedOctets sets the MatchedOctets counter for a given classifier term and marks it as set.
+func (m *MemberInterfaceInfo) SetMatchedOctets(classifierType, termID string, val uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t := m.createOrGetTerm(classifierType, termID)
+	setCounter(&t.MatchedOctets, &t.MatchedOctetsOffset, &t.MatchedOctetsSet, val)
 }
 
 // --- PortChannelInfo Methods ---
@@ -765,7 +1377,8 @@ func (p *PortChannelInfo) ClearMemberInfo(intf string) {
 	delete(p.Members, intf)
 }
 
-// AggregateCounters calculates the sum of counters for all members of a port-channel.
+// AggregateCounters calculates the sum of the total counters for all members of a port-channel,
+// so that the resets of the counters of a member do not decrease the sums.
 // It returns a map of queueID to aggregated QueueCounters.
 func (p *PortChannelInfo) AggregateCounters() map[string]*QueueCounters {
 	aggregatedCounters := make(map[string]*QueueCounters)
@@ -780,10 +1393,42 @@ func (p *PortChannelInfo) AggregateCounters() map[string]*QueueCounters {
 				agg = new(QueueCounters)
 				aggregatedCounters[queueID] = agg
 			}
-			agg.TxBytes += counters.TxBytes
-			agg.TxPackets += counters.TxPackets
-			agg.DroppedBytes += counters.DroppedBytes
-			agg.DroppedPackets += counters.DroppedPackets
+			total := counters.Total()
+			agg.TxBytes += total.TxBytes
+			agg.TxPackets += total.TxPackets
+			agg.DroppedBytes += total.DroppedBytes
+			agg.DroppedPackets += total.DroppedPackets
+		}
+		memberInfo.mu.Unlock()
+	}
+	return aggregatedCounters
+}
+
+// AggregateTermCounters calculates the sum of the total input classifier counters for all members
+// of a port-channel. It returns a map of classifier type to term ID to aggregated TermCounters.
+func (p *PortChannelInfo) AggregateTermCounters() map[string]map[string]*TermCounters {
+	aggregatedCounters := make(map[string]map[string]*TermCounters)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, memberInfo := range p.Members {
+		memberInfo.mu.Lock()
+		for classifierType, terms := range memberInfo.Classifiers {
+			aggTerms, ok := aggregatedCounters[classifierType]
+			if !ok {
+				aggTerms = make(map[string]*TermCounters)
+				aggregatedCounters[classifierType] = aggTerms
+			}
+			for termID, counters := range terms {
+				agg, ok := aggTerms[termID]
+				if !ok {
+					agg = new(TermCounters)
+					aggTerms[termID] = agg
+				}
+				total := counters.Total()
+				agg.MatchedPackets += total.MatchedPackets
+				agg.MatchedOctets += total.MatchedOctets
+			}
 		}
 		memberInfo.mu.Unlock()
 	}
@@ -799,6 +1444,7 @@ func newTargetQoSInfo(targetHostname string) *TargetQoSInfo {
 		PortChannels:        make(map[string]*PortChannelInfo),
 		MemberToPCMap:       make(map[string]string),
 		UnassociatedMembers: make(map[string]*MemberInterfaceInfo),
+		nativeQueueCounters: make(map[string]bool),
 	}
 }
 
@@ -865,33 +1511,174 @@ func (t *TargetQoSInfo) FindAndRemoveMember(memberName string) (string, bool) {
 	return oldPCName, true
 }
 
+// RemovePortChannelIfEmpty removes the port-channel if it has no members, and returns true if it
+// was removed.
+func (t *TargetQoSInfo) RemovePortChannelIfEmpty(pcName string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pcInfo, ok := t.PortChannels[pcName]
+	if !ok {
+		return false
+	}
+	pcInfo.mu.Lock()
+	empty := len(pcInfo.Members) == 0
+	pcInfo.mu.Unlock()
+	if empty {
+		delete(t.PortChannels, pcName)
+	}
+	return empty
+}
+
+// RemovePortChannel removes the port-channel and the mapping of its members, and returns true if it
+// was present.
+func (t *TargetQoSInfo) RemovePortChannel(pcName string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.PortChannels[pcName]; !ok {
+		return false
+	}
+	delete(t.PortChannels, pcName)
+	for member, name := range t.MemberToPCMap {
+		if name == pcName {
+			delete(t.MemberToPCMap, member)
+		}
+	}
+	return true
+}
+
+// SetNativeQueueCounters records that the device reports the own queue counters of the
+// port-channel, and returns true if it was not recorded yet.
+func (t *TargetQoSInfo) SetNativeQueueCounters(pcName string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.nativeQueueCounters == nil {
+		t.nativeQueueCounters = make(map[string]bool)
+	}
+	if t.nativeQueueCounters[pcName] {
+		return false
+	}
+	t.nativeQueueCounters[pcName] = true
+	return true
+}
+
+// HasNativeQueueCounters returns true if the device reports the own queue counters of the
+// port-channel.
+func (t *TargetQoSInfo) HasNativeQueueCounters(pcName string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.nativeQueueCounters[pcName]
+}
+
+// ClearNativeQueueCounters forgets that the device reports the own queue counters of the
+// port-channel, e.g. once the port-channel is deleted.
+func (t *TargetQoSInfo) ClearNativeQueueCounters(pcName string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.nativeQueueCounters, pcName)
+}
+
+// CreateOrRetrieveUnassociatedMember returns the info of a member without a known port-channel
+// from the "waiting room", creating it if it doesn't exist.
+func (t *TargetQoSInfo) CreateOrRetrieveUnassociatedMember(memberName string) *MemberInterfaceInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.UnassociatedMembers[memberName]; !ok {
+		t.UnassociatedMembers[memberName] = NewMemberInterfaceInfo(memberName)
+	}
+	return t.UnassociatedMembers[memberName]
+}
+
+// ClearUnassociatedMembers empties the "waiting room" of members without a known port-channel.
+func (t *TargetQoSInfo) ClearUnassociatedMembers() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.UnassociatedMembers = make(map[string]*MemberInterfaceInfo)
+}
+
+// RemoveUnassociatedMember removes a member from the "waiting room". It returns the removed info
+// and true if the member was found.
+func (t *TargetQoSInfo) RemoveUnassociatedMember(memberName string) (*MemberInterfaceInfo, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	info, ok := t.UnassociatedMembers[memberName]
+	if ok {
+		delete(t.UnassociatedMembers, memberName)
+	}
+	return info, ok
+}
+
+// clone returns a deep copy of the member info.
+func (m *MemberInterfaceInfo) clone() *MemberInterfaceInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := NewMemberInterfaceInfo(m.interfaceName)
+	for id, q := range m.Queues {
+		qCopy := *q
+		c.Queues[id] = &qCopy
+	}
+	for classifierType, terms := range m.Classifiers {
+		for id, t := range terms {
+			*c.createOrGetTerm(classifierType, id) = *t
+		}
+	}
+	return c
+}
+
+// clone returns a deep copy of the port-channel info.
+func (p *PortChannelInfo) clone() *PortChannelInfo {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	c := &PortChannelInfo{
+		portChannelName: p.portChannelName,
+		Members:         make(map[string]*MemberInterfaceInfo, len(p.Members)),
+	}
+	for name, m := range p.Members {
+		c.Members[name] = m.clone()
+	}
+	return c
+}
+
+// clone returns a deep copy of the target info.
+func (t *TargetQoSInfo) clone() *TargetQoSInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	c := newTargetQoSInfo(t.TargetHostname)
+	for name, pc := range t.PortChannels {
+		c.PortChannels[name] = pc.clone()
+	}
+	maps.Copy(c.MemberToPCMap, t.MemberToPCMap)
+	for name, m := range t.UnassociatedMembers {
+		c.UnassociatedMembers[name] = m.clone()
+	}
+	maps.Copy(c.nativeQueueCounters, t.nativeQueueCounters)
+	return c
+}
+
 // --- QoSAggregationMapCache Methods ---
 
 // RetrieveTargetQoSInfo fetches the TargetQoSInfo for a given target hostname.
 // It returns the info and a boolean indicating if the target was found.
 func (c *QoSAggregationMapCache) RetrieveTargetQoSInfo(targetHostname string) (*TargetQoSInfo, bool) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	info, ok := c.data[targetHostname]
-	return info, ok
+	return c.Get(targetHostname)
+}
+
+// Clone returns a deep copy of the cache, which is not modified by later changes to the cache.
+func (c *QoSAggregationMapCache) Clone() *QoSAggregationMapCache {
+	return &QoSAggregationMapCache{c.TargetStore.Clone((*TargetQoSInfo).clone)}
+}
+
+// Restore replaces the content of the cache with a deep copy of the content of other.
+func (c *QoSAggregationMapCache) Restore(other *QoSAggregationMapCache) {
+	c.TargetStore.Restore(other.TargetStore, (*TargetQoSInfo).clone)
 }
 
 // ClearAllTargetQoSInfo removes all entries from the cache.
 func (c *QoSAggregationMapCache) ClearAllTargetQoSInfo() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.data = make(map[string]*TargetQoSInfo)
+	c.TargetStore.Reset()
 }
 
 // CreateOrUpdateTargetQoSInfo retrieves an existing TargetQoSInfo for the given target
 // or creates a new one if it doesn't exist, then stores it in the cache.
 func (c *QoSAggregationMapCache) CreateOrUpdateTargetQoSInfo(targetHostname string) *TargetQoSInfo {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	info, ok := c.data[targetHostname]
-	if !ok {
-		info = newTargetQoSInfo(targetHostname)
-		c.data[targetHostname] = info
-	}
-	return info
+	return c.GetOrCreate(targetHostname, func() *TargetQoSInfo { return newTargetQoSInfo(targetHostname) })
 }
@@ -15,8 +15,11 @@
 package ftutilities
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/protobuf/testing/protocmp"
@@ -106,6 +109,39 @@ func TestJoin(t *testing.T) {
 	}
 }
 
+func TestJoinDoesNotAlias(t *testing.T) {
+	p2 := &gnmipb.Path{Origin: "openconfig", Elem: make([]*gnmipb.PathElem, 1, 4)}
+	p2.Elem[0] = &gnmipb.PathElem{Name: "interfaces"}
+	got := Join(nil, p2)
+	if got == p2 {
+		t.Fatalf("Join(nil, p2) returned p2, want a new path")
+	}
+	got.Elem = append(got.Elem, &gnmipb.PathElem{Name: "interface"})
+	if p2.GetElem()[:2][1] != nil {
+		t.Errorf("appending to the result of Join(nil, p2) modified the elements of p2")
+	}
+	if got := Join(nil, nil); got != nil {
+		t.Errorf("Join(nil, nil) = %v, want nil", got)
+	}
+}
+
+func TestJoinInto(t *testing.T) {
+	prefix := &gnmipb.Path{Origin: "eos_native", Target: "dut", Elem: []*gnmipb.PathElem{{Name: "Sysdb"}}}
+	var scratch gnmipb.Path
+	for _, name := range []string{"hardware", "macsec"} {
+		got := JoinInto(&scratch, prefix, &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: name}}})
+		want := &gnmipb.Path{Origin: "eos_native", Target: "dut", Elem: []*gnmipb.PathElem{{Name: "Sysdb"}, {Name: name}}}
+		if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+			t.Errorf("JoinInto() returned an unexpected diff (-want +got): %v", diff)
+		}
+	}
+	if allocs := testing.AllocsPerRun(100, func() {
+		JoinInto(&scratch, prefix, prefix)
+	}); allocs != 0 {
+		t.Errorf("JoinInto() allocated %v times, want 0", allocs)
+	}
+}
+
 func TestStringToPath(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -268,6 +304,155 @@ func TestConfigToState(t *testing.T) {
 	}
 }
 
+func TestStripPathPrefix(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		prefix string
+		want   string
+	}{
+		{
+			name: "empty path",
+			s:    "",
+			want: "",
+		},
+		{
+			name:   "single element prefix",
+			s:      "/openconfig/interfaces/interface",
+			prefix: "openconfig",
+			want:   "interfaces/interface",
+		},
+		{
+			name:   "multi element prefix",
+			s:      "/interfaces/interface[name=Ethernet1/1]/state/counters",
+			prefix: "/interfaces/interface[name=Ethernet1/1]",
+			want:   "state/counters",
+		},
+		{
+			name:   "key value containing slash does not match other key",
+			s:      "/interfaces/interface[name=Ethernet1/1]/state",
+			prefix: "/interfaces/interface[name=Ethernet1]",
+			want:   "interfaces/interface[name=Ethernet1/1]/state",
+		},
+		{
+			name:   "module qualified element name",
+			s:      "/ofa/stats/Cisco-IOS-XR-ofa-npu-stats-oper:npu-numbers/npu-number",
+			prefix: "/ofa/stats/npu-numbers",
+			want:   "npu-number",
+		},
+		{
+			name:   "partial element name is not stripped",
+			s:      "/openconfig-extra/interfaces",
+			prefix: "openconfig",
+			want:   "openconfig-extra/interfaces",
+		},
+		{
+			name:   "prefix equals path",
+			s:      "/openconfig",
+			prefix: "openconfig",
+			want:   "",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := StripPathPrefix(tc.s, tc.prefix); got != tc.want {
+				t.Errorf("StripPathPrefix(%q, %q) = %q, want %q", tc.s, tc.prefix, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestForcePathPrefix(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		prefix string
+		want   string
+	}{
+		{
+			name: "empty prefix",
+			s:    "/interfaces/interface",
+			want: "/interfaces/interface",
+		},
+		{
+			name:   "prefix added",
+			s:      "/interfaces/interface",
+			prefix: "openconfig",
+			want:   "/openconfig/interfaces/interface",
+		},
+		{
+			name:   "prefix already present",
+			s:      "/openconfig/interfaces/interface",
+			prefix: "/openconfig/",
+			want:   "/openconfig/interfaces/interface",
+		},
+		{
+			name:   "empty path",
+			s:      "",
+			prefix: "openconfig",
+			want:   "/openconfig/",
+		},
+		{
+			name:   "keyed prefix already present",
+			s:      "/interfaces/interface[name=Ethernet1/1]/state",
+			prefix: "/interfaces/interface[name=Ethernet1/1]",
+			want:   "/interfaces/interface[name=Ethernet1/1]/state",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ForcePathPrefix(tc.s, tc.prefix); got != tc.want {
+				t.Errorf("ForcePathPrefix(%q, %q) = %q, want %q", tc.s, tc.prefix, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSplitSubinterface(t *testing.T) {
+	tests := []struct {
+		name       string
+		intfName   string
+		wantParent string
+		wantIndex  uint32
+	}{
+		{
+			name:       "no subinterface",
+			intfName:   "Bundle-Ether2",
+			wantParent: "Bundle-Ether2",
+		},
+		{
+			name:       "subinterface",
+			intfName:   "Bundle-Ether2.100",
+			wantParent: "Bundle-Ether2",
+			wantIndex:  100,
+		},
+		{
+			name:       "slotted subinterface",
+			intfName:   "HundredGigE0/0/0/1.4000",
+			wantParent: "HundredGigE0/0/0/1",
+			wantIndex:  4000,
+		},
+		{
+			name:       "non numeric suffix",
+			intfName:   "Ethernet1.abc",
+			wantParent: "Ethernet1.abc",
+		},
+		{
+			name:       "leading dot",
+			intfName:   ".100",
+			wantParent: ".100",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotParent, gotIndex := SplitSubinterface(tc.intfName)
+			if gotParent != tc.wantParent || gotIndex != tc.wantIndex {
+				t.Errorf("SplitSubinterface(%q) = (%q, %d), want (%q, %d)", tc.intfName, gotParent, gotIndex, tc.wantParent, tc.wantIndex)
+			}
+		})
+	}
+}
+
 func TestFilter(t *testing.T) {
 	twoNotifs := &gnmipb.Notification{
 		Prefix: &gnmipb.Path{
@@ -940,6 +1125,7 @@ func TestMatchPath(t *testing.T) {
 		name    string
 		path    *gnmipb.Path
 		pattern *gnmipb.Path
+		opts    []MatchOption
 		want    bool
 	}{
 		{
@@ -1014,6 +1200,94 @@ func TestMatchPath(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name:    "multi-level wildcard match",
+			path:    &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}}},
+			pattern: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a"}, {Name: "..."}, {Name: "d"}}},
+			want:    true,
+		},
+		{
+			name:    "multi-level wildcard matches no elements",
+			path:    &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a"}, {Name: "d"}}},
+			pattern: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a"}, {Name: "..."}, {Name: "d"}}},
+			want:    true,
+		},
+		{
+			name:    "trailing multi-level wildcard",
+			path:    &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a"}, {Name: "b"}, {Name: "c"}}},
+			pattern: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a"}, {Name: "..."}}},
+			want:    true,
+		},
+		{
+			name:    "multi-level wildcard mismatch",
+			path:    &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a"}, {Name: "b"}, {Name: "c"}}},
+			pattern: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a"}, {Name: "..."}, {Name: "d"}}},
+			want:    false,
+		},
+		{
+			name:    "multi-level and single-level wildcards",
+			path:    &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}, {Name: "e"}}},
+			pattern: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a"}, {Name: "..."}, {Name: "d"}, {Name: "*"}}},
+			want:    true,
+		},
+		{
+			name:    "key-constrained wildcard match",
+			path:    &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "Ethernet3/1"}}}},
+			pattern: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "Ethernet*"}}}},
+			want:    true,
+		},
+		{
+			name:    "key-constrained wildcard mismatch",
+			path:    &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "Port-Channel1"}}}},
+			pattern: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "Ethernet*"}}}},
+			want:    false,
+		},
+		{
+			name:    "key-constrained wildcard missing key",
+			path:    &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface"}}},
+			pattern: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "Ethernet*"}}}},
+			want:    false,
+		},
+		{
+			name:    "key-constrained wildcard with suffix",
+			path:    &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "Ethernet3/1"}}}},
+			pattern: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "Eth*/1"}}}},
+			want:    true,
+		},
+		{
+			name:    "match with keys",
+			path:    &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "Ethernet1", "unit": "0"}}}},
+			pattern: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "Ethernet1"}}}},
+			opts:    []MatchOption{MatchPathWithKeys()},
+			want:    true,
+		},
+		{
+			name:    "match with keys value mismatch",
+			path:    &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "Ethernet2"}}}},
+			pattern: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "Ethernet1"}}}},
+			opts:    []MatchOption{MatchPathWithKeys()},
+			want:    false,
+		},
+		{
+			name:    "match with keys missing key",
+			path:    &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface"}}},
+			pattern: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "Ethernet1"}}}},
+			opts:    []MatchOption{MatchPathWithKeys()},
+			want:    false,
+		},
+		{
+			name:    "match with keys wildcard value",
+			path:    &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "Ethernet2"}}}},
+			pattern: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "*"}}}},
+			opts:    []MatchOption{MatchPathWithKeys()},
+			want:    true,
+		},
+		{
+			name:    "key value ignored without option",
+			path:    &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "Ethernet2"}}}},
+			pattern: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "interface", Key: map[string]string{"name": "Ethernet1"}}}},
+			want:    true,
+		},
 		{
 			name:    "nil",
 			path:    nil,
@@ -1036,7 +1310,7 @@ func TestMatchPath(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got := MatchPath(tc.path, tc.pattern)
+			got := MatchPath(tc.path, tc.pattern, tc.opts...)
 			if got != tc.want {
 				t.Errorf("MatchPath(%v, %v) = %v, want: %v", tc.path, tc.pattern, got, tc.want)
 			}
@@ -1480,9 +1754,7 @@ func TestTargetMacSecInfo(t *testing.T) {
 }
 
 func TestAristaMACSecMapCache(t *testing.T) {
-	cache := &AristaMACSecMapCache{
-		data: make(map[string]*TargetMacSecInfo),
-	}
+	cache := NewAristaMACSecMapCache()
 
 	target1 := "host1"
 	target2 := "host2"
@@ -1544,8 +1816,8 @@ func TestAristaMACSecMapCache(t *testing.T) {
 	// Test ClearAllTargetMacSecInfo.
 	cache.CreateOrUpdateTargetMacSecInfo(target1) // Add target1 back.
 	cache.ClearAllTargetMacSecInfo()
-	if len(cache.data) != 0 {
-		t.Errorf("cache.data length after ClearAll = %d, want 0", len(cache.data))
+	if got := cache.Targets(); len(got) != 0 {
+		t.Errorf("Targets() after ClearAll = %v, want none", got)
 	}
 	_, ok = cache.RetrieveTargetMacSecInfo(target1)
 	if ok {
@@ -1556,3 +1828,490 @@ func TestAristaMACSecMapCache(t *testing.T) {
 		t.Errorf("RetrieveTargetMacSecInfo(%q) after ClearAll: ok = true, want false", target2)
 	}
 }
+
+func TestPruneEmptyInterfaces(t *testing.T) {
+	cache := NewAristaMACSecMapCache()
+	for _, target := range []string{"host1", "host2"} {
+		cache.CreateOrUpdateTargetMacSecInfo(target).CreateOrGetInterface("Ethernet1")
+	}
+	cache.PruneEmptyInterfaces("host1")
+	if _, ok := cache.RetrieveTargetMacSecInfo("host1"); ok {
+		t.Errorf("RetrieveTargetMacSecInfo(%q) after PruneEmptyInterfaces(%q): ok = true, want false", "host1", "host1")
+	}
+	// The empty interfaces of the other targets are kept, as they may still be in their initial
+	// updates.
+	info, ok := cache.RetrieveTargetMacSecInfo("host2")
+	if !ok {
+		t.Fatalf("RetrieveTargetMacSecInfo(%q) after PruneEmptyInterfaces(%q): ok = false, want true", "host2", "host1")
+	}
+	if _, ok := info.InterfaceInfo("Ethernet1"); !ok {
+		t.Errorf("PruneEmptyInterfaces(%q) removed Ethernet1 of %q", "host1", "host2")
+	}
+	cache.PruneEmptyInterfaces("unknown")
+}
+
+func TestEvictStaleTargets(t *testing.T) {
+	now := time.Unix(1000, 0)
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	macsec := NewAristaMACSecMapCache()
+	qos := NewQoSAggregationMapCache()
+	macsec.CreateOrUpdateTargetMacSecInfo("host1")
+	qos.CreateOrUpdateTargetQoSInfo("host1")
+	now = now.Add(time.Minute)
+	macsec.CreateOrUpdateTargetMacSecInfo("host2")
+	qos.CreateOrUpdateTargetQoSInfo("host2")
+	now = now.Add(30 * time.Second)
+
+	// Eviction is disabled by default.
+	if got := macsec.EvictStaleTargets(); got != nil {
+		t.Errorf("AristaMACSecMapCache.EvictStaleTargets() without TTL = %v, want nil", got)
+	}
+	if err := SetTargetCacheTTL(time.Minute); err != nil {
+		t.Fatalf("SetTargetCacheTTL() got unexpected error: %v", err)
+	}
+	t.Cleanup(func() { SetTargetCacheTTL(0) })
+
+	// Retrieving a target refreshes it.
+	qos.RetrieveTargetQoSInfo("host1")
+	if diff := cmp.Diff([]string{"host1"}, macsec.EvictStaleTargets()); diff != "" {
+		t.Errorf("AristaMACSecMapCache.EvictStaleTargets() returned an unexpected diff (-want +got): %v", diff)
+	}
+	if _, ok := macsec.RetrieveTargetMacSecInfo("host1"); ok {
+		t.Errorf("RetrieveTargetMacSecInfo(%q) after eviction: ok = true, want false", "host1")
+	}
+	if got := qos.EvictStaleTargets(); got != nil {
+		t.Errorf("QoSAggregationMapCache.EvictStaleTargets() = %v, want nil", got)
+	}
+	now = now.Add(time.Minute)
+	if diff := cmp.Diff([]string{"host1", "host2"}, qos.EvictStaleTargets()); diff != "" {
+		t.Errorf("QoSAggregationMapCache.EvictStaleTargets() returned an unexpected diff (-want +got): %v", diff)
+	}
+	if _, ok := qos.RetrieveTargetQoSInfo("host2"); ok {
+		t.Errorf("RetrieveTargetQoSInfo(%q) after eviction: ok = true, want false", "host2")
+	}
+}
+
+func TestAggregateCountersReset(t *testing.T) {
+	pc := NewQoSAggregationMapCache().CreateOrUpdateTargetQoSInfo("host1").CreateOrRetrievePortChannel("Port-Channel10")
+	m1 := pc.CreateOrRetrieveMember("Ethernet1")
+	m2 := pc.CreateOrRetrieveMember("Ethernet2")
+	for _, v := range []uint64{1000, 1500, 200, 300} {
+		m1.SetTxBytes("0", v)
+		m1.SetMatchedPackets("IPV4", "10", v)
+	}
+	m2.SetTxBytes("0", 100)
+	m2.SetMatchedPackets("IPV4", "10", 100)
+
+	// Ethernet1 was reset after 1500, so its total is 1500 + 300.
+	if got := pc.AggregateCounters()["0"].TxBytes; got != 1900 {
+		t.Errorf("AggregateCounters() TxBytes of queue 0 = %d, want 1900", got)
+	}
+	if got := pc.AggregateTermCounters()["IPV4"]["10"].MatchedPackets; got != 1900 {
+		t.Errorf("AggregateTermCounters() MatchedPackets of term 10 = %d, want 1900", got)
+	}
+	if q := m1.Queues["0"]; q.TxBytes != 300 || q.TxBytesOffset != 1500 {
+		t.Errorf("queue 0 of Ethernet1 = %+v, want TxBytes 300 and TxBytesOffset 1500", q)
+	}
+}
+
+func TestSetTargetCacheTTLError(t *testing.T) {
+	if err := SetTargetCacheTTL(-time.Second); err == nil {
+		t.Errorf("SetTargetCacheTTL(-1s) returned nil error, want error")
+	}
+}
+
+func TestMaybeConvertOptical(t *testing.T) {
+	tests := []struct {
+		name               string
+		breakoutChildNames bool
+		portName           string
+		opticsType         string
+		want               string
+		wantWanted         bool
+	}{
+		{
+			name:       "400G",
+			portName:   "Optics0/0/0/0",
+			opticsType: "400G",
+			want:       "FourHundredGigE0/0/0/0",
+			wantWanted: true,
+		},
+		{
+			name:       "breakout parent",
+			portName:   "Optics0/0/0/1",
+			opticsType: "4x100G",
+			want:       "FourHundredGigE0/0/0/1",
+			wantWanted: true,
+		},
+		{
+			name:       "unknown optics type",
+			portName:   "Optics0/0/0/2",
+			opticsType: "",
+			want:       "Optics0/0/0/2",
+			wantWanted: true,
+		},
+		{
+			name:       "breakout child ignored by default",
+			portName:   "Optics0/0/0/1/2",
+			opticsType: "4x100G",
+			want:       "Optics0/0/0/1/2",
+		},
+		{
+			name:               "breakout child",
+			breakoutChildNames: true,
+			portName:           "Optics0/0/0/1/2",
+			opticsType:         "4x100G",
+			want:               "HundredGigE0/0/0/1/2",
+			wantWanted:         true,
+		},
+		{
+			name:               "breakout child of 4x10G",
+			breakoutChildNames: true,
+			portName:           "Optics0/0/0/1/0",
+			opticsType:         "4x10G-SR",
+			want:               "TenGigE0/0/0/1/0",
+			wantWanted:         true,
+		},
+		{
+			name:               "invalid port name",
+			breakoutChildNames: true,
+			portName:           "Optics0/0",
+			opticsType:         "400G",
+			want:               "Optics0/0",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			naming := OpticsNaming{BreakoutChildNames: tc.breakoutChildNames}
+			got, wanted := MaybeConvertOpticalWithNaming(tc.portName, tc.opticsType, naming)
+			if got != tc.want || wanted != tc.wantWanted {
+				t.Errorf("MaybeConvertOpticalWithNaming(%q, %q, %+v) = %q, %t, want %q, %t", tc.portName, tc.opticsType, naming, got, wanted, tc.want, tc.wantWanted)
+			}
+			if tc.breakoutChildNames {
+				return
+			}
+			// The default naming is used without naming.
+			if got, wanted := MaybeConvertOptical(tc.portName, tc.opticsType); got != tc.want || wanted != tc.wantWanted {
+				t.Errorf("MaybeConvertOptical(%q, %q) = %q, %t, want %q, %t", tc.portName, tc.opticsType, got, wanted, tc.want, tc.wantWanted)
+			}
+		})
+	}
+}
+
+func TestNewOpticsNaming(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    map[string]string
+		want    OpticsNaming
+		wantErr bool
+	}{
+		{name: "not set"},
+		{name: "breakout child names", opts: map[string]string{BreakoutChildNamesOption: "true"}, want: OpticsNaming{BreakoutChildNames: true}},
+		{name: "invalid breakout child names", opts: map[string]string{BreakoutChildNamesOption: "yes"}, wantErr: true},
+		{
+			name: "optics prefixes",
+			opts: map[string]string{OpticsPrefixesOption: `{"2x400G": "FourHundredGigE"}`},
+			want: OpticsNaming{Prefixes: map[string]string{"2x400g": "FourHundredGigE"}},
+		},
+		{name: "optics prefixes not an object", opts: map[string]string{OpticsPrefixesOption: `["400G"]`}, wantErr: true},
+		{name: "empty interface prefix", opts: map[string]string{OpticsPrefixesOption: `{"400G": ""}`}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewOpticsNaming(tc.opts)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewOpticsNaming(%v) got error %v, want error %t", tc.opts, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NewOpticsNaming(%v) returned unexpected diff (-want +got):\n%s", tc.opts, diff)
+			}
+		})
+	}
+}
+
+func TestOpticsPrefixes(t *testing.T) {
+	naming, err := NewOpticsNaming(map[string]string{OpticsPrefixesOption: `{"2x400G": "FourHundredGigE", "400G-ZR": "ZR"}`})
+	if err != nil {
+		t.Fatalf("NewOpticsNaming() got unexpected error: %v", err)
+	}
+	tests := []struct {
+		portName   string
+		opticsType string
+		naming     OpticsNaming
+		want       string
+	}{
+		{portName: "Optics0/0/0/0", opticsType: "800G-DR8", want: "EightHundredGigE0/0/0/0"},
+		{portName: "Optics0/0/0/1", opticsType: "2x400G", want: "EightHundredGigE0/0/0/1"},
+		{portName: "Optics0/0/0/1", opticsType: "2x400G", naming: naming, want: "FourHundredGigE0/0/0/1"},
+		{portName: "Optics0/0/0/2", opticsType: "400G-ZR+", naming: naming, want: "ZR0/0/0/2"},
+		{portName: "Optics0/0/0/2", opticsType: "400G-ZR+", want: "FourHundredGigE0/0/0/2"},
+		{portName: "Optics0/0/0/3", opticsType: "400G-FR4", naming: naming, want: "FourHundredGigE0/0/0/3"},
+		{portName: "Optics0/0/0/4", opticsType: "100G", naming: naming, want: "HundredGigE0/0/0/4"},
+	}
+	for _, tc := range tests {
+		if got, _ := MaybeConvertOpticalWithNaming(tc.portName, tc.opticsType, tc.naming); got != tc.want {
+			t.Errorf("MaybeConvertOpticalWithNaming(%q, %q, %+v) = %q, want %q", tc.portName, tc.opticsType, tc.naming, got, tc.want)
+		}
+	}
+}
+
+func TestCiscoXRBundleName(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		intfName string
+		want     string
+	}{
+		{
+			name:     "default keeps native name",
+			intfName: "Bundle-Ether1",
+			want:     "Bundle-Ether1",
+		},
+		{
+			name:     "port channel",
+			format:   "Port-Channel%d",
+			intfName: "Bundle-Ether1",
+			want:     "Port-Channel1",
+		},
+		{
+			name:     "subinterface",
+			format:   "ae%d",
+			intfName: "Bundle-Ether12.100",
+			want:     "ae12.100",
+		},
+		{
+			name:     "physical interface unchanged",
+			format:   "ae%d",
+			intfName: "HundredGigE0/0/0/1",
+			want:     "HundredGigE0/0/0/1",
+		},
+		{
+			name:     "non numeric bundle id unchanged",
+			format:   "ae%d",
+			intfName: "Bundle-EtherX",
+			want:     "Bundle-EtherX",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CiscoXRBundleName(tc.intfName, tc.format); got != tc.want {
+				t.Errorf("CiscoXRBundleName(%q, %q) = %q, want %q", tc.intfName, tc.format, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsCiscoXRBundle(t *testing.T) {
+	for name, want := range map[string]bool{
+		"Bundle-Ether1":      true,
+		"Bundle-Ether12.100": false,
+		"Bundle-Ether":       false,
+		"HundredGigE0/0/0/1": false,
+	} {
+		if got := IsCiscoXRBundle(name); got != want {
+			t.Errorf("IsCiscoXRBundle(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestCiscoXRBundleNameFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    map[string]string
+		want    string
+		wantErr bool
+	}{
+		{name: "not set"},
+		{name: "set", opts: map[string]string{BundleNameFormatOption: "ae%d"}, want: "ae%d"},
+		{name: "no verb", opts: map[string]string{BundleNameFormatOption: "ae"}, wantErr: true},
+		{name: "string verb", opts: map[string]string{BundleNameFormatOption: "ae%s"}, wantErr: true},
+		{name: "two verbs", opts: map[string]string{BundleNameFormatOption: "ae%d%d"}, wantErr: true},
+		{name: "separated verbs", opts: map[string]string{BundleNameFormatOption: "%d-%d"}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := CiscoXRBundleNameFormat(tc.opts)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("CiscoXRBundleNameFormat(%v) got error %v, want error %t", tc.opts, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("CiscoXRBundleNameFormat(%v) = %q, want %q", tc.opts, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAnalogValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		precision uint32
+		in        float64
+		want      *gnmipb.TypedValue
+	}{
+		{
+			name: "default double",
+			in:   -1.47,
+			want: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: -1.47}},
+		},
+		{
+			name:      "decimal64",
+			precision: 2,
+			in:        -1.47,
+			want:      &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DecimalVal{DecimalVal: &gnmipb.Decimal64{Digits: -147, Precision: 2}}},
+		},
+		{
+			name:      "decimal64 rounded",
+			precision: 1,
+			in:        2.36,
+			want:      &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DecimalVal{DecimalVal: &gnmipb.Decimal64{Digits: 24, Precision: 1}}},
+		},
+		{
+			name:      "out of range falls back to double",
+			precision: 18,
+			in:        1e6,
+			want:      &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: 1e6}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, AnalogValue(tc.in, tc.precision), protocmp.Transform()); diff != "" {
+				t.Errorf("AnalogValue(%v, %d) returned unexpected diff (-want +got):\n%s", tc.in, tc.precision, diff)
+			}
+		})
+	}
+}
+
+func TestAnalogPrecision(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    map[string]string
+		want    uint32
+		wantErr bool
+	}{
+		{name: "not set"},
+		{name: "set", opts: map[string]string{AnalogPrecisionOption: "2"}, want: 2},
+		{name: "maximum", opts: map[string]string{AnalogPrecisionOption: "18"}, want: 18},
+		{name: "too large", opts: map[string]string{AnalogPrecisionOption: "19"}, wantErr: true},
+		{name: "negative", opts: map[string]string{AnalogPrecisionOption: "-1"}, wantErr: true},
+		{name: "not a number", opts: map[string]string{AnalogPrecisionOption: "two"}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := AnalogPrecision(tc.opts)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("AnalogPrecision(%v) got error %v, want error %t", tc.opts, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("AnalogPrecision(%v) = %d, want %d", tc.opts, got, tc.want)
+			}
+		})
+	}
+}
+
+func benchmarkNotification() *gnmipb.Notification {
+	n := &gnmipb.Notification{
+		Prefix: &gnmipb.Path{
+			Origin: "eos_native",
+			Target: "dut",
+			Elem:   []*gnmipb.PathElem{{Name: "Sysdb"}, {Name: "macsec"}, {Name: "mkaStatus"}, {Name: "portStatus"}},
+		},
+	}
+	for i := 0; i < 20; i++ {
+		n.Update = append(n.Update, &gnmipb.Update{
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: fmt.Sprintf("Ethernet%d", i)}, {Name: "actorStatus"}, {Name: "abcd"}, {Name: "success"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: true}},
+		})
+	}
+	return n
+}
+
+func BenchmarkJoin(b *testing.B) {
+	n := benchmarkNotification()
+	for i := 0; i < b.N; i++ {
+		for _, u := range n.GetUpdate() {
+			Join(n.GetPrefix(), u.GetPath())
+		}
+	}
+}
+
+func BenchmarkJoinInto(b *testing.B) {
+	n := benchmarkNotification()
+	var scratch gnmipb.Path
+	for i := 0; i < b.N; i++ {
+		for _, u := range n.GetUpdate() {
+			JoinInto(&scratch, n.GetPrefix(), u.GetPath())
+		}
+	}
+}
+
+func BenchmarkMatchPath(b *testing.B) {
+	n := benchmarkNotification()
+	path := Join(n.GetPrefix(), n.GetUpdate()[0].GetPath())
+	pattern := &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "Sysdb"}, {Name: "macsec"}, {Name: "mkaStatus"}, {Name: "portStatus"},
+			{Name: "*"}, {Name: "actorStatus"}, {Name: "*"}, {Name: "success"},
+		},
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MatchPath(path, pattern)
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	n := benchmarkNotification()
+	for i := 0; i < b.N; i++ {
+		Filter(n, func(path *gnmipb.Path, isDelete bool) bool {
+			return path.GetElem()[len(path.GetElem())-1].GetName() == "success"
+		})
+	}
+}
+
+func TestReadSubscribeResponses(t *testing.T) {
+	syncSR := &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true}}
+	updateSR := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{Timestamp: 1, Prefix: &gnmipb.Path{Target: "dut"}},
+		},
+	}
+	tests := []struct {
+		name    string
+		in      string
+		want    []*gnmipb.SubscribeResponse
+		wantErr bool
+	}{
+		{
+			name: "single",
+			in:   "sync_response: true\n",
+			want: []*gnmipb.SubscribeResponse{syncSR},
+		},
+		{
+			name: "stream",
+			in:   "---\nupdate: {\n  timestamp: 1\n  prefix: { target: \"dut\" }\n}\n ---\nsync_response: true\n---\n\n",
+			want: []*gnmipb.SubscribeResponse{updateSR, syncSR},
+		},
+		{
+			name: "empty",
+			in:   "",
+		},
+		{
+			name:    "invalid",
+			in:      "sync_response: true\n---\nupdate: {\n",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ReadSubscribeResponses(strings.NewReader(tc.in))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ReadSubscribeResponses() got error %v, want error: %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ReadSubscribeResponses() returned an unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
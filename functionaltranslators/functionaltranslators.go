@@ -0,0 +1,265 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package functionaltranslators is the entry point for collectors embedding the functional
+// translators. It selects the registered translators applying to a device and dispatches the
+// device's notifications to them:
+//
+//	p, err := functionaltranslators.New(ftconsts.VendorArista, "4.34.1F", nil)
+//	if err != nil {
+//		return err
+//	}
+//	subscribe(p.SubscriptionPaths())
+//	for sr := range responses {
+//		for _, out := range p.Process(sr) {
+//			publish(out)
+//		}
+//	}
+package functionaltranslators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+	// Links and registers all the functional translators.
+	_ "github.com/openconfig/functional-translators/registrar"
+	"github.com/openconfig/ygot/ygot"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Options are the optional parameters of New.
+type Options struct {
+	// HardwareModel is the hardware model of the device, matched against the translators metadata.
+	HardwareModel string
+	// Target is the target of the notifications of the device, e.g. "router1". Sync responses
+	// carry no target, so it is passed to the translators at sync, for the stateful translators to
+	// flush the pending state of the device. The pending state is kept if it is empty. See
+	// translator.FunctionalTranslator.SyncTarget.
+	Target string
+	// Outputs restricts the pipeline to the given output schema strings, e.g.
+	// "/openconfig/interfaces/interface/state/counters/in-pkts". All the outputs of the applicable
+	// translators are provided if empty.
+	Outputs []string
+	// Translators are the candidate translators, keyed by ID. Defaults to new instances of the
+	// translators of the registry, so that the state of the translators is not shared between
+	// pipelines.
+	Translators map[string]*translator.FunctionalTranslator
+	// TranslatorOptions are the options of the translators, keyed by ID, e.g. the debounce interval
+	// of a translator in this deployment. They are set with SetOptions on the translators of the
+	// pipeline, including those passed in Translators. The options of other translators are
+	// ignored.
+	TranslatorOptions map[string]translator.Options
+	// OnError is called when a translator fails to translate a notification. Errors are logged if
+	// it is nil.
+	OnError func(id string, err error)
+}
+
+// Pipeline translates the notifications of a device with the translators applying to it.
+type Pipeline interface {
+	// Process returns the translated responses of a response from the device. A sync response is
+	// returned once, after it has been passed to every translator and after the notifications they
+	// flushed at sync. An error response is returned unchanged.
+	Process(*gnmipb.SubscribeResponse) []*gnmipb.SubscribeResponse
+	// SubscriptionPaths returns the sorted, minimal set of input paths the device must be subscribed
+	// to. See translator.MinimalSubscriptions.
+	SubscriptionPaths() []*gnmipb.Path
+	// Subscriptions returns the subscriptions to the SubscriptionPaths, in the modes recommended by
+	// the translators. See translator.Subscriptions.
+	Subscriptions() []*gnmipb.Subscription
+}
+
+// member is a translator of a pipeline, with the unqualified schema strings of its inputs.
+type member struct {
+	ft     *translator.FunctionalTranslator
+	inputs []string
+}
+
+type pipeline struct {
+	members []*member
+	paths   []*gnmipb.Path
+	target  string
+	onError func(id string, err error)
+}
+
+// New returns a Pipeline of the translators applying to a device of the given vendor and software
+// version. It returns an error if no translator applies.
+func New(vendor, version string, opts *Options) (Pipeline, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	candidates := opts.Translators
+	if candidates == nil {
+		fts, err := registry.ForTarget(vendor, version, opts.HardwareModel)
+		if err != nil {
+			return nil, err
+		}
+		candidates = map[string]*translator.FunctionalTranslator{}
+		for _, ft := range fts {
+			candidates[ft.ID()] = ft
+		}
+	}
+	device := &translator.DeviceMetadata{
+		Vendor:          vendor,
+		HardwareModel:   opts.HardwareModel,
+		SoftwareVersion: version,
+	}
+	wantOutputs := map[string]bool{}
+	for _, o := range opts.Outputs {
+		wantOutputs[o] = true
+	}
+	p := &pipeline{target: opts.Target, onError: opts.OnError}
+	if p.onError == nil {
+		p.onError = func(id string, err error) {
+			log.Errorf("Functional translator %s failed to translate: %v", id, err)
+		}
+	}
+	seen := map[string]bool{}
+	for _, ft := range candidates {
+		if !ft.Supports(device) {
+			continue
+		}
+		used := false
+		for out, inputs := range ft.OutputToInputMap() {
+			if len(wantOutputs) > 0 && !wantOutputs[out] {
+				continue
+			}
+			used = true
+			for _, in := range inputs {
+				key, err := ygot.PathToString(in)
+				if err != nil {
+					return nil, fmt.Errorf("invalid input path of %s: %v", ft.ID(), err)
+				}
+				key = in.GetOrigin() + ":" + key
+				if !seen[key] {
+					seen[key] = true
+					p.paths = append(p.paths, in)
+				}
+			}
+		}
+		if used {
+			if o, ok := opts.TranslatorOptions[ft.ID()]; ok {
+				if err := ft.SetOptions(o); err != nil {
+					return nil, err
+				}
+			}
+			m := &member{ft: ft}
+			for _, in := range ft.InputSchemas() {
+				m.inputs = append(m.inputs, unqualified(in))
+			}
+			p.members = append(p.members, m)
+		}
+	}
+	if len(p.members) == 0 {
+		return nil, fmt.Errorf("no functional translator applies to %s %s", vendor, version)
+	}
+	sort.Slice(p.members, func(i, j int) bool { return p.members[i].ft.ID() < p.members[j].ft.ID() })
+	p.paths = translator.MinimalSubscriptions(p.paths, strings.EqualFold(vendor, ftconsts.VendorArista))
+	return p, nil
+}
+
+func (p *pipeline) SubscriptionPaths() []*gnmipb.Path {
+	return p.paths
+}
+
+func (p *pipeline) Subscriptions() []*gnmipb.Subscription {
+	fts := make([]*translator.FunctionalTranslator, 0, len(p.members))
+	for _, m := range p.members {
+		fts = append(fts, m.ft)
+	}
+	return translator.Subscriptions(p.paths, fts...)
+}
+
+func (p *pipeline) Process(sr *gnmipb.SubscribeResponse) []*gnmipb.SubscribeResponse {
+	if translator.IsSyncResponse(sr) {
+		var out []*gnmipb.SubscribeResponse
+		for _, m := range p.members {
+			srs, err := m.ft.SyncTarget(p.target, sr)
+			if err != nil {
+				p.onError(m.ft.ID(), err)
+				continue
+			}
+			for _, o := range srs {
+				if !translator.IsSyncResponse(o) {
+					out = append(out, o)
+				}
+			}
+		}
+		return append(out, sr)
+	}
+	if translator.IsErrorResponse(sr) {
+		return []*gnmipb.SubscribeResponse{sr}
+	}
+	schemas := notificationSchemas(sr.GetUpdate())
+	var out []*gnmipb.SubscribeResponse
+	for _, m := range p.members {
+		if !m.relevant(schemas) {
+			continue
+		}
+		srs, err := m.ft.TranslateSplit(sr)
+		if err != nil {
+			p.onError(m.ft.ID(), err)
+			continue
+		}
+		out = append(out, srs...)
+	}
+	return out
+}
+
+// notificationSchemas returns the unqualified schema strings of the updated and deleted paths of
+// a notification.
+func notificationSchemas(n *gnmipb.Notification) []string {
+	var schemas []string
+	for _, u := range n.GetUpdate() {
+		schemas = append(schemas, unqualified(ftutilities.GNMIPathToSchemaString(ftutilities.Join(n.GetPrefix(), u.GetPath()), true)))
+	}
+	for _, d := range n.GetDelete() {
+		schemas = append(schemas, unqualified(ftutilities.GNMIPathToSchemaString(ftutilities.Join(n.GetPrefix(), d), true)))
+	}
+	return schemas
+}
+
+// unqualified removes the module names of the elements of a schema string, since devices do not
+// consistently qualify element names, e.g. "Cisco-IOS-XR-ofa-npu-stats-oper:npu-numbers".
+func unqualified(schema string) string {
+	if !strings.Contains(schema, ":") {
+		return schema
+	}
+	elems := strings.Split(schema, "/")
+	for i, e := range elems {
+		if _, name, ok := strings.Cut(e, ":"); ok {
+			elems[i] = name
+		}
+	}
+	return strings.Join(elems, "/")
+}
+
+// relevant returns true if one of the schema strings is at, under or above an input of the member,
+// so that deletes of the parents of its inputs are also dispatched to it.
+func (m *member) relevant(schemas []string) bool {
+	for _, s := range schemas {
+		for _, in := range m.inputs {
+			if s == in || strings.HasPrefix(s, in+"/") || strings.HasPrefix(in, s+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functionaltranslators
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/functional-translators/arista/aristaqosaggregatecounters"
+	"github.com/openconfig/functional-translators/ftconsts"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registrar"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func poeTranslators() map[string]*translator.FunctionalTranslator {
+	return map[string]*translator.FunctionalTranslator{
+		ftconsts.AristaPoETranslator: registrar.FunctionalTranslatorRegistry[ftconsts.AristaPoETranslator],
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		vendor    string
+		opts      *Options
+		wantPaths []*gnmipb.Path
+		wantErr   bool
+	}{
+		{
+			name:   "poe",
+			vendor: ftconsts.VendorArista,
+			opts:   &Options{Translators: poeTranslators()},
+			wantPaths: []*gnmipb.Path{
+				{
+					Origin: "eos_native",
+					Elem:   []*gnmipb.PathElem{{Name: "Sysdb"}, {Name: "hardware"}, {Name: "poe"}, {Name: "status"}, {Name: "port"}},
+				},
+			},
+		},
+		{
+			name:    "vendor mismatch",
+			vendor:  ftconsts.VendorCiscoXR,
+			opts:    &Options{Translators: poeTranslators()},
+			wantErr: true,
+		},
+		{
+			name:   "unsupported outputs",
+			vendor: ftconsts.VendorArista,
+			opts: &Options{
+				Translators: poeTranslators(),
+				Outputs:     []string{"/openconfig/interfaces/interface/state/counters/in-pkts"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := New(tc.vendor, "4.34.1F", tc.opts)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("New() got error %v, want error: %t", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.wantPaths, p.SubscriptionPaths(), protocmp.Transform()); diff != "" {
+				t.Errorf("SubscriptionPaths() returned an unexpected diff (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestSubscriptions(t *testing.T) {
+	p, err := New(ftconsts.VendorArista, "4.34.1F", &Options{
+		Translators: map[string]*translator.FunctionalTranslator{
+			ftconsts.AristaQoSAggregateCountersTranslator: aristaqosaggregatecounters.New(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() returned an unexpected error: %v", err)
+	}
+	subs := p.Subscriptions()
+	if len(subs) != len(p.SubscriptionPaths()) {
+		t.Fatalf("Subscriptions() returned %d subscriptions, want one per subscription path %v", len(subs), p.SubscriptionPaths())
+	}
+	for _, s := range subs {
+		want := &gnmipb.Subscription{Path: s.GetPath(), Mode: gnmipb.SubscriptionMode_ON_CHANGE}
+		if s.GetPath().GetElem()[0].GetName() == "qos" {
+			want = &gnmipb.Subscription{Path: s.GetPath(), Mode: gnmipb.SubscriptionMode_SAMPLE, SampleInterval: uint64(30 * time.Second)}
+		}
+		if diff := cmp.Diff(want, s, protocmp.Transform()); diff != "" {
+			t.Errorf("Subscriptions() returned an unexpected diff (-want +got): %v", diff)
+		}
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	for _, vendor := range []string{ftconsts.VendorArista, ftconsts.VendorCiscoXR, ftconsts.VendorJuniper} {
+		p, err := New(vendor, "", nil)
+		if err != nil {
+			t.Fatalf("New(%q) returned an unexpected error: %v", vendor, err)
+		}
+		if len(p.SubscriptionPaths()) == 0 {
+			t.Errorf("New(%q).SubscriptionPaths() is empty", vendor)
+		}
+	}
+}
+
+func TestNewTranslatorOptions(t *testing.T) {
+	qos := func() map[string]*translator.FunctionalTranslator {
+		return map[string]*translator.FunctionalTranslator{
+			ftconsts.AristaQoSAggregateCountersTranslator: aristaqosaggregatecounters.New(),
+		}
+	}
+	tests := []struct {
+		name    string
+		options map[string]translator.Options
+		want    translator.Options
+		wantErr bool
+	}{
+		{
+			name:    "options set",
+			options: map[string]translator.Options{ftconsts.AristaQoSAggregateCountersTranslator: {"debounce": "1s"}},
+			want:    translator.Options{"debounce": "1s"},
+		},
+		{
+			name:    "options of other translators ignored",
+			options: map[string]translator.Options{ftconsts.AristaPoETranslator: {"debounce": "1s"}},
+			want:    nil,
+		},
+		{
+			name:    "invalid options",
+			options: map[string]translator.Options{ftconsts.AristaQoSAggregateCountersTranslator: {"debounce": "soon"}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fts := qos()
+			_, err := New(ftconsts.VendorArista, "4.34.1F", &Options{Translators: fts, TranslatorOptions: tc.options})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("New() got error %v, want error: %t", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, fts[ftconsts.AristaQoSAggregateCountersTranslator].Options()); diff != "" {
+				t.Errorf("Options() returned an unexpected diff (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	p, err := New(ftconsts.VendorArista, "4.34.1F", &Options{Translators: poeTranslators()})
+	if err != nil {
+		t.Fatalf("New() returned an unexpected error: %v", err)
+	}
+	poeInput, err := ftutilities.LoadSubscribeResponse("testdata/poe_input.txt")
+	if err != nil {
+		t.Fatalf("Failed to load input: %v", err)
+	}
+	poeOutput, err := ftutilities.LoadSubscribeResponse("testdata/poe_output.txt")
+	if err != nil {
+		t.Fatalf("Failed to load output: %v", err)
+	}
+	syncResponse := &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true}}
+	errorResponse := &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_Error{Error: &gnmipb.Error{Code: 14, Message: "unavailable"}}}
+	tests := []struct {
+		name  string
+		input *gnmipb.SubscribeResponse
+		want  []*gnmipb.SubscribeResponse
+	}{
+		{
+			name:  "translated",
+			input: poeInput,
+			want:  []*gnmipb.SubscribeResponse{poeOutput},
+		},
+		{
+			name:  "sync response",
+			input: syncResponse,
+			want:  []*gnmipb.SubscribeResponse{syncResponse},
+		},
+		{
+			name:  "error response",
+			input: errorResponse,
+			want:  []*gnmipb.SubscribeResponse{errorResponse},
+		},
+		{
+			name: "not an input",
+			input: &gnmipb.SubscribeResponse{
+				Response: &gnmipb.SubscribeResponse_Update{
+					Update: &gnmipb.Notification{
+						Prefix: &gnmipb.Path{Origin: "eos_native", Target: "dut"},
+						Update: []*gnmipb.Update{{
+							Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "Sysdb"}, {Name: "hardware"}, {Name: "fan"}}},
+							Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 1}},
+						}},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := p.Process(tc.input)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update", "delete")); diff != "" {
+				t.Errorf("Process() returned an unexpected diff (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestUnqualified(t *testing.T) {
+	got := unqualified("/Cisco-IOS-XR-platforms-ofa-oper/ofa/stats/nodes/node/Cisco-IOS-XR-ofa-npu-stats-oper:npu-numbers/npu-number")
+	want := "/Cisco-IOS-XR-platforms-ofa-oper/ofa/stats/nodes/node/npu-numbers/npu-number"
+	if got != want {
+		t.Errorf("unqualified() = %q, want %q", got, want)
+	}
+}
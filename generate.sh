@@ -36,3 +36,5 @@ bash ciscoxr/ciscoxrsubcounters/generate.sh
 
 rm -rf public
 rm -rf yang
+
+go run ./cmd/ftpathsgen -out ftpaths/paths.go
@@ -0,0 +1,229 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package juniperqueue translates the egress queue statistics of the Junos native interface sensor
+// to openconfig qos output queue state.
+//
+// The native sensor reports the queues of each interface by number, e.g.
+// /junos/system/linecard/interface/interface[name=et-0/0/1]/egress-queue-info[queue-number=3],
+// which are translated to the output queue of the interface named after the queue number.
+package juniperqueue
+
+import (
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const nativeOrigin = "junos"
+
+var (
+	translateMap = map[string][]string{
+		"/openconfig/qos/interfaces/interface/output/queues/queue/state/name": {
+			"/junos/system/linecard/interface",
+		},
+		"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts": {
+			"/junos/system/linecard/interface",
+		},
+		"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-octets": {
+			"/junos/system/linecard/interface",
+		},
+		"/openconfig/qos/interfaces/interface/output/queues/queue/state/dropped-pkts": {
+			"/junos/system/linecard/interface",
+		},
+		"/openconfig/qos/interfaces/interface/output/queues/queue/state/avg-queue-len": {
+			"/junos/system/linecard/interface",
+		},
+		"/openconfig/qos/interfaces/interface/output/queues/queue/state/max-queue-len": {
+			"/junos/system/linecard/interface",
+		},
+	}
+	paths = ftutilities.MustStringMapPaths(translateMap)
+	// queuePattern matches the egress queue leaves of the native interface sensor, relative to the
+	// junos origin.
+	queuePattern = &gnmipb.Path{
+		Origin: nativeOrigin,
+		Elem: []*gnmipb.PathElem{
+			{Name: "system"}, {Name: "linecard"}, {Name: "interface"},
+			{Name: "interface", Key: map[string]string{"name": "*"}},
+			{Name: "egress-queue-info", Key: map[string]string{"queue-number": "*"}},
+			{Name: "*"},
+		},
+	}
+	// queueLeaves maps the native queue leaves to the openconfig queue state leaves. The buffer
+	// occupancies are in bytes, as the openconfig queue lengths.
+	queueLeaves = map[string]string{
+		"packets":               "transmit-pkts",
+		"bytes":                 "transmit-octets",
+		"avg-buffer-occupancy":  "avg-queue-len",
+		"peak-buffer-occupancy": "max-queue-len",
+	}
+	// dropLeaves are the native drop counters of a queue, whose sum is the openconfig dropped-pkts.
+	dropLeaves = map[string]bool{
+		"tail-drop-packets": true,
+		"red-drop-packets":  true,
+		"rl-drop-packets":   true,
+	}
+)
+
+func init() {
+	registry.Register(ftconsts.JuniperQueueTranslator, NewE)
+}
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Juniper queue functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	return translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:               ftconsts.JuniperQueueTranslator,
+			Translate:        translate,
+			OutputToInputMap: paths,
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorJuniper,
+				},
+			},
+		},
+	)
+}
+
+// normalize returns the path with the junos origin. Junos devices may not set the origin, in which
+// case "junos" is the first element of the path.
+func normalize(path *gnmipb.Path) *gnmipb.Path {
+	elems := path.GetElem()
+	if path.GetOrigin() == "" && len(elems) > 0 && elems[0].GetName() == nativeOrigin {
+		return &gnmipb.Path{Origin: nativeOrigin, Elem: elems[1:], Target: path.GetTarget()}
+	}
+	return path
+}
+
+func queuePath(intf, queue, leaf string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "qos"},
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"interface-id": intf}},
+			{Name: "output"},
+			{Name: "queues"},
+			{Name: "queue", Key: map[string]string{"name": queue}},
+			{Name: "state"},
+			{Name: leaf},
+		},
+	}
+}
+
+func uintUpdate(intf, queue, leaf string, v uint64) *gnmipb.Update {
+	return &gnmipb.Update{
+		Path: queuePath(intf, queue, leaf),
+		Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}},
+	}
+}
+
+// queueKey identifies an egress queue of an interface.
+type queueKey struct {
+	intf  string
+	queue string
+}
+
+// queueStats holds the statistics of a queue reported in a notification.
+type queueStats struct {
+	updates []*gnmipb.Update
+	drops   uint64
+	// hasDrops is set if the notification reports a drop counter of the queue.
+	hasDrops bool
+}
+
+// translate translates the egress queues of a notification. The native sensor reports all the
+// drop counters of a queue together, so dropped-pkts is the sum of those of the notification.
+func translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	var keys []queueKey
+	stats := map[queueKey]*queueStats{}
+	for _, u := range n.GetUpdate() {
+		path := normalize(ftutilities.Join(n.GetPrefix(), u.GetPath()))
+		if path.GetOrigin() != nativeOrigin || !ftutilities.MatchPath(path, queuePattern) {
+			continue
+		}
+		elems := path.GetElem()
+		leaf := elems[5].GetName()
+		ocLeaf, ok := queueLeaves[leaf]
+		if !ok && !dropLeaves[leaf] {
+			continue
+		}
+		key := queueKey{intf: elems[3].GetKey()["name"], queue: elems[4].GetKey()["queue-number"]}
+		if key.intf == "" || key.queue == "" {
+			log.Errorf("Failed to translate update %v: missing interface name or queue number", u)
+			continue
+		}
+		v, err := ftutilities.ToUint64(u.GetVal())
+		if err != nil {
+			log.Errorf("Failed to translate update %v: failed to read %s: %v", u, leaf, err)
+			continue
+		}
+		s, ok := stats[key]
+		if !ok {
+			s = &queueStats{}
+			stats[key] = s
+			keys = append(keys, key)
+		}
+		if dropLeaves[leaf] {
+			s.drops += v
+			s.hasDrops = true
+			continue
+		}
+		s.updates = append(s.updates, uintUpdate(key.intf, key.queue, ocLeaf, v))
+	}
+	var updates []*gnmipb.Update
+	for _, k := range keys {
+		s := stats[k]
+		updates = append(updates, &gnmipb.Update{
+			Path: queuePath(k.intf, k.queue, "name"),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: k.queue}},
+		})
+		updates = append(updates, s.updates...)
+		if s.hasDrops {
+			updates = append(updates, uintUpdate(k.intf, k.queue, "dropped-pkts", s.drops))
+		}
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package junipertransceiver translates the Junos native linecard optics sensor to openconfig
+// transceiver physical channels.
+//
+// The native sensor reports the optics of each interface, e.g. "et-0/0/1", which are translated to
+// the transceiver component of the port, named as in the Junos openconfig implementation, e.g.
+// "FPC0:PIC0:PORT1:Xcvr0".
+package junipertransceiver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	nativeOrigin = "junos"
+
+	laneOutputPower   = "lane-laser-output-power-dbm"
+	laneReceiverPower = "lane-laser-receiver-power-dbm"
+	laneBiasCurrent   = "lane-laser-bias-current"
+)
+
+var (
+	translateMap = map[string][]string{
+		"/openconfig/components/component/transceiver/physical-channels/channel/state/index": {
+			"/junos/system/linecard/optics",
+		},
+		"/openconfig/components/component/transceiver/physical-channels/channel/state/output-power/instant": {
+			"/junos/system/linecard/optics",
+		},
+		"/openconfig/components/component/transceiver/physical-channels/channel/state/input-power/instant": {
+			"/junos/system/linecard/optics",
+		},
+		"/openconfig/components/component/transceiver/physical-channels/channel/state/laser-bias-current/instant": {
+			"/junos/system/linecard/optics",
+		},
+	}
+	paths = ftutilities.MustStringMapPaths(translateMap)
+	// lanePattern matches the lane leaves of the native optics sensor, relative to the junos origin.
+	lanePattern = &gnmipb.Path{
+		Origin: nativeOrigin,
+		Elem: []*gnmipb.PathElem{
+			{Name: "system"}, {Name: "linecard"}, {Name: "optics"},
+			{Name: "optics-diag", Key: map[string]string{"if-name": "*"}},
+			{Name: "optics-lane-diag-stats", Key: map[string]string{"lane-number": "*"}},
+			{Name: "*"},
+		},
+	}
+	// channelLeaves maps the native lane leaves to the openconfig channel state leaves.
+	channelLeaves = map[string][]string{
+		laneOutputPower:   {"output-power", "instant"},
+		laneReceiverPower: {"input-power", "instant"},
+		laneBiasCurrent:   {"laser-bias-current", "instant"},
+	}
+)
+
+func init() {
+	registry.Register(ftconsts.JuniperTransceiverTranslator, NewE)
+}
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Juniper transceiver functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	ft, err := translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:                   ftconsts.JuniperTransceiverTranslator,
+			TranslateWithOptions: translate,
+			ValidateOptions:      validateOptions,
+			OutputToInputMap:     paths,
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorJuniper,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ft, nil
+}
+
+// normalize returns the path with the junos origin. Junos devices may not set the origin, in which
+// case "junos" is the first element of the path.
+func normalize(path *gnmipb.Path) *gnmipb.Path {
+	elems := path.GetElem()
+	if path.GetOrigin() == "" && len(elems) > 0 && elems[0].GetName() == nativeOrigin {
+		return &gnmipb.Path{Origin: nativeOrigin, Elem: elems[1:], Target: path.GetTarget()}
+	}
+	return path
+}
+
+// componentName returns the name of the transceiver component of a Junos interface, e.g.
+// "FPC0:PIC0:PORT1:Xcvr0" for "et-0/0/1" and its channelized interfaces such as "et-0/0/1:2".
+func componentName(ifName string) (string, error) {
+	_, fpp, ok := strings.Cut(ifName, "-")
+	if !ok {
+		return "", fmt.Errorf("interface %q has no media type prefix", ifName)
+	}
+	fpp, _, _ = strings.Cut(fpp, ":")
+	parts := strings.Split(fpp, "/")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("interface %q is not of the form <type>-<fpc>/<pic>/<port>", ifName)
+	}
+	for _, p := range parts {
+		if _, err := strconv.ParseUint(p, 10, 32); err != nil {
+			return "", fmt.Errorf("interface %q has a non numeric fpc, pic or port", ifName)
+		}
+	}
+	return fmt.Sprintf("FPC%s:PIC%s:PORT%s:Xcvr0", parts[0], parts[1], parts[2]), nil
+}
+
+func channelPath(component, lane string, leaf ...string) *gnmipb.Path {
+	p := &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": component}},
+			{Name: "transceiver"},
+			{Name: "physical-channels"},
+			{Name: "channel", Key: map[string]string{"index": lane}},
+			{Name: "state"},
+		},
+	}
+	for _, l := range leaf {
+		p.Elem = append(p.Elem, &gnmipb.PathElem{Name: l})
+	}
+	return p
+}
+
+// buildUpdates translates a single native lane leaf to openconfig updates, with the analog
+// precision.
+func buildUpdates(path *gnmipb.Path, val *gnmipb.TypedValue, precision uint32) ([]*gnmipb.Update, error) {
+	elems := path.GetElem()
+	leaf := elems[len(elems)-1].GetName()
+	ocLeaf, ok := channelLeaves[leaf]
+	if !ok {
+		return nil, nil
+	}
+	component, err := componentName(elems[3].GetKey()["if-name"])
+	if err != nil {
+		return nil, err
+	}
+	lane := elems[4].GetKey()["lane-number"]
+	index, err := strconv.ParseUint(lane, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lane number %q: %v", lane, err)
+	}
+	v, err := ftutilities.ToDouble(val)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", leaf, err)
+	}
+	return []*gnmipb.Update{
+		{
+			Path: channelPath(component, lane, "index"),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: index}},
+		},
+		{
+			Path: channelPath(component, lane, ocLeaf...),
+			Val:  ftutilities.AnalogValue(v, precision),
+		},
+	}, nil
+}
+
+// validateOptions rejects an invalid analog precision.
+func validateOptions(opts translator.Options) error {
+	_, err := ftutilities.AnalogPrecision(opts)
+	return err
+}
+
+func translate(sr *gnmipb.SubscribeResponse, opts translator.Options) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	precision, err := ftutilities.AnalogPrecision(opts)
+	if err != nil {
+		return nil, err
+	}
+	var updates []*gnmipb.Update
+	// The index of a channel is emitted once per notification, even if several of its leaves are.
+	seenIndex := map[string]bool{}
+	for _, u := range n.GetUpdate() {
+		path := normalize(ftutilities.Join(n.GetPrefix(), u.GetPath()))
+		if path.GetOrigin() != nativeOrigin || !ftutilities.MatchPath(path, lanePattern) {
+			continue
+		}
+		ups, err := buildUpdates(path, u.GetVal(), precision)
+		if err != nil {
+			log.Errorf("Failed to translate update %v: %v", u, err)
+			continue
+		}
+		for _, up := range ups {
+			elems := up.GetPath().GetElem()
+			if elems[len(elems)-1].GetName() == "index" {
+				key := elems[1].GetKey()["name"] + "/" + elems[4].GetKey()["index"]
+				if seenIndex[key] {
+					continue
+				}
+				seenIndex[key] = true
+			}
+			updates = append(updates, up)
+		}
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+			},
+		},
+	}, nil
+}
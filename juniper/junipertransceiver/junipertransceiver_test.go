@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package junipertransceiver
+
+import (
+	"testing"
+
+	"github.com/openconfig/functional-translators/fttest"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/translator"
+)
+
+func TestTranslate(t *testing.T) {
+	fttest.RunGoldenTests(t, New(), "testdata")
+}
+
+func TestTranslateDecimal64(t *testing.T) {
+	ft := New()
+	opts := translator.Options{ftutilities.AnalogPrecisionOption: "2"}
+	if err := ft.SetOptions(opts); err != nil {
+		t.Fatalf("SetOptions(%v) got unexpected error: %v", opts, err)
+	}
+	fttest.RunGoldenTests(t, ft, "testdata/decimal64")
+}
+
+func TestComponentName(t *testing.T) {
+	tests := []struct {
+		ifName  string
+		want    string
+		wantErr bool
+	}{
+		{ifName: "et-0/0/1", want: "FPC0:PIC0:PORT1:Xcvr0"},
+		{ifName: "xe-1/2/3", want: "FPC1:PIC2:PORT3:Xcvr0"},
+		{ifName: "et-0/1/4:2", want: "FPC0:PIC1:PORT4:Xcvr0"},
+		{ifName: "ae0", wantErr: true},
+		{ifName: "et-0/0", wantErr: true},
+		{ifName: "et-a/0/1", wantErr: true},
+	}
+	for _, tc := range tests {
+		got, err := componentName(tc.ifName)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("componentName(%q) got error %v, want error: %t", tc.ifName, err, tc.wantErr)
+		}
+		if got != tc.want {
+			t.Errorf("componentName(%q) = %q, want %q", tc.ifName, got, tc.want)
+		}
+	}
+}
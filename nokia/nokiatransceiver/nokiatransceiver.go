@@ -0,0 +1,214 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nokiatransceiver translates the Nokia SR Linux native transceiver channel state to
+// openconfig transceiver physical channels.
+//
+// SR Linux reports the transceiver of each port under its interface, e.g.
+// /interface[name=ethernet-1/1]/transceiver/channel[index=1]/input-power/latest-value. The
+// transceiver component is named after the interface of the port, e.g. "ethernet-1/1", and the
+// channel index is the native channel index.
+package nokiatransceiver
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/openconfig/functional-translators/ftconsts"
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	nativeOrigin = "srl_nokia"
+
+	inputPower       = "input-power"
+	outputPower      = "output-power"
+	laserBiasCurrent = "laser-bias-current"
+)
+
+var (
+	translateMap = map[string][]string{
+		"/openconfig/components/component/transceiver/physical-channels/channel/state/index": {
+			"/srl_nokia/interface/transceiver/channel/input-power/latest-value",
+			"/srl_nokia/interface/transceiver/channel/output-power/latest-value",
+			"/srl_nokia/interface/transceiver/channel/laser-bias-current/latest-value",
+		},
+		"/openconfig/components/component/transceiver/physical-channels/channel/state/input-power/instant": {
+			"/srl_nokia/interface/transceiver/channel/input-power/latest-value",
+		},
+		"/openconfig/components/component/transceiver/physical-channels/channel/state/output-power/instant": {
+			"/srl_nokia/interface/transceiver/channel/output-power/latest-value",
+		},
+		"/openconfig/components/component/transceiver/physical-channels/channel/state/laser-bias-current/instant": {
+			"/srl_nokia/interface/transceiver/channel/laser-bias-current/latest-value",
+		},
+	}
+	paths = ftutilities.MustStringMapPaths(translateMap)
+	// channelPattern matches the latest values of the native channel state.
+	channelPattern = &gnmipb.Path{
+		Origin: nativeOrigin,
+		Elem: []*gnmipb.PathElem{
+			{Name: "interface", Key: map[string]string{"name": "*"}},
+			{Name: "transceiver"},
+			{Name: "channel", Key: map[string]string{"index": "*"}},
+			{Name: "*"},
+			{Name: "latest-value"},
+		},
+	}
+	// channelLeaves maps the native channel containers to the openconfig channel state leaves. The
+	// native power is in dBm and the bias current in mA, as in openconfig.
+	channelLeaves = map[string][]string{
+		inputPower:       {"input-power", "instant"},
+		outputPower:      {"output-power", "instant"},
+		laserBiasCurrent: {"laser-bias-current", "instant"},
+	}
+)
+
+func init() {
+	registry.Register(ftconsts.NokiaTransceiverTranslator, NewE)
+}
+
+// New creates a functional translator.
+func New() *translator.FunctionalTranslator {
+	ft, err := NewE()
+	if err != nil {
+		log.Fatalf("Failed to create Nokia transceiver functional translator: %v", err)
+	}
+	return ft
+}
+
+// NewE is like New, but returns an error instead of exiting if the translator cannot be created.
+func NewE() (*translator.FunctionalTranslator, error) {
+	return translator.NewFunctionalTranslator(
+		translator.FunctionalTranslatorOptions{
+			ID:                   ftconsts.NokiaTransceiverTranslator,
+			TranslateWithOptions: translate,
+			ValidateOptions:      validateOptions,
+			OutputToInputMap:     paths,
+			Metadata: []*translator.FTMetadata{
+				{
+					Vendor: ftconsts.VendorNokia,
+				},
+			},
+		},
+	)
+}
+
+func channelPath(component, index string, leaf ...string) *gnmipb.Path {
+	p := &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": component}},
+			{Name: "transceiver"},
+			{Name: "physical-channels"},
+			{Name: "channel", Key: map[string]string{"index": index}},
+			{Name: "state"},
+		},
+	}
+	for _, l := range leaf {
+		p.Elem = append(p.Elem, &gnmipb.PathElem{Name: l})
+	}
+	return p
+}
+
+// buildUpdates translates a single native channel value to openconfig updates, with the analog
+// precision. includeIndex is false if the index of the channel is already translated.
+func buildUpdates(path *gnmipb.Path, val *gnmipb.TypedValue, includeIndex bool, precision uint32) ([]*gnmipb.Update, error) {
+	elems := path.GetElem()
+	ocLeaf, ok := channelLeaves[elems[3].GetName()]
+	if !ok {
+		return nil, nil
+	}
+	component := elems[0].GetKey()["name"]
+	if component == "" {
+		return nil, fmt.Errorf("interface without name")
+	}
+	index := elems[2].GetKey()["index"]
+	ix, err := strconv.ParseUint(index, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid channel index %q: %v", index, err)
+	}
+	v, err := ftutilities.ToDouble(val)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", elems[3].GetName(), err)
+	}
+	var updates []*gnmipb.Update
+	if includeIndex {
+		updates = append(updates, &gnmipb.Update{
+			Path: channelPath(component, index, "index"),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: ix}},
+		})
+	}
+	return append(updates, &gnmipb.Update{
+		Path: channelPath(component, index, ocLeaf...),
+		Val:  ftutilities.AnalogValue(v, precision),
+	}), nil
+}
+
+// validateOptions rejects an invalid analog precision.
+func validateOptions(opts translator.Options) error {
+	_, err := ftutilities.AnalogPrecision(opts)
+	return err
+}
+
+func translate(sr *gnmipb.SubscribeResponse, opts translator.Options) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	precision, err := ftutilities.AnalogPrecision(opts)
+	if err != nil {
+		return nil, err
+	}
+	var updates []*gnmipb.Update
+	// The index of a channel is emitted once per notification, even if several of its leaves are.
+	seenIndex := map[string]bool{}
+	for _, u := range n.GetUpdate() {
+		path := ftutilities.Join(n.GetPrefix(), u.GetPath())
+		if path.GetOrigin() != nativeOrigin || !ftutilities.MatchPath(path, channelPattern) {
+			continue
+		}
+		elems := path.GetElem()
+		key := elems[0].GetKey()["name"] + "/" + elems[2].GetKey()["index"]
+		ups, err := buildUpdates(path, u.GetVal(), !seenIndex[key], precision)
+		if err != nil {
+			log.Errorf("Failed to translate update %v: %v", u, err)
+			continue
+		}
+		if len(ups) > 0 {
+			seenIndex[key] = true
+		}
+		updates = append(updates, ups...)
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+			},
+		},
+	}, nil
+}
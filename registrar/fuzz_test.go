@@ -0,0 +1,36 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar
+
+import (
+	"testing"
+
+	"github.com/openconfig/functional-translators/fttest"
+	"github.com/openconfig/functional-translators/registry"
+	"github.com/openconfig/functional-translators/translator"
+)
+
+// FuzzTranslators fuzzes all the registered FTs, seeded with their input paths.
+func FuzzTranslators(f *testing.F) {
+	var fts []*translator.FunctionalTranslator
+	for _, id := range registry.IDs() {
+		ft, err := registry.New(id)
+		if err != nil {
+			f.Fatalf("registry.New(%q) got unexpected error: %v", id, err)
+		}
+		fts = append(fts, ft)
+	}
+	fttest.FuzzAll(f, fts)
+}
@@ -18,59 +18,84 @@ package registrar
 import (
 	"github.com/openconfig/functional-translators/arista/aristacfmpm"
 	"github.com/openconfig/functional-translators/arista/aristacfmstate"
+	"github.com/openconfig/functional-translators/arista/aristadropcounters"
 	"github.com/openconfig/functional-translators/arista/aristainterface"
 	"github.com/openconfig/functional-translators/arista/aristamacseccounters"
 	"github.com/openconfig/functional-translators/arista/aristamacsecstate"
+	"github.com/openconfig/functional-translators/arista/aristapoe"
 	"github.com/openconfig/functional-translators/arista/aristapwstate"
 	"github.com/openconfig/functional-translators/arista/aristaqosaggregatecounters"
+	"github.com/openconfig/functional-translators/arista/aristaqosmaps"
+	"github.com/openconfig/functional-translators/arista/aristaxcvrpresence"
 	"github.com/openconfig/functional-translators/ciscoxr/ciscoxr8000icresource"
 	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrarp"
 	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrcarrier"
+	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrenvmon"
 	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrfabric"
 	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrfpd"
 	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrfragment"
+	"github.com/openconfig/functional-translators/ciscoxr/ciscoxripv4"
 	"github.com/openconfig/functional-translators/ciscoxr/ciscoxripv6"
 	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrlagmac"
-	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrlaser"
 	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrmount"
 	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrpower"
+	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrpsu"
 	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrqos"
+	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrqospolicy"
+	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrsrtepolicy"
 	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrsubcounters"
+	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrswitch"
 	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrtransceiver"
 	"github.com/openconfig/functional-translators/ciscoxr/ciscoxrvendordrops"
 	"github.com/openconfig/functional-translators/ftconsts"
+	"github.com/openconfig/functional-translators/juniper/juniperqueue"
+	"github.com/openconfig/functional-translators/juniper/junipertransceiver"
+	"github.com/openconfig/functional-translators/nokia/nokiatransceiver"
 	"github.com/openconfig/functional-translators/translator"
 )
 
 var (
 	// FunctionalTranslatorRegistry is an eagerly initialized map with all functional translators. All
-	// new functional translator IDs should be added here to be included.
+	// new functional translator IDs should be added here to be included, and registered in the
+	// registry package from the init function of their package.
 	// TODO: Add the remaining functional translators already listed in ftconsts.go when released.
 	FunctionalTranslatorRegistry = map[string]*translator.FunctionalTranslator{
 		// go/keep-sorted start
 		ftconsts.AristaCFMPMFunctionalTranslator:                          aristacfmpm.New(),
 		ftconsts.AristaCfmStateFunctionalTranslator:                       aristacfmstate.New(),
+		ftconsts.AristaDropCountersTranslator:                             aristadropcounters.New(),
 		ftconsts.AristaInterfaceDescriptionFunctionalTranslator:           aristainterface.NewDescFT(),
 		ftconsts.AristaInterfaceMacFunctionalTranslator:                   aristainterface.NewMacFT(),
 		ftconsts.AristaMacsecCountersTranslator:                           aristamacseccounters.New(),
 		ftconsts.AristaMacsecStateFunctionalTranslator:                    aristamacsecstate.New(),
 		ftconsts.AristaPWStateFunctionalTranslator:                        aristapwstate.New(),
+		ftconsts.AristaPoETranslator:                                      aristapoe.New(),
 		ftconsts.AristaQoSAggregateCountersTranslator:                     aristaqosaggregatecounters.New(),
+		ftconsts.AristaQoSMapsTranslator:                                  aristaqosmaps.New(),
+		ftconsts.AristaXcvrPresenceTranslator:                             aristaxcvrpresence.New(),
 		ftconsts.CiscoXR8000IntegratedCircuitResourceFunctionalTranslator: ciscoxr8000icresource.New(),
 		ftconsts.CiscoXRArpTranslator:                                     ciscoxrarp.New(),
 		ftconsts.CiscoXRCarrierTranslator:                                 ciscoxrcarrier.New(),
+		ftconsts.CiscoXREnvmonTranslator:                                  ciscoxrenvmon.New(),
 		ftconsts.CiscoXRFabricTranslator:                                  ciscoxrfabric.New(),
 		ftconsts.CiscoXRFpdTranslator:                                     ciscoxrfpd.New(),
 		ftconsts.CiscoXRFragmentTranslator:                                ciscoxrfragment.New(),
+		ftconsts.CiscoXRIPv4Translator:                                    ciscoxripv4.New(),
 		ftconsts.CiscoXRIPv6Translator:                                    ciscoxripv6.New(),
 		ftconsts.CiscoXRLagMacFunctionalTranslator:                        ciscoxrlagmac.New(),
-		ftconsts.CiscoXRLaserTranslator:                                   ciscoxrlaser.New(),
 		ftconsts.CiscoXRMountTranslator:                                   ciscoxrmount.New(),
+		ftconsts.CiscoXRPowerSupplyTranslator:                             ciscoxrpsu.New(),
 		ftconsts.CiscoXRPowerTranslator:                                   ciscoxrpower.New(),
+		ftconsts.CiscoXRQosPolicyTranslator:                               ciscoxrqospolicy.New(),
 		ftconsts.CiscoXRQosTranslator:                                     ciscoxrqos.New(),
+		ftconsts.CiscoXRSRTEPolicyTranslator:                              ciscoxrsrtepolicy.New(),
 		ftconsts.CiscoXRSubinterfaceCounterTranslator:                     ciscoxrsubcounters.New(),
+		ftconsts.CiscoXRSwitchTranslator:                                  ciscoxrswitch.New(),
 		ftconsts.CiscoXRTransceiverTranslator:                             ciscoxrtransceiver.New(),
 		ftconsts.CiscoXRVendorDropsTranslator:                             ciscoxrvendordrops.New(),
+		ftconsts.JuniperQueueTranslator:                                   juniperqueue.New(),
+		ftconsts.JuniperTransceiverTranslator:                             junipertransceiver.New(),
+		ftconsts.NokiaTransceiverTranslator:                               nokiatransceiver.New(),
 		// go/keep-sorted end
 	}
 )
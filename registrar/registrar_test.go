@@ -1,7 +1,13 @@
 package registrar
 
 import (
+	"maps"
+	"slices"
+	"sort"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/functional-translators/registry"
 )
 
 func TestFTMetadataConsistency(t *testing.T) {
@@ -28,3 +34,79 @@ func TestFTMetadataConsistency(t *testing.T) {
 		}
 	}
 }
+
+func TestRegistryConsistency(t *testing.T) {
+	var want []string
+	for id := range FunctionalTranslatorRegistry {
+		want = append(want, id)
+	}
+	sort.Strings(want)
+	if diff := cmp.Diff(want, registry.IDs()); diff != "" {
+		t.Errorf("registry.IDs() returned an unexpected diff from FunctionalTranslatorRegistry (-want +got): %v", diff)
+	}
+	for _, id := range registry.IDs() {
+		ft, err := registry.New(id)
+		if err != nil {
+			t.Errorf("registry.New(%q) got unexpected error: %v", id, err)
+			continue
+		}
+		if ft.ID() != id {
+			t.Errorf("registry.New(%q) returned FT %s", id, ft.ID())
+		}
+	}
+}
+
+// allowedOverlaps are the output schema paths emitted by two FTs applying to the same devices, by
+// pair of FT IDs in sorted order, which are allowed since the FTs emit them for different list
+// entries, or emit the same values for the same entries.
+var allowedOverlaps = map[[2]string][]string{
+	// The temperatures of the envmon sensor components and of the transceiver components.
+	{"ciscoxr-envmon-ft", "ciscoxr-transceiver-ft"}: {
+		"/openconfig/components/component/state/temperature/instant",
+	},
+	// The primary IPv4 addresses, which ciscoxrsubcounters has always translated and ciscoxripv4
+	// translates the same way, along with the secondary addresses, the address types and deletes.
+	{"ciscoxr-ipv4-ft", "ciscoxr-subinterface-counter-ft"}: {
+		"/openconfig/interfaces/interface/subinterfaces/subinterface/ipv4/addresses/address/state/ip",
+		"/openconfig/interfaces/interface/subinterfaces/subinterface/ipv4/addresses/address/state/prefix-length",
+	},
+}
+
+// TestNoOverlappingOutputs checks that the FTs returned by registry.ForTarget for a device do not
+// emit the same output paths, for the software versions and hardware models of the metadata of the
+// registered FTs.
+func TestNoOverlappingOutputs(t *testing.T) {
+	type device struct{ vendor, swVersion, hwModel string }
+	devices := map[device]bool{}
+	for _, ft := range FunctionalTranslatorRegistry {
+		for _, m := range ft.Metadata() {
+			d := device{vendor: m.Vendor, swVersion: m.SoftwareVersion, hwModel: m.HardwareModel}
+			if m.SoftwareVersionRange != nil {
+				d.swVersion = m.SoftwareVersionRange.InclusiveMin
+			}
+			devices[d] = true
+		}
+	}
+	for d := range devices {
+		fts, err := registry.ForTarget(d.vendor, d.swVersion, d.hwModel)
+		if err != nil {
+			t.Fatalf("registry.ForTarget(%q, %q, %q) got unexpected error: %v", d.vendor, d.swVersion, d.hwModel, err)
+		}
+		// owners maps the output paths to the ID of the first FT emitting them.
+		owners := map[string]string{}
+		for _, ft := range fts {
+			for _, output := range slices.Sorted(maps.Keys(ft.OutputToInputMap())) {
+				owner, ok := owners[output]
+				if !ok {
+					owners[output] = ft.ID()
+					continue
+				}
+				pair := [2]string{owner, ft.ID()}
+				slices.Sort(pair[:])
+				if !slices.Contains(allowedOverlaps[pair], output) {
+					t.Errorf("registry.ForTarget(%q, %q, %q) returned FTs %s and %s both emitting %s", d.vendor, d.swVersion, d.hwModel, owner, ft.ID(), output)
+				}
+			}
+		}
+	}
+}
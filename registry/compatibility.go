@@ -0,0 +1,114 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/openconfig/functional-translators/translator"
+)
+
+// TranslatorCompatibility describes a registered FT applying to a device.
+type TranslatorCompatibility struct {
+	ID      string
+	Version string
+	// HardwareModels are the hardware models the FT is restricted to, empty if it applies to all
+	// the hardware models of the device.
+	HardwareModels []string
+	// OutputPaths are the sorted schema strings of the output paths of the FT.
+	OutputPaths []string
+}
+
+// Compatibility lists the output paths which can be synthesized by the registered FTs for a
+// vendor and software version.
+type Compatibility struct {
+	Vendor          string
+	SoftwareVersion string
+	// Translators are the FTs applying to the software version, sorted by ID.
+	Translators []*TranslatorCompatibility
+	// Unsupported are the sorted output paths provided by FTs of the vendor for other software
+	// versions only, i.e. the coverage gaps of the software version.
+	Unsupported []string
+}
+
+// OutputPaths returns the sorted output paths of all the FTs applying to the software version.
+func (c *Compatibility) OutputPaths() []string {
+	seen := map[string]bool{}
+	var paths []string
+	for _, t := range c.Translators {
+		for _, p := range t.OutputPaths {
+			if !seen[p] {
+				seen[p] = true
+				paths = append(paths, p)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// CompatibilityReport returns the registered FTs applying to devices of the given vendor and
+// software version, whatever their hardware model, with the output paths they can synthesize and
+// those only synthesized for other software versions. It returns an error if one of the FTs
+// cannot be created.
+func CompatibilityReport(vendor, swVersion string) (*Compatibility, error) {
+	c := &Compatibility{Vendor: vendor, SoftwareVersion: swVersion}
+	supported := map[string]bool{}
+	other := map[string]bool{}
+	for _, id := range IDs() {
+		ft, err := New(id)
+		if err != nil {
+			return nil, err
+		}
+		applies, models := ft.HardwareModels(vendor, swVersion)
+		switch {
+		case applies:
+			c.Translators = append(c.Translators, &TranslatorCompatibility{
+				ID:             ft.ID(),
+				Version:        ft.Version(),
+				HardwareModels: models,
+				OutputPaths:    ft.SupportedOutputPaths(),
+			})
+			for _, p := range ft.SupportedOutputPaths() {
+				supported[p] = true
+			}
+		case ofVendor(ft, vendor):
+			for _, p := range ft.SupportedOutputPaths() {
+				other[p] = true
+			}
+		}
+	}
+	for p := range other {
+		if !supported[p] {
+			c.Unsupported = append(c.Unsupported, p)
+		}
+	}
+	sort.Strings(c.Unsupported)
+	return c, nil
+}
+
+// ofVendor returns whether the FT has metadata for the vendor, or no metadata.
+func ofVendor(ft *translator.FunctionalTranslator, vendor string) bool {
+	if len(ft.Metadata()) == 0 {
+		return true
+	}
+	for _, m := range ft.Metadata() {
+		if m.Vendor == "" || strings.EqualFold(m.Vendor, vendor) {
+			return true
+		}
+	}
+	return false
+}
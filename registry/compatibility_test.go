@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	operStatus = "/openconfig/interfaces/interface/state/oper-status"
+	inPkts     = "/openconfig/interfaces/interface/state/counters/in-pkts"
+	outPkts    = "/openconfig/interfaces/interface/state/counters/out-pkts"
+)
+
+func reportFactory(id, version string, metadata []*translator.FTMetadata, outputs ...string) Factory {
+	return func() (*translator.FunctionalTranslator, error) {
+		m := map[string][]string{}
+		for _, o := range outputs {
+			m[o] = []string{"/eos_native/Sysdb/interface/status"}
+		}
+		return translator.NewFunctionalTranslator(translator.FunctionalTranslatorOptions{
+			ID: id,
+			Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+				return nil, nil
+			},
+			OutputToInputMap: ftutilities.MustStringMapPaths(m),
+			Metadata:         metadata,
+			Version:          version,
+		})
+	}
+}
+
+func init() {
+	Register("report-status", reportFactory("report-status", "1.0.0", []*translator.FTMetadata{
+		{Vendor: "report-vendor"},
+	}, operStatus))
+	Register("report-counters", reportFactory("report-counters", "2.1.0", []*translator.FTMetadata{
+		{Vendor: "report-vendor", SoftwareVersionRange: &translator.SWRange{InclusiveMin: "2.0", ExclusiveMax: "3.0"}},
+	}, inPkts, outPkts))
+	Register("report-model", reportFactory("report-model", "", []*translator.FTMetadata{
+		{Vendor: "report-vendor", HardwareModel: "model-a", SoftwareVersionRange: &translator.SWRange{InclusiveMin: "1.0", ExclusiveMax: "3.0"}},
+	}, inPkts))
+}
+
+func TestCompatibilityReport(t *testing.T) {
+	tests := []struct {
+		name      string
+		swVersion string
+		want      *Compatibility
+		wantPaths []string
+	}{
+		{
+			name:      "full_coverage",
+			swVersion: "2.5",
+			want: &Compatibility{
+				Vendor:          "report-vendor",
+				SoftwareVersion: "2.5",
+				Translators: []*TranslatorCompatibility{
+					{ID: "report-counters", Version: "2.1.0", OutputPaths: []string{inPkts, outPkts}},
+					{ID: "report-model", HardwareModels: []string{"model-a"}, OutputPaths: []string{inPkts}},
+					{ID: "report-status", Version: "1.0.0", OutputPaths: []string{operStatus}},
+				},
+			},
+			wantPaths: []string{inPkts, outPkts, operStatus},
+		},
+		{
+			name:      "coverage_gaps",
+			swVersion: "1.5",
+			want: &Compatibility{
+				Vendor:          "report-vendor",
+				SoftwareVersion: "1.5",
+				Translators: []*TranslatorCompatibility{
+					{ID: "report-model", HardwareModels: []string{"model-a"}, OutputPaths: []string{inPkts}},
+					{ID: "report-status", Version: "1.0.0", OutputPaths: []string{operStatus}},
+				},
+				Unsupported: []string{outPkts},
+			},
+			wantPaths: []string{inPkts, operStatus},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := CompatibilityReport("report-vendor", tc.swVersion)
+			if err != nil {
+				t.Fatalf("CompatibilityReport(%q) got unexpected error: %v", tc.swVersion, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("CompatibilityReport(%q) returned an unexpected diff (-want +got): %v", tc.swVersion, diff)
+			}
+			if diff := cmp.Diff(tc.wantPaths, got.OutputPaths()); diff != "" {
+				t.Errorf("OutputPaths() returned an unexpected diff (-want +got): %v", diff)
+			}
+		})
+	}
+}
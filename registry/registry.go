@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registry holds the constructors of the functional translators, which register
+// themselves from the init function of their package. Importing the registrar package links and
+// registers all of them.
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/translator"
+)
+
+// Factory creates a new instance of a functional translator, or returns an error if it cannot be
+// created.
+type Factory func() (*translator.FunctionalTranslator, error)
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+// Register registers the constructor of the FT with the given ID. It must be called once per ID,
+// from the init function of the package of the FT.
+func Register(id string, f Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := factories[id]; ok {
+		log.Fatalf("Functional translator %s is registered twice", id)
+	}
+	factories[id] = f
+}
+
+// IDs returns the sorted IDs of the registered FTs.
+func IDs() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	ids := make([]string, 0, len(factories))
+	for id := range factories {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// New returns a new instance of the registered FT with the given ID. It returns an error if no FT
+// is registered with the ID or if the FT cannot be created.
+func New(id string) (*translator.FunctionalTranslator, error) {
+	mu.RLock()
+	f, ok := factories[id]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no functional translator registered with ID %q", id)
+	}
+	ft, err := f()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create functional translator %s: %v", id, err)
+	}
+	return ft, nil
+}
+
+// ForTarget returns new instances of the registered FTs applying to a device of the given vendor,
+// software version and hardware model, sorted by ID. Since the instances are new, their state is
+// not shared with the instances returned by other calls. It returns an error if one of the FTs
+// cannot be created.
+func ForTarget(vendor, swVersion, hwModel string) ([]*translator.FunctionalTranslator, error) {
+	device := &translator.DeviceMetadata{
+		Vendor:          vendor,
+		SoftwareVersion: swVersion,
+		HardwareModel:   hwModel,
+	}
+	var fts []*translator.FunctionalTranslator
+	for _, id := range IDs() {
+		ft, err := New(id)
+		if err != nil {
+			return nil, err
+		}
+		if ft.Supports(device) {
+			fts = append(fts, ft)
+		}
+	}
+	return fts, nil
+}
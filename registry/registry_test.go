@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/functional-translators/translator"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func testFactory(id, vendor string, swRange *translator.SWRange) Factory {
+	return func() (*translator.FunctionalTranslator, error) {
+		return translator.NewFunctionalTranslator(translator.FunctionalTranslatorOptions{
+			ID: id,
+			Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+				return nil, nil
+			},
+			Metadata: []*translator.FTMetadata{{Vendor: vendor, SoftwareVersionRange: swRange}},
+		})
+	}
+}
+
+func init() {
+	Register("test-b", testFactory("test-b", "test-vendor", nil))
+	Register("test-a", testFactory("test-a", "test-vendor", &translator.SWRange{InclusiveMin: "1.0", ExclusiveMax: "2.0"}))
+	Register("test-c", testFactory("test-c", "other-vendor", nil))
+}
+
+func TestForTarget(t *testing.T) {
+	tests := []struct {
+		name      string
+		vendor    string
+		swVersion string
+		want      []string
+	}{
+		{
+			name:      "all_versions",
+			vendor:    "test-vendor",
+			swVersion: "1.5",
+			want:      []string{"test-a", "test-b"},
+		},
+		{
+			name:      "out_of_range",
+			vendor:    "test-vendor",
+			swVersion: "2.1",
+			want:      []string{"test-b"},
+		},
+		{
+			name:   "unknown_vendor",
+			vendor: "unknown",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fts, err := ForTarget(tc.vendor, tc.swVersion, "")
+			if err != nil {
+				t.Fatalf("ForTarget(%q, %q) got unexpected error: %v", tc.vendor, tc.swVersion, err)
+			}
+			var got []string
+			for _, ft := range fts {
+				got = append(got, ft.ID())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ForTarget(%q, %q) returned an unexpected diff (-want +got): %v", tc.vendor, tc.swVersion, diff)
+			}
+		})
+	}
+}
+
+func TestForTargetNewInstances(t *testing.T) {
+	first, err := ForTarget("test-vendor", "1.5", "")
+	if err != nil {
+		t.Fatalf("ForTarget() got unexpected error: %v", err)
+	}
+	second, err := ForTarget("test-vendor", "1.5", "")
+	if err != nil {
+		t.Fatalf("ForTarget() got unexpected error: %v", err)
+	}
+	for i := range first {
+		if first[i] == second[i] {
+			t.Errorf("ForTarget() returned the instance of %s twice", first[i].ID())
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	if ft, err := New("test-a"); err != nil || ft.ID() != "test-a" {
+		t.Errorf("New(%q) = %v, %v, want test-a FT", "test-a", ft, err)
+	}
+	if _, err := New("unknown"); err == nil {
+		t.Errorf("New(%q) got nil error, want error", "unknown")
+	}
+}
+
+func TestFactoryError(t *testing.T) {
+	Register("test-error", func() (*translator.FunctionalTranslator, error) {
+		return nil, errors.New("cannot create")
+	})
+	defer func() {
+		mu.Lock()
+		delete(factories, "test-error")
+		mu.Unlock()
+	}()
+	if _, err := New("test-error"); err == nil {
+		t.Errorf("New(%q) got nil error, want error", "test-error")
+	}
+	if _, err := ForTarget("test-vendor", "1.5", ""); err == nil {
+		t.Errorf("ForTarget() got nil error, want error")
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplemapper
+
+import (
+	"fmt"
+
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Combiner combines the values of the input leaves of an aggregation into the value of the output
+// leaf. It is called with at least one value.
+type Combiner func(vals []*gnmipb.TypedValue) (*gnmipb.TypedValue, error)
+
+type aggregation struct {
+	inputs  []*gnmipb.Path
+	output  *gnmipb.Path
+	combine Combiner
+}
+
+// numericKind is the type of the result of a numeric combiner, which is the narrowest type able
+// to represent all of its inputs.
+type numericKind int
+
+const (
+	kindUint numericKind = iota
+	kindInt
+	kindDouble
+)
+
+func numericKindOf(vals []*gnmipb.TypedValue) (numericKind, error) {
+	kind := kindUint
+	for _, v := range vals {
+		switch t := v.GetValue().(type) {
+		case *gnmipb.TypedValue_UintVal:
+		case *gnmipb.TypedValue_IntVal:
+			kind = max(kind, kindInt)
+		case *gnmipb.TypedValue_DoubleVal, *gnmipb.TypedValue_FloatVal:
+			kind = kindDouble
+		default:
+			return 0, fmt.Errorf("cannot combine non numeric value type %T", t)
+		}
+	}
+	return kind, nil
+}
+
+func toInt(v *gnmipb.TypedValue) int64 {
+	if u, ok := v.GetValue().(*gnmipb.TypedValue_UintVal); ok {
+		return int64(u.UintVal)
+	}
+	return v.GetIntVal()
+}
+
+func toDouble(v *gnmipb.TypedValue) float64 {
+	switch t := v.GetValue().(type) {
+	case *gnmipb.TypedValue_UintVal:
+		return float64(t.UintVal)
+	case *gnmipb.TypedValue_IntVal:
+		return float64(t.IntVal)
+	case *gnmipb.TypedValue_FloatVal:
+		return float64(t.FloatVal)
+	default:
+		return v.GetDoubleVal()
+	}
+}
+
+// Sum is a Combiner returning the sum of numeric values, e.g. the total drops of the queues of an
+// interface. The result is unsigned if all the values are, and a double if any value is.
+func Sum(vals []*gnmipb.TypedValue) (*gnmipb.TypedValue, error) {
+	kind, err := numericKindOf(vals)
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case kindUint:
+		var sum uint64
+		for _, v := range vals {
+			sum += v.GetUintVal()
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: sum}}, nil
+	case kindInt:
+		var sum int64
+		for _, v := range vals {
+			sum += toInt(v)
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: sum}}, nil
+	default:
+		var sum float64
+		for _, v := range vals {
+			sum += toDouble(v)
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: sum}}, nil
+	}
+}
+
+// Max is a Combiner returning the largest of numeric values. The result type follows Sum.
+func Max(vals []*gnmipb.TypedValue) (*gnmipb.TypedValue, error) {
+	kind, err := numericKindOf(vals)
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case kindUint:
+		m := vals[0].GetUintVal()
+		for _, v := range vals[1:] {
+			m = max(m, v.GetUintVal())
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: m}}, nil
+	case kindInt:
+		m := toInt(vals[0])
+		for _, v := range vals[1:] {
+			m = max(m, toInt(v))
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: m}}, nil
+	default:
+		m := toDouble(vals[0])
+		for _, v := range vals[1:] {
+			m = max(m, toDouble(v))
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: m}}, nil
+	}
+}
+
+// And is a Combiner returning the logical AND of bool values.
+func And(vals []*gnmipb.TypedValue) (*gnmipb.TypedValue, error) {
+	result := true
+	for _, v := range vals {
+		b, ok := v.GetValue().(*gnmipb.TypedValue_BoolVal)
+		if !ok {
+			return nil, fmt.Errorf("cannot AND non bool value type %T", v.GetValue())
+		}
+		result = result && b.BoolVal
+	}
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: result}}, nil
+}
+
+// matchBind returns the variable bindings of a leaf path if it matches an aggregation input. Key
+// values of the input may be variables, "*" or constants.
+func matchBind(input, path *gnmipb.Path) (map[string]string, bool) {
+	if len(input.GetElem()) != len(path.GetElem()) {
+		return nil, false
+	}
+	bindings := map[string]string{}
+	for i, ie := range input.GetElem() {
+		e := path.GetElem()[i]
+		if ie.GetName() != e.GetName() {
+			return nil, false
+		}
+		for k, v := range ie.GetKey() {
+			got, ok := e.GetKey()[k]
+			switch {
+			case !ok:
+				return nil, false
+			case isVar(v):
+				bindings[v] = got
+			case v != "*" && v != got:
+				return nil, false
+			}
+		}
+	}
+	return bindings, true
+}
+
+// apply combines the input leaves set in the input schema root and sets the output leaves in
+// outRoot. The set leaves are enumerated rather than looked up with wildcards, since a wildcard
+// lookup fails if any of the list entries it matches does not have the leaf.
+func (a *aggregation) apply(inSchema, outSchema *ytypes.Schema, outRoot ygot.GoStruct) error {
+	notifications, err := ygot.TogNMINotifications(inSchema.Root, 0, ygot.GNMINotificationsConfig{UsePathElem: true})
+	if err != nil {
+		return fmt.Errorf("failed to convert aggregation inputs: %v", err)
+	}
+	// Values are grouped by output path, in the order the output paths are first bound.
+	var order []string
+	groups := map[string][]*gnmipb.TypedValue{}
+	outPaths := map[string]*gnmipb.Path{}
+	for _, n := range notifications {
+		for _, u := range n.GetUpdate() {
+			for _, input := range a.inputs {
+				bindings, ok := matchBind(input, u.GetPath())
+				if !ok {
+					continue
+				}
+				outPath, err := applyBind(bindings, a.output)
+				if err != nil {
+					return fmt.Errorf("failed to apply bindings to aggregation output path: %v", err)
+				}
+				key, err := ygot.PathToString(outPath)
+				if err != nil {
+					return fmt.Errorf("invalid aggregation output path: %v", err)
+				}
+				if _, ok := groups[key]; !ok {
+					order = append(order, key)
+					outPaths[key] = outPath
+				}
+				groups[key] = append(groups[key], u.GetVal())
+			}
+		}
+	}
+	for _, key := range order {
+		val, err := a.combine(groups[key])
+		if err != nil {
+			return fmt.Errorf("failed to combine values of %s: %v", key, err)
+		}
+		if _, _, err := ytypes.GetOrCreateNode(outSchema.RootSchema(), outRoot, outPaths[key]); err != nil {
+			return fmt.Errorf("failed to get or create node for aggregation output path: %v", err)
+		}
+		if err := ytypes.SetNode(outSchema.RootSchema(), outRoot, outPaths[key], val); err != nil {
+			return fmt.Errorf("failed to set node for aggregation output path: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplemapper
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/functional-translators/arista/aristainterface/yang/openconfig"
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func uintVal(v uint64) *gnmipb.TypedValue {
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}}
+}
+
+func intVal(v int64) *gnmipb.TypedValue {
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: v}}
+}
+
+func doubleVal(v float64) *gnmipb.TypedValue {
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_DoubleVal{DoubleVal: v}}
+}
+
+func boolVal(v bool) *gnmipb.TypedValue {
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: v}}
+}
+
+func TestCombiners(t *testing.T) {
+	tests := []struct {
+		name    string
+		combine Combiner
+		vals    []*gnmipb.TypedValue
+		want    *gnmipb.TypedValue
+		wantErr bool
+	}{
+		{
+			name:    "sum uint",
+			combine: Sum,
+			vals:    []*gnmipb.TypedValue{uintVal(1), uintVal(2), uintVal(3)},
+			want:    uintVal(6),
+		},
+		{
+			name:    "sum int",
+			combine: Sum,
+			vals:    []*gnmipb.TypedValue{uintVal(1), intVal(-3)},
+			want:    intVal(-2),
+		},
+		{
+			name:    "sum double",
+			combine: Sum,
+			vals:    []*gnmipb.TypedValue{uintVal(1), doubleVal(0.5)},
+			want:    doubleVal(1.5),
+		},
+		{
+			name:    "sum non numeric",
+			combine: Sum,
+			vals:    []*gnmipb.TypedValue{uintVal(1), boolVal(true)},
+			wantErr: true,
+		},
+		{
+			name:    "max uint",
+			combine: Max,
+			vals:    []*gnmipb.TypedValue{uintVal(4), uintVal(9), uintVal(2)},
+			want:    uintVal(9),
+		},
+		{
+			name:    "max int",
+			combine: Max,
+			vals:    []*gnmipb.TypedValue{intVal(-4), intVal(-9)},
+			want:    intVal(-4),
+		},
+		{
+			name:    "max double",
+			combine: Max,
+			vals:    []*gnmipb.TypedValue{doubleVal(-1.5), uintVal(0)},
+			want:    doubleVal(0),
+		},
+		{
+			name:    "and true",
+			combine: And,
+			vals:    []*gnmipb.TypedValue{boolVal(true), boolVal(true)},
+			want:    boolVal(true),
+		},
+		{
+			name:    "and false",
+			combine: And,
+			vals:    []*gnmipb.TypedValue{boolVal(true), boolVal(false)},
+			want:    boolVal(false),
+		},
+		{
+			name:    "and non bool",
+			combine: And,
+			vals:    []*gnmipb.TypedValue{boolVal(true), uintVal(1)},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.combine(tc.vals)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("combine(%v) got error %v, want error: %t", tc.vals, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("combine(%v) returned an unexpected diff (-want +got): %v", tc.vals, diff)
+			}
+		})
+	}
+}
+
+func ocPath(elems ...*gnmipb.PathElem) *gnmipb.Path {
+	return &gnmipb.Path{Elem: elems}
+}
+
+func TestHandlerAggregations(t *testing.T) {
+	intf := func(name string) []*gnmipb.PathElem {
+		return []*gnmipb.PathElem{{Name: "interfaces"}, {Name: "interface", Key: map[string]string{"name": name}}}
+	}
+	lacpIntf := func(name string) []*gnmipb.PathElem {
+		return []*gnmipb.PathElem{{Name: "lacp"}, {Name: "interfaces"}, {Name: "interface", Key: map[string]string{"name": name}}}
+	}
+	leaf := func(prefix []*gnmipb.PathElem, names ...string) *gnmipb.Path {
+		p := ocPath(prefix...)
+		for _, n := range names {
+			p.Elem = append(p.Elem, &gnmipb.PathElem{Name: n})
+		}
+		return p
+	}
+
+	m, err := NewSimpleMapper(openconfig.Schema, openconfig.Schema, nil,
+		func(*gnmipb.Notification) ([]*gnmipb.Path, error) { return nil, nil },
+		WithAggregation("/openconfig/interfaces/interface[name=<intf>]/state/counters/resets", Sum,
+			"/openconfig/interfaces/interface[name=<intf>]/ethernet/state/counters/in-crc-errors",
+			"/openconfig/interfaces/interface[name=<intf>]/ethernet/state/counters/in-symbol-error",
+		),
+		WithAggregation("/openconfig/interfaces/interface[name=all]/state/counters/link-transitions", Max,
+			"/openconfig/interfaces/interface[name=*]/state/counters/interface-transitions",
+		),
+		WithAggregation("/openconfig/lacp/interfaces/interface[name=<lag>]/state/fallback", And,
+			"/openconfig/lacp/interfaces/interface[name=<lag>]/config/fallback",
+			"/openconfig/lacp/interfaces/interface[name=<lag>]/state/fallback",
+		),
+	)
+	if err != nil {
+		t.Fatalf("NewSimpleMapper() returned an unexpected error: %v", err)
+	}
+	wantSchemas := map[string][]string{
+		"/openconfig/interfaces/interface/state/counters/resets": {
+			"/openconfig/interfaces/interface/ethernet/state/counters/in-crc-errors",
+			"/openconfig/interfaces/interface/ethernet/state/counters/in-symbol-error",
+		},
+		"/openconfig/interfaces/interface/state/counters/link-transitions": {
+			"/openconfig/interfaces/interface/state/counters/interface-transitions",
+		},
+		"/openconfig/lacp/interfaces/interface/state/fallback": {
+			"/openconfig/lacp/interfaces/interface/config/fallback",
+			"/openconfig/lacp/interfaces/interface/state/fallback",
+		},
+	}
+	if diff := cmp.Diff(wantSchemas, m.OutputToInputSchemaStrings()); diff != "" {
+		t.Errorf("OutputToInputSchemaStrings() returned an unexpected diff (-want +got): %v", diff)
+	}
+
+	input := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 42,
+				Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "dut"},
+				Update: []*gnmipb.Update{
+					{Path: leaf(intf("Ethernet1"), "ethernet", "state", "counters", "in-crc-errors"), Val: uintVal(3)},
+					{Path: leaf(intf("Ethernet1"), "ethernet", "state", "counters", "in-symbol-error"), Val: uintVal(4)},
+					{Path: leaf(intf("Ethernet1"), "state", "counters", "interface-transitions"), Val: uintVal(5)},
+					{Path: leaf(intf("Ethernet2"), "state", "counters", "interface-transitions"), Val: uintVal(7)},
+					{Path: leaf(lacpIntf("Port-Channel1"), "config", "fallback"), Val: boolVal(true)},
+					{Path: leaf(lacpIntf("Port-Channel1"), "state", "fallback"), Val: boolVal(false)},
+				},
+			},
+		},
+	}
+	want := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 42,
+				Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "dut"},
+				Update: []*gnmipb.Update{
+					{Path: leaf(intf("Ethernet1"), "state", "counters", "resets"), Val: uintVal(7)},
+					{Path: leaf(intf("all"), "state", "counters", "link-transitions"), Val: uintVal(7)},
+					{Path: leaf(lacpIntf("Port-Channel1"), "state", "fallback"), Val: boolVal(false)},
+				},
+			},
+		},
+	}
+	got, err := m.Handler(input)
+	if err != nil {
+		t.Fatalf("Handler() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update")); diff != "" {
+		t.Errorf("Handler() returned an unexpected diff (-want +got): %v", diff)
+	}
+}
+
+func TestWithAggregationErrors(t *testing.T) {
+	deleteHandler := func(*gnmipb.Notification) ([]*gnmipb.Path, error) { return nil, nil }
+	for _, opt := range []Option{
+		WithAggregation("/openconfig/interfaces/interface[name=<intf>]/state/counters/resets", nil,
+			"/openconfig/interfaces/interface[name=<intf>]/ethernet/state/counters/in-crc-errors"),
+		WithAggregation("/openconfig/interfaces/interface[name=<intf>]/state/counters/resets", Sum),
+	} {
+		if _, err := NewSimpleMapper(openconfig.Schema, openconfig.Schema, nil, deleteHandler, opt); err == nil {
+			t.Errorf("NewSimpleMapper() with an invalid aggregation returned nil error, want error")
+		}
+	}
+}
@@ -0,0 +1,350 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplemapper
+
+import (
+	"fmt"
+	"sort"
+
+	log "github.com/openconfig/functional-translators/ftlog"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/functional-translators/translator"
+	"github.com/openconfig/ygot/ygot"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Transform converts the value of a mapped or renamed leaf. A nil value drops the leaf.
+type Transform func(*gnmipb.TypedValue) (*gnmipb.TypedValue, error)
+
+// Rename maps the leaves matching an input pattern to an output path template.
+type Rename struct {
+	// Input is the pattern of the input leaves, with its origin as first element, e.g.
+	// "/eos_native/Sysdb/interface/status/eth/phy/slice/1/intfStatus/<ifname>/mtu". Element names
+	// and key values of the form "<var>" match any value and bind it to the variable, and "*"
+	// matches any value; other key values must be equal.
+	Input string
+	// Output is the template of the output leaf, e.g.
+	// "/openconfig/interfaces/interface[name=<ifname>]/state/mtu". Its origin defaults to
+	// openconfig, and its key values may use the variables bound by Input.
+	Output string
+	// Transform, if set, converts the input values. The values are copied unchanged otherwise.
+	Transform Transform
+}
+
+// RenameAggregation maps several input leaves to a single output leaf, as WithAggregation does for
+// NewSimpleMapper.
+type RenameAggregation struct {
+	// Inputs are the patterns of the input leaves, as Rename.Input. Each of them must bind all the
+	// variables of Output.
+	Inputs []string
+	// Output is the template of the output leaf, as Rename.Output.
+	Output string
+	// Combine combines the values of the input leaves of a notification which bind the same output
+	// path, e.g. Sum, Max or And.
+	Combine Combiner
+}
+
+// RenameOptions contains the options of an FT created by NewRenameTranslator.
+type RenameOptions struct {
+	ID           string
+	Metadata     []*translator.FTMetadata
+	Renames      []Rename
+	Aggregations []RenameAggregation
+}
+
+// compiledAggregation is a RenameAggregation with its paths parsed.
+type compiledAggregation struct {
+	inputs  []*compiledRename
+	output  *gnmipb.Path
+	combine Combiner
+}
+
+// compiledRename is a Rename with its paths parsed.
+type compiledRename struct {
+	input     *gnmipb.Path
+	output    *gnmipb.Path
+	transform Transform
+}
+
+// NewRenameTranslator returns an FT translating each leaf matching the input of a rename to the
+// output path of the rename, its variables substituted with the values bound by the input. A leaf
+// matching several renames is translated by each of them. Deletes of leaves matching an input are
+// translated to deletes of the output path, other deletes are ignored. The aggregations combine
+// the values of their input leaves of a notification, and ignore deletes. Unlike NewSimpleMapper,
+// it needs no ygot schema, so it suits the trivial per-leaf renames of native paths.
+func NewRenameTranslator(opts RenameOptions) (*translator.FunctionalTranslator, error) {
+	if len(opts.Renames) == 0 && len(opts.Aggregations) == 0 {
+		return nil, fmt.Errorf("%s has no renames or aggregations", opts.ID)
+	}
+	r := &renamer{}
+	outputToInput := map[string][]*gnmipb.Path{}
+	for _, rn := range opts.Renames {
+		c, err := compileRename(rn)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", opts.ID, err)
+		}
+		r.renames = append(r.renames, c)
+		out := ftutilities.GNMIPathToSchemaString(c.output, true)
+		outputToInput[out] = append(outputToInput[out], varsToWildcardElems(c.input))
+	}
+	for _, a := range opts.Aggregations {
+		c, err := compileAggregation(a)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", opts.ID, err)
+		}
+		r.aggregations = append(r.aggregations, c)
+		out := ftutilities.GNMIPathToSchemaString(c.output, true)
+		for _, in := range c.inputs {
+			outputToInput[out] = append(outputToInput[out], varsToWildcardElems(in.input))
+		}
+	}
+	for _, ins := range outputToInput {
+		sort.Slice(ins, ftutilities.SortByYgotString(ins))
+	}
+	return translator.NewFunctionalTranslator(translator.FunctionalTranslatorOptions{
+		ID:               opts.ID,
+		Translate:        r.translate,
+		OutputToInputMap: outputToInput,
+		Metadata:         opts.Metadata,
+	})
+}
+
+func compileRename(rn Rename) (*compiledRename, error) {
+	in, _, err := parseMapperPath(rn.Input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input %q: %v", rn.Input, err)
+	}
+	if in.GetOrigin() == "" {
+		return nil, fmt.Errorf("input %q has no valid origin", rn.Input)
+	}
+	out, _, err := parseMapperPath(rn.Output)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output %q: %v", rn.Output, err)
+	}
+	if out.GetOrigin() == "" {
+		out.Origin = "openconfig"
+	}
+	bound := map[string]bool{}
+	for _, e := range in.GetElem() {
+		if isVar(e.GetName()) {
+			bound[e.GetName()] = true
+		}
+		for _, v := range e.GetKey() {
+			if isVar(v) {
+				bound[v] = true
+			}
+		}
+	}
+	for _, e := range out.GetElem() {
+		if isVar(e.GetName()) {
+			return nil, fmt.Errorf("output %q has variable element name %q", rn.Output, e.GetName())
+		}
+		for _, v := range e.GetKey() {
+			if isVar(v) && !bound[v] {
+				return nil, fmt.Errorf("output %q uses variable %q not bound by input %q", rn.Output, v, rn.Input)
+			}
+		}
+	}
+	return &compiledRename{input: in, output: out, transform: rn.Transform}, nil
+}
+
+func compileAggregation(a RenameAggregation) (*compiledAggregation, error) {
+	if a.Combine == nil || len(a.Inputs) == 0 {
+		return nil, fmt.Errorf("aggregation to %q must have a combiner and at least one input", a.Output)
+	}
+	c := &compiledAggregation{combine: a.Combine}
+	for _, in := range a.Inputs {
+		// Each input is checked to bind the variables of the output.
+		rn, err := compileRename(Rename{Input: in, Output: a.Output})
+		if err != nil {
+			return nil, err
+		}
+		c.inputs = append(c.inputs, rn)
+		c.output = rn.output
+	}
+	return c, nil
+}
+
+// varsToWildcardElems returns the path with its variable element names and key values replaced
+// by "*".
+func varsToWildcardElems(path *gnmipb.Path) *gnmipb.Path {
+	ret := varsToWildcards(path)
+	for _, e := range ret.GetElem() {
+		if isVar(e.GetName()) {
+			e.Name = "*"
+		}
+	}
+	return ret
+}
+
+// bindPattern matches a path against a rename input and returns the bound variables.
+func bindPattern(pattern, path *gnmipb.Path) (map[string]string, bool) {
+	if pattern.GetOrigin() != path.GetOrigin() || len(pattern.GetElem()) != len(path.GetElem()) {
+		return nil, false
+	}
+	bindings := map[string]string{}
+	bind := func(v, got string) bool {
+		switch {
+		case v == "*":
+		case isVar(v):
+			if b, ok := bindings[v]; ok && b != got {
+				return false
+			}
+			bindings[v] = got
+		default:
+			return v == got
+		}
+		return true
+	}
+	for i, pe := range pattern.GetElem() {
+		e := path.GetElem()[i]
+		if !bind(pe.GetName(), e.GetName()) {
+			return nil, false
+		}
+		for k, v := range pe.GetKey() {
+			got, ok := e.GetKey()[k]
+			if !ok || !bind(v, got) {
+				return nil, false
+			}
+		}
+	}
+	return bindings, true
+}
+
+type renamer struct {
+	renames      []*compiledRename
+	aggregations []*compiledAggregation
+}
+
+// outputPath returns the output path of the rename for the path, if the path matches its input.
+func (c *compiledRename) outputPath(path *gnmipb.Path) (*gnmipb.Path, bool, error) {
+	bindings, ok := bindPattern(c.input, path)
+	if !ok {
+		return nil, false, nil
+	}
+	out, err := applyBind(bindings, c.output)
+	if err != nil {
+		return nil, false, err
+	}
+	out.Origin = ""
+	return out, true, nil
+}
+
+func (r *renamer) translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	var updates []*gnmipb.Update
+	var deletes []*gnmipb.Path
+	for _, u := range n.GetUpdate() {
+		path := ftutilities.Join(n.GetPrefix(), u.GetPath())
+		for _, c := range r.renames {
+			out, ok, err := c.outputPath(path)
+			if err != nil {
+				log.Errorf("Failed to rename update %v: %v", u, err)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			val := u.GetVal()
+			if c.transform != nil {
+				if val, err = c.transform(val); err != nil {
+					log.Errorf("Failed to transform update %v: %v", u, err)
+					continue
+				}
+				if val == nil {
+					continue
+				}
+			}
+			updates = append(updates, &gnmipb.Update{Path: out, Val: val})
+		}
+	}
+	for _, a := range r.aggregations {
+		updates = append(updates, a.combineUpdates(n)...)
+	}
+	for _, d := range n.GetDelete() {
+		path := ftutilities.Join(n.GetPrefix(), d)
+		for _, c := range r.renames {
+			out, ok, err := c.outputPath(path)
+			if err != nil {
+				log.Errorf("Failed to rename delete %v: %v", d, err)
+				continue
+			}
+			if ok {
+				deletes = append(deletes, out)
+			}
+		}
+	}
+	if len(updates) == 0 && len(deletes) == 0 {
+		return nil, nil
+	}
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix: &gnmipb.Path{
+					Origin: "openconfig",
+					Target: n.GetPrefix().GetTarget(),
+				},
+				Update: updates,
+				Delete: deletes,
+			},
+		},
+	}, nil
+}
+
+// combineUpdates returns the output leaves of the aggregation for the updates of the notification,
+// in the order their output paths are first bound.
+func (a *compiledAggregation) combineUpdates(n *gnmipb.Notification) []*gnmipb.Update {
+	var order []string
+	groups := map[string][]*gnmipb.TypedValue{}
+	outPaths := map[string]*gnmipb.Path{}
+	for _, u := range n.GetUpdate() {
+		path := ftutilities.Join(n.GetPrefix(), u.GetPath())
+		for _, in := range a.inputs {
+			out, ok, err := in.outputPath(path)
+			if err != nil {
+				log.Errorf("Failed to aggregate update %v: %v", u, err)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			key, err := ygot.PathToString(out)
+			if err != nil {
+				log.Errorf("Failed to aggregate update %v: invalid output path: %v", u, err)
+				continue
+			}
+			if _, ok := groups[key]; !ok {
+				order = append(order, key)
+				outPaths[key] = out
+			}
+			groups[key] = append(groups[key], u.GetVal())
+		}
+	}
+	var updates []*gnmipb.Update
+	for _, key := range order {
+		val, err := a.combine(groups[key])
+		if err != nil {
+			log.Errorf("Failed to combine values of %s: %v", key, err)
+			continue
+		}
+		updates = append(updates, &gnmipb.Update{Path: outPaths[key], Val: val})
+	}
+	return updates
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplemapper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// mustRenamePath parses a path, its first element being its origin if it is a valid origin.
+func mustRenamePath(t *testing.T, s string) *gnmipb.Path {
+	t.Helper()
+	p, _, err := parseMapperPath(s)
+	if err != nil {
+		t.Fatalf("parseMapperPath(%q) got unexpected error: %v", s, err)
+	}
+	return p
+}
+
+func TestNewRenameTranslatorErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		renames []Rename
+	}{
+		{name: "no renames"},
+		{
+			name:    "input without origin",
+			renames: []Rename{{Input: "/interfaces/interface[name=<n>]/config/mtu", Output: "/interfaces/interface[name=<n>]/state/mtu"}},
+		},
+		{
+			name:    "unbound variable",
+			renames: []Rename{{Input: "/eos_native/Sysdb/intf/<n>/mtu", Output: "/interfaces/interface[name=<m>]/state/mtu"}},
+		},
+		{
+			name:    "variable output element",
+			renames: []Rename{{Input: "/eos_native/Sysdb/intf/<n>/mtu", Output: "/interfaces/<n>/state/mtu"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewRenameTranslator(RenameOptions{ID: "test-ft", Renames: tc.renames}); err == nil {
+				t.Errorf("NewRenameTranslator() got no error, want error")
+			}
+		})
+	}
+}
+
+func TestRenameTranslator(t *testing.T) {
+	ft, err := NewRenameTranslator(RenameOptions{
+		ID: "test-ft",
+		Renames: []Rename{
+			{
+				Input:  "/eos_native/Sysdb/interface/status/<ifname>/mtu",
+				Output: "/interfaces/interface[name=<ifname>]/state/mtu",
+			},
+			{
+				Input:  "/eos_native/Sysdb/interface/status/<ifname>/speed",
+				Output: "/openconfig/interfaces/interface[name=<ifname>]/ethernet/state/port-speed",
+				Transform: func(v *gnmipb.TypedValue) (*gnmipb.TypedValue, error) {
+					switch v.GetUintVal() {
+					case 0:
+						return nil, nil
+					case 100:
+						return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "SPEED_100GB"}}, nil
+					}
+					return nil, fmt.Errorf("unsupported speed %d", v.GetUintVal())
+				},
+			},
+			{
+				Input:  "/eos_native/Sysdb/queue[intf=<ifname>][id=<q>]/pkts",
+				Output: "/qos/interfaces/interface[interface-id=<ifname>]/output/queues/queue[name=<q>]/state/transmit-pkts",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewRenameTranslator() got unexpected error: %v", err)
+	}
+	wantMap := map[string][]*gnmipb.Path{
+		"/openconfig/interfaces/interface/state/mtu":                                   {mustRenamePath(t, "/eos_native/Sysdb/interface/status/*/mtu")},
+		"/openconfig/interfaces/interface/ethernet/state/port-speed":                   {mustRenamePath(t, "/eos_native/Sysdb/interface/status/*/speed")},
+		"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts": {mustRenamePath(t, "/eos_native/Sysdb/queue[intf=*][id=*]/pkts")},
+	}
+	if diff := cmp.Diff(wantMap, ft.OutputToInputMap(), protocmp.Transform()); diff != "" {
+		t.Errorf("OutputToInputMap() returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	input := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 42,
+				Prefix:    &gnmipb.Path{Origin: "eos_native", Target: "dut", Elem: []*gnmipb.PathElem{{Name: "Sysdb"}}},
+				Update: []*gnmipb.Update{
+					{Path: mustRenamePath(t, "/interface/status/Ethernet1/mtu"), Val: uintVal(9000)},
+					{Path: mustRenamePath(t, "/interface/status/Ethernet1/speed"), Val: uintVal(100)},
+					{Path: mustRenamePath(t, "/interface/status/Ethernet2/speed"), Val: uintVal(0)},
+					{Path: mustRenamePath(t, "/interface/status/Ethernet3/speed"), Val: uintVal(7)},
+					{Path: mustRenamePath(t, "/interface/status/Ethernet1/duplex"), Val: uintVal(1)},
+					{Path: mustRenamePath(t, "/queue[intf=Ethernet1][id=3]/pkts"), Val: uintVal(12)},
+				},
+				Delete: []*gnmipb.Path{
+					mustRenamePath(t, "/interface/status/Ethernet4/mtu"),
+					mustRenamePath(t, "/interface/status/Ethernet4"),
+				},
+			},
+		},
+	}
+	want := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 42,
+				Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "dut"},
+				Update: []*gnmipb.Update{
+					{Path: mustRenamePath(t, "/interfaces/interface[name=Ethernet1]/state/mtu"), Val: uintVal(9000)},
+					{
+						Path: mustRenamePath(t, "/interfaces/interface[name=Ethernet1]/ethernet/state/port-speed"),
+						Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "SPEED_100GB"}},
+					},
+					{Path: mustRenamePath(t, "/qos/interfaces/interface[interface-id=Ethernet1]/output/queues/queue[name=3]/state/transmit-pkts"), Val: uintVal(12)},
+				},
+				Delete: []*gnmipb.Path{mustRenamePath(t, "/interfaces/interface[name=Ethernet4]/state/mtu")},
+			},
+		},
+	}
+	got, err := ft.Translate(input)
+	if err != nil {
+		t.Fatalf("Translate() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Translate() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestRenameTranslatorAggregations(t *testing.T) {
+	ft, err := NewRenameTranslator(RenameOptions{
+		ID: "test-ft",
+		Aggregations: []RenameAggregation{
+			{
+				Inputs: []string{
+					"/eos_native/Sysdb/macsec/status/cpStatus/<intf>/controlledPortEnabled",
+					"/eos_native/Sysdb/macsec/mkaStatus/portStatus/<intf>/success",
+				},
+				Output:  "/macsec/interfaces/interface[name=<intf>]/state/status",
+				Combine: And,
+			},
+			{
+				Inputs:  []string{"/eos_native/Sysdb/queue[intf=<intf>][id=*]/drops"},
+				Output:  "/interfaces/interface[name=<intf>]/state/counters/out-discards",
+				Combine: Sum,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewRenameTranslator() got unexpected error: %v", err)
+	}
+	wantMap := map[string][]*gnmipb.Path{
+		"/openconfig/macsec/interfaces/interface/state/status": {
+			mustRenamePath(t, "/eos_native/Sysdb/macsec/mkaStatus/portStatus/*/success"),
+			mustRenamePath(t, "/eos_native/Sysdb/macsec/status/cpStatus/*/controlledPortEnabled"),
+		},
+		"/openconfig/interfaces/interface/state/counters/out-discards": {
+			mustRenamePath(t, "/eos_native/Sysdb/queue[intf=*][id=*]/drops"),
+		},
+	}
+	if diff := cmp.Diff(wantMap, ft.OutputToInputMap(), protocmp.Transform()); diff != "" {
+		t.Errorf("OutputToInputMap() returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	input := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 42,
+				Prefix:    &gnmipb.Path{Origin: "eos_native", Target: "dut", Elem: []*gnmipb.PathElem{{Name: "Sysdb"}}},
+				Update: []*gnmipb.Update{
+					{Path: mustRenamePath(t, "/macsec/status/cpStatus/Ethernet1/controlledPortEnabled"), Val: boolVal(true)},
+					{Path: mustRenamePath(t, "/macsec/mkaStatus/portStatus/Ethernet1/success"), Val: boolVal(false)},
+					{Path: mustRenamePath(t, "/macsec/status/cpStatus/Ethernet2/controlledPortEnabled"), Val: boolVal(true)},
+					{Path: mustRenamePath(t, "/queue[intf=Ethernet1][id=0]/drops"), Val: uintVal(3)},
+					{Path: mustRenamePath(t, "/queue[intf=Ethernet1][id=1]/drops"), Val: uintVal(4)},
+					{Path: mustRenamePath(t, "/queue[intf=Ethernet2][id=0]/drops"), Val: doubleVal(1.5)},
+					{Path: mustRenamePath(t, "/queue[intf=Ethernet3][id=0]/drops"), Val: boolVal(true)},
+				},
+			},
+		},
+	}
+	want := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 42,
+				Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "dut"},
+				Update: []*gnmipb.Update{
+					{Path: mustRenamePath(t, "/macsec/interfaces/interface[name=Ethernet1]/state/status"), Val: boolVal(false)},
+					{Path: mustRenamePath(t, "/macsec/interfaces/interface[name=Ethernet2]/state/status"), Val: boolVal(true)},
+					{Path: mustRenamePath(t, "/interfaces/interface[name=Ethernet1]/state/counters/out-discards"), Val: uintVal(7)},
+					{Path: mustRenamePath(t, "/interfaces/interface[name=Ethernet2]/state/counters/out-discards"), Val: doubleVal(1.5)},
+				},
+			},
+		},
+	}
+	got, err := ft.Translate(input)
+	if err != nil {
+		t.Fatalf("Translate() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Translate() returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	for _, a := range []RenameAggregation{
+		{Inputs: []string{"/eos_native/Sysdb/intf/<n>/drops"}, Output: "/interfaces/interface[name=<n>]/state/counters/out-discards"},
+		{Output: "/interfaces/interface[name=<n>]/state/counters/out-discards", Combine: Sum},
+		{Inputs: []string{"/eos_native/Sysdb/drops"}, Output: "/interfaces/interface[name=<n>]/state/counters/out-discards", Combine: Sum},
+	} {
+		if _, err := NewRenameTranslator(RenameOptions{ID: "test-ft", Aggregations: []RenameAggregation{a}}); err == nil {
+			t.Errorf("NewRenameTranslator() with aggregation %+v got no error, want error", a)
+		}
+	}
+}
@@ -20,7 +20,7 @@ import (
 	"sort"
 	"strings"
 
-	log "github.com/golang/glog"
+	log "github.com/openconfig/functional-translators/ftlog"
 	"google.golang.org/protobuf/proto"
 	"github.com/openconfig/ygot/ygot"
 	"github.com/openconfig/ygot/ytypes"
@@ -128,8 +128,9 @@ func yangValToGNMIVal(val any) (*gnmipb.TypedValue, error) {
 type SchemaFn func() (*ytypes.Schema, error)
 
 type pathMapping struct {
-	input  *gnmipb.Path
-	output *gnmipb.Path
+	input     *gnmipb.Path
+	output    *gnmipb.Path
+	transform Transform
 }
 
 // parseMapperPath converts a path string to a gNMI path and a schema path. It takes care of
@@ -151,11 +152,57 @@ func parseMapperPath(pathStr string) (*gnmipb.Path, string, error) {
 	return path, schemaPath, nil
 }
 
+// Option configures optional behavior of a SimpleMapper.
+type Option func(*options)
+
+type options struct {
+	aggregations []aggregationSpec
+	transforms   map[string]Transform
+}
+
+type aggregationSpec struct {
+	output  string
+	inputs  []string
+	combine Combiner
+}
+
+// WithAggregation maps several input leaves to a single output leaf. The value of the output leaf
+// is the result of combine over the values of all the input leaves in a notification which bind
+// the same output path. Inputs may use "*" as a key value to match all the entries of a list, e.g.
+// all the queues of an interface, and must bind all the variables of the output path.
+func WithAggregation(output string, combine Combiner, inputs ...string) Option {
+	return func(o *options) {
+		o.aggregations = append(o.aggregations, aggregationSpec{output: output, inputs: inputs, combine: combine})
+	}
+}
+
+// WithTransform converts the values of the output leaf of a mapping, e.g. to scale units or remap
+// enums, instead of copying the input values. output must be a key of the outputToInput map of
+// NewSimpleMapper, and the transformed values must match the output schema.
+func WithTransform(output string, transform Transform) Option {
+	return func(o *options) {
+		if o.transforms == nil {
+			o.transforms = map[string]Transform{}
+		}
+		o.transforms[output] = transform
+	}
+}
+
 // NewSimpleMapper creates a new simple mapper.
-func NewSimpleMapper(inSchema, outSchema SchemaFn, outputToInput map[string]string, deleteHandler func(*gnmipb.Notification) ([]*gnmipb.Path, error)) (*SimpleMapper, error) {
+func NewSimpleMapper(inSchema, outSchema SchemaFn, outputToInput map[string]string, deleteHandler func(*gnmipb.Notification) ([]*gnmipb.Path, error), opts ...Option) (*SimpleMapper, error) {
+	var mo options
+	for _, opt := range opts {
+		opt(&mo)
+	}
 	var mappings []pathMapping
 	outputToInputSchemaStrings := make(map[string][]string)
 	outputToInputSchemaMap := make(map[string]map[string]bool)
+	addSchemaPaths := func(oSchemaPath, iSchemaPath string) {
+		if _, ok := outputToInputSchemaMap[oSchemaPath]; !ok {
+			outputToInputSchemaMap[oSchemaPath] = make(map[string]bool)
+		}
+		outputToInputSchemaMap[oSchemaPath][iSchemaPath] = true
+	}
 	for o, i := range outputToInput {
 		oPath, oSchemaPath, err := parseMapperPath(o)
 		if err != nil {
@@ -166,13 +213,36 @@ func NewSimpleMapper(inSchema, outSchema SchemaFn, outputToInput map[string]stri
 			return nil, err
 		}
 		mappings = append(mappings, pathMapping{
-			input:  iPath,
-			output: oPath,
+			input:     iPath,
+			output:    oPath,
+			transform: mo.transforms[o],
 		})
-		if _, ok := outputToInputSchemaMap[oSchemaPath]; !ok {
-			outputToInputSchemaMap[oSchemaPath] = make(map[string]bool)
+		addSchemaPaths(oSchemaPath, iSchemaPath)
+	}
+	for o, t := range mo.transforms {
+		if _, ok := outputToInput[o]; !ok || t == nil {
+			return nil, fmt.Errorf("transform of %q must be non nil and for a mapped output", o)
 		}
-		outputToInputSchemaMap[oSchemaPath][iSchemaPath] = true
+	}
+	var aggregations []aggregation
+	for _, spec := range mo.aggregations {
+		if spec.combine == nil || len(spec.inputs) == 0 {
+			return nil, fmt.Errorf("aggregation to %q must have a combiner and at least one input", spec.output)
+		}
+		oPath, oSchemaPath, err := parseMapperPath(spec.output)
+		if err != nil {
+			return nil, err
+		}
+		agg := aggregation{output: oPath, combine: spec.combine}
+		for _, i := range spec.inputs {
+			iPath, iSchemaPath, err := parseMapperPath(i)
+			if err != nil {
+				return nil, err
+			}
+			agg.inputs = append(agg.inputs, iPath)
+			addSchemaPaths(oSchemaPath, iSchemaPath)
+		}
+		aggregations = append(aggregations, agg)
 	}
 	for o, is := range outputToInputSchemaMap {
 		for i := range is {
@@ -194,6 +264,7 @@ func NewSimpleMapper(inSchema, outSchema SchemaFn, outputToInput map[string]stri
 		inSchema:                   isc,
 		outSchema:                  osc,
 		mapEntries:                 mappings,
+		aggregations:               aggregations,
 		deleteHandler:              deleteHandler,
 		outputToInputSchemaStrings: outputToInputSchemaStrings,
 	}, nil
@@ -204,6 +275,7 @@ type SimpleMapper struct {
 	inSchema                   *ytypes.Schema
 	outSchema                  *ytypes.Schema
 	mapEntries                 []pathMapping
+	aggregations               []aggregation
 	deleteHandler              func(*gnmipb.Notification) ([]*gnmipb.Path, error)
 	outputToInputSchemaStrings map[string][]string
 }
@@ -249,19 +321,33 @@ func (m *SimpleMapper) updateHandler(inSchema, outSchema *ytypes.Schema, notific
 			if err != nil {
 				return nil, fmt.Errorf("failed to apply bindings to output path: %v", err)
 			}
-			if _, _, err := ytypes.GetOrCreateNode(outSchema.RootSchema(), returnRootGoStruct, outPath); err != nil {
-				return nil, fmt.Errorf("failed to get or create node for output path: %v", err)
-			}
 			val, err := yangValToGNMIVal(tn.Data)
 			if err != nil {
 				return nil, fmt.Errorf("failed to convert yang val to gNMI val: %v", err)
 			}
+			if mapEntry.transform != nil {
+				if val, err = mapEntry.transform(val); err != nil {
+					return nil, fmt.Errorf("failed to transform value of %v: %v", tn.Path, err)
+				}
+				if val == nil {
+					continue
+				}
+			}
+			if _, _, err := ytypes.GetOrCreateNode(outSchema.RootSchema(), returnRootGoStruct, outPath); err != nil {
+				return nil, fmt.Errorf("failed to get or create node for output path: %v", err)
+			}
 			if err := ytypes.SetNode(outSchema.RootSchema(), returnRootGoStruct, outPath, val); err != nil {
 				return nil, fmt.Errorf("failed to set node for output path: %v", err)
 			}
 		}
 	}
 
+	for _, agg := range m.aggregations {
+		if err := agg.apply(inSchema, outSchema, returnRootGoStruct); err != nil {
+			return nil, err
+		}
+	}
+
 	outgoingNotifications, err := ygot.TogNMINotifications(returnRootGoStruct, notification.GetTimestamp(), ygot.GNMINotificationsConfig{UsePathElem: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert outgoing notification: %v", err)
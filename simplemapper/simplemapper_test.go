@@ -15,6 +15,8 @@
 package simplemapper
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -626,3 +628,96 @@ func TestVarsToWildcards(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlerTransforms(t *testing.T) {
+	desc := func(name, container string) *gnmipb.Path {
+		return ocPath(
+			&gnmipb.PathElem{Name: "interfaces"},
+			&gnmipb.PathElem{Name: "interface", Key: map[string]string{"name": name}},
+			&gnmipb.PathElem{Name: container},
+			&gnmipb.PathElem{Name: "description"},
+		)
+	}
+	stringVal := func(s string) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: s}}
+	}
+	const output = "/openconfig/interfaces/interface[name=<intf>]/state/description"
+	mapping := map[string]string{output: "/openconfig/interfaces/interface[name=<intf>]/config/description"}
+	deleteHandler := func(*gnmipb.Notification) ([]*gnmipb.Path, error) { return nil, nil }
+	upper := func(v *gnmipb.TypedValue) (*gnmipb.TypedValue, error) {
+		switch s := v.GetStringVal(); s {
+		case "":
+			return nil, nil
+		case "invalid":
+			return nil, fmt.Errorf("invalid description")
+		default:
+			return stringVal(strings.ToUpper(s)), nil
+		}
+	}
+	m, err := NewSimpleMapper(openconfig.Schema, openconfig.Schema, mapping, deleteHandler, WithTransform(output, upper))
+	if err != nil {
+		t.Fatalf("NewSimpleMapper() returned an unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		updates []*gnmipb.Update
+		want    []*gnmipb.Update
+		wantErr bool
+	}{
+		{
+			name: "transformed",
+			updates: []*gnmipb.Update{
+				{Path: desc("Ethernet1", "config"), Val: stringVal("uplink")},
+				{Path: desc("Ethernet2", "config"), Val: stringVal("")},
+			},
+			want: []*gnmipb.Update{{Path: desc("Ethernet1", "state"), Val: stringVal("UPLINK")}},
+		},
+		{
+			name:    "transform error",
+			updates: []*gnmipb.Update{{Path: desc("Ethernet1", "config"), Val: stringVal("invalid")}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			input := &gnmipb.SubscribeResponse{
+				Response: &gnmipb.SubscribeResponse_Update{
+					Update: &gnmipb.Notification{
+						Timestamp: 42,
+						Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "dut"},
+						Update:    tc.updates,
+					},
+				},
+			}
+			got, err := m.Handler(input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Handler() returned error %v, want error: %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			want := &gnmipb.SubscribeResponse{
+				Response: &gnmipb.SubscribeResponse_Update{
+					Update: &gnmipb.Notification{
+						Timestamp: 42,
+						Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "dut"},
+						Update:    tc.want,
+					},
+				},
+			}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Handler() returned an unexpected diff (-want +got): %v", diff)
+			}
+		})
+	}
+
+	for _, opt := range []Option{
+		WithTransform(output, nil),
+		WithTransform("/openconfig/interfaces/interface[name=<intf>]/state/name", upper),
+	} {
+		if _, err := NewSimpleMapper(openconfig.Schema, openconfig.Schema, mapping, deleteHandler, opt); err == nil {
+			t.Errorf("NewSimpleMapper() with an invalid transform returned nil error, want error")
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// TranslateBatch translates a batch of responses, e.g. the initial updates of a device after a
+// resync, and returns the non-nil translated responses in order. Consecutive notifications with
+// the same prefix and timestamp and without deletes are coalesced into one notification before
+// being translated, so that the FT parses the prefix and allocates its output once per group of
+// notifications rather than once per notification. Sync responses are handled as by
+// TranslateResponses.
+//
+// Coalescing changes what the FT sees: it returns at most one response per group, holding the
+// updates it would have returned for each notification of the group only if it translates the
+// updates independently of each other. FTs computing values across the updates of a notification,
+// e.g. sums or correlations of leaves, may return different values, and callers of such FTs should
+// use TranslateResponses instead.
+//
+// If a group fails to translate, its responses are translated again one at a time, so that a
+// response which fails to translate only drops its own outputs. Responses which fail to translate
+// are skipped, and their errors are joined in the returned error.
+func (ft *FunctionalTranslator) TranslateBatch(inputs []*gnmipb.SubscribeResponse) ([]*gnmipb.SubscribeResponse, error) {
+	outputs := make([]*gnmipb.SubscribeResponse, 0, len(inputs))
+	var errs []error
+	for start := 0; start < len(inputs); {
+		end := start + 1
+		for end < len(inputs) && coalescable(inputs[start], inputs[end]) {
+			end++
+		}
+		outs, err := ft.TranslateResponses(coalesce(inputs[start:end]))
+		switch {
+		case err == nil:
+			outputs = append(outputs, outs...)
+		case end-start == 1:
+			errs = append(errs, fmt.Errorf("response %d: %v", start, err))
+		default:
+			for i := start; i < end; i++ {
+				outs, err := ft.TranslateResponses(inputs[i])
+				if err != nil {
+					errs = append(errs, fmt.Errorf("response %d: %v", i, err))
+					continue
+				}
+				outputs = append(outputs, outs...)
+			}
+		}
+		start = end
+	}
+	return outputs, errors.Join(errs...)
+}
+
+// coalescable returns true if the notification of b can be merged into the notification of a, i.e.
+// if the merged notification applies the same updates in the same order.
+func coalescable(a, b *gnmipb.SubscribeResponse) bool {
+	na, nb := a.GetUpdate(), b.GetUpdate()
+	if na == nil || nb == nil || len(a.GetExtension()) > 0 || len(b.GetExtension()) > 0 {
+		return false
+	}
+	// Deletes are applied before the updates of a notification, so merging deletes could reorder
+	// them with the updates of the previous notifications.
+	if len(na.GetDelete()) > 0 || len(nb.GetDelete()) > 0 || na.GetAtomic() || nb.GetAtomic() {
+		return false
+	}
+	return na.GetTimestamp() == nb.GetTimestamp() && proto.Equal(na.GetPrefix(), nb.GetPrefix())
+}
+
+// coalesce returns a response with the updates of the coalescable responses. The responses are
+// not modified.
+func coalesce(srs []*gnmipb.SubscribeResponse) *gnmipb.SubscribeResponse {
+	if len(srs) == 1 {
+		return srs[0]
+	}
+	n := 0
+	for _, sr := range srs {
+		n += len(sr.GetUpdate().GetUpdate())
+	}
+	updates := make([]*gnmipb.Update, 0, n)
+	for _, sr := range srs {
+		updates = append(updates, sr.GetUpdate().GetUpdate()...)
+	}
+	first := srs[0].GetUpdate()
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: first.GetTimestamp(),
+				Prefix:    first.GetPrefix(),
+				Update:    updates,
+			},
+		},
+	}
+}
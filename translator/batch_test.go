@@ -0,0 +1,168 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// newSumFT returns an FT emitting the sum of the counters of each notification as the
+// transmit-pkts counter of queue "sum", and failing for a counter of 0.
+func newSumFT(t testing.TB) (*FunctionalTranslator, *int) {
+	t.Helper()
+	calls := 0
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID: "test-ft",
+		Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+			calls++
+			var sum uint64
+			for _, u := range sr.GetUpdate().GetUpdate() {
+				if u.GetVal().GetUintVal() == 0 {
+					return nil, fmt.Errorf("counter is 0")
+				}
+				sum += u.GetVal().GetUintVal()
+			}
+			if sum == 0 {
+				return nil, nil
+			}
+			out := counterSR(sr.GetUpdate().GetPrefix().GetTarget(), []*gnmipb.Update{uintUpdate(counterPath("sum", "transmit-pkts"), sum)})
+			out.GetUpdate().Timestamp = sr.GetUpdate().GetTimestamp()
+			return out, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+	}
+	return ft, &calls
+}
+
+func TestTranslateBatch(t *testing.T) {
+	q0 := counterPath("0", "transmit-pkts")
+	q1 := counterPath("1", "transmit-pkts")
+	sum := func(target string, v uint64) *gnmipb.SubscribeResponse {
+		return counterSR(target, []*gnmipb.Update{uintUpdate(counterPath("sum", "transmit-pkts"), v)})
+	}
+	withTimestamp := func(sr *gnmipb.SubscribeResponse, ts int64) *gnmipb.SubscribeResponse {
+		sr.GetUpdate().Timestamp = ts
+		return sr
+	}
+	syncSR := &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true}}
+
+	tests := []struct {
+		name      string
+		inputs    []*gnmipb.SubscribeResponse
+		want      []*gnmipb.SubscribeResponse
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name: "coalesced",
+			inputs: []*gnmipb.SubscribeResponse{
+				counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 1)}),
+				counterSR("dut", []*gnmipb.Update{uintUpdate(q1, 2)}),
+			},
+			// The FT sees a single notification, so its sum covers both updates.
+			want:      []*gnmipb.SubscribeResponse{sum("dut", 3)},
+			wantCalls: 1,
+		},
+		{
+			name: "different_targets_timestamps_and_deletes",
+			inputs: []*gnmipb.SubscribeResponse{
+				counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 1)}),
+				counterSR("dut2", []*gnmipb.Update{uintUpdate(q0, 2)}),
+				withTimestamp(counterSR("dut2", []*gnmipb.Update{uintUpdate(q1, 3)}), 2),
+				withTimestamp(counterSR("dut2", []*gnmipb.Update{uintUpdate(q1, 4)}, q0), 2),
+			},
+			want: []*gnmipb.SubscribeResponse{
+				sum("dut", 1),
+				sum("dut2", 2),
+				withTimestamp(sum("dut2", 3), 2),
+				withTimestamp(sum("dut2", 4), 2),
+			},
+			wantCalls: 4,
+		},
+		{
+			name: "sync_response",
+			inputs: []*gnmipb.SubscribeResponse{
+				counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 1)}),
+				syncSR,
+				counterSR("dut", []*gnmipb.Update{uintUpdate(q1, 2)}),
+			},
+			want:      []*gnmipb.SubscribeResponse{sum("dut", 1), syncSR, sum("dut", 2)},
+			wantCalls: 2,
+		},
+		{
+			name: "error",
+			inputs: []*gnmipb.SubscribeResponse{
+				counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 0)}),
+				counterSR("dut", []*gnmipb.Update{uintUpdate(q1, 2)}),
+				counterSR("dut2", []*gnmipb.Update{uintUpdate(q0, 3)}),
+			},
+			// The failed group is translated again one response at a time.
+			want:      []*gnmipb.SubscribeResponse{sum("dut", 2), sum("dut2", 3)},
+			wantCalls: 4,
+			wantErr:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ft, calls := newSumFT(t)
+			got, err := ft.TranslateBatch(tc.inputs)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("TranslateBatch() got error %v, want error: %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("TranslateBatch() returned an unexpected diff (-want +got): %v", diff)
+			}
+			if *calls != tc.wantCalls {
+				t.Errorf("TranslateBatch() made %d calls to translate, want %d", *calls, tc.wantCalls)
+			}
+		})
+	}
+}
+
+func batchInputs() []*gnmipb.SubscribeResponse {
+	inputs := make([]*gnmipb.SubscribeResponse, 1000)
+	for i := range inputs {
+		inputs[i] = counterSR("dut", []*gnmipb.Update{uintUpdate(counterPath(fmt.Sprint(i%8), "transmit-pkts"), uint64(i+1))})
+	}
+	return inputs
+}
+
+func BenchmarkTranslate(b *testing.B) {
+	ft, _ := newSumFT(b)
+	inputs := batchInputs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, sr := range inputs {
+			ft.Translate(sr)
+		}
+	}
+}
+
+func BenchmarkTranslateBatch(b *testing.B) {
+	ft, _ := newSumFT(b)
+	inputs := batchInputs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ft.TranslateBatch(inputs)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/proto"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// DeltaTranslator wraps a FunctionalTranslator and emits the values of selected counter paths as
+// the delta since the previous emission for the same target and path, for consumers that cannot
+// handle monotonic counters.
+//
+// The first value seen for a path is emitted as a delta of 0. When a counter decreases, it is
+// treated as a reset: the path is deleted and the new value, which is the count since the reset,
+// is emitted as the delta in the same notification. As gNMI processes deletes before updates,
+// consumers see the delete as the reset indicator.
+//
+// Each DeltaTranslator has its own cache of last values, so a consumer should create one per
+// pipeline rather than sharing the translators in the registry.
+type DeltaTranslator struct {
+	ft       *FunctionalTranslator
+	patterns []*gnmipb.Path
+
+	mu sync.Mutex
+	// last holds the last absolute value per target and path.
+	last map[string]map[string]uint64
+}
+
+// NewDeltaTranslator returns a DeltaTranslator emitting deltas for the output paths of ft matching
+// any of the patterns. Patterns are matched with ftutilities.MatchPath, so "*" matches any element
+// and keys are ignored.
+func NewDeltaTranslator(ft *FunctionalTranslator, patterns []*gnmipb.Path) (*DeltaTranslator, error) {
+	if ft == nil {
+		return nil, fmt.Errorf("delta translator requires a functional translator")
+	}
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("delta translator for %s requires at least one path pattern", ft.ID())
+	}
+	return &DeltaTranslator{
+		ft:       ft,
+		patterns: patterns,
+		last:     map[string]map[string]uint64{},
+	}, nil
+}
+
+// ID returns the identifier of the wrapped functional translator.
+func (d *DeltaTranslator) ID() string {
+	return d.ft.ID()
+}
+
+// Translate translates the input with the wrapped functional translator and replaces the values of
+// the selected counter paths with deltas.
+func (d *DeltaTranslator) Translate(input *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	out, err := d.ft.Translate(input)
+	if err != nil || out.GetUpdate() == nil {
+		return out, err
+	}
+	// Do not modify the response returned by the translator, which may be the input itself.
+	out = proto.Clone(out).(*gnmipb.SubscribeResponse)
+	n := out.GetUpdate()
+	target := n.GetPrefix().GetTarget()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	last, ok := d.last[target]
+	if !ok {
+		last = map[string]uint64{}
+		d.last[target] = last
+	}
+	for _, del := range n.GetDelete() {
+		key, err := ygot.PathToString(ftutilities.Join(n.GetPrefix(), del))
+		if err != nil {
+			continue
+		}
+		for k := range last {
+			if k == key || strings.HasPrefix(k, key+"/") {
+				delete(last, k)
+			}
+		}
+	}
+	for _, u := range n.GetUpdate() {
+		v, ok := u.GetVal().GetValue().(*gnmipb.TypedValue_UintVal)
+		if !ok {
+			continue
+		}
+		fullPath := ftutilities.Join(n.GetPrefix(), u.GetPath())
+		if !ftutilities.PathInList(fullPath, d.patterns) {
+			continue
+		}
+		key, err := ygot.PathToString(fullPath)
+		if err != nil {
+			return nil, fmt.Errorf("%s failed to compute delta for %v: %v", d.ft.ID(), u.GetPath(), err)
+		}
+		prev, seen := last[key]
+		last[key] = v.UintVal
+		var delta uint64
+		switch {
+		case !seen:
+			delta = 0
+		case v.UintVal < prev:
+			// The counter was reset; the current value is the count since the reset.
+			n.Delete = append(n.Delete, u.GetPath())
+			delta = v.UintVal
+		default:
+			delta = v.UintVal - prev
+		}
+		u.Val = &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: delta}}
+	}
+	return out, nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/functional-translators/ftutilities"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func counterPath(queue, leaf string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{
+			{Name: "qos"},
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"interface-id": "Ethernet1"}},
+			{Name: "output"},
+			{Name: "queues"},
+			{Name: "queue", Key: map[string]string{"name": queue}},
+			{Name: "state"},
+			{Name: leaf},
+		},
+	}
+}
+
+func counterSR(target string, updates []*gnmipb.Update, deletes ...*gnmipb.Path) *gnmipb.SubscribeResponse {
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 1,
+				Prefix:    &gnmipb.Path{Origin: "openconfig", Target: target},
+				Update:    updates,
+				Delete:    deletes,
+			},
+		},
+	}
+}
+
+func uintUpdate(p *gnmipb.Path, v uint64) *gnmipb.Update {
+	return &gnmipb.Update{Path: p, Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}}}
+}
+
+func TestNewDeltaTranslator(t *testing.T) {
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID:        "test-ft",
+		Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) { return sr, nil },
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+	}
+	if _, err := NewDeltaTranslator(nil, []*gnmipb.Path{counterPath("*", "transmit-pkts")}); err == nil {
+		t.Errorf("NewDeltaTranslator(nil, ...) got nil error, want error")
+	}
+	if _, err := NewDeltaTranslator(ft, nil); err == nil {
+		t.Errorf("NewDeltaTranslator(%s, nil) got nil error, want error", ft.ID())
+	}
+}
+
+func TestDeltaTranslate(t *testing.T) {
+	pattern, err := ftutilities.StringToPath("/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts")
+	if err != nil {
+		t.Fatalf("StringToPath got unexpected error: %v", err)
+	}
+	q0 := counterPath("0", "transmit-pkts")
+	q1 := counterPath("1", "transmit-pkts")
+	octets := counterPath("0", "transmit-octets")
+
+	// Each step is fed in order to the same DeltaTranslator.
+	steps := []struct {
+		name  string
+		input *gnmipb.SubscribeResponse
+		want  *gnmipb.SubscribeResponse
+	}{
+		{
+			name:  "first_value_is_zero_delta",
+			input: counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 100), uintUpdate(octets, 1000)}),
+			want:  counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 0), uintUpdate(octets, 1000)}),
+		},
+		{
+			name:  "increase_emitted_as_delta",
+			input: counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 150), uintUpdate(q1, 7), uintUpdate(octets, 2000)}),
+			want:  counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 50), uintUpdate(q1, 0), uintUpdate(octets, 2000)}),
+		},
+		{
+			name:  "targets_are_independent",
+			input: counterSR("other", []*gnmipb.Update{uintUpdate(q0, 500)}),
+			want:  counterSR("other", []*gnmipb.Update{uintUpdate(q0, 0)}),
+		},
+		{
+			name:  "reset_deletes_path_and_emits_count_since_reset",
+			input: counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 20)}),
+			want:  counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 20)}, q0),
+		},
+		{
+			name:  "value_after_reset",
+			input: counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 30)}),
+			want:  counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 10)}),
+		},
+		{
+			name:  "delete_clears_last_value",
+			input: counterSR("dut", nil, &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "qos"}}}),
+			want:  counterSR("dut", nil, &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "qos"}}}),
+		},
+		{
+			name:  "value_after_delete_is_zero_delta",
+			input: counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 40), uintUpdate(q1, 9)}),
+			want:  counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 0), uintUpdate(q1, 0)}),
+		},
+	}
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID:        "test-ft",
+		Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) { return sr, nil },
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+	}
+	dt, err := NewDeltaTranslator(ft, []*gnmipb.Path{pattern})
+	if err != nil {
+		t.Fatalf("NewDeltaTranslator got unexpected error: %v", err)
+	}
+	for _, step := range steps {
+		got, err := dt.Translate(step.input)
+		if err != nil {
+			t.Fatalf("%s: Translate(%v) got unexpected error: %v", step.name, step.input, err)
+		}
+		if diff := cmp.Diff(step.want, got, protocmp.Transform()); diff != "" {
+			t.Errorf("%s: Translate(%v) returned diff (-want +got):\n%s", step.name, step.input, diff)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"sync/atomic"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// globalDryRun puts all FTs in dry-run mode when set.
+var globalDryRun atomic.Bool
+
+// SetGlobalDryRun enables or disables the dry-run mode of all FTs, regardless of their own
+// setting.
+func SetGlobalDryRun(enabled bool) {
+	globalDryRun.Store(enabled)
+}
+
+// DryRunStats holds the number of outputs suppressed by an FT in dry-run mode.
+type DryRunStats struct {
+	// Responses is the number of suppressed SubscribeResponses.
+	Responses uint64
+	// Updates is the number of updates in the suppressed SubscribeResponses.
+	Updates uint64
+	// Deletes is the number of deletes in the suppressed SubscribeResponses.
+	Deletes uint64
+}
+
+type dryRunCounters struct {
+	responses atomic.Uint64
+	updates   atomic.Uint64
+	deletes   atomic.Uint64
+}
+
+func (c *dryRunCounters) record(sr *gnmipb.SubscribeResponse) {
+	c.responses.Add(1)
+	c.updates.Add(uint64(len(sr.GetUpdate().GetUpdate())))
+	c.deletes.Add(uint64(len(sr.GetUpdate().GetDelete())))
+}
+
+// SetDryRun enables or disables the dry-run mode of the FT. In dry-run mode the FT translates its
+// inputs as usual, including updating any state it keeps, but its outputs are counted and logged
+// instead of being returned, so that a new FT can be observed against production streams before
+// its outputs are emitted. Sync responses are still passed through.
+func (ft *FunctionalTranslator) SetDryRun(enabled bool) {
+	ft.dryRun.Store(enabled)
+}
+
+// DryRun returns true if the FT is in dry-run mode, either on its own or globally.
+func (ft *FunctionalTranslator) DryRun() bool {
+	return ft.dryRun.Load() || globalDryRun.Load()
+}
+
+// DryRunStats returns the number of outputs suppressed by the FT in dry-run mode.
+func (ft *FunctionalTranslator) DryRunStats() DryRunStats {
+	return DryRunStats{
+		Responses: ft.dryRunStats.responses.Load(),
+		Updates:   ft.dryRunStats.updates.Load(),
+		Deletes:   ft.dryRunStats.deletes.Load(),
+	}
+}
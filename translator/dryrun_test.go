@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestDryRun(t *testing.T) {
+	q0 := counterPath("0", "transmit-pkts")
+	input := counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 1), uintUpdate(q0, 2)}, q0)
+	syncSR := &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true}}
+
+	tests := []struct {
+		name      string
+		optDryRun bool
+		setDryRun bool
+		global    bool
+		want      *gnmipb.SubscribeResponse
+		wantStats DryRunStats
+	}{
+		{
+			name: "disabled",
+			want: input,
+		},
+		{
+			name:      "enabled_by_option",
+			optDryRun: true,
+			wantStats: DryRunStats{Responses: 1, Updates: 2, Deletes: 1},
+		},
+		{
+			name:      "enabled_by_setter",
+			setDryRun: true,
+			wantStats: DryRunStats{Responses: 1, Updates: 2, Deletes: 1},
+		},
+		{
+			name:      "enabled_globally",
+			global:    true,
+			wantStats: DryRunStats{Responses: 1, Updates: 2, Deletes: 1},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var translated int
+			ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+				ID: "test-ft",
+				Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+					translated++
+					return sr, nil
+				},
+				DryRun: tc.optDryRun,
+			})
+			if err != nil {
+				t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+			}
+			if tc.setDryRun {
+				ft.SetDryRun(true)
+			}
+			if tc.global {
+				SetGlobalDryRun(true)
+				t.Cleanup(func() { SetGlobalDryRun(false) })
+			}
+			got, err := ft.Translate(input)
+			if err != nil {
+				t.Fatalf("Translate() got unexpected error: %v", err)
+			}
+			if translated != 1 {
+				t.Errorf("Translate() called the translate function %d times, want 1", translated)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Translate() returned diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStats, ft.DryRunStats()); diff != "" {
+				t.Errorf("DryRunStats() returned diff (-want +got):\n%s", diff)
+			}
+			// Sync responses are always passed through.
+			got, err = ft.Translate(syncSR)
+			if err != nil {
+				t.Fatalf("Translate(sync) got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(syncSR, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Translate(sync) returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
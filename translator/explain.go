@@ -0,0 +1,197 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/ygot/ygot"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Explanation reports how an FT handles a notification. See Explain.
+type Explanation struct {
+	// ID is the ID of the FT.
+	ID string
+	// Inputs has an entry for each update and delete of the notification, deletes last.
+	Inputs []*InputExplanation
+	// Output is the response the FT generates for the notification, or nil if it generates none.
+	Output *gnmipb.SubscribeResponse
+	// Outputs has an entry for each update and delete of Output, deletes last.
+	Outputs []*OutputExplanation
+	// Err is the error returned by the FT.
+	Err error
+}
+
+// InputExplanation reports how an FT handles a path of a notification.
+type InputExplanation struct {
+	// Path is the path of the update or delete, joined with the prefix of the notification.
+	Path   *gnmipb.Path
+	Delete bool
+	// Patterns are the input schema strings of the FT matching the path, sorted. The path is not
+	// consumed by the FT if there are none.
+	Patterns []string
+	// Outputs are the output schema strings which the FT provides from the matching patterns,
+	// sorted.
+	Outputs []string
+}
+
+// Consumed returns true if the path matches an input of the FT.
+func (e *InputExplanation) Consumed() bool {
+	return len(e.Patterns) > 0
+}
+
+// OutputExplanation reports a path generated by an FT.
+type OutputExplanation struct {
+	// Path is the path of the update or delete, joined with the prefix of the output.
+	Path   *gnmipb.Path
+	Delete bool
+	// Schema is the output schema string of the FT providing the path, or empty if the FT does not
+	// declare the path in its OutputToInputMap.
+	Schema string
+}
+
+// Explain translates the notification like Translate, without side effects, and reports which
+// input patterns of the FT match its paths and which outputs are generated, to help debugging why
+// a native notification produces no openconfig output. The state of a stateful FT is restored
+// after the translation, the output is returned also in dry-run mode and it is not recorded by the
+// instrumentation. The Sync function is not called for sync responses, which are reported as
+// passed through.
+func (ft *FunctionalTranslator) Explain(sr *gnmipb.SubscribeResponse) *Explanation {
+	e := &Explanation{ID: ft.id}
+	if IsSyncResponse(sr) {
+		e.Output = sr
+		return e
+	}
+	n := sr.GetUpdate()
+	for _, u := range n.GetUpdate() {
+		e.Inputs = append(e.Inputs, ft.explainInput(ftutilities.Join(n.GetPrefix(), u.GetPath()), false))
+	}
+	for _, d := range n.GetDelete() {
+		e.Inputs = append(e.Inputs, ft.explainInput(ftutilities.Join(n.GetPrefix(), d), true))
+	}
+
+	if ft.state != nil {
+		snapshot := ft.state.State()
+		defer func() {
+			if err := ft.state.RestoreState(snapshot); err != nil {
+				e.Err = errors.Join(e.Err, fmt.Errorf("%s failed to restore state after explaining: %v", ft.id, err))
+			}
+		}()
+	}
+	e.Output, e.Err = ft.translate(normalizeTarget(sr))
+	out := e.Output.GetUpdate()
+	for _, u := range out.GetUpdate() {
+		e.Outputs = append(e.Outputs, ft.explainOutput(ftutilities.Join(out.GetPrefix(), u.GetPath()), false))
+	}
+	for _, d := range out.GetDelete() {
+		e.Outputs = append(e.Outputs, ft.explainOutput(ftutilities.Join(out.GetPrefix(), d), true))
+	}
+	return e
+}
+
+// schemaOverlaps returns true if one of the schema strings is equal to or an ancestor of the
+// other, e.g. for a container input path and the leaves updated under it.
+func schemaOverlaps(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
+
+func (ft *FunctionalTranslator) explainInput(path *gnmipb.Path, isDelete bool) *InputExplanation {
+	e := &InputExplanation{Path: path, Delete: isDelete}
+	s := ftutilities.GNMIPathToSchemaString(path, true)
+	outputs := map[string]bool{}
+	for _, in := range ft.index.allInputSchemas {
+		if !schemaOverlaps(s, in) {
+			continue
+		}
+		e.Patterns = append(e.Patterns, in)
+		for _, out := range ft.index.outputs[in] {
+			if !outputs[out] {
+				outputs[out] = true
+				e.Outputs = append(e.Outputs, out)
+			}
+		}
+	}
+	sort.Strings(e.Outputs)
+	return e
+}
+
+func (ft *FunctionalTranslator) explainOutput(path *gnmipb.Path, isDelete bool) *OutputExplanation {
+	e := &OutputExplanation{Path: path, Delete: isDelete}
+	s := ftutilities.GNMIPathToSchemaString(path, true)
+	for out := range ft.index.inputs {
+		// Prefer the most specific output, if the FT declares both a container and its leaves.
+		if (s == out || strings.HasPrefix(s, out+"/")) && len(out) > len(e.Schema) {
+			e.Schema = out
+		}
+	}
+	return e
+}
+
+func pathString(p *gnmipb.Path) string {
+	s, err := ygot.PathToString(p)
+	if err != nil {
+		s = p.String()
+	}
+	if p.GetOrigin() != "" {
+		return p.GetOrigin() + ":" + s
+	}
+	return s
+}
+
+func operation(isDelete bool) string {
+	if isDelete {
+		return "delete"
+	}
+	return "update"
+}
+
+// String returns a human readable report of the explanation.
+func (e *Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s:\n", e.ID)
+	if IsSyncResponse(e.Output) {
+		b.WriteString("  sync response passed through\n")
+		return b.String()
+	}
+	for _, in := range e.Inputs {
+		fmt.Fprintf(&b, "  input %s %s: ", operation(in.Delete), pathString(in.Path))
+		if !in.Consumed() {
+			b.WriteString("matches no input of the FT\n")
+			continue
+		}
+		fmt.Fprintf(&b, "matches %s, provides %s\n", strings.Join(in.Patterns, ", "), strings.Join(in.Outputs, ", "))
+	}
+	if e.Err != nil {
+		fmt.Fprintf(&b, "  error: %v\n", e.Err)
+	}
+	if len(e.Outputs) == 0 {
+		b.WriteString("  no output\n")
+	}
+	for _, out := range e.Outputs {
+		fmt.Fprintf(&b, "  output %s %s", operation(out.Delete), pathString(out.Path))
+		if out.Schema == "" {
+			b.WriteString(": not declared by the FT\n")
+			continue
+		}
+		fmt.Fprintf(&b, ": %s\n", out.Schema)
+	}
+	return b.String()
+}
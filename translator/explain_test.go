@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/functional-translators/ftutilities"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestExplain(t *testing.T) {
+	nativePath := func(leaf string) *gnmipb.Path {
+		return &gnmipb.Path{
+			Elem: []*gnmipb.PathElem{
+				{Name: "Kernel"}, {Name: "counters"}, {Name: "queue", Key: map[string]string{"name": "0"}}, {Name: leaf},
+			},
+		}
+	}
+	nativeSR := func(updates []*gnmipb.Update, deletes ...*gnmipb.Path) *gnmipb.SubscribeResponse {
+		sr := counterSR("dut", updates, deletes...)
+		sr.GetUpdate().Prefix.Origin = "eos_native"
+		return sr
+	}
+	// The FT emits the "packets" counter as transmit-pkts, and the "bytes" counter as the
+	// undeclared transmit-octets.
+	translate := func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+		var updates []*gnmipb.Update
+		for _, u := range sr.GetUpdate().GetUpdate() {
+			elems := u.GetPath().GetElem()
+			switch elems[len(elems)-1].GetName() {
+			case "packets":
+				updates = append(updates, uintUpdate(counterPath("0", "transmit-pkts"), u.GetVal().GetUintVal()))
+			case "bytes":
+				updates = append(updates, uintUpdate(counterPath("0", "transmit-octets"), u.GetVal().GetUintVal()))
+			}
+		}
+		if len(updates) == 0 {
+			return nil, nil
+		}
+		return counterSR("dut", updates), nil
+	}
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID:        "test-ft",
+		Translate: translate,
+		OutputToInputMap: ftutilities.MustStringMapPaths(map[string][]string{
+			"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts": {
+				"/eos_native/Kernel/counters/queue/packets",
+			},
+		}),
+		DryRun: true,
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		in   *gnmipb.SubscribeResponse
+		want string
+	}{
+		{
+			name: "translated",
+			in:   nativeSR([]*gnmipb.Update{uintUpdate(nativePath("packets"), 1), uintUpdate(nativePath("bytes"), 2)}, nativePath("packets")),
+			want: `test-ft:
+  input update eos_native:/Kernel/counters/queue[name=0]/packets: matches /eos_native/Kernel/counters/queue/packets, provides /openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts
+  input update eos_native:/Kernel/counters/queue[name=0]/bytes: matches no input of the FT
+  input delete eos_native:/Kernel/counters/queue[name=0]/packets: matches /eos_native/Kernel/counters/queue/packets, provides /openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts
+  output update openconfig:/qos/interfaces/interface[interface-id=Ethernet1]/output/queues/queue[name=0]/state/transmit-pkts: /openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts
+  output update openconfig:/qos/interfaces/interface[interface-id=Ethernet1]/output/queues/queue[name=0]/state/transmit-octets: not declared by the FT
+`,
+		},
+		{
+			name: "container",
+			in:   nativeSR([]*gnmipb.Update{{Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "Kernel"}}}}}),
+			want: `test-ft:
+  input update eos_native:/Kernel: matches /eos_native/Kernel/counters/queue/packets, provides /openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts
+  no output
+`,
+		},
+		{
+			name: "sync_response",
+			in:   &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true}},
+			want: "test-ft:\n  sync response passed through\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ft.Explain(tc.in)
+			if diff := cmp.Diff(tc.want, got.String()); diff != "" {
+				t.Errorf("Explain().String() returned an unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+	if stats := ft.DryRunStats(); stats.Responses != 0 {
+		t.Errorf("DryRunStats() after Explain = %+v, want no suppressed responses", stats)
+	}
+}
+
+func TestExplainRestoresState(t *testing.T) {
+	ft := newCountingFT(t)
+	sr := counterSR("dut", []*gnmipb.Update{uintUpdate(counterPath("0", "transmit-pkts"), 1)})
+	if _, err := ft.Translate(sr); err != nil {
+		t.Fatalf("Translate() got unexpected error: %v", err)
+	}
+	e := ft.Explain(sr)
+	if e.Err != nil {
+		t.Fatalf("Explain() got unexpected error: %v", e.Err)
+	}
+	if e.Output != nil {
+		t.Errorf("Explain() output = %v, want nil", e.Output)
+	}
+	if got := ft.State(); got != 1 {
+		t.Errorf("State() after Explain = %v, want 1", got)
+	}
+	if diff := cmp.Diff([]*InputExplanation{{Path: ftutilities.Join(sr.GetUpdate().GetPrefix(), counterPath("0", "transmit-pkts"))}}, e.Inputs, protocmp.Transform()); diff != "" {
+		t.Errorf("Explain() inputs returned an unexpected diff (-want +got):\n%s", diff)
+	}
+}
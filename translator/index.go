@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"sort"
+
+	"github.com/openconfig/functional-translators/ftutilities"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// schemaIndex indexes the OutputToInputMap of an FT by schema string in both directions, so that
+// lookups do not need to scan the map or convert the input paths to strings on every call. It is
+// built once when the FT is created and is read-only afterwards.
+type schemaIndex struct {
+	// inputs maps an output schema string to the input paths needed to provide it.
+	inputs map[string][]*gnmipb.Path
+	// inputSchemas maps an output schema string to the sorted schema strings of its input paths.
+	inputSchemas map[string][]string
+	// outputs maps an input schema string to the sorted output schema strings it provides.
+	outputs map[string][]string
+	// allInputSchemas is the sorted list of distinct input schema strings.
+	allInputSchemas []string
+}
+
+func newSchemaIndex(outputToInput map[string][]*gnmipb.Path) *schemaIndex {
+	idx := &schemaIndex{
+		inputs:       make(map[string][]*gnmipb.Path, len(outputToInput)),
+		inputSchemas: make(map[string][]string, len(outputToInput)),
+		outputs:      map[string][]string{},
+	}
+	for out, inputs := range outputToInput {
+		idx.inputs[out] = inputs
+		seen := map[string]bool{}
+		for _, in := range inputs {
+			s := ftutilities.GNMIPathToSchemaString(in, false)
+			if seen[s] {
+				continue
+			}
+			seen[s] = true
+			idx.inputSchemas[out] = append(idx.inputSchemas[out], s)
+			if _, ok := idx.outputs[s]; !ok {
+				idx.allInputSchemas = append(idx.allInputSchemas, s)
+			}
+			idx.outputs[s] = append(idx.outputs[s], out)
+		}
+		sort.Strings(idx.inputSchemas[out])
+	}
+	for _, outs := range idx.outputs {
+		sort.Strings(outs)
+	}
+	sort.Strings(idx.allInputSchemas)
+	return idx
+}
+
+// InputsForOutput returns the input paths needed to provide the given output schema string, e.g.
+// "/openconfig/interfaces/interface/state/oper-status", and whether the output is supported by
+// the FT. The returned slice must not be modified.
+func (ft *FunctionalTranslator) InputsForOutput(output string) ([]*gnmipb.Path, bool) {
+	inputs, ok := ft.index.inputs[output]
+	return inputs, ok
+}
+
+// InputSchemasForOutput returns the sorted, distinct schema strings of the input paths needed to
+// provide the given output schema string. The returned slice must not be modified.
+func (ft *FunctionalTranslator) InputSchemasForOutput(output string) []string {
+	return ft.index.inputSchemas[output]
+}
+
+// OutputsForInput returns the sorted output schema strings which are provided from the given input
+// schema string, e.g. "/eos_native/Kernel/proc/cpu/utilization/total". The returned slice must not
+// be modified.
+func (ft *FunctionalTranslator) OutputsForInput(input string) []string {
+	return ft.index.outputs[input]
+}
+
+// InputSchemas returns the sorted, distinct schema strings of all the input paths of the FT. The
+// returned slice must not be modified.
+func (ft *FunctionalTranslator) InputSchemas() []string {
+	return ft.index.allInputSchemas
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/functional-translators/ftutilities"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestSchemaIndex(t *testing.T) {
+	const (
+		operStatus = "/openconfig/interfaces/interface/state/oper-status"
+		adminState = "/openconfig/interfaces/interface/state/admin-status"
+		counters   = "/openconfig/interfaces/interface/state/counters/in-pkts"
+		status     = "/eos_native/Sysdb/interface/status/eth/phy/slice/1/intfStatus"
+		config     = "/eos_native/Sysdb/interface/config/eth/phy/slice/1/intfConfig"
+		smash      = "/eos_native/Smash/counters/ethIntf/FocalPointV2/current/counter"
+	)
+	outputToInput := ftutilities.MustStringMapPaths(map[string][]string{
+		operStatus: {status},
+		adminState: {config, status, config},
+		counters:   {smash},
+	})
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID:               "test-ft",
+		Translate:        func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) { return sr, nil },
+		OutputToInputMap: outputToInput,
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+	}
+
+	for _, tc := range []struct {
+		output           string
+		wantInputs       []*gnmipb.Path
+		wantOK           bool
+		wantInputSchemas []string
+	}{
+		{
+			output:           operStatus,
+			wantInputs:       outputToInput[operStatus],
+			wantOK:           true,
+			wantInputSchemas: []string{status},
+		},
+		{
+			output:           adminState,
+			wantInputs:       outputToInput[adminState],
+			wantOK:           true,
+			wantInputSchemas: []string{config, status},
+		},
+		{
+			output: "/openconfig/interfaces/interface/state/mtu",
+		},
+	} {
+		gotInputs, gotOK := ft.InputsForOutput(tc.output)
+		if gotOK != tc.wantOK {
+			t.Errorf("InputsForOutput(%q) got ok %t, want %t", tc.output, gotOK, tc.wantOK)
+		}
+		if diff := cmp.Diff(tc.wantInputs, gotInputs, protocmp.Transform()); diff != "" {
+			t.Errorf("InputsForOutput(%q) returned unexpected diff (-want +got):\n%s", tc.output, diff)
+		}
+		if diff := cmp.Diff(tc.wantInputSchemas, ft.InputSchemasForOutput(tc.output)); diff != "" {
+			t.Errorf("InputSchemasForOutput(%q) returned unexpected diff (-want +got):\n%s", tc.output, diff)
+		}
+	}
+
+	for _, tc := range []struct {
+		input string
+		want  []string
+	}{
+		{input: status, want: []string{adminState, operStatus}},
+		{input: config, want: []string{adminState}},
+		{input: smash, want: []string{counters}},
+		{input: "/eos_native/Sysdb/unknown"},
+	} {
+		if diff := cmp.Diff(tc.want, ft.OutputsForInput(tc.input)); diff != "" {
+			t.Errorf("OutputsForInput(%q) returned unexpected diff (-want +got):\n%s", tc.input, diff)
+		}
+	}
+
+	if diff := cmp.Diff([]string{smash, config, status}, ft.InputSchemas()); diff != "" {
+		t.Errorf("InputSchemas() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Instrumentation receives the result and latency of the translations of the FTs, e.g. to export
+// them as metrics. Its methods are called concurrently by the FTs and must be fast. Sync responses
+// are not reported.
+type Instrumentation interface {
+	// Translated is called when an FT translated a response to an output, including an output
+	// suppressed in dry-run mode.
+	Translated(id string, latency time.Duration)
+	// Dropped is called when an FT translated a response to no output, e.g. because it did not
+	// contain any of its inputs.
+	Dropped(id string, latency time.Duration)
+	// Failed is called when an FT failed to translate a response.
+	Failed(id string, latency time.Duration, err error)
+}
+
+// instrumentationHolder allows storing an Instrumentation in an atomic.Pointer.
+type instrumentationHolder struct {
+	i Instrumentation
+}
+
+// globalInstrumentation holds the instrumentation of the FTs which do not set their own.
+var globalInstrumentation atomic.Pointer[instrumentationHolder]
+
+// SetGlobalInstrumentation sets the instrumentation of all FTs which were not created with their
+// own Instrumentation. A nil instrumentation disables it.
+func SetGlobalInstrumentation(i Instrumentation) {
+	globalInstrumentation.Store(&instrumentationHolder{i: i})
+}
+
+// Instrumentation returns the instrumentation of the FT, or nil if it is not instrumented.
+func (ft *FunctionalTranslator) Instrumentation() Instrumentation {
+	if ft.instrumentation != nil {
+		return ft.instrumentation
+	}
+	if h := globalInstrumentation.Load(); h != nil {
+		return h.i
+	}
+	return nil
+}
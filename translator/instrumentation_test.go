@@ -0,0 +1,107 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// fakeInstrumentation records the results reported by the FTs.
+type fakeInstrumentation struct {
+	results []string
+}
+
+func (f *fakeInstrumentation) Translated(id string, _ time.Duration) {
+	f.results = append(f.results, id+":translated")
+}
+
+func (f *fakeInstrumentation) Dropped(id string, _ time.Duration) {
+	f.results = append(f.results, id+":dropped")
+}
+
+func (f *fakeInstrumentation) Failed(id string, _ time.Duration, _ error) {
+	f.results = append(f.results, id+":failed")
+}
+
+func TestInstrumentation(t *testing.T) {
+	q0 := counterPath("0", "transmit-pkts")
+	syncSR := &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true}}
+	inputs := []*gnmipb.SubscribeResponse{
+		counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 1)}),
+		counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 0)}),
+		counterSR("dut", nil),
+		syncSR,
+	}
+	translate := func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+		updates := sr.GetUpdate().GetUpdate()
+		if len(updates) == 0 {
+			return nil, nil
+		}
+		if updates[0].GetVal().GetUintVal() == 0 {
+			return nil, fmt.Errorf("counter is 0")
+		}
+		return sr, nil
+	}
+
+	tests := []struct {
+		name   string
+		own    bool
+		global bool
+		want   []string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "own",
+			own:  true,
+			want: []string{"test-ft:translated", "test-ft:failed", "test-ft:dropped"},
+		},
+		{
+			name:   "global",
+			global: true,
+			want:   []string{"test-ft:translated", "test-ft:failed", "test-ft:dropped"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			inst := &fakeInstrumentation{}
+			opts := FunctionalTranslatorOptions{ID: "test-ft", Translate: translate}
+			if tc.own {
+				opts.Instrumentation = inst
+			}
+			if tc.global {
+				SetGlobalInstrumentation(inst)
+				t.Cleanup(func() { SetGlobalInstrumentation(nil) })
+			}
+			ft, err := NewFunctionalTranslator(opts)
+			if err != nil {
+				t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+			}
+			for _, sr := range inputs {
+				ft.Translate(sr)
+			}
+			if diff := cmp.Diff(tc.want, inst.results); diff != "" {
+				t.Errorf("Translate() reported an unexpected diff (-want +got): %v", diff)
+			}
+		})
+	}
+}
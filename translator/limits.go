@@ -0,0 +1,149 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	log "github.com/openconfig/functional-translators/ftlog"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// NotificationLimits bounds the notifications output by an FT, so that large tables do not exceed
+// the maximum gRPC message size of the collector. A zero value means no limit.
+type NotificationLimits struct {
+	// MaxUpdates is the maximum number of updates and deletes in a notification.
+	MaxUpdates int
+	// MaxBytes is the maximum serialized size of a SubscribeResponse.
+	MaxBytes int
+}
+
+// globalLimits holds the limits of the FTs which do not set their own.
+var globalLimits atomic.Pointer[NotificationLimits]
+
+// SetGlobalNotificationLimits sets the limits applied by TranslateSplit for all FTs which were
+// not created with their own NotificationLimits.
+func SetGlobalNotificationLimits(l NotificationLimits) {
+	globalLimits.Store(&l)
+}
+
+// lengthVarintSlack is the number of bytes reserved for the growth of the length prefix of the
+// notification within the SubscribeResponse as updates are added.
+const lengthVarintSlack = 4
+
+// NotificationLimits returns the limits applied by TranslateSplit.
+func (ft *FunctionalTranslator) NotificationLimits() NotificationLimits {
+	if ft.limits != nil {
+		return *ft.limits
+	}
+	if l := globalLimits.Load(); l != nil {
+		return *l
+	}
+	return NotificationLimits{}
+}
+
+// TranslateSplit translates the input as TranslateResponses does, and splits the output
+// notifications into several notifications if they exceed the NotificationLimits of the FT. See
+// SplitResponse.
+func (ft *FunctionalTranslator) TranslateSplit(input *gnmipb.SubscribeResponse) ([]*gnmipb.SubscribeResponse, error) {
+	outs, err := ft.TranslateResponses(input)
+	if err != nil {
+		return nil, err
+	}
+	return ft.split(outs)
+}
+
+// split splits the outputs of the FT exceeding its notification limits.
+func (ft *FunctionalTranslator) split(outs []*gnmipb.SubscribeResponse) ([]*gnmipb.SubscribeResponse, error) {
+	var srs []*gnmipb.SubscribeResponse
+	for _, out := range outs {
+		split, err := SplitResponse(out, ft.NotificationLimits())
+		if err != nil {
+			return nil, fmt.Errorf("%s failed to split output: %v", ft.id, err)
+		}
+		srs = append(srs, split...)
+	}
+	return srs, nil
+}
+
+// SplitResponse splits a SubscribeResponse whose notification exceeds the limits into several
+// notifications with the same prefix and timestamp. Deletes are placed before updates, as they
+// are applied before the updates of a notification. A single update larger than MaxBytes is sent
+// in its own notification. Atomic notifications cannot be split, and an error is returned if they
+// exceed the limits. Responses which are not notifications are returned unchanged.
+func SplitResponse(sr *gnmipb.SubscribeResponse, limits NotificationLimits) ([]*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil || (limits.MaxUpdates <= 0 && limits.MaxBytes <= 0) {
+		return []*gnmipb.SubscribeResponse{sr}, nil
+	}
+	count := len(n.GetUpdate()) + len(n.GetDelete())
+	if (limits.MaxUpdates <= 0 || count <= limits.MaxUpdates) && (limits.MaxBytes <= 0 || proto.Size(sr) <= limits.MaxBytes) {
+		return []*gnmipb.SubscribeResponse{sr}, nil
+	}
+	if n.GetAtomic() {
+		return nil, fmt.Errorf("atomic notification with %d updates and deletes of %d bytes exceeds limits %+v", count, proto.Size(sr), limits)
+	}
+
+	newResponse := func() *gnmipb.SubscribeResponse {
+		return &gnmipb.SubscribeResponse{
+			Response: &gnmipb.SubscribeResponse_Update{
+				Update: &gnmipb.Notification{
+					Timestamp: n.GetTimestamp(),
+					Prefix:    n.GetPrefix(),
+				},
+			},
+		}
+	}
+	base := proto.Size(newResponse()) + lengthVarintSlack
+
+	var ret []*gnmipb.SubscribeResponse
+	var (
+		cur      *gnmipb.SubscribeResponse
+		curCount int
+		curSize  int
+	)
+	add := func(m proto.Message, isDelete bool) {
+		// Updates and deletes are length-delimited fields with single byte tags.
+		size := 1 + protowire.SizeBytes(proto.Size(m))
+		full := cur != nil && ((limits.MaxUpdates > 0 && curCount >= limits.MaxUpdates) ||
+			(limits.MaxBytes > 0 && curCount > 0 && curSize+size > limits.MaxBytes))
+		if cur == nil || full {
+			cur = newResponse()
+			curCount, curSize = 0, base
+			ret = append(ret, cur)
+		}
+		if limits.MaxBytes > 0 && curCount == 0 && curSize+size > limits.MaxBytes {
+			log.Warningf("Update %v of %d bytes exceeds the notification size limit of %d bytes", m, size, limits.MaxBytes)
+		}
+		if isDelete {
+			cur.GetUpdate().Delete = append(cur.GetUpdate().Delete, m.(*gnmipb.Path))
+		} else {
+			cur.GetUpdate().Update = append(cur.GetUpdate().Update, m.(*gnmipb.Update))
+		}
+		curCount++
+		curSize += size
+	}
+	for _, d := range n.GetDelete() {
+		add(d, true)
+	}
+	for _, u := range n.GetUpdate() {
+		add(u, false)
+	}
+	return ret, nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestSplitResponse(t *testing.T) {
+	q0, q1, q2 := counterPath("0", "transmit-pkts"), counterPath("1", "transmit-pkts"), counterPath("2", "transmit-pkts")
+	d0 := counterPath("3", "transmit-pkts")
+	input := counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 1), uintUpdate(q1, 2), uintUpdate(q2, 3)}, d0)
+	oneUpdateSize := proto.Size(counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 1)}))
+
+	tests := []struct {
+		name    string
+		input   *gnmipb.SubscribeResponse
+		limits  NotificationLimits
+		want    []*gnmipb.SubscribeResponse
+		wantErr bool
+	}{
+		{
+			name:  "no_limits",
+			input: input,
+			want:  []*gnmipb.SubscribeResponse{input},
+		},
+		{
+			name:   "within_limits",
+			input:  input,
+			limits: NotificationLimits{MaxUpdates: 4, MaxBytes: proto.Size(input)},
+			want:   []*gnmipb.SubscribeResponse{input},
+		},
+		{
+			name:   "max_updates",
+			input:  input,
+			limits: NotificationLimits{MaxUpdates: 2},
+			want: []*gnmipb.SubscribeResponse{
+				counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 1)}, d0),
+				counterSR("dut", []*gnmipb.Update{uintUpdate(q1, 2), uintUpdate(q2, 3)}),
+			},
+		},
+		{
+			name:   "max_bytes",
+			input:  input,
+			limits: NotificationLimits{MaxBytes: oneUpdateSize + lengthVarintSlack},
+			want: []*gnmipb.SubscribeResponse{
+				counterSR("dut", nil, d0),
+				counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 1)}),
+				counterSR("dut", []*gnmipb.Update{uintUpdate(q1, 2)}),
+				counterSR("dut", []*gnmipb.Update{uintUpdate(q2, 3)}),
+			},
+		},
+		{
+			name:   "update_larger_than_max_bytes",
+			input:  counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 1), uintUpdate(q1, 2)}),
+			limits: NotificationLimits{MaxBytes: 10},
+			want: []*gnmipb.SubscribeResponse{
+				counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 1)}),
+				counterSR("dut", []*gnmipb.Update{uintUpdate(q1, 2)}),
+			},
+		},
+		{
+			name: "atomic",
+			input: &gnmipb.SubscribeResponse{
+				Response: &gnmipb.SubscribeResponse_Update{
+					Update: &gnmipb.Notification{Atomic: true, Update: []*gnmipb.Update{uintUpdate(q0, 1), uintUpdate(q1, 2)}},
+				},
+			},
+			limits:  NotificationLimits{MaxUpdates: 1},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SplitResponse(tc.input, tc.limits)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("SplitResponse() got error %v, want error: %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("SplitResponse() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			for _, sr := range got {
+				if tc.limits.MaxBytes > 0 && len(sr.GetUpdate().GetUpdate())+len(sr.GetUpdate().GetDelete()) > 1 && proto.Size(sr) > tc.limits.MaxBytes {
+					t.Errorf("SplitResponse() returned %d bytes, want at most %d", proto.Size(sr), tc.limits.MaxBytes)
+				}
+			}
+		})
+	}
+}
+
+func TestTranslateSplit(t *testing.T) {
+	q0, q1 := counterPath("0", "transmit-pkts"), counterPath("1", "transmit-pkts")
+	input := counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 1), uintUpdate(q1, 2)})
+	newFT := func(limits *NotificationLimits) *FunctionalTranslator {
+		ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+			ID:                 "test-ft",
+			Translate:          func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) { return sr, nil },
+			NotificationLimits: limits,
+		})
+		if err != nil {
+			t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+		}
+		return ft
+	}
+	split := []*gnmipb.SubscribeResponse{
+		counterSR("dut", []*gnmipb.Update{uintUpdate(q0, 1)}),
+		counterSR("dut", []*gnmipb.Update{uintUpdate(q1, 2)}),
+	}
+
+	tests := []struct {
+		name   string
+		global *NotificationLimits
+		ft     *FunctionalTranslator
+		want   []*gnmipb.SubscribeResponse
+	}{
+		{
+			name: "no_limits",
+			ft:   newFT(nil),
+			want: []*gnmipb.SubscribeResponse{input},
+		},
+		{
+			name:   "global_limits",
+			global: &NotificationLimits{MaxUpdates: 1},
+			ft:     newFT(nil),
+			want:   split,
+		},
+		{
+			name:   "ft_limits_override_global",
+			global: &NotificationLimits{MaxUpdates: 1},
+			ft:     newFT(&NotificationLimits{MaxUpdates: 2}),
+			want:   []*gnmipb.SubscribeResponse{input},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.global != nil {
+				SetGlobalNotificationLimits(*tc.global)
+				t.Cleanup(func() { SetGlobalNotificationLimits(NotificationLimits{}) })
+			}
+			got, err := tc.ft.TranslateSplit(input)
+			if err != nil {
+				t.Fatalf("TranslateSplit() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("TranslateSplit() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
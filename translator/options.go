@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Options are the per-deployment options of an FT, keyed by name, e.g. "debounce": "1s", passed
+// to its TranslateWithOptions function. The FTs document the options they read, and ignore the
+// others. Options must not be modified once passed to an FT.
+type Options map[string]string
+
+// String returns the value of the option, or def if it is not set.
+func (o Options) String(name, def string) string {
+	if v, ok := o[name]; ok {
+		return v
+	}
+	return def
+}
+
+// Bool returns the value of the boolean option, e.g. "true", or def if it is not set.
+func (o Options) Bool(name string, def bool) (bool, error) {
+	v, ok := o[name]
+	if !ok {
+		return def, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid boolean option %s=%q: %v", name, v, err)
+	}
+	return b, nil
+}
+
+// Duration returns the value of the duration option, e.g. "1s", or def if it is not set.
+func (o Options) Duration(name string, def time.Duration) (time.Duration, error) {
+	v, ok := o[name]
+	if !ok {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration option %s=%q: %v", name, v, err)
+	}
+	return d, nil
+}
+
+// optionsHolder holds the options of an FT, replaced atomically by SetOptions.
+type optionsHolder struct {
+	options atomic.Pointer[Options]
+}
+
+func (h *optionsHolder) load() Options {
+	if o := h.options.Load(); o != nil {
+		return *o
+	}
+	return nil
+}
+
+func (h *optionsHolder) store(o Options) {
+	c := maps.Clone(o)
+	h.options.Store(&c)
+}
+
+// SetOptions replaces the options of the FT, passed to its TranslateWithOptions function from the
+// next translated notification, e.g. with the configuration of the deployment. It returns an error,
+// and keeps the current options, if the FT rejects them.
+func (ft *FunctionalTranslator) SetOptions(o Options) error {
+	if ft.validateOptions != nil {
+		if err := ft.validateOptions(o); err != nil {
+			return fmt.Errorf("%s rejected options %v: %v", ft.id, o, err)
+		}
+	}
+	ft.options.store(o)
+	return nil
+}
+
+// Options returns a copy of the options of the FT.
+func (ft *FunctionalTranslator) Options() Options {
+	return maps.Clone(ft.options.load())
+}
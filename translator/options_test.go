@@ -0,0 +1,154 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// newDoublingFT returns an FT doubling the value of its single counter update if its "double"
+// option is set.
+func newDoublingFT(opts Options) (*FunctionalTranslator, error) {
+	return NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID: "test-ft",
+		TranslateWithOptions: func(sr *gnmipb.SubscribeResponse, opts Options) (*gnmipb.SubscribeResponse, error) {
+			double, err := opts.Bool("double", false)
+			if err != nil {
+				return nil, err
+			}
+			v := sr.GetUpdate().GetUpdate()[0].GetVal().GetUintVal()
+			if double {
+				v *= 2
+			}
+			return counterSR("dut", []*gnmipb.Update{uintUpdate(counterPath("0", "transmit-pkts"), v)}), nil
+		},
+		Options: opts,
+		ValidateOptions: func(opts Options) error {
+			_, err := opts.Bool("double", false)
+			return err
+		},
+	})
+}
+
+func TestTranslateWithOptions(t *testing.T) {
+	input := counterSR("dut", []*gnmipb.Update{uintUpdate(counterPath("0", "transmit-pkts"), 21)})
+	ft, err := newDoublingFT(Options{"double": "true"})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator() returned an unexpected error: %v", err)
+	}
+	for _, tc := range []struct {
+		name    string
+		options Options
+		wantErr bool
+		want    uint64
+	}{
+		{
+			name: "initial options",
+			want: 42,
+		},
+		{
+			name:    "options set",
+			options: Options{"double": "false"},
+			want:    21,
+		},
+		{
+			name:    "invalid options kept",
+			options: Options{"double": "twice"},
+			wantErr: true,
+			want:    21,
+		},
+		{
+			name:    "options removed",
+			options: Options{},
+			want:    21,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.options != nil {
+				if err := ft.SetOptions(tc.options); (err != nil) != tc.wantErr {
+					t.Fatalf("SetOptions(%v) returned error %v, want error %t", tc.options, err, tc.wantErr)
+				}
+			}
+			got, err := ft.Translate(input)
+			if err != nil {
+				t.Fatalf("Translate() returned an unexpected error: %v", err)
+			}
+			want := counterSR("dut", []*gnmipb.Update{uintUpdate(counterPath("0", "transmit-pkts"), tc.want)})
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Translate() returned an unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewFunctionalTranslatorOptions(t *testing.T) {
+	if _, err := newDoublingFT(Options{"double": "twice"}); err == nil {
+		t.Errorf("NewFunctionalTranslator() with invalid options returned nil error, want error")
+	}
+	identity := func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) { return sr, nil }
+	_, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID:        "test-ft",
+		Translate: identity,
+		TranslateWithOptions: func(sr *gnmipb.SubscribeResponse, _ Options) (*gnmipb.SubscribeResponse, error) {
+			return sr, nil
+		},
+	})
+	if err == nil {
+		t.Errorf("NewFunctionalTranslator() with both translate functions returned nil error, want error")
+	}
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{ID: "test-ft", Translate: identity, Options: Options{"a": "b"}})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator() returned an unexpected error: %v", err)
+	}
+	got := ft.Options()
+	got["a"] = "c"
+	if diff := cmp.Diff(Options{"a": "b"}, ft.Options()); diff != "" {
+		t.Errorf("Options() returned an unexpected diff after modifying a previous copy (-want +got):\n%s", diff)
+	}
+}
+
+func TestOptionsAccessors(t *testing.T) {
+	opts := Options{"name": "value", "enabled": "true", "interval": "1.5s", "bad": "x"}
+	if got := opts.String("name", "def"); got != "value" {
+		t.Errorf("String(name) = %q, want %q", got, "value")
+	}
+	if got := opts.String("missing", "def"); got != "def" {
+		t.Errorf("String(missing) = %q, want %q", got, "def")
+	}
+	if got, err := opts.Bool("enabled", false); err != nil || !got {
+		t.Errorf("Bool(enabled) = %t, %v, want true, nil", got, err)
+	}
+	if got, err := opts.Bool("missing", true); err != nil || !got {
+		t.Errorf("Bool(missing) = %t, %v, want true, nil", got, err)
+	}
+	if _, err := opts.Bool("bad", false); err == nil {
+		t.Errorf("Bool(bad) returned nil error, want error")
+	}
+	if got, err := opts.Duration("interval", 0); err != nil || got != 1500*time.Millisecond {
+		t.Errorf("Duration(interval) = %v, %v, want 1.5s, nil", got, err)
+	}
+	if got, err := opts.Duration("missing", time.Second); err != nil || got != time.Second {
+		t.Errorf("Duration(missing) = %v, %v, want 1s, nil", got, err)
+	}
+	if _, err := opts.Duration("bad", 0); err == nil {
+		t.Errorf("Duration(bad) returned nil error, want error")
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"fmt"
+
+	"github.com/openconfig/functional-translators/ftutilities"
+	"google.golang.org/protobuf/proto"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	// PassthroughID is the ID of the FTs created by NewPassthrough.
+	PassthroughID = "passthrough-ft"
+
+	openconfigOrigin = "openconfig"
+)
+
+// NewPassthrough returns an FT forwarding the openconfig updates and deletes at or under one of the
+// allowed paths unchanged, and dropping everything else, e.g. to combine translated native data
+// with filtered openconfig data of the same subscription. The allowed paths must have the
+// openconfig origin or no origin, and may contain wildcards as in ftutilities.MatchPath; their key
+// values are significant. Notifications without origin are assumed to be openconfig, as Arista
+// devices do not set it. The FT supports all devices.
+func NewPassthrough(allowedPaths []*gnmipb.Path) (*FunctionalTranslator, error) {
+	if len(allowedPaths) == 0 {
+		return nil, fmt.Errorf("%s has no allowed paths", PassthroughID)
+	}
+	p := &passthrough{}
+	outputToInput := map[string][]*gnmipb.Path{}
+	for _, a := range allowedPaths {
+		if a.GetOrigin() != "" && a.GetOrigin() != openconfigOrigin {
+			return nil, fmt.Errorf("%s allowed path %v has origin %q, want %q", PassthroughID, a, a.GetOrigin(), openconfigOrigin)
+		}
+		in := &gnmipb.Path{Origin: openconfigOrigin, Elem: a.GetElem()}
+		out := ftutilities.GNMIPathToSchemaString(in, true)
+		outputToInput[out] = append(outputToInput[out], in)
+		pattern := proto.Clone(in).(*gnmipb.Path)
+		pattern.Elem = append(pattern.Elem, &gnmipb.PathElem{Name: "..."})
+		p.patterns = append(p.patterns, pattern)
+	}
+	return NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID:               PassthroughID,
+		Translate:        p.translate,
+		OutputToInputMap: outputToInput,
+	})
+}
+
+type passthrough struct {
+	// patterns match the paths at or under the allowed paths.
+	patterns []*gnmipb.Path
+}
+
+func (p *passthrough) allowed(path *gnmipb.Path, _ bool) bool {
+	if path.GetOrigin() != "" && path.GetOrigin() != openconfigOrigin {
+		return false
+	}
+	for _, pattern := range p.patterns {
+		if ftutilities.MatchPath(path, pattern, ftutilities.MatchPathWithKeys()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *passthrough) translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	n := sr.GetUpdate()
+	if n == nil {
+		return nil, nil
+	}
+	filtered := ftutilities.Filter(n, p.allowed)
+	if filtered == nil {
+		return nil, nil
+	}
+	filtered.Atomic = n.GetAtomic()
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{Update: filtered},
+	}, nil
+}
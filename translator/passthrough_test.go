@@ -0,0 +1,136 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestNewPassthroughErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+	}{
+		{name: "no allowed paths"},
+		{name: "native origin", allowed: []string{"eos_native:/Sysdb/interface"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var allowed []*gnmipb.Path
+			for _, a := range tc.allowed {
+				allowed = append(allowed, mustPath(t, a))
+			}
+			if _, err := NewPassthrough(allowed); err == nil {
+				t.Errorf("NewPassthrough(%v) got no error, want error", tc.allowed)
+			}
+		})
+	}
+}
+
+func TestPassthroughTranslate(t *testing.T) {
+	ft, err := NewPassthrough([]*gnmipb.Path{
+		mustPath(t, "/interfaces/interface[name=Ethernet1]/state"),
+		mustPath(t, "openconfig:/system/*/state"),
+	})
+	if err != nil {
+		t.Fatalf("NewPassthrough() got unexpected error: %v", err)
+	}
+	if got, want := ft.OutputToInputMap(), outputToInput(t, map[string][]string{
+		"/openconfig/interfaces/interface/state": {"openconfig:/interfaces/interface[name=Ethernet1]/state"},
+		"/openconfig/system/*/state":             {"openconfig:/system/*/state"},
+	}); !cmp.Equal(got, want, protocmp.Transform()) {
+		t.Errorf("OutputToInputMap() = %v, want %v", got, want)
+	}
+
+	update := func(path string, v uint64) *gnmipb.Update {
+		return &gnmipb.Update{Path: mustPath(t, path), Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}}}
+	}
+	tests := []struct {
+		name  string
+		input *gnmipb.Notification
+		want  *gnmipb.Notification
+	}{
+		{
+			name: "filtered",
+			input: &gnmipb.Notification{
+				Timestamp: 1,
+				Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "dut"},
+				Update: []*gnmipb.Update{
+					update("/interfaces/interface[name=Ethernet1]/state/counters/in-pkts", 1),
+					update("/interfaces/interface[name=Ethernet2]/state/counters/in-pkts", 2),
+					update("/system/memory/state/physical", 3),
+					update("/system/memory/config/physical", 4),
+				},
+				Delete: []*gnmipb.Path{
+					mustPath(t, "/interfaces/interface[name=Ethernet1]/state/description"),
+					mustPath(t, "/interfaces"),
+				},
+			},
+			want: &gnmipb.Notification{
+				Timestamp: 1,
+				Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "dut"},
+				Update: []*gnmipb.Update{
+					update("/interfaces/interface[name=Ethernet1]/state/counters/in-pkts", 1),
+					update("/system/memory/state/physical", 3),
+				},
+				Delete: []*gnmipb.Path{mustPath(t, "/interfaces/interface[name=Ethernet1]/state/description")},
+			},
+		},
+		{
+			name: "no origin",
+			input: &gnmipb.Notification{
+				Prefix: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "system"}}},
+				Update: []*gnmipb.Update{update("/cpus/state/total", 5)},
+			},
+			want: &gnmipb.Notification{
+				Prefix: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "system"}}},
+				Update: []*gnmipb.Update{update("/cpus/state/total", 5)},
+			},
+		},
+		{
+			name: "native origin dropped",
+			input: &gnmipb.Notification{
+				Prefix: &gnmipb.Path{Origin: "eos_native"},
+				Update: []*gnmipb.Update{update("/interfaces/interface[name=Ethernet1]/state/mtu", 6)},
+			},
+		},
+		{
+			name: "nothing allowed",
+			input: &gnmipb.Notification{
+				Update: []*gnmipb.Update{update("/components/component[name=x]/state/temperature", 7)},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ft.Translate(&gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_Update{Update: tc.input}})
+			if err != nil {
+				t.Fatalf("Translate() got unexpected error: %v", err)
+			}
+			var want *gnmipb.SubscribeResponse
+			if tc.want != nil {
+				want = &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_Update{Update: tc.want}}
+			}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Translate() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
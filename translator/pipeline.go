@@ -0,0 +1,232 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/ygot/ygot"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// PipelineOptions contains the options of a pipeline created by NewPipeline.
+type PipelineOptions struct {
+	ID string
+	// Stages are the chained FTs. The output of each stage is the input of the next stage, e.g. a
+	// native to openconfig FT followed by an FT aggregating the openconfig counters.
+	Stages []*FunctionalTranslator
+	// Metadata is the metadata of the pipeline. Defaults to the metadata of the first stage.
+	Metadata []*FTMetadata
+}
+
+// StageError is the error returned by a pipeline when one of its stages fails.
+type StageError struct {
+	// Stage is the index of the failed stage.
+	Stage int
+	// ID is the ID of the FT of the failed stage.
+	ID  string
+	Err error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("stage %d (%s): %v", e.Stage, e.ID, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// NewPipeline returns an FT chaining the given stages. Its OutputToInputMap maps the outputs of
+// the last stage to the inputs of the first stage they are built from, and every input of a stage
+// must be provided by the outputs of the previous stage. Errors of the stages are returned as
+// *StageError. The pipeline is stateful if one of its stages is.
+func NewPipeline(opts PipelineOptions) (*FunctionalTranslator, error) {
+	if len(opts.Stages) == 0 {
+		return nil, fmt.Errorf("pipeline %s has no stages", opts.ID)
+	}
+	outputToInput := opts.Stages[0].OutputToInputMap()
+	for i := 1; i < len(opts.Stages); i++ {
+		var err error
+		if outputToInput, err = chainPaths(outputToInput, opts.Stages[i]); err != nil {
+			return nil, fmt.Errorf("pipeline %s: %v", opts.ID, err)
+		}
+	}
+	metadata := opts.Metadata
+	if metadata == nil {
+		metadata = opts.Stages[0].Metadata()
+	}
+	p := &pipeline{stages: opts.Stages}
+	var state *StateOptions
+	for _, s := range opts.Stages {
+		if s.Stateful() {
+			state = &StateOptions{
+				Reset:        p.reset,
+				State:        p.state,
+				RestoreState: p.restoreState,
+				ResetTarget:  p.resetTarget,
+			}
+			break
+		}
+	}
+	var flush func() ([]*gnmipb.Notification, error)
+	for _, s := range opts.Stages {
+		if s.flush != nil {
+			flush = p.flush
+			break
+		}
+	}
+	return NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID:               opts.ID,
+		Translate:        p.translate,
+		Sync:             p.sync,
+		Flush:            flush,
+		OutputToInputMap: outputToInput,
+		Metadata:         metadata,
+		State:            state,
+	})
+}
+
+// chainPaths returns the OutputToInputMap of the outputs of next, with the inputs of prev the
+// inputs of next are built from. An input of next is built from the outputs of prev at, under or
+// above it, since inputs are often subscribed at a container.
+func chainPaths(prev map[string][]*gnmipb.Path, next *FunctionalTranslator) (map[string][]*gnmipb.Path, error) {
+	chained := map[string][]*gnmipb.Path{}
+	for out, inputs := range next.OutputToInputMap() {
+		seen := map[string]bool{}
+		for _, in := range inputs {
+			s := ftutilities.GNMIPathToSchemaString(in, false)
+			provided := false
+			for prevOut, prevInputs := range prev {
+				if prevOut != s && !strings.HasPrefix(prevOut, s+"/") && !strings.HasPrefix(s, prevOut+"/") {
+					continue
+				}
+				provided = true
+				for _, p := range prevInputs {
+					key, err := ygot.PathToString(p)
+					if err != nil {
+						return nil, fmt.Errorf("invalid input path %v: %v", p, err)
+					}
+					key = p.GetOrigin() + ":" + key
+					if !seen[key] {
+						seen[key] = true
+						chained[out] = append(chained[out], p)
+					}
+				}
+			}
+			if !provided {
+				return nil, fmt.Errorf("input %s of %s is not provided by the previous stage", s, next.ID())
+			}
+		}
+		sort.Slice(chained[out], ftutilities.SortByYgotString(chained[out]))
+	}
+	return chained, nil
+}
+
+type pipeline struct {
+	stages []*FunctionalTranslator
+}
+
+func (p *pipeline) translate(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	return p.translateFrom(0, sr)
+}
+
+// translateFrom translates the response with the stages from the given one.
+func (p *pipeline) translateFrom(first int, sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	for i := first; i < len(p.stages); i++ {
+		out, err := p.stages[i].Translate(sr)
+		if err != nil {
+			return nil, &StageError{Stage: i, ID: p.stages[i].ID(), Err: err}
+		}
+		if out == nil {
+			return nil, nil
+		}
+		sr = out
+	}
+	return sr, nil
+}
+
+// sync passes the sync response of the target to every stage, in order.
+func (p *pipeline) sync(target string, sr *gnmipb.SubscribeResponse) error {
+	for i, s := range p.stages {
+		if err := s.handleSync(target, sr); err != nil {
+			return &StageError{Stage: i, ID: s.ID(), Err: err}
+		}
+	}
+	return nil
+}
+
+// flush returns the notifications flushed by every stage at sync, translated by the following
+// stages.
+func (p *pipeline) flush() ([]*gnmipb.Notification, error) {
+	var flushed []*gnmipb.Notification
+	for i, s := range p.stages {
+		if s.flush == nil {
+			continue
+		}
+		notifs, err := s.flush()
+		if err != nil {
+			return nil, &StageError{Stage: i, ID: s.ID(), Err: err}
+		}
+		for _, n := range notifs {
+			out, err := p.translateFrom(i+1, &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_Update{Update: n}})
+			if err != nil {
+				return nil, err
+			}
+			if out != nil {
+				flushed = append(flushed, out.GetUpdate())
+			}
+		}
+	}
+	return flushed, nil
+}
+
+func (p *pipeline) reset() {
+	for _, s := range p.stages {
+		s.Reset()
+	}
+}
+
+// resetTarget discards the state of the target in every stage.
+func (p *pipeline) resetTarget(target string) {
+	for _, s := range p.stages {
+		s.OnTargetDisconnect(target)
+	}
+}
+
+// state returns the states of the stages.
+func (p *pipeline) state() any {
+	states := make([]any, len(p.stages))
+	for i, s := range p.stages {
+		states[i] = s.State()
+	}
+	return states
+}
+
+func (p *pipeline) restoreState(snapshot any) error {
+	states, ok := snapshot.([]any)
+	if !ok || len(states) != len(p.stages) {
+		return fmt.Errorf("unexpected state %T, want the states of %d stages", snapshot, len(p.stages))
+	}
+	for i, s := range p.stages {
+		if err := s.RestoreState(states[i]); err != nil {
+			return &StageError{Stage: i, ID: s.ID(), Err: err}
+		}
+	}
+	return nil
+}
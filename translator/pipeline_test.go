@@ -0,0 +1,274 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var nativeCountersPath = &gnmipb.Path{
+	Origin: "eos_native",
+	Elem:   []*gnmipb.PathElem{{Name: "Sysdb"}, {Name: "qos"}, {Name: "counters"}},
+}
+
+// newNativeStage returns an FT emitting the value of the first native update as the transmit-pkts
+// counter of queue 0, and nil for notifications without updates.
+func newNativeStage(t *testing.T) *FunctionalTranslator {
+	t.Helper()
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID: "native",
+		Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+			updates := sr.GetUpdate().GetUpdate()
+			if len(updates) == 0 {
+				return nil, nil
+			}
+			return counterSR("dut", []*gnmipb.Update{uintUpdate(counterPath("0", "transmit-pkts"), updates[0].GetVal().GetUintVal())}), nil
+		},
+		OutputToInputMap: map[string][]*gnmipb.Path{
+			"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts": {nativeCountersPath},
+		},
+		Metadata: []*FTMetadata{{Vendor: "test-vendor"}},
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+	}
+	return ft
+}
+
+// newIncrementStage returns an FT emitting the transmit-pkts counter of queue 0 incremented by
+// one, and failing for a counter of 0. It counts the notifications it translates as its state.
+func newIncrementStage(t *testing.T, input string) *FunctionalTranslator {
+	t.Helper()
+	count := 0
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID: "increment",
+		Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+			count++
+			v := sr.GetUpdate().GetUpdate()[0].GetVal().GetUintVal()
+			if v == 0 {
+				return nil, fmt.Errorf("counter is 0")
+			}
+			return counterSR("dut", []*gnmipb.Update{uintUpdate(counterPath("1", "transmit-pkts"), v+1)}), nil
+		},
+		OutputToInputMap: map[string][]*gnmipb.Path{
+			"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts": {
+				{Origin: "openconfig", Elem: []*gnmipb.PathElem{{Name: "qos"}, {Name: "interfaces"}, {Name: input}}},
+			},
+		},
+		State: &StateOptions{
+			Reset: func() { count = 0 },
+			State: func() any { return count },
+			RestoreState: func(snapshot any) error {
+				count = snapshot.(int)
+				return nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+	}
+	return ft
+}
+
+func TestNewPipeline(t *testing.T) {
+	tests := []struct {
+		name    string
+		stages  func(t *testing.T) []*FunctionalTranslator
+		want    map[string][]*gnmipb.Path
+		wantErr bool
+	}{
+		{
+			name: "chained",
+			stages: func(t *testing.T) []*FunctionalTranslator {
+				return []*FunctionalTranslator{newNativeStage(t), newIncrementStage(t, "interface")}
+			},
+			want: map[string][]*gnmipb.Path{
+				"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts": {nativeCountersPath},
+			},
+		},
+		{
+			name: "input_not_provided",
+			stages: func(t *testing.T) []*FunctionalTranslator {
+				return []*FunctionalTranslator{newNativeStage(t), newIncrementStage(t, "queues")}
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no_stages",
+			stages:  func(t *testing.T) []*FunctionalTranslator { return nil },
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ft, err := NewPipeline(PipelineOptions{ID: "pipeline", Stages: tc.stages(t)})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewPipeline() got error %v, want error: %t", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, ft.OutputToInputMap(), protocmp.Transform()); diff != "" {
+				t.Errorf("OutputToInputMap() returned an unexpected diff (-want +got): %v", diff)
+			}
+			if diff := cmp.Diff([]*FTMetadata{{Vendor: "test-vendor"}}, ft.Metadata()); diff != "" {
+				t.Errorf("Metadata() returned an unexpected diff (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestPipelineTranslate(t *testing.T) {
+	native := func(v uint64) *gnmipb.SubscribeResponse {
+		return &gnmipb.SubscribeResponse{
+			Response: &gnmipb.SubscribeResponse_Update{
+				Update: &gnmipb.Notification{
+					Prefix: &gnmipb.Path{Origin: "eos_native", Target: "dut"},
+					Update: []*gnmipb.Update{uintUpdate(nativeCountersPath, v)},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name      string
+		input     *gnmipb.SubscribeResponse
+		want      *gnmipb.SubscribeResponse
+		wantStage int
+		wantErr   bool
+	}{
+		{
+			name:  "translated",
+			input: native(1),
+			want:  counterSR("dut", []*gnmipb.Update{uintUpdate(counterPath("1", "transmit-pkts"), 2)}),
+		},
+		{
+			name:  "first_stage_nil",
+			input: counterSR("dut", nil),
+		},
+		{
+			name:      "second_stage_error",
+			input:     native(0),
+			wantStage: 1,
+			wantErr:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ft, err := NewPipeline(PipelineOptions{
+				ID:     "pipeline",
+				Stages: []*FunctionalTranslator{newNativeStage(t), newIncrementStage(t, "interface")},
+			})
+			if err != nil {
+				t.Fatalf("NewPipeline() got unexpected error: %v", err)
+			}
+			got, err := ft.Translate(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Translate() got error %v, want error: %t", err, tc.wantErr)
+			}
+			if err != nil {
+				var stageErr *StageError
+				if !errors.As(err, &stageErr) || stageErr.Stage != tc.wantStage {
+					t.Errorf("Translate() got error %v, want error of stage %d", err, tc.wantStage)
+				}
+				return
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Translate() returned an unexpected diff (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestPipelineFlush(t *testing.T) {
+	native, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID:        "native",
+		Translate: func(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) { return nil, nil },
+		Flush: func() ([]*gnmipb.Notification, error) {
+			return []*gnmipb.Notification{
+				counterSR("dut", []*gnmipb.Update{uintUpdate(counterPath("0", "transmit-pkts"), 1)}).GetUpdate(),
+			}, nil
+		},
+		OutputToInputMap: map[string][]*gnmipb.Path{
+			"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts": {nativeCountersPath},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+	}
+	ft, err := NewPipeline(PipelineOptions{
+		ID:     "pipeline",
+		Stages: []*FunctionalTranslator{native, newIncrementStage(t, "interface")},
+	})
+	if err != nil {
+		t.Fatalf("NewPipeline() got unexpected error: %v", err)
+	}
+	syncSR := &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true}}
+	got, err := ft.TranslateResponses(syncSR)
+	if err != nil {
+		t.Fatalf("TranslateResponses() got unexpected error: %v", err)
+	}
+	// The flushed notification of the first stage is translated by the second stage.
+	want := []*gnmipb.SubscribeResponse{
+		counterSR("dut", []*gnmipb.Update{uintUpdate(counterPath("1", "transmit-pkts"), 2)}),
+		syncSR,
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("TranslateResponses() returned an unexpected diff (-want +got): %v", diff)
+	}
+}
+
+func TestPipelineState(t *testing.T) {
+	ft, err := NewPipeline(PipelineOptions{
+		ID:     "pipeline",
+		Stages: []*FunctionalTranslator{newNativeStage(t), newIncrementStage(t, "interface")},
+	})
+	if err != nil {
+		t.Fatalf("NewPipeline() got unexpected error: %v", err)
+	}
+	if !ft.Stateful() {
+		t.Fatalf("Stateful() = false, want true")
+	}
+	syncSR := &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true}}
+	if _, err := ft.Translate(syncSR); err != nil {
+		t.Fatalf("Translate(%v) got unexpected error: %v", syncSR, err)
+	}
+	if _, err := ft.Translate(counterSR("dut", []*gnmipb.Update{uintUpdate(nativeCountersPath, 1)})); err != nil {
+		t.Fatalf("Translate() got unexpected error: %v", err)
+	}
+	snapshot := ft.State()
+	if diff := cmp.Diff([]any{nil, 1}, snapshot); diff != "" {
+		t.Errorf("State() returned an unexpected diff (-want +got): %v", diff)
+	}
+	ft.Reset()
+	if diff := cmp.Diff([]any{nil, 0}, ft.State()); diff != "" {
+		t.Errorf("State() after Reset() returned an unexpected diff (-want +got): %v", diff)
+	}
+	if err := ft.RestoreState(snapshot); err != nil {
+		t.Fatalf("RestoreState() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]any{nil, 1}, ft.State()); diff != "" {
+		t.Errorf("State() after RestoreState() returned an unexpected diff (-want +got): %v", diff)
+	}
+	if err := ft.RestoreState([]any{nil}); err == nil {
+		t.Errorf("RestoreState() with the state of one stage returned nil error, want error")
+	}
+}
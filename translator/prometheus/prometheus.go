@@ -0,0 +1,154 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package prometheus exports the translation metrics of the functional translators in the
+// Prometheus text exposition format:
+//
+//	m := prometheus.New()
+//	translator.SetGlobalInstrumentation(m)
+//	http.Handle("/metrics", m)
+package prometheus
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the upper bounds in seconds of the buckets of the latency histograms.
+var DefaultBuckets = []float64{0.00001, 0.00005, 0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1}
+
+const (
+	responsesMetric = "functional_translator_responses_total"
+	latencyMetric   = "functional_translator_latency_seconds"
+)
+
+// Metrics implements translator.Instrumentation, and serves the metrics over HTTP. It exports:
+//   - functional_translator_responses_total, the number of translated responses by translator
+//     and result, which is one of "translated", "dropped" or "failed";
+//   - functional_translator_latency_seconds, the histogram of the translation latency by
+//     translator.
+type Metrics struct {
+	buckets []float64
+
+	mu         sync.Mutex
+	translator map[string]*translatorMetrics
+}
+
+type translatorMetrics struct {
+	translated, dropped, failed uint64
+	// bucketCounts are the non-cumulative counts of the latency buckets, with the +Inf bucket last.
+	bucketCounts []uint64
+	sum          float64
+}
+
+// New returns Metrics with latency histograms of the given bucket upper bounds in seconds, or of
+// DefaultBuckets if none are given.
+func New(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Metrics{
+		buckets:    buckets,
+		translator: map[string]*translatorMetrics{},
+	}
+}
+
+// record must be called with the mutex held.
+func (m *Metrics) record(id string, latency time.Duration) *translatorMetrics {
+	t, ok := m.translator[id]
+	if !ok {
+		t = &translatorMetrics{bucketCounts: make([]uint64, len(m.buckets)+1)}
+		m.translator[id] = t
+	}
+	s := latency.Seconds()
+	t.bucketCounts[sort.SearchFloat64s(m.buckets, s)]++
+	t.sum += s
+	return t
+}
+
+// Translated implements translator.Instrumentation.
+func (m *Metrics) Translated(id string, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record(id, latency).translated++
+}
+
+// Dropped implements translator.Instrumentation.
+func (m *Metrics) Dropped(id string, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record(id, latency).dropped++
+}
+
+// Failed implements translator.Instrumentation.
+func (m *Metrics) Failed(id string, latency time.Duration, _ error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record(id, latency).failed++
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format, sorted by translator.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	m.mu.Lock()
+	ids := make([]string, 0, len(m.translator))
+	for id := range m.translator {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	fmt.Fprintf(&b, "# HELP %s Responses handled by the functional translators, by result.\n", responsesMetric)
+	fmt.Fprintf(&b, "# TYPE %s counter\n", responsesMetric)
+	for _, id := range ids {
+		t := m.translator[id]
+		for _, r := range []struct {
+			result string
+			count  uint64
+		}{{"translated", t.translated}, {"dropped", t.dropped}, {"failed", t.failed}} {
+			fmt.Fprintf(&b, "%s{translator=%q,result=%q} %d\n", responsesMetric, id, r.result, r.count)
+		}
+	}
+	fmt.Fprintf(&b, "# HELP %s Latency of the translations of the functional translators.\n", latencyMetric)
+	fmt.Fprintf(&b, "# TYPE %s histogram\n", latencyMetric)
+	for _, id := range ids {
+		t := m.translator[id]
+		var cumulative uint64
+		for i, count := range t.bucketCounts {
+			cumulative += count
+			le := "+Inf"
+			if i < len(m.buckets) {
+				le = strconv.FormatFloat(m.buckets[i], 'g', -1, 64)
+			}
+			fmt.Fprintf(&b, "%s_bucket{translator=%q,le=%q} %d\n", latencyMetric, id, le, cumulative)
+		}
+		fmt.Fprintf(&b, "%s_sum{translator=%q} %s\n", latencyMetric, id, strconv.FormatFloat(t.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "%s_count{translator=%q} %d\n", latencyMetric, id, cumulative)
+	}
+	m.mu.Unlock()
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
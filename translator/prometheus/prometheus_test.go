@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/functional-translators/translator"
+)
+
+var _ translator.Instrumentation = (*Metrics)(nil)
+
+func TestMetrics(t *testing.T) {
+	m := New(1, 0.5)
+	m.Translated("ft-b", 250*time.Millisecond)
+	m.Translated("ft-b", 750*time.Millisecond)
+	m.Dropped("ft-b", 2*time.Second)
+	m.Failed("ft-a", time.Second, errors.New("failed"))
+
+	want := `# HELP functional_translator_responses_total Responses handled by the functional translators, by result.
+# TYPE functional_translator_responses_total counter
+functional_translator_responses_total{translator="ft-a",result="translated"} 0
+functional_translator_responses_total{translator="ft-a",result="dropped"} 0
+functional_translator_responses_total{translator="ft-a",result="failed"} 1
+functional_translator_responses_total{translator="ft-b",result="translated"} 2
+functional_translator_responses_total{translator="ft-b",result="dropped"} 1
+functional_translator_responses_total{translator="ft-b",result="failed"} 0
+# HELP functional_translator_latency_seconds Latency of the translations of the functional translators.
+# TYPE functional_translator_latency_seconds histogram
+functional_translator_latency_seconds_bucket{translator="ft-a",le="0.5"} 0
+functional_translator_latency_seconds_bucket{translator="ft-a",le="1"} 1
+functional_translator_latency_seconds_bucket{translator="ft-a",le="+Inf"} 1
+functional_translator_latency_seconds_sum{translator="ft-a"} 1
+functional_translator_latency_seconds_count{translator="ft-a"} 1
+functional_translator_latency_seconds_bucket{translator="ft-b",le="0.5"} 1
+functional_translator_latency_seconds_bucket{translator="ft-b",le="1"} 2
+functional_translator_latency_seconds_bucket{translator="ft-b",le="+Inf"} 3
+functional_translator_latency_seconds_sum{translator="ft-b"} 3
+functional_translator_latency_seconds_count{translator="ft-b"} 3
+`
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if diff := cmp.Diff(want, rec.Body.String()); diff != "" {
+		t.Errorf("ServeHTTP() returned an unexpected diff (-want +got):\n%s", diff)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("ServeHTTP() Content-Type = %q, want text/plain; version=0.0.4", ct)
+	}
+}
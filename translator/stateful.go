@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"fmt"
+
+	"github.com/openconfig/functional-translators/ftstate"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// StatefulTranslator is a translator which keeps state across notifications, e.g. to combine the
+// values of several native paths into one output. Each instance owns its state, so that
+// independent pipelines in one process do not share it.
+type StatefulTranslator interface {
+	ID() string
+	Translate(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
+	// Reset discards the state, e.g. when the subscription to the device is restarted.
+	Reset()
+	// State returns a snapshot of the state, which is not modified by later translations.
+	State() any
+	// RestoreState replaces the state with a snapshot returned by State.
+	RestoreState(any) error
+}
+
+// StateOptions are the functions of a stateful FT managing its state. See StatefulTranslator.
+type StateOptions struct {
+	Reset        func()
+	State        func() any
+	RestoreState func(any) error
+	// Store, if set, is the per-target store of the state, which the application manages through
+	// the Store method of the FT, e.g. to set its TTL or to reset the state of a target.
+	Store ftstate.Store
+	// ResetTarget, if set, discards the state of a target. It defaults to the ResetTarget method
+	// of Store, and is set by FTs keeping per-target state outside of their store.
+	ResetTarget func(target string)
+}
+
+var _ StatefulTranslator = (*FunctionalTranslator)(nil)
+
+// Stateful returns true if the FT keeps state across notifications.
+func (ft *FunctionalTranslator) Stateful() bool {
+	return ft.state != nil
+}
+
+// Store returns the per-target store of the state of the FT, or nil if the FT is stateless or
+// does not keep its state per target.
+func (ft *FunctionalTranslator) Store() ftstate.Store {
+	if ft.state == nil {
+		return nil
+	}
+	return ft.state.Store
+}
+
+// Reset discards the state of the FT. It is a no-op for stateless FTs.
+func (ft *FunctionalTranslator) Reset() {
+	if ft.state != nil {
+		ft.state.Reset()
+	}
+}
+
+// OnTargetDisconnect discards the state of the target, e.g. the port-channel membership or the
+// MACsec CKNs learned from it, when the subscription to the target is torn down, so that stale
+// state does not corrupt the outputs after a reconnection. It is a no-op for stateless FTs and
+// for FTs which do not keep their state per target. The target is normalized like those of the
+// notifications, see SetGlobalTargetNormalizer.
+func (ft *FunctionalTranslator) OnTargetDisconnect(target string) {
+	target = NormalizeTarget(target)
+	switch {
+	case ft.state == nil:
+	case ft.state.ResetTarget != nil:
+		ft.state.ResetTarget(target)
+	case ft.state.Store != nil:
+		ft.state.Store.ResetTarget(target)
+	}
+}
+
+// State returns a snapshot of the state of the FT, or nil for stateless FTs.
+func (ft *FunctionalTranslator) State() any {
+	if ft.state == nil {
+		return nil
+	}
+	return ft.state.State()
+}
+
+// RestoreState replaces the state of the FT with a snapshot returned by State on an FT with the
+// same ID.
+func (ft *FunctionalTranslator) RestoreState(snapshot any) error {
+	if ft.state == nil {
+		if snapshot != nil {
+			return fmt.Errorf("%s is stateless, cannot restore state %T", ft.id, snapshot)
+		}
+		return nil
+	}
+	if err := ft.state.RestoreState(snapshot); err != nil {
+		return fmt.Errorf("%s failed to restore state: %v", ft.id, err)
+	}
+	return nil
+}
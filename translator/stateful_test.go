@@ -0,0 +1,174 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/functional-translators/ftstate"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// newCountingFT returns an FT counting the notifications it translates.
+func newCountingFT(t *testing.T) *FunctionalTranslator {
+	t.Helper()
+	count := 0
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID: "test-ft",
+		Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+			count++
+			return nil, nil
+		},
+		State: &StateOptions{
+			Reset: func() { count = 0 },
+			State: func() any { return count },
+			RestoreState: func(snapshot any) error {
+				c, ok := snapshot.(int)
+				if !ok {
+					return fmt.Errorf("unexpected state type %T", snapshot)
+				}
+				count = c
+				return nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+	}
+	return ft
+}
+
+func TestStatefulTranslator(t *testing.T) {
+	ft := newCountingFT(t)
+	if !ft.Stateful() {
+		t.Fatalf("Stateful() = false, want true")
+	}
+	sr := counterSR("dut", []*gnmipb.Update{uintUpdate(counterPath("0", "transmit-pkts"), 1)})
+	for i := 0; i < 3; i++ {
+		if _, err := ft.Translate(sr); err != nil {
+			t.Fatalf("Translate() got unexpected error: %v", err)
+		}
+	}
+	snapshot := ft.State()
+	if snapshot != 3 {
+		t.Errorf("State() = %v, want 3", snapshot)
+	}
+	ft.Reset()
+	if got := ft.State(); got != 0 {
+		t.Errorf("State() after Reset() = %v, want 0", got)
+	}
+	if err := ft.RestoreState(snapshot); err != nil {
+		t.Fatalf("RestoreState(%v) got unexpected error: %v", snapshot, err)
+	}
+	if got := ft.State(); got != 3 {
+		t.Errorf("State() after RestoreState() = %v, want 3", got)
+	}
+	if err := ft.RestoreState("invalid"); err == nil {
+		t.Errorf("RestoreState() with an invalid snapshot returned nil error, want error")
+	}
+}
+
+func TestStatelessTranslator(t *testing.T) {
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID: "test-ft",
+		Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+			return sr, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+	}
+	if ft.Stateful() {
+		t.Errorf("Stateful() = true, want false")
+	}
+	ft.Reset()
+	if got := ft.State(); got != nil {
+		t.Errorf("State() = %v, want nil", got)
+	}
+	if err := ft.RestoreState(nil); err != nil {
+		t.Errorf("RestoreState(nil) got unexpected error: %v", err)
+	}
+	if err := ft.RestoreState(1); err == nil {
+		t.Errorf("RestoreState(1) returned nil error, want error")
+	}
+	if got := ft.Store(); got != nil {
+		t.Errorf("Store() = %v, want nil", got)
+	}
+}
+
+func TestStore(t *testing.T) {
+	store := ftstate.NewTargetStore[int](ftstate.Options{})
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID: "test-ft",
+		Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+			target := sr.GetUpdate().GetPrefix().GetTarget()
+			store.Set(target, len(sr.GetUpdate().GetUpdate()))
+			return nil, nil
+		},
+		State: &StateOptions{
+			Reset:        store.Reset,
+			State:        func() any { return store.Clone(func(v int) int { return v }) },
+			RestoreState: func(any) error { return nil },
+			Store:        store,
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+	}
+	for _, target := range []string{"dut1", "dut2"} {
+		if _, err := ft.Translate(counterSR(target, []*gnmipb.Update{uintUpdate(counterPath("0", "transmit-pkts"), 1)})); err != nil {
+			t.Fatalf("Translate() got unexpected error: %v", err)
+		}
+	}
+	ft.Store().ResetTarget("dut1")
+	if diff := cmp.Diff([]string{"dut2"}, ft.Store().Targets()); diff != "" {
+		t.Errorf("Targets() after ResetTarget() returned an unexpected diff (-want +got):\n%s", diff)
+	}
+
+	// OnTargetDisconnect resets the target in the store, including through a pipeline.
+	p, err := NewPipeline(PipelineOptions{ID: "test-pipeline", Stages: []*FunctionalTranslator{ft}})
+	if err != nil {
+		t.Fatalf("NewPipeline() got unexpected error: %v", err)
+	}
+	p.OnTargetDisconnect("dut2")
+	if got := ft.Store().Targets(); len(got) != 0 {
+		t.Errorf("Targets() after OnTargetDisconnect() = %v, want none", got)
+	}
+	// It is a no-op for stateless FTs.
+	stateless, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID:        "stateless-ft",
+		Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) { return sr, nil },
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+	}
+	stateless.OnTargetDisconnect("dut1")
+}
+
+func TestIncompleteStateOptions(t *testing.T) {
+	_, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID: "test-ft",
+		Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+			return sr, nil
+		},
+		State: &StateOptions{Reset: func() {}},
+	})
+	if err == nil {
+		t.Errorf("NewFunctionalTranslator() with incomplete State options returned nil error, want error")
+	}
+}
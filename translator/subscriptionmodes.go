@@ -0,0 +1,107 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openconfig/functional-translators/ftutilities"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// SubscriptionMode is the recommended mode of the subscription to an input path of an FT, e.g.
+// ON_CHANGE for a status, or SAMPLE every 30s for counters.
+type SubscriptionMode struct {
+	Mode gnmipb.SubscriptionMode
+	// SampleInterval is the interval of the SAMPLE mode. It must be zero for the other modes.
+	SampleInterval time.Duration
+}
+
+// validateSubscriptionModes returns an error if a mode is not for an input of the FT, or has an
+// invalid sample interval.
+func validateSubscriptionModes(modes map[string]*SubscriptionMode, idx *schemaIndex) error {
+	for in, m := range modes {
+		if _, ok := idx.outputs[in]; !ok {
+			return fmt.Errorf("subscription mode of %q, which is not an input of the OutputToInputMap", in)
+		}
+		if m == nil {
+			return fmt.Errorf("nil subscription mode of %q", in)
+		}
+		if (m.Mode == gnmipb.SubscriptionMode_SAMPLE) != (m.SampleInterval > 0) {
+			return fmt.Errorf("subscription mode %v of %q has sample interval %v", m.Mode, in, m.SampleInterval)
+		}
+	}
+	return nil
+}
+
+// SubscriptionMode returns the recommended subscription mode of an input path of the FT, and
+// whether the FT recommends one. The input is identified by its schema string, e.g.
+// "/eos_native/Sysdb/macsec/status/cpStatus".
+func (ft *FunctionalTranslator) SubscriptionMode(input string) (SubscriptionMode, bool) {
+	m, ok := ft.subscriptionModes[input]
+	if !ok {
+		return SubscriptionMode{}, false
+	}
+	return *m, true
+}
+
+// merge returns the mode of a subscription covering the inputs of modes m and o: SAMPLE with the
+// shortest interval if either is SAMPLE, so that no counter is sampled less often than
+// recommended, otherwise ON_CHANGE if either is ON_CHANGE.
+func (m SubscriptionMode) merge(o SubscriptionMode) SubscriptionMode {
+	switch {
+	case m.Mode == gnmipb.SubscriptionMode_SAMPLE && o.Mode == gnmipb.SubscriptionMode_SAMPLE:
+		return SubscriptionMode{Mode: m.Mode, SampleInterval: min(m.SampleInterval, o.SampleInterval)}
+	case m.Mode == gnmipb.SubscriptionMode_SAMPLE, o.Mode == gnmipb.SubscriptionMode_SAMPLE:
+		if m.Mode == gnmipb.SubscriptionMode_SAMPLE {
+			return m
+		}
+		return o
+	case m.Mode == gnmipb.SubscriptionMode_ON_CHANGE:
+		return m
+	default:
+		return o
+	}
+}
+
+// Subscriptions returns the subscriptions to the paths, e.g. those returned by
+// RequiredSubscriptions, in the recommended modes of the inputs of the FTs they are related to,
+// i.e. equal to, under or covering them. A path related to inputs of different modes is
+// subscribed in the mode which serves them all, see SubscriptionMode. The paths related to no
+// input with a recommended mode are subscribed in TARGET_DEFINED mode.
+func Subscriptions(paths []*gnmipb.Path, fts ...*FunctionalTranslator) []*gnmipb.Subscription {
+	var subs []*gnmipb.Subscription
+	for _, p := range paths {
+		pathElems := ftutilities.GNMIPathToSchemaStrings(p, false)
+		var mode SubscriptionMode
+		for _, ft := range fts {
+			for in, m := range ft.subscriptionModes {
+				inElems := strings.Split(strings.TrimPrefix(in, "/"), "/")
+				if selects(pathElems, inElems) || selects(inElems, pathElems) {
+					mode = mode.merge(*m)
+				}
+			}
+		}
+		subs = append(subs, &gnmipb.Subscription{
+			Path:           p,
+			Mode:           mode.Mode,
+			SampleInterval: uint64(mode.SampleInterval),
+		})
+	}
+	return subs
+}
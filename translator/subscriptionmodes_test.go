@@ -0,0 +1,149 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/openconfig/functional-translators/ftutilities"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	intfStatus  = "/eos_native/Sysdb/interface/status/eth/phy/slice/1/intfStatus"
+	intfCounter = "/eos_native/Smash/counters/ethIntf/SandCounters/current/counter"
+	qosCounter  = "/eos_native/Smash/qos/queue"
+)
+
+func newModesFT(t *testing.T, id string, modes map[string]*SubscriptionMode) *FunctionalTranslator {
+	t.Helper()
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID:        id,
+		Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) { return sr, nil },
+		OutputToInputMap: ftutilities.MustStringMapPaths(map[string][]string{
+			"/openconfig/interfaces/interface/state/oper-status":          {intfStatus},
+			"/openconfig/interfaces/interface/state/counters/in-pkts":     {intfCounter + "/*/statistics/inUcastPkts"},
+			"/openconfig/qos/interfaces/interface/output/queues/queue/id": {qosCounter},
+		}),
+		SubscriptionModes: modes,
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator() got unexpected error: %v", err)
+	}
+	return ft
+}
+
+func TestSubscriptionMode(t *testing.T) {
+	ft := newModesFT(t, "test-ft", map[string]*SubscriptionMode{
+		intfStatus: {Mode: gnmipb.SubscriptionMode_ON_CHANGE},
+	})
+	if got, ok := ft.SubscriptionMode(intfStatus); !ok || got.Mode != gnmipb.SubscriptionMode_ON_CHANGE {
+		t.Errorf("SubscriptionMode(%q) = %v, %t, want ON_CHANGE, true", intfStatus, got, ok)
+	}
+	if got, ok := ft.SubscriptionMode(qosCounter); ok {
+		t.Errorf("SubscriptionMode(%q) = %v, %t, want false", qosCounter, got, ok)
+	}
+}
+
+func TestInvalidSubscriptionModes(t *testing.T) {
+	for _, modes := range []map[string]*SubscriptionMode{
+		{"/eos_native/Sysdb/unknown": {Mode: gnmipb.SubscriptionMode_ON_CHANGE}},
+		{intfStatus: nil},
+		{intfStatus: {Mode: gnmipb.SubscriptionMode_SAMPLE}},
+		{intfStatus: {Mode: gnmipb.SubscriptionMode_ON_CHANGE, SampleInterval: time.Second}},
+	} {
+		_, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+			ID:        "test-ft",
+			Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) { return sr, nil },
+			OutputToInputMap: ftutilities.MustStringMapPaths(map[string][]string{
+				"/openconfig/interfaces/interface/state/oper-status": {intfStatus},
+			}),
+			SubscriptionModes: modes,
+		})
+		if err == nil {
+			t.Errorf("NewFunctionalTranslator() with subscription modes %v got no error, want error", modes)
+		}
+	}
+}
+
+func TestSubscriptions(t *testing.T) {
+	status := newModesFT(t, "status-ft", map[string]*SubscriptionMode{
+		intfStatus: {Mode: gnmipb.SubscriptionMode_ON_CHANGE},
+	})
+	counters := newModesFT(t, "counters-ft", map[string]*SubscriptionMode{
+		intfCounter + "/*/statistics/inUcastPkts": {Mode: gnmipb.SubscriptionMode_SAMPLE, SampleInterval: 30 * time.Second},
+		intfStatus: {Mode: gnmipb.SubscriptionMode_SAMPLE, SampleInterval: 10 * time.Second},
+	})
+	tests := []struct {
+		name  string
+		paths []string
+		fts   []*FunctionalTranslator
+		want  []*gnmipb.Subscription
+	}{
+		{
+			name:  "modes of the inputs",
+			paths: []string{"eos_native:/Sysdb/interface/status/eth/phy/slice/1/intfStatus", "eos_native:/Smash/qos/queue"},
+			fts:   []*FunctionalTranslator{status},
+			want: []*gnmipb.Subscription{
+				{Path: mustPath(t, "eos_native:/Sysdb/interface/status/eth/phy/slice/1/intfStatus"), Mode: gnmipb.SubscriptionMode_ON_CHANGE},
+				{Path: mustPath(t, "eos_native:/Smash/qos/queue"), Mode: gnmipb.SubscriptionMode_TARGET_DEFINED},
+			},
+		},
+		{
+			name:  "collapsed input",
+			paths: []string{"eos_native:/Smash/counters/ethIntf/SandCounters/current/counter"},
+			fts:   []*FunctionalTranslator{counters},
+			want: []*gnmipb.Subscription{
+				{
+					Path:           mustPath(t, "eos_native:/Smash/counters/ethIntf/SandCounters/current/counter"),
+					Mode:           gnmipb.SubscriptionMode_SAMPLE,
+					SampleInterval: uint64(30 * time.Second),
+				},
+			},
+		},
+		{
+			name:  "covering path of inputs with different modes",
+			paths: []string{"eos_native:/Sysdb/interface/status", "eos_native:/Smash"},
+			fts:   []*FunctionalTranslator{status, counters},
+			want: []*gnmipb.Subscription{
+				{
+					Path:           mustPath(t, "eos_native:/Sysdb/interface/status"),
+					Mode:           gnmipb.SubscriptionMode_SAMPLE,
+					SampleInterval: uint64(10 * time.Second),
+				},
+				{
+					Path:           mustPath(t, "eos_native:/Smash"),
+					Mode:           gnmipb.SubscriptionMode_SAMPLE,
+					SampleInterval: uint64(30 * time.Second),
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var paths []*gnmipb.Path
+			for _, p := range tc.paths {
+				paths = append(paths, mustPath(t, p))
+			}
+			if diff := cmp.Diff(tc.want, Subscriptions(paths, tc.fts...), protocmp.Transform()); diff != "" {
+				t.Errorf("Subscriptions() returned an unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
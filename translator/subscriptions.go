@@ -0,0 +1,139 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/openconfig/functional-translators/ftconsts"
+	"github.com/openconfig/functional-translators/ftutilities"
+	"google.golang.org/protobuf/proto"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// RequiredSubscriptions returns the minimal set of native paths a device must be subscribed to for
+// the FT to provide the desired output paths, sorted by origin and path. An output path selects
+// the outputs of the FT equal to or under it, e.g. /interfaces/interface/state/counters selects
+// all the counters; its keys, including wildcard keys, are ignored, and its origin defaults to
+// openconfig. An element named "*" matches any element, and "..." any number of elements, e.g.
+// /interfaces/.../counters selects the counters of the interfaces and of their subinterfaces.
+//
+// The input paths covered by another input path are removed. For Arista devices, which cannot
+// subscribe to wildcard paths, the input paths are first collapsed to their longest prefix without
+// wildcard. Nil is returned if the FT does not support the device.
+func (ft *FunctionalTranslator) RequiredSubscriptions(outputs []*gnmipb.Path, device *DeviceMetadata) []*gnmipb.Path {
+	if device == nil || !ft.metadataMatch(device) {
+		return nil
+	}
+	var desired [][]string
+	for _, o := range outputs {
+		desired = append(desired, ftutilities.GNMIPathToSchemaStrings(o, true))
+	}
+	var inputs []*gnmipb.Path
+	for out, ins := range ft.OutputToInputMap() {
+		outElems := strings.Split(strings.TrimPrefix(out, "/"), "/")
+		for _, d := range desired {
+			if selects(d, outElems) {
+				inputs = append(inputs, ins...)
+				break
+			}
+		}
+	}
+	return MinimalSubscriptions(inputs, strings.EqualFold(device.Vendor, ftconsts.VendorArista))
+}
+
+// selects returns true if the desired output selects the output, both given as schema elements:
+// the output is equal to or under it, a desired element named "*" matching any element and "..."
+// any number of elements, including none.
+func selects(desired, out []string) bool {
+	for i, d := range desired {
+		if d == "..." {
+			for skip := 0; i+skip <= len(out); skip++ {
+				if selects(desired[i+1:], out[i+skip:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if i >= len(out) || (d != "*" && d != out[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MinimalSubscriptions returns the paths which are not covered by another of the paths, sorted by
+// origin and path. A path covers the paths of the same origin under it, the "*" element name and
+// key value matching any name and value. If collapseWildcards is set, the paths are first
+// truncated to their longest prefix without wildcard: before an element named "*" or "...", or
+// after an element with a key value containing "*", whose keys are removed.
+func MinimalSubscriptions(paths []*gnmipb.Path, collapseWildcards bool) []*gnmipb.Path {
+	var candidates []*gnmipb.Path
+	for _, p := range paths {
+		if collapseWildcards {
+			p = collapseWildcard(p)
+		}
+		candidates = append(candidates, p)
+	}
+	var minimal []*gnmipb.Path
+	for i, c := range candidates {
+		covered := false
+		for j, o := range candidates {
+			// Of equivalent paths, the first one is kept.
+			if i != j && covers(o, c) && (j < i || !covers(c, o)) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			minimal = append(minimal, c)
+		}
+	}
+	sort.SliceStable(minimal, func(i, j int) bool {
+		if minimal[i].GetOrigin() != minimal[j].GetOrigin() {
+			return minimal[i].GetOrigin() < minimal[j].GetOrigin()
+		}
+		return ftutilities.SortByYgotString(minimal)(i, j)
+	})
+	return minimal
+}
+
+// collapseWildcard returns the longest prefix of the path without wildcard.
+func collapseWildcard(p *gnmipb.Path) *gnmipb.Path {
+	for i, e := range p.GetElem() {
+		if e.GetName() == "*" || e.GetName() == "..." {
+			return &gnmipb.Path{Origin: p.GetOrigin(), Elem: p.GetElem()[:i]}
+		}
+		for _, v := range e.GetKey() {
+			if strings.Contains(v, "*") {
+				elems := append(p.GetElem()[:i:i], &gnmipb.PathElem{Name: e.GetName()})
+				return &gnmipb.Path{Origin: p.GetOrigin(), Elem: elems}
+			}
+		}
+	}
+	return p
+}
+
+// covers returns true if the subscription to path a includes the subscription to path b.
+func covers(a, b *gnmipb.Path) bool {
+	if a.GetOrigin() != b.GetOrigin() {
+		return false
+	}
+	pattern := proto.Clone(a).(*gnmipb.Path)
+	pattern.Elem = append(pattern.Elem, &gnmipb.PathElem{Name: "..."})
+	return ftutilities.MatchPath(b, pattern, ftutilities.MatchPathWithKeys())
+}
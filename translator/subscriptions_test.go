@@ -0,0 +1,240 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openconfig/functional-translators/ftconsts"
+	"github.com/openconfig/ygot/ygot"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// mustPath returns the path of a string of the form "origin:/path", the origin being optional.
+func mustPath(t *testing.T, s string) *gnmipb.Path {
+	t.Helper()
+	var origin string
+	if i := strings.Index(s, ":/"); i >= 0 {
+		origin, s = s[:i], s[i+1:]
+	}
+	p, err := ygot.StringToStructuredPath(s)
+	if err != nil {
+		t.Fatalf("StringToStructuredPath(%q) got unexpected error: %v", s, err)
+	}
+	p.Origin = origin
+	return p
+}
+
+func pathStrings(t *testing.T, paths []*gnmipb.Path) []string {
+	t.Helper()
+	var s []string
+	for _, p := range paths {
+		ps, err := ygot.PathToString(p)
+		if err != nil {
+			t.Fatalf("PathToString(%v) got unexpected error: %v", p, err)
+		}
+		s = append(s, p.GetOrigin()+":"+ps)
+	}
+	return s
+}
+
+func outputToInput(t *testing.T, m map[string][]string) map[string][]*gnmipb.Path {
+	t.Helper()
+	paths := map[string][]*gnmipb.Path{}
+	for out, ins := range m {
+		for _, in := range ins {
+			paths[out] = append(paths[out], mustPath(t, in))
+		}
+	}
+	return paths
+}
+
+func TestRequiredSubscriptions(t *testing.T) {
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID:        "test-ft",
+		Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) { return sr, nil },
+		OutputToInputMap: outputToInput(t, map[string][]string{
+			"/openconfig/interfaces/interface/state/counters/in-pkts": {
+				"eos_native:/Smash/counters/ethIntf/SandCounters/current/counter/*/statistics/inUcastPkts",
+				"eos_native:/Smash/counters/ethIntf/SandCounters/current/counter/*/statistics/inMulticastPkts",
+			},
+			"/openconfig/interfaces/interface/state/counters/out-pkts": {
+				"eos_native:/Smash/counters/ethIntf/SandCounters/current/counter/*/statistics/outUcastPkts",
+			},
+			"/openconfig/interfaces/interface/state/oper-status": {
+				"eos_native:/Sysdb/interface/status/eth/phy/slice/1/intfStatus",
+				"eos_native:/Sysdb/interface/status/eth/phy/slice/1/intfStatus/operStatus",
+			},
+			"/openconfig/qos/interfaces/interface/output/queues/queue/state/transmit-pkts": {
+				"eos_native:/Smash/qos/queue[name=*]/transmitPkts",
+			},
+		}),
+		Metadata: []*FTMetadata{{Vendor: ftconsts.VendorArista}, {Vendor: "TEST"}},
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator() got unexpected error: %v", err)
+	}
+	tests := []struct {
+		name    string
+		outputs []string
+		vendor  string
+		want    []string
+	}{
+		{
+			name:    "leaves collapsed for arista",
+			outputs: []string{"/interfaces/interface/state/counters/in-pkts", "/openconfig/interfaces/interface/state/counters/out-pkts"},
+			vendor:  ftconsts.VendorArista,
+			want:    []string{"eos_native:/Smash/counters/ethIntf/SandCounters/current/counter"},
+		},
+		{
+			name:    "covered paths removed",
+			outputs: []string{"/interfaces/interface/state/oper-status"},
+			vendor:  ftconsts.VendorArista,
+			want:    []string{"eos_native:/Sysdb/interface/status/eth/phy/slice/1/intfStatus"},
+		},
+		{
+			name:    "container",
+			outputs: []string{"/interfaces"},
+			vendor:  ftconsts.VendorArista,
+			want: []string{
+				"eos_native:/Smash/counters/ethIntf/SandCounters/current/counter",
+				"eos_native:/Sysdb/interface/status/eth/phy/slice/1/intfStatus",
+			},
+		},
+		{
+			name:    "wildcard key collapsed",
+			outputs: []string{"/qos"},
+			vendor:  ftconsts.VendorArista,
+			want:    []string{"eos_native:/Smash/qos/queue"},
+		},
+		{
+			name:    "wildcards kept",
+			outputs: []string{"/interfaces/interface/state/counters", "/qos"},
+			vendor:  "TEST",
+			want: []string{
+				"eos_native:/Smash/counters/ethIntf/SandCounters/current/counter/*/statistics/inMulticastPkts",
+				"eos_native:/Smash/counters/ethIntf/SandCounters/current/counter/*/statistics/inUcastPkts",
+				"eos_native:/Smash/counters/ethIntf/SandCounters/current/counter/*/statistics/outUcastPkts",
+				"eos_native:/Smash/qos/queue[name=*]/transmitPkts",
+			},
+		},
+		{
+			name:    "wildcard element",
+			outputs: []string{"/interfaces/interface/*/oper-status"},
+			vendor:  ftconsts.VendorArista,
+			want:    []string{"eos_native:/Sysdb/interface/status/eth/phy/slice/1/intfStatus"},
+		},
+		{
+			name:    "multi-level wildcard",
+			outputs: []string{"/.../out-pkts", "/qos/.../queue"},
+			vendor:  "TEST",
+			want: []string{
+				"eos_native:/Smash/counters/ethIntf/SandCounters/current/counter/*/statistics/outUcastPkts",
+				"eos_native:/Smash/qos/queue[name=*]/transmitPkts",
+			},
+		},
+		{
+			name:    "wildcard keys ignored",
+			outputs: []string{"/interfaces/interface[name=*]/state/counters/in-pkts", "/interfaces/interface[name=Ethernet1]/state/oper-status"},
+			vendor:  ftconsts.VendorArista,
+			want: []string{
+				"eos_native:/Smash/counters/ethIntf/SandCounters/current/counter",
+				"eos_native:/Sysdb/interface/status/eth/phy/slice/1/intfStatus",
+			},
+		},
+		{
+			name:    "unsupported output",
+			outputs: []string{"/components"},
+			vendor:  ftconsts.VendorArista,
+		},
+		{
+			name:    "unsupported device",
+			outputs: []string{"/interfaces"},
+			vendor:  "OTHER",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var outputs []*gnmipb.Path
+			for _, o := range tc.outputs {
+				outputs = append(outputs, mustPath(t, o))
+			}
+			got := pathStrings(t, ft.RequiredSubscriptions(outputs, &DeviceMetadata{Vendor: tc.vendor}))
+			if len(got) != len(tc.want) {
+				t.Fatalf("RequiredSubscriptions() = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("RequiredSubscriptions() = %v, want %v", got, tc.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestMinimalSubscriptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{
+			name:  "duplicates",
+			paths: []string{"openconfig:/a/b", "openconfig:/a/b"},
+			want:  []string{"openconfig:/a/b"},
+		},
+		{
+			name:  "origins differ",
+			paths: []string{"openconfig:/a", "eos_native:/a/b"},
+			want:  []string{"eos_native:/a/b", "openconfig:/a"},
+		},
+		{
+			name:  "wildcard covers",
+			paths: []string{"openconfig:/a/c/d", "openconfig:/a/*/d", "openconfig:/a/b"},
+			want:  []string{"openconfig:/a/*/d", "openconfig:/a/b"},
+		},
+		{
+			name:  "keys",
+			paths: []string{"openconfig:/a/b[k=1]/c", "openconfig:/a/b[k=2]/c", "openconfig:/a/b[k=1]"},
+			want:  []string{"openconfig:/a/b[k=1]", "openconfig:/a/b[k=2]/c"},
+		},
+		{
+			name:  "wildcard key covers",
+			paths: []string{"openconfig:/a/b[k=1]/c", "openconfig:/a/b[k=*]/c"},
+			want:  []string{"openconfig:/a/b[k=*]/c"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var paths []*gnmipb.Path
+			for _, p := range tc.paths {
+				paths = append(paths, mustPath(t, p))
+			}
+			got := pathStrings(t, MinimalSubscriptions(paths, false))
+			if len(got) != len(tc.want) {
+				t.Fatalf("MinimalSubscriptions() = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("MinimalSubscriptions() = %v, want %v", got, tc.want)
+					break
+				}
+			}
+		})
+	}
+}
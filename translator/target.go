@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"net"
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/proto"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// globalTargetNormalizer holds the function normalizing the targets of the notifications, if any.
+var globalTargetNormalizer atomic.Pointer[func(string) string]
+
+// SetGlobalTargetNormalizer sets the function rewriting the target of the notifications before
+// they are translated by any FT, e.g. LowercaseTarget. The state of the stateful FTs is then kept
+// under the normalized target, whatever the case or the qualification of the name of the device
+// in its subscriptions, and their outputs carry the normalized target. OnTargetDisconnect
+// normalizes its target too. A nil function, the default, leaves the targets unchanged.
+func SetGlobalTargetNormalizer(fn func(string) string) {
+	if fn == nil {
+		globalTargetNormalizer.Store(nil)
+		return
+	}
+	globalTargetNormalizer.Store(&fn)
+}
+
+// NormalizeTarget returns the target rewritten by the global target normalizer.
+func NormalizeTarget(target string) string {
+	fn := globalTargetNormalizer.Load()
+	if fn == nil || target == "" {
+		return target
+	}
+	return (*fn)(target)
+}
+
+// LowercaseTarget returns the target in lower case, as host names are case-insensitive.
+func LowercaseTarget(target string) string {
+	return strings.ToLower(target)
+}
+
+// StripTargetDomain returns the target without its domain, e.g. "router1" for
+// "router1.example.com". IP addresses are returned unchanged.
+func StripTargetDomain(target string) string {
+	if net.ParseIP(target) != nil {
+		return target
+	}
+	host, _, _ := strings.Cut(target, ".")
+	return host
+}
+
+// normalizeTarget returns the notification response with its target normalized, the input itself
+// if its target is unchanged. The input is not modified.
+func normalizeTarget(input *gnmipb.SubscribeResponse) *gnmipb.SubscribeResponse {
+	n := input.GetUpdate()
+	target := n.GetPrefix().GetTarget()
+	normalized := NormalizeTarget(target)
+	if normalized == target {
+		return input
+	}
+	prefix := proto.Clone(n.GetPrefix()).(*gnmipb.Path)
+	prefix.Target = normalized
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: n.GetTimestamp(),
+				Prefix:    prefix,
+				Update:    n.GetUpdate(),
+				Delete:    n.GetDelete(),
+				Atomic:    n.GetAtomic(),
+			},
+		},
+		Extension: input.GetExtension(),
+	}
+}
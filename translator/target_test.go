@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestTargetNormalizers(t *testing.T) {
+	tests := []struct {
+		fn     func(string) string
+		target string
+		want   string
+	}{
+		{fn: LowercaseTarget, target: "Router1.Example.COM", want: "router1.example.com"},
+		{fn: StripTargetDomain, target: "router1.example.com", want: "router1"},
+		{fn: StripTargetDomain, target: "router1", want: "router1"},
+		{fn: StripTargetDomain, target: "192.0.2.1", want: "192.0.2.1"},
+		{fn: StripTargetDomain, target: "2001:db8::1", want: "2001:db8::1"},
+	}
+	for _, tc := range tests {
+		if got := tc.fn(tc.target); got != tc.want {
+			t.Errorf("normalizing %q = %q, want %q", tc.target, got, tc.want)
+		}
+	}
+}
+
+func TestSetGlobalTargetNormalizer(t *testing.T) {
+	SetGlobalTargetNormalizer(func(target string) string { return StripTargetDomain(LowercaseTarget(target)) })
+	t.Cleanup(func() { SetGlobalTargetNormalizer(nil) })
+
+	var translated, reset string
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID: "test-ft",
+		Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+			translated = sr.GetUpdate().GetPrefix().GetTarget()
+			return sr, nil
+		},
+		State: &StateOptions{
+			Reset:        func() {},
+			State:        func() any { return nil },
+			RestoreState: func(any) error { return nil },
+			ResetTarget:  func(target string) { reset = target },
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator() got unexpected error: %v", err)
+	}
+	input := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 1,
+				Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "Router1.example.com"},
+				Update:    []*gnmipb.Update{{Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a"}}}}},
+			},
+		},
+	}
+	out, err := ft.Translate(input)
+	if err != nil {
+		t.Fatalf("Translate() got unexpected error: %v", err)
+	}
+	if translated != "router1" || out.GetUpdate().GetPrefix().GetTarget() != "router1" {
+		t.Errorf("Translate() translated target %q and returned target %q, want %q", translated, out.GetUpdate().GetPrefix().GetTarget(), "router1")
+	}
+	if got := input.GetUpdate().GetPrefix().GetTarget(); got != "Router1.example.com" {
+		t.Errorf("Translate() modified the target of its input to %q", got)
+	}
+	if len(out.GetUpdate().GetUpdate()) != 1 || out.GetUpdate().GetTimestamp() != 1 {
+		t.Errorf("Translate() = %v, want the updates and timestamp of the input", out)
+	}
+	ft.OnTargetDisconnect("ROUTER1.example.com")
+	if reset != "router1" {
+		t.Errorf("OnTargetDisconnect() reset target %q, want %q", reset, "router1")
+	}
+
+	SetGlobalTargetNormalizer(nil)
+	if _, err := ft.Translate(input); err != nil {
+		t.Fatalf("Translate() got unexpected error: %v", err)
+	}
+	if translated != "Router1.example.com" {
+		t.Errorf("Translate() without normalizer translated target %q, want %q", translated, "Router1.example.com")
+	}
+}
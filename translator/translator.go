@@ -19,20 +19,17 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
-	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
+	log "github.com/openconfig/functional-translators/ftlog"
 	"github.com/openconfig/functional-translators/ftutilities"
+	"github.com/openconfig/ygot/ytypes"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
-var (
-	// versionCompRE is used to extract all components from a version string,
-	// e.g. "12.1X1.2" to [12 1 X 1 2]
-	versionCompRE = regexp.MustCompile(`(\d+|[A-Z]+)`)
-)
-
 // SWRange represents a range of software versions.
 type SWRange struct {
 	InclusiveMin string
@@ -53,10 +50,7 @@ const (
 
 // Contains evaluates whether the software version of the device matches the given FT metadata.
 // For example, whether a version string "4.34.2F-12345" is within the range [4.34.2F, 4.34.2G).
-// Empty version is effectively "0".
-// Numbers are compared to numbers as numbers. Letters are compared to letters as strings,
-// e.g. "X" > "AB".
-// Numbers are compared to strings as strings, e.g. "A" > "12".
+// Versions are compared with CompareVersions.
 func (r *SWRange) Contains(version string) bool {
 	// version must be >= Min
 	if CompareVersions(version, r.InclusiveMin) == LessThan {
@@ -66,50 +60,14 @@ func (r *SWRange) Contains(version string) bool {
 	return CompareVersions(version, r.ExclusiveMax) == LessThan
 }
 
-// CompareVersions returns whether v1 is less than, equal to, or greater than v2.
-func CompareVersions(v1, v2 string) CompareResult {
-	c1 := versionCompRE.FindAllString(strings.ToUpper(v1), -1)
-	c2 := versionCompRE.FindAllString(strings.ToUpper(v2), -1)
-	maxLen := len(c1)
-	if len(c2) > maxLen {
-		maxLen = len(c2)
-	}
-
-	for i := 0; i < maxLen; i++ {
-		s1, s2 := "0", "0"
-		if i < len(c1) {
-			s1 = c1[i]
-		}
-		if i < len(c2) {
-			s2 = c2[i]
-		}
-
-		n1, err1 := strconv.Atoi(s1)
-		n2, err2 := strconv.Atoi(s2)
-
-		if err1 == nil && err2 == nil { // Both are numbers
-			if n1 < n2 {
-				return LessThan
-			}
-			if n1 > n2 {
-				return GreaterThan
-			}
-		} else { // At least one is not a number, compare as strings
-			if s1 < s2 {
-				return LessThan
-			}
-			if s1 > s2 {
-				return GreaterThan
-			}
-		}
-	}
-	return Equal
-}
-
 // FTMetadata contains metadata to identify when a FT should be used.
 type FTMetadata struct {
 	Vendor        string
 	HardwareModel string
+	// HardwareModels are regular expressions matching the whole hardware model of the devices,
+	// ignoring case, e.g. "88\d\d" for the Cisco 8800 models but not the NCS 5500 models. The
+	// device must match one of them. Cannot be set with HardwareModel.
+	HardwareModels []string
 	// SoftwareVersion is a single version string. Cannot be set with SoftwareVersionRange.
 	SoftwareVersion string
 	// SoftwareVersionRange is a range of version strings. Cannot be set with SoftwareVersion.
@@ -127,14 +85,62 @@ type DeviceMetadata struct {
 
 // FunctionalTranslatorOptions contains the options for a FunctionalTranslator.
 type FunctionalTranslatorOptions struct {
-	ID               string
-	Translate        func(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
+	ID        string
+	Translate func(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
+	// TranslateWithOptions is like Translate, but is also passed the current options of the FT, so
+	// that its behaviors can be configured per deployment. Exactly one of Translate and
+	// TranslateWithOptions must be set.
+	TranslateWithOptions func(*gnmipb.SubscribeResponse, Options) (*gnmipb.SubscribeResponse, error)
+	// Options are the initial options of the FT. See SetOptions.
+	Options Options
+	// ValidateOptions, if set, returns an error for options the FT cannot use, e.g. with invalid
+	// values, which are then rejected by NewFunctionalTranslator and SetOptions.
+	ValidateOptions  func(Options) error
 	OutputToInputMap map[string][]*gnmipb.Path
 	Metadata         []*FTMetadata
 	// MatchPaths is a function when given a superset of output paths and device metadata, returns
 	// a MatchedPaths which contains the subset of output paths supported by the FT (OutputPaths)
 	// and a set of paths (InputPaths) needed to provide those paths as output.
 	MatchPaths func(map[string]*gnmipb.Path, *DeviceMetadata) (*MatchedPaths, error)
+	// Sync is an optional function called with the target of a sync_response when it is received,
+	// before the sync marker is forwarded downstream. Stateful translators use it to flush the
+	// pending state of the target which can no longer be completed by its initial set of updates.
+	// Sync responses carry no target, so the target is the one passed to SyncTarget, and is empty
+	// for Translate: the pending state of every target must then be kept, since it may belong to a
+	// target still in its initial updates.
+	Sync func(target string, sr *gnmipb.SubscribeResponse) error
+	// Flush is an optional function called when a sync_response is received, after Sync, returning
+	// the notifications of the state derived from the initial updates which is emitted before the
+	// sync marker, e.g. the outputs held until all their inputs were received. The notifications
+	// are only returned by TranslateResponses and the methods using it, as Translate returns a
+	// single response.
+	Flush func() ([]*gnmipb.Notification, error)
+	// DryRun makes the FT translate its inputs, including any state updates, but count and log
+	// its outputs instead of returning them. See SetDryRun.
+	DryRun bool
+	// NotificationLimits, if set, overrides the global limits applied by TranslateSplit. See
+	// SetGlobalNotificationLimits.
+	NotificationLimits *NotificationLimits
+	// State is set by FTs keeping state across notifications, to reset, snapshot and restore it.
+	// All its functions must be set.
+	State *StateOptions
+	// Instrumentation, if set, overrides the global instrumentation. See SetGlobalInstrumentation.
+	Instrumentation Instrumentation
+	// ValidateOutput, if set, returns the schema of the generated ygot OpenConfig structs of the FT,
+	// e.g. the Schema function of its yang/openconfig package. Each translated notification is
+	// then unmarshalled into the structs and validated, and Translate fails for outputs with paths
+	// or values not matching the schema. Meant for development and tests, as it is expensive.
+	ValidateOutput func() (*ytypes.Schema, error)
+	// SubscriptionModes maps the schema strings of inputs of the OutputToInputMap, e.g.
+	// "/eos_native/Sysdb/macsec/status/cpStatus", to their recommended subscription mode. See
+	// Subscriptions.
+	SubscriptionModes map[string]*SubscriptionMode
+	// Version is the version of the FT, e.g. "1.2.0", to be increased when its outputs change, so
+	// that the coverage of a deployment can be tracked. See CompatibilityReport in the registry.
+	Version string
+	// LogInvalidOutput makes the FT log the outputs not matching the schema of ValidateOutput and
+	// return them, instead of failing, e.g. to find the non-conformant outputs of a deployment.
+	LogInvalidOutput bool
 }
 
 // FunctionalTranslator is a per-platform (vendor/hw_model/sw_model) struct, which handles the
@@ -144,8 +150,26 @@ type FunctionalTranslator struct {
 	id               string
 	translate        func(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error)
 	outputToInputMap map[string][]*gnmipb.Path
+	index            *schemaIndex
 	metadata         []*FTMetadata
 	matchPaths       func(map[string]*gnmipb.Path, *DeviceMetadata) (*MatchedPaths, error)
+	sync             func(string, *gnmipb.SubscribeResponse) error
+	flush            func() ([]*gnmipb.Notification, error)
+	dryRun           atomic.Bool
+	dryRunStats      dryRunCounters
+	limits           *NotificationLimits
+	state            *StateOptions
+	instrumentation  Instrumentation
+	outputSchema     *ytypes.Schema
+	options          optionsHolder
+	validateOptions  func(Options) error
+
+	// subscriptionModes maps input schema strings to their recommended subscription mode.
+	subscriptionModes map[string]*SubscriptionMode
+	version           string
+	// hwModelPatterns holds the compiled HardwareModels of the metadata, by expression.
+	hwModelPatterns  map[string]*regexp.Regexp
+	logInvalidOutput bool
 }
 
 // NewFunctionalTranslator returns a FunctionalTranslator initialized with provided information.
@@ -154,9 +178,17 @@ func NewFunctionalTranslator(opts FunctionalTranslatorOptions) (*FunctionalTrans
 	if opts.ID == "" {
 		return nil, fmt.Errorf("Functional Translator ID is nil")
 	}
-	if opts.Translate == nil {
+	if opts.Translate == nil && opts.TranslateWithOptions == nil {
 		return nil, fmt.Errorf("%s has a nil Translate() function", opts.ID)
 	}
+	if opts.Translate != nil && opts.TranslateWithOptions != nil {
+		return nil, fmt.Errorf("%s has both Translate() and TranslateWithOptions() functions", opts.ID)
+	}
+	if opts.ValidateOptions != nil {
+		if err := opts.ValidateOptions(opts.Options); err != nil {
+			return nil, fmt.Errorf("%s has invalid options %v: %v", opts.ID, opts.Options, err)
+		}
+	}
 
 	for out, inputs := range opts.OutputToInputMap {
 		if out[0] != '/' {
@@ -180,12 +212,51 @@ func NewFunctionalTranslator(opts FunctionalTranslatorOptions) (*FunctionalTrans
 		}
 	}
 
+	index := newSchemaIndex(opts.OutputToInputMap)
+	if err := validateSubscriptionModes(opts.SubscriptionModes, index); err != nil {
+		return nil, fmt.Errorf("%s: %v", opts.ID, err)
+	}
+
+	hwModelPatterns, err := compileHardwareModels(opts.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", opts.ID, err)
+	}
+
+	if s := opts.State; s != nil && (s.Reset == nil || s.State == nil || s.RestoreState == nil) {
+		return nil, fmt.Errorf("%s has incomplete State options", opts.ID)
+	}
+
+	outputSchema, err := newOutputSchema(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	ft := &FunctionalTranslator{
 		id:               opts.ID,
 		translate:        opts.Translate,
 		outputToInputMap: opts.OutputToInputMap,
+		index:            index,
 		metadata:         opts.Metadata,
 		matchPaths:       opts.MatchPaths,
+		sync:             opts.Sync,
+		flush:            opts.Flush,
+		limits:           opts.NotificationLimits,
+		state:            opts.State,
+		instrumentation:  opts.Instrumentation,
+		outputSchema:     outputSchema,
+		validateOptions:  opts.ValidateOptions,
+
+		subscriptionModes: opts.SubscriptionModes,
+		version:           opts.Version,
+		hwModelPatterns:   hwModelPatterns,
+		logInvalidOutput:  opts.LogInvalidOutput,
+	}
+	ft.dryRun.Store(opts.DryRun)
+	ft.options.store(opts.Options)
+	if opts.TranslateWithOptions != nil {
+		ft.translate = func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+			return opts.TranslateWithOptions(sr, ft.options.load())
+		}
 	}
 
 	// Apply default values if not provided.
@@ -207,6 +278,22 @@ func (ft *FunctionalTranslator) Metadata() []*FTMetadata {
 	return ft.metadata
 }
 
+// Version returns the version of the FT, empty if it is not versioned.
+func (ft *FunctionalTranslator) Version() string {
+	return ft.version
+}
+
+// SupportedOutputPaths returns the sorted schema strings of the output paths the FT can
+// synthesize, e.g. "/openconfig/interfaces/interface/state/counters/in-pkts".
+func (ft *FunctionalTranslator) SupportedOutputPaths() []string {
+	paths := make([]string, 0, len(ft.outputToInputMap))
+	for out := range ft.outputToInputMap {
+		paths = append(paths, out)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
 // OutputToInputMap returns the map between output OpenConfig paths and the corresponding
 // gNMI input path(s) used by the FunctionalTranslator.
 func (ft *FunctionalTranslator) OutputToInputMap() map[string][]*gnmipb.Path {
@@ -214,8 +301,141 @@ func (ft *FunctionalTranslator) OutputToInputMap() map[string][]*gnmipb.Path {
 }
 
 // Translate translates vendor notifications to notifications OpenConfig-compliant notifications.
+// Sync responses are not translated; they are passed through unchanged after the optional Sync
+// function has been called with an empty target, see SyncTarget, so that consumers waiting for the
+// end of the initial updates still receive the marker. Error responses are passed through unchanged, so that consumers are
+// notified of the errors of the device. The target of the notifications is normalized first, see
+// SetGlobalTargetNormalizer.
+// In dry-run mode, the translated notifications are counted and logged, and nil is returned.
+// If the FT validates its outputs, an error is returned for invalid outputs, in dry-run mode too.
 func (ft *FunctionalTranslator) Translate(input *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
-	return ft.translate(input)
+	if IsSyncResponse(input) {
+		if err := ft.handleSync("", input); err != nil {
+			return nil, err
+		}
+		return input, nil
+	}
+	if IsErrorResponse(input) {
+		return input, nil
+	}
+	input = normalizeTarget(input)
+	var out *gnmipb.SubscribeResponse
+	var err error
+	if inst := ft.Instrumentation(); inst != nil {
+		start := time.Now()
+		out, err = ft.translateValidated(input)
+		latency := time.Since(start)
+		switch {
+		case err != nil:
+			inst.Failed(ft.id, latency, err)
+		case out == nil:
+			inst.Dropped(ft.id, latency)
+		default:
+			inst.Translated(ft.id, latency)
+		}
+	} else {
+		out, err = ft.translateValidated(input)
+	}
+	if err != nil || out == nil || !ft.DryRun() {
+		return out, err
+	}
+	ft.dryRunStats.record(out)
+	log.V(1).Infof("%s dry-run suppressed output: %v", ft.id, out)
+	return nil, nil
+}
+
+// handleSync calls the Sync function of the FT, if any, with the target of a sync response.
+func (ft *FunctionalTranslator) handleSync(target string, sr *gnmipb.SubscribeResponse) error {
+	if ft.sync == nil {
+		return nil
+	}
+	if err := ft.sync(target, sr); err != nil {
+		return fmt.Errorf("%s failed to handle sync response: %v", ft.id, err)
+	}
+	return nil
+}
+
+// translateValidated translates the input, and validates the output if the FT validates its
+// outputs.
+func (ft *FunctionalTranslator) translateValidated(input *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+	out, err := ft.translate(input)
+	if err != nil || out == nil {
+		return out, err
+	}
+	if err := ft.checkOutput(out); err != nil {
+		return nil, fmt.Errorf("%s returned an invalid output: %v", ft.id, err)
+	}
+	return out, nil
+}
+
+// IsSyncResponse returns true if the SubscribeResponse signals the end of the initial updates.
+func IsSyncResponse(sr *gnmipb.SubscribeResponse) bool {
+	_, ok := sr.GetResponse().(*gnmipb.SubscribeResponse_SyncResponse)
+	return ok
+}
+
+// IsErrorResponse returns true if the SubscribeResponse reports an error of the device.
+func IsErrorResponse(sr *gnmipb.SubscribeResponse) bool {
+	// The error field is deprecated, but still sent by some devices.
+	_, ok := sr.GetResponse().(*gnmipb.SubscribeResponse_Error)
+	return ok
+}
+
+// TranslateResponses translates the input as Translate does, and returns the non-nil output. For
+// sync responses, the notifications flushed by the Flush function of the FT are returned before
+// the sync response. In dry-run mode, the flushed notifications are counted and logged instead.
+func (ft *FunctionalTranslator) TranslateResponses(input *gnmipb.SubscribeResponse) ([]*gnmipb.SubscribeResponse, error) {
+	out, err := ft.Translate(input)
+	if err != nil {
+		return nil, err
+	}
+	return ft.withFlushed(input, out)
+}
+
+// SyncTarget handles the sync response of a target as TranslateSplit does, but passes the target
+// to the Sync function of the FT. Callers subscribed to a single device use it instead of the
+// Translate methods, so that the FT flushes the pending state of the device. The target is
+// normalized like those of the notifications, see SetGlobalTargetNormalizer.
+func (ft *FunctionalTranslator) SyncTarget(target string, sr *gnmipb.SubscribeResponse) ([]*gnmipb.SubscribeResponse, error) {
+	if !IsSyncResponse(sr) {
+		return nil, fmt.Errorf("%s cannot sync target %q with a response which is not a sync response: %v", ft.id, target, sr)
+	}
+	if err := ft.handleSync(NormalizeTarget(target), sr); err != nil {
+		return nil, err
+	}
+	outs, err := ft.withFlushed(sr, sr)
+	if err != nil {
+		return nil, err
+	}
+	return ft.split(outs)
+}
+
+// withFlushed returns the non-nil output of the input, preceded for sync responses by the
+// notifications flushed by the Flush function of the FT.
+func (ft *FunctionalTranslator) withFlushed(input, out *gnmipb.SubscribeResponse) ([]*gnmipb.SubscribeResponse, error) {
+	var outputs []*gnmipb.SubscribeResponse
+	if IsSyncResponse(input) && ft.flush != nil {
+		notifs, err := ft.flush()
+		if err != nil {
+			return nil, fmt.Errorf("%s failed to flush its state at sync: %v", ft.id, err)
+		}
+		for _, n := range notifs {
+			sr := &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_Update{Update: n}}
+			if err := ft.checkOutput(sr); err != nil {
+				return nil, fmt.Errorf("%s flushed an invalid output: %v", ft.id, err)
+			}
+			if ft.DryRun() {
+				ft.dryRunStats.record(sr)
+				log.V(1).Infof("%s dry-run suppressed flushed output: %v", ft.id, sr)
+				continue
+			}
+			outputs = append(outputs, sr)
+		}
+	}
+	if out != nil {
+		outputs = append(outputs, out)
+	}
+	return outputs, nil
 }
 
 // MatchPaths is a function when given a superset of output paths and device metadata, returns
@@ -231,8 +451,7 @@ func (ft *FunctionalTranslator) OutputToInput(output *gnmipb.Path) (bool, []*gnm
 	if len(ft.OutputToInputMap()) == 0 {
 		return false, nil, fmt.Errorf("Functional Translator %s has a nil OutputToInputMap", ft.ID())
 	}
-	outputKey := ftutilities.GNMIPathToSchemaString(output, false)
-	inputs, ok := ft.OutputToInputMap()[outputKey]
+	inputs, ok := ft.InputsForOutput(ftutilities.GNMIPathToSchemaString(output, false))
 	return ok, inputs, nil
 }
 
@@ -258,6 +477,80 @@ func (m *FTMetadata) swVersionMatch(got *DeviceMetadata) bool {
 	}
 }
 
+// compileHardwareModels returns the compiled HardwareModels of the metadata, by expression. It
+// returns an error for invalid expressions, or metadata with both HardwareModel and HardwareModels.
+func compileHardwareModels(metadata []*FTMetadata) (map[string]*regexp.Regexp, error) {
+	patterns := map[string]*regexp.Regexp{}
+	for _, m := range metadata {
+		if m.HardwareModel != "" && len(m.HardwareModels) > 0 {
+			return nil, fmt.Errorf("metadata %v has both HardwareModel and HardwareModels set", m)
+		}
+		for _, expr := range m.HardwareModels {
+			re, err := regexp.Compile("(?i)^(?:" + expr + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("invalid hardware model %q: %v", expr, err)
+			}
+			patterns[expr] = re
+		}
+	}
+	return patterns, nil
+}
+
+// hwModelMatch returns true if the hardware model matches the FT metadata, i.e. its HardwareModel
+// or one of its HardwareModels, or if the metadata has no hardware model.
+func (ft *FunctionalTranslator) hwModelMatch(m *FTMetadata, model string) bool {
+	switch {
+	case m.HardwareModel != "":
+		return strings.EqualFold(m.HardwareModel, model)
+	case len(m.HardwareModels) > 0:
+		for _, expr := range m.HardwareModels {
+			if re := ft.hwModelPatterns[expr]; re != nil && re.MatchString(model) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// Supports returns true if the FT applies to the given device, i.e. if the device matches one of
+// the FT metadata, or if the FT has no metadata.
+func (ft *FunctionalTranslator) Supports(device *DeviceMetadata) bool {
+	return ft.metadataMatch(device)
+}
+
+// HardwareModels returns whether the FT applies to devices of the vendor and software version,
+// whatever their hardware model, and the hardware models, or expressions of HardwareModels, it is
+// then restricted to. No models are returned if the FT applies to all the hardware models.
+func (ft *FunctionalTranslator) HardwareModels(vendor, swVersion string) (bool, []string) {
+	if len(ft.metadata) == 0 {
+		return true, nil
+	}
+	got := &DeviceMetadata{Vendor: vendor, SoftwareVersion: swVersion}
+	var applies bool
+	var models []string
+	for _, m := range ft.metadata {
+		if m.Vendor != "" && !strings.EqualFold(m.Vendor, vendor) {
+			continue
+		}
+		if !m.swVersionMatch(got) {
+			continue
+		}
+		applies = true
+		switch {
+		case m.HardwareModel != "":
+			models = append(models, m.HardwareModel)
+		case len(m.HardwareModels) > 0:
+			models = append(models, m.HardwareModels...)
+		default:
+			// The FT applies to all the hardware models.
+			return true, nil
+		}
+	}
+	return applies, models
+}
+
 func (ft *FunctionalTranslator) metadataMatch(got *DeviceMetadata) bool {
 	if len(ft.metadata) == 0 {
 		return true
@@ -266,7 +559,7 @@ func (ft *FunctionalTranslator) metadataMatch(got *DeviceMetadata) bool {
 		if m.Vendor != "" && !strings.EqualFold(m.Vendor, got.Vendor) {
 			continue
 		}
-		if m.HardwareModel != "" && !strings.EqualFold(m.HardwareModel, got.HardwareModel) {
+		if !ft.hwModelMatch(m, got.HardwareModel) {
 			continue
 		}
 		if !m.swVersionMatch(got) {
@@ -300,11 +593,7 @@ func (ft *FunctionalTranslator) defaultPathMatcher(outputSuperset map[string]*gn
 				return nil, fmt.Errorf("GNMIPathPathToString(path) = %s does not match desired output path %s", outputKey, key)
 			}
 			returnInputPaths = append(returnInputPaths, inputs...)
-			inputKeys := make([]string, 0, len(inputs))
-			for _, input := range inputs {
-				inputKeys = append(inputKeys, ftutilities.GNMIPathToSchemaString(input, false))
-			}
-			returnOutputToInput[key] = inputKeys
+			returnOutputToInput[key] = append([]string(nil), ft.InputSchemasForOutput(key)...)
 		}
 	}
 	// Sort for consistent return ordering.
@@ -15,6 +15,7 @@
 package translator
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -132,6 +133,24 @@ func TestMetadataMatch(t *testing.T) {
 			},
 			wantMatch: false,
 		},
+		{
+			name: "hw_models_match_one",
+			ftMetaData: []*FTMetadata{
+				{
+					HardwareModels: []string{"non-hw", "H."},
+				},
+			},
+			wantMatch: true,
+		},
+		{
+			name: "hw_models_whole_model",
+			ftMetaData: []*FTMetadata{
+				{
+					HardwareModels: []string{"h", "w"},
+				},
+			},
+			wantMatch: false,
+		},
 	}
 
 	inputMetaData := &DeviceMetadata{
@@ -293,6 +312,84 @@ func TestCompareVersions(t *testing.T) {
 			v2:   "1.0",
 			want: LessThan,
 		},
+		{
+			name: "eos_double_digit_minor",
+			v1:   "4.9.1F",
+			v2:   "4.10.0F",
+			want: LessThan,
+		},
+		{
+			name: "eos_maintenance_release",
+			v1:   "4.33.1.1F",
+			v2:   "4.33.1F",
+			want: GreaterThan,
+		},
+		{
+			name: "eos_release_type",
+			v1:   "4.33.1M",
+			v2:   "4.33.1F",
+			want: GreaterThan,
+		},
+		{
+			name: "eos_release_type_before_build",
+			v1:   "4.33.1F-999",
+			v2:   "4.33.1M",
+			want: LessThan,
+		},
+		{
+			name: "xr_double_digit_minor",
+			v1:   "7.11.2",
+			v2:   "7.9.1",
+			want: GreaterThan,
+		},
+		{
+			name: "xr_interim_before_release",
+			v1:   "24.3.30.06I",
+			v2:   "24.3.30",
+			want: LessThan,
+		},
+		{
+			name: "xr_interim_after_previous_release",
+			v1:   "24.3.30.06I",
+			v2:   "24.3.20",
+			want: GreaterThan,
+		},
+		{
+			name: "xr_interim_builds",
+			v1:   "24.3.30.06I",
+			v2:   "24.3.30.10I",
+			want: LessThan,
+		},
+		{
+			name: "pre_release_before_release",
+			v1:   "7.11.2-rc1",
+			v2:   "7.11.2",
+			want: LessThan,
+		},
+		{
+			name: "pre_release_ordering",
+			v1:   "7.11.2-beta2",
+			v2:   "7.11.2-rc1",
+			want: LessThan,
+		},
+		{
+			name: "sros_prefix",
+			v1:   "TiMOS-B-24.7.R1",
+			v2:   "24.7.R1",
+			want: Equal,
+		},
+		{
+			name: "sros_double_digit_minor",
+			v1:   "B-24.10.R1",
+			v2:   "C-24.7.R2",
+			want: GreaterThan,
+		},
+		{
+			name: "srlinux_prefix_and_build",
+			v1:   "v24.10.1-492-g6c0b8e0a",
+			v2:   "24.10.1",
+			want: GreaterThan,
+		},
 	}
 
 	for _, tc := range tests {
@@ -640,6 +737,50 @@ func TestSensibility(t *testing.T) {
 	})
 }
 
+func TestHardwareModels(t *testing.T) {
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID: "test",
+		Translate: func(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+			return nil, nil
+		},
+		Metadata: []*FTMetadata{
+			{Vendor: "CISCOXR", HardwareModels: []string{`88\d\d`, "8201-.*"}, SoftwareVersionRange: &SWRange{InclusiveMin: "7.0", ExclusiveMax: "24.0"}},
+			{Vendor: "CISCOXR", SoftwareVersionRange: &SWRange{InclusiveMin: "24.0", ExclusiveMax: "25.0"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator() got unexpected error: %v", err)
+	}
+	tests := []struct {
+		swVersion   string
+		hwModel     string
+		wantSupport bool
+	}{
+		{swVersion: "7.11.2", hwModel: "8808", wantSupport: true},
+		{swVersion: "7.11.2", hwModel: "8201-32FH", wantSupport: true},
+		{swVersion: "7.11.2", hwModel: "NCS-5508", wantSupport: false},
+		{swVersion: "7.11.2", hwModel: "88080", wantSupport: false},
+		{swVersion: "24.3.1", hwModel: "NCS-5508", wantSupport: true},
+	}
+	for _, tc := range tests {
+		device := &DeviceMetadata{Vendor: "CISCOXR", SoftwareVersion: tc.swVersion, HardwareModel: tc.hwModel}
+		if got := ft.Supports(device); got != tc.wantSupport {
+			t.Errorf("Supports(%v) = %t, want %t", device, got, tc.wantSupport)
+		}
+	}
+
+	applies, models := ft.HardwareModels("CISCOXR", "7.11.2")
+	if diff := cmp.Diff([]string{`88\d\d`, "8201-.*"}, models); !applies || diff != "" {
+		t.Errorf("HardwareModels(%q) = %t, %v, want true and diff (-want +got):\n%s", "7.11.2", applies, models, diff)
+	}
+	if applies, models := ft.HardwareModels("CISCOXR", "24.3.1"); !applies || models != nil {
+		t.Errorf("HardwareModels(%q) = %t, %v, want true, nil", "24.3.1", applies, models)
+	}
+	if applies, _ := ft.HardwareModels("CISCOXR", "6.5.1"); applies {
+		t.Errorf("HardwareModels(%q) = %t, want false", "6.5.1", applies)
+	}
+}
+
 func TestNewFunctionalTranslator(t *testing.T) {
 	ftMetadata := FTMetadata{Vendor: "vendor"}
 	tests := []struct {
@@ -744,6 +885,28 @@ func TestNewFunctionalTranslator(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid_HardwareModels",
+			opts: FunctionalTranslatorOptions{
+				ID: "test-id",
+				Translate: func(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+					return nil, nil
+				},
+				Metadata: []*FTMetadata{{Vendor: "vendor", HardwareModels: []string{"88("}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "both_HardwareModel_and_HardwareModels",
+			opts: FunctionalTranslatorOptions{
+				ID: "test-id",
+				Translate: func(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+					return nil, nil
+				},
+				Metadata: []*FTMetadata{{Vendor: "vendor", HardwareModel: "8808", HardwareModels: []string{`88\d\d`}}},
+			},
+			wantErr: true,
+		},
 		{
 			name: "empty_Metadata_is_valid",
 			opts: FunctionalTranslatorOptions{
@@ -846,3 +1009,196 @@ func TestOutputToInput(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslateSyncResponse(t *testing.T) {
+	syncSR := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true},
+	}
+	updateSR := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{Update: &gnmipb.Notification{Timestamp: 1}},
+	}
+	tests := []struct {
+		name          string
+		syncErr       error
+		input         *gnmipb.SubscribeResponse
+		want          *gnmipb.SubscribeResponse
+		wantSyncCalls int
+		wantErr       bool
+	}{
+		{
+			name:          "sync_passed_through",
+			input:         syncSR,
+			want:          syncSR,
+			wantSyncCalls: 1,
+		},
+		{
+			name:          "sync_handler_error",
+			syncErr:       fmt.Errorf("flush failed"),
+			input:         syncSR,
+			wantSyncCalls: 1,
+			wantErr:       true,
+		},
+		{
+			name:  "update_translated",
+			input: updateSR,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			syncCalls := 0
+			ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+				ID:        "test-ft",
+				Translate: func(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) { return nil, nil },
+				Sync: func(target string, _ *gnmipb.SubscribeResponse) error {
+					syncCalls++
+					if target != "" {
+						t.Errorf("Translate(%v) called Sync with target %q, want empty target", tc.input, target)
+					}
+					return tc.syncErr
+				},
+			})
+			if err != nil {
+				t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+			}
+			got, err := ft.Translate(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Translate(%v) got error: %v, want error: %v", tc.input, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Translate(%v) returned diff (-want +got):\n%s", tc.input, diff)
+			}
+			if syncCalls != tc.wantSyncCalls {
+				t.Errorf("Translate(%v) called Sync %d times, want %d", tc.input, syncCalls, tc.wantSyncCalls)
+			}
+		})
+	}
+}
+
+func TestSyncTarget(t *testing.T) {
+	syncSR := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true},
+	}
+	var targets []string
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID:        "test-ft",
+		Translate: func(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) { return nil, nil },
+		Sync: func(target string, _ *gnmipb.SubscribeResponse) error {
+			targets = append(targets, target)
+			return nil
+		},
+		Flush: func() ([]*gnmipb.Notification, error) {
+			return []*gnmipb.Notification{{Timestamp: 1, Prefix: &gnmipb.Path{Target: "dut"}}}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+	}
+	SetGlobalTargetNormalizer(LowercaseTarget)
+	t.Cleanup(func() { SetGlobalTargetNormalizer(nil) })
+
+	got, err := ft.SyncTarget("DUT", syncSR)
+	if err != nil {
+		t.Fatalf("SyncTarget(%q) got unexpected error: %v", "DUT", err)
+	}
+	want := []*gnmipb.SubscribeResponse{
+		{Response: &gnmipb.SubscribeResponse_Update{Update: &gnmipb.Notification{Timestamp: 1, Prefix: &gnmipb.Path{Target: "dut"}}}},
+		syncSR,
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("SyncTarget(%q) returned diff (-want +got):\n%s", "DUT", diff)
+	}
+	if diff := cmp.Diff([]string{"dut"}, targets); diff != "" {
+		t.Errorf("SyncTarget(%q) called Sync with unexpected targets (-want +got):\n%s", "DUT", diff)
+	}
+
+	updateSR := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{Update: &gnmipb.Notification{Timestamp: 1}},
+	}
+	if _, err := ft.SyncTarget("dut", updateSR); err == nil {
+		t.Errorf("SyncTarget(%v) got nil error, want error", updateSR)
+	}
+}
+
+func TestTranslateErrorResponse(t *testing.T) {
+	errorSR := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Error{Error: &gnmipb.Error{Code: 14, Message: "unavailable"}},
+	}
+	ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+		ID: "test-ft",
+		Translate: func(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+			return nil, fmt.Errorf("unexpected translation")
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+	}
+	got, err := ft.Translate(errorSR)
+	if err != nil {
+		t.Fatalf("Translate(%v) got unexpected error: %v", errorSR, err)
+	}
+	if diff := cmp.Diff(errorSR, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Translate(%v) returned diff (-want +got):\n%s", errorSR, diff)
+	}
+}
+
+func TestTranslateResponses(t *testing.T) {
+	syncSR := &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true},
+	}
+	flushed := &gnmipb.Notification{Timestamp: 2, Prefix: &gnmipb.Path{Origin: "openconfig", Target: "dut"}}
+	tests := []struct {
+		name     string
+		flushErr error
+		dryRun   bool
+		input    *gnmipb.SubscribeResponse
+		want     []*gnmipb.SubscribeResponse
+		wantErr  bool
+	}{
+		{
+			name:  "flushed_before_sync",
+			input: syncSR,
+			want: []*gnmipb.SubscribeResponse{
+				{Response: &gnmipb.SubscribeResponse_Update{Update: flushed}},
+				syncSR,
+			},
+		},
+		{
+			name:   "flush_suppressed_in_dry_run",
+			dryRun: true,
+			input:  syncSR,
+			want:   []*gnmipb.SubscribeResponse{syncSR},
+		},
+		{
+			name:     "flush_error",
+			flushErr: fmt.Errorf("flush failed"),
+			input:    syncSR,
+			wantErr:  true,
+		},
+		{
+			name:  "update_not_flushed",
+			input: &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_Update{Update: &gnmipb.Notification{Timestamp: 1}}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+				ID:        "test-ft",
+				Translate: func(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) { return nil, nil },
+				Flush: func() ([]*gnmipb.Notification, error) {
+					return []*gnmipb.Notification{flushed}, tc.flushErr
+				},
+				DryRun: tc.dryRun,
+			})
+			if err != nil {
+				t.Fatalf("NewFunctionalTranslator got unexpected error: %v", err)
+			}
+			got, err := ft.TranslateResponses(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("TranslateResponses(%v) got error: %v, want error: %v", tc.input, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("TranslateResponses(%v) returned diff (-want +got):\n%s", tc.input, diff)
+			}
+		})
+	}
+}
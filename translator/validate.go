@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	log "github.com/openconfig/functional-translators/ftlog"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// newOutputSchema returns the schema used to validate the outputs of the FT, or nil if the
+// outputs are not validated. See FunctionalTranslatorOptions.ValidateOutput.
+func newOutputSchema(opts FunctionalTranslatorOptions) (*ytypes.Schema, error) {
+	if opts.ValidateOutput == nil {
+		return nil, nil
+	}
+	schema, err := opts.ValidateOutput()
+	if err != nil {
+		return nil, fmt.Errorf("%s failed to get the output schema: %v", opts.ID, err)
+	}
+	if schema == nil || schema.Root == nil || reflect.TypeOf(schema.Root).Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("%s has an output schema without root", opts.ID)
+	}
+	return schema, nil
+}
+
+// ValidatesOutput returns true if the outputs of the FT are validated against the generated ygot
+// structs. See FunctionalTranslatorOptions.ValidateOutput.
+func (ft *FunctionalTranslator) ValidatesOutput() bool {
+	return ft.outputSchema != nil
+}
+
+// checkOutput returns the error of validateOutput, unless the FT only logs its invalid outputs.
+// See FunctionalTranslatorOptions.LogInvalidOutput.
+func (ft *FunctionalTranslator) checkOutput(sr *gnmipb.SubscribeResponse) error {
+	err := ft.validateOutput(sr)
+	if err == nil || !ft.logInvalidOutput {
+		return err
+	}
+	log.Warningf("%s returned an invalid output: %v: %v", ft.id, err, sr)
+	return nil
+}
+
+// validateOutput returns an error if the notification of a response returned by the FT has paths
+// or values not matching the output schema. Sync responses are not validated.
+func (ft *FunctionalTranslator) validateOutput(sr *gnmipb.SubscribeResponse) error {
+	n := sr.GetUpdate()
+	if ft.outputSchema == nil || n == nil {
+		return nil
+	}
+	// Make a shallow copy of the schema and replace the root, so that the outputs are validated
+	// independently and concurrently.
+	schema := *ft.outputSchema
+	root, ok := reflect.New(reflect.TypeOf(schema.Root).Elem()).Interface().(ygot.GoStruct)
+	if !ok {
+		return fmt.Errorf("output schema root %T is not a GoStruct", schema.Root)
+	}
+	schema.Root = root
+	if err := ytypes.UnmarshalNotifications(&schema, []*gnmipb.Notification{n}, nil); err != nil {
+		return err
+	}
+	if v, ok := root.(ygot.ValidatedGoStruct); ok {
+		// FTs translate to state paths, so leafrefs to config leaves, e.g. the keys of lists, are
+		// not resolved.
+		return v.Validate(&ytypes.LeafrefOptions{IgnoreMissingData: true})
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/openconfig/ygot/ytypes"
+
+	oc "github.com/openconfig/functional-translators/ciscoxr/ciscoxrpower/yang/openconfig"
+	log "github.com/openconfig/functional-translators/ftlog"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func componentStateSR(leaf string, val *gnmipb.TypedValue) *gnmipb.SubscribeResponse {
+	return &gnmipb.SubscribeResponse{
+		Response: &gnmipb.SubscribeResponse_Update{
+			Update: &gnmipb.Notification{
+				Timestamp: 1,
+				Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "dut"},
+				Update: []*gnmipb.Update{{
+					Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+						{Name: "components"},
+						{Name: "component", Key: map[string]string{"name": "PM0"}},
+						{Name: "state"},
+						{Name: leaf},
+					}},
+					Val: val,
+				}},
+			},
+		},
+	}
+}
+
+func TestValidateOutput(t *testing.T) {
+	stringVal := func(s string) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: s}}
+	}
+	tests := []struct {
+		name     string
+		out      *gnmipb.SubscribeResponse
+		validate bool
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			out:      componentStateSR("description", stringVal("power module")),
+			validate: true,
+		},
+		{
+			name:     "nil output",
+			validate: true,
+		},
+		{
+			name:     "unknown path",
+			out:      componentStateSR("descripton", stringVal("power module")),
+			validate: true,
+			wantErr:  true,
+		},
+		{
+			name:     "wrong type",
+			out:      componentStateSR("description", &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 1}}),
+			validate: true,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid value",
+			out:      componentStateSR("base-mac-address", stringVal("not a mac")),
+			validate: true,
+			wantErr:  true,
+		},
+		{
+			name: "not validated",
+			out:  componentStateSR("descripton", stringVal("power module")),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := FunctionalTranslatorOptions{
+				ID: "test-ft",
+				Translate: func(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+					return tc.out, nil
+				},
+			}
+			if tc.validate {
+				opts.ValidateOutput = oc.Schema
+			}
+			ft, err := NewFunctionalTranslator(opts)
+			if err != nil {
+				t.Fatalf("NewFunctionalTranslator() got unexpected error: %v", err)
+			}
+			if got := ft.ValidatesOutput(); got != tc.validate {
+				t.Errorf("ValidatesOutput() = %v, want %v", got, tc.validate)
+			}
+			// The outputs are validated twice, to check that they are validated independently.
+			for i := 0; i < 2; i++ {
+				got, err := ft.Translate(componentStateSR("description", nil))
+				if (err != nil) != tc.wantErr {
+					t.Fatalf("Translate() got error %v, want error %v", err, tc.wantErr)
+				}
+				if err == nil && got != tc.out {
+					t.Errorf("Translate() = %v, want %v", got, tc.out)
+				}
+			}
+		})
+	}
+}
+
+// warningCounter is a logger counting the warnings.
+type warningCounter struct {
+	warnings int
+}
+
+func (*warningCounter) Infof(string, ...any)      {}
+func (w *warningCounter) Warningf(string, ...any) { w.warnings++ }
+func (*warningCounter) Errorf(string, ...any)     {}
+func (*warningCounter) Fatalf(string, ...any)     {}
+func (*warningCounter) V(int) bool                { return false }
+
+func TestLogInvalidOutput(t *testing.T) {
+	tests := []struct {
+		name         string
+		out          *gnmipb.SubscribeResponse
+		wantWarnings int
+	}{
+		{
+			name: "valid",
+			out:  componentStateSR("description", &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "power module"}}),
+		},
+		{
+			name:         "wrong type",
+			out:          componentStateSR("description", &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 1}}),
+			wantWarnings: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := &warningCounter{}
+			log.SetLogger(w)
+			defer log.SetLogger(nil)
+			ft, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+				ID: "test-ft",
+				Translate: func(*gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+					return tc.out, nil
+				},
+				ValidateOutput:   oc.Schema,
+				LogInvalidOutput: true,
+			})
+			if err != nil {
+				t.Fatalf("NewFunctionalTranslator() got unexpected error: %v", err)
+			}
+			got, err := ft.Translate(componentStateSR("description", nil))
+			if err != nil {
+				t.Fatalf("Translate() got unexpected error: %v", err)
+			}
+			if got != tc.out {
+				t.Errorf("Translate() = %v, want %v", got, tc.out)
+			}
+			if w.warnings != tc.wantWarnings {
+				t.Errorf("Translate() logged %d warnings, want %d", w.warnings, tc.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestValidateOutputSchemaErrors(t *testing.T) {
+	for name, schema := range map[string]func() (*ytypes.Schema, error){
+		"error":   func() (*ytypes.Schema, error) { return nil, errors.New("no schema") },
+		"no root": func() (*ytypes.Schema, error) { return &ytypes.Schema{}, nil },
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewFunctionalTranslator(FunctionalTranslatorOptions{
+				ID: "test-ft",
+				Translate: func(sr *gnmipb.SubscribeResponse) (*gnmipb.SubscribeResponse, error) {
+					return sr, nil
+				},
+				ValidateOutput: schema,
+			})
+			if err == nil {
+				t.Errorf("NewFunctionalTranslator() got nil error, want error")
+			}
+		})
+	}
+}
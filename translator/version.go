@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// versionCompRE is used to extract all components from a version string,
+	// e.g. "12.1X1.2" to [12 1 X 1 2]
+	versionCompRE = regexp.MustCompile(`(\d+|[A-Z]+)`)
+	// vendorPrefixRE matches the prefixes of the versions before their first number, e.g. "v" of
+	// SR Linux "v24.10.1", or "TiMOS-B-" and "C-" of SR OS "TiMOS-B-24.7.R1" and "C-24.7.R1".
+	vendorPrefixRE = regexp.MustCompile(`^(?:TIMOS-)?(?:[A-Z]-|V)?(\d)`)
+	// releaseTypeRE matches the letters ending a version, attached to its last number, e.g. "F" of
+	// EOS "4.33.1F".
+	releaseTypeRE = regexp.MustCompile(`^(.*\d)([A-Z]+)$`)
+	// interimRE matches the four numbers of IOS XR interim builds, e.g. "24.3.30.06I", which are
+	// pre-releases of "24.3.30".
+	interimRE = regexp.MustCompile(`^\d+\.\d+\.\d+\.\d+$`)
+	// preReleaseRE matches the suffixes of pre-release versions, e.g. "rc1" of "7.11.2-rc1".
+	preReleaseRE = regexp.MustCompile(`^(?:ALPHA|BETA|DEV|EFT|PRE|RC)(?:\d|[.-]|$)`)
+)
+
+// version is a parsed software version.
+type version struct {
+	// release holds the components of the release, e.g. [4 33 1] for "4.33.1F-123".
+	release []string
+	// preRelease is set for pre-release versions, which precede their release.
+	preRelease bool
+	// preReleaseComps holds the components of the pre-release, e.g. [RC 1] for "7.11.2-rc1".
+	preReleaseComps []string
+	// releaseType holds the letters attached to the last number, e.g. "F" for "4.33.1F".
+	releaseType string
+	// build holds the components of the build suffix, e.g. [123] for "4.33.1F-123".
+	build []string
+}
+
+// parseVersion parses the version string of a device, e.g. EOS "4.33.1F", IOS XR "7.11.2" or
+// "24.3.30.06I", SR OS "TiMOS-B-24.7.R1" or SR Linux "v24.10.1-492-g6c0b8e0a".
+func parseVersion(s string) *version {
+	s = vendorPrefixRE.ReplaceAllString(strings.ToUpper(strings.TrimSpace(s)), "${1}")
+	main, build, _ := strings.Cut(s, "-")
+	v := &version{}
+	if m := releaseTypeRE.FindStringSubmatch(main); m != nil {
+		main, v.releaseType = m[1], m[2]
+	}
+	v.release = versionCompRE.FindAllString(main, -1)
+	if v.releaseType == "I" && interimRE.MatchString(main) {
+		v.preRelease = true
+		v.preReleaseComps = v.release[3:]
+		v.release = v.release[:3]
+		v.releaseType = ""
+	}
+	if preReleaseRE.MatchString(build) {
+		v.preRelease = true
+		v.preReleaseComps = append(v.preReleaseComps, versionCompRE.FindAllString(build, -1)...)
+		build = ""
+	}
+	v.build = versionCompRE.FindAllString(build, -1)
+	return v
+}
+
+// CompareVersions returns whether v1 is less than, equal to, or greater than v2.
+// The versions are compared by release first, e.g. "4.33.1" for EOS "4.33.1F-123", then a
+// pre-release, e.g. "7.11.2-rc1" or IOS XR interim build "7.11.2.20I", precedes its release, then
+// by the release type, e.g. "F" < "M" for EOS, and then by build, e.g. "123", a version without
+// build being the lowest. Vendor prefixes, e.g. "TiMOS-B-" of SR OS or "v" of SR Linux, and the
+// case of the letters are ignored.
+// Components are compared one by one, a missing component being "0". Numbers are compared to
+// numbers as numbers, e.g. "10" > "9". Letters are compared to letters as strings, e.g.
+// "X" > "AB". Numbers are compared to strings as strings, e.g. "A" > "12".
+func CompareVersions(v1, v2 string) CompareResult {
+	p1, p2 := parseVersion(v1), parseVersion(v2)
+	if r := compareComponents(p1.release, p2.release); r != Equal {
+		return r
+	}
+	switch {
+	case p1.preRelease && !p2.preRelease:
+		return LessThan
+	case !p1.preRelease && p2.preRelease:
+		return GreaterThan
+	}
+	if r := compareComponents(p1.preReleaseComps, p2.preReleaseComps); r != Equal {
+		return r
+	}
+	switch {
+	case p1.releaseType < p2.releaseType:
+		return LessThan
+	case p1.releaseType > p2.releaseType:
+		return GreaterThan
+	}
+	return compareComponents(p1.build, p2.build)
+}
+
+// compareComponents compares the components of two versions one by one.
+func compareComponents(c1, c2 []string) CompareResult {
+	maxLen := len(c1)
+	if len(c2) > maxLen {
+		maxLen = len(c2)
+	}
+
+	for i := 0; i < maxLen; i++ {
+		s1, s2 := "0", "0"
+		if i < len(c1) {
+			s1 = c1[i]
+		}
+		if i < len(c2) {
+			s2 = c2[i]
+		}
+
+		n1, err1 := strconv.Atoi(s1)
+		n2, err2 := strconv.Atoi(s2)
+
+		if err1 == nil && err2 == nil { // Both are numbers
+			if n1 < n2 {
+				return LessThan
+			}
+			if n1 > n2 {
+				return GreaterThan
+			}
+		} else { // At least one is not a number, compare as strings
+			if s1 < s2 {
+				return LessThan
+			}
+			if s1 > s2 {
+				return GreaterThan
+			}
+		}
+	}
+	return Equal
+}